@@ -0,0 +1,56 @@
+#!/bin/bash
+
+# Function to show usage
+show_usage() {
+    echo "Usage: make db-migrate-<command> DRIVER=<database_driver> [VERSION=<version>]"
+    echo "Example: make db-migrate-up DRIVER=postgres"
+    echo "
+Available commands:"
+    echo "  - up          : Apply all pending migrations"
+    echo "  - down        : Rollback last migration"
+    echo "  - goto        : Migrate up or down to VERSION"
+    echo "  - force       : Set the version to VERSION after fixing a dirty migration"
+    echo "  - status      : List migrations and whether they are applied"
+    echo "
+Available database drivers:"
+    echo "  - postgres    : PostgreSQL database"
+    echo "  - mysql       : MySQL database"
+    echo "  - mongodb     : MongoDB database"
+    exit 1
+}
+
+# Parse command line arguments
+while getopts ":d:h" opt; do
+    case $opt in
+        d) DB_DRIVER="$OPTARG";;
+        h) show_usage;;
+    esac
+done
+shift $((OPTIND - 1))
+
+# Validate required arguments
+if [ -z "$DB_DRIVER" ] || [ -z "$1" ]; then
+    echo "❌ Error: Database driver and command are required"
+    show_usage
+fi
+
+# Map the driver to its DB_DRIVER name
+case $DB_DRIVER in
+    postgres|postgresql)
+        DB_DRIVER="postgres"
+        ;;
+    mysql)
+        DB_DRIVER="mysql"
+        ;;
+    mongodb|mongo)
+        DB_DRIVER="mongo"
+        ;;
+    *)
+        echo "❌ Error: Unsupported database driver: $DB_DRIVER"
+        show_usage
+        ;;
+esac
+
+# Run the migrations embedded in the application
+echo "🔄 Running migrate $* for $DB_DRIVER..."
+DB_DRIVER=$DB_DRIVER go run ./cmd/rest migrate "$@"
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const finderDirective = "//repogen:finder"
+
+// entity is an entity struct as read from its package.
+type entity struct {
+	Name    string
+	Package string
+	Dir     string
+	Table   string
+	IDType  string
+	Tenant  bool
+	Fields  []field
+	Finders []finder
+	// Imports maps the package names of the entity file to their paths.
+	Imports map[string]string
+}
+
+type field struct {
+	Name     string
+	Column   string
+	Type     ast.Expr
+	Nullable bool
+}
+
+// finder matches records whose fields all equal the given arguments.
+type finder struct {
+	Fields []field
+	Unique bool
+}
+
+// Method returns the name of the finder, e.g. FindByStatusAndCustomerID.
+func (f finder) Method() string {
+	names := make([]string, len(f.Fields))
+	for i, field := range f.Fields {
+		names[i] = field.Name
+	}
+
+	return "FindBy" + strings.Join(names, "And")
+}
+
+// Columns returns the columns the finder matches on.
+func (f finder) Columns() []string {
+	columns := make([]string, len(f.Fields))
+	for i, field := range f.Fields {
+		columns[i] = field.Column
+	}
+
+	return columns
+}
+
+// parseEntity reads the entity struct typeName and its finder annotations
+// from the Go files of dir.
+func parseEntity(dir string, typeName string) (*entity, error) {
+	fset := token.NewFileSet()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || strings.HasSuffix(path, "_gen.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	for _, file := range files {
+		spec, doc := findType(file, typeName)
+		if spec == nil {
+			continue
+		}
+
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a struct", typeName)
+		}
+
+		e := &entity{
+			Name:    typeName,
+			Package: file.Name.Name,
+			Dir:     dir,
+			Table:   tableName(files, typeName),
+			Imports: fileImports(file),
+		}
+
+		if err := e.readFields(st); err != nil {
+			return nil, err
+		}
+
+		if err := e.readFinders(doc); err != nil {
+			return nil, err
+		}
+
+		return e, nil
+	}
+
+	return nil, fmt.Errorf("type %s not found in %s", typeName, dir)
+}
+
+func findType(file *ast.File, name string) (*ast.TypeSpec, *ast.CommentGroup) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != name {
+				continue
+			}
+
+			if ts.Doc != nil {
+				return ts, ts.Doc
+			}
+
+			return ts, gen.Doc
+		}
+	}
+
+	return nil, nil
+}
+
+// tableName returns the literal returned by the TableName method of the
+// entity, falling back to its pluralized snake case name.
+func tableName(files []*ast.File, typeName string) string {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Name != "TableName" || fn.Recv == nil || receiverName(fn.Recv) != typeName {
+				continue
+			}
+
+			for _, stmt := range fn.Body.List {
+				ret, ok := stmt.(*ast.ReturnStmt)
+				if !ok || len(ret.Results) != 1 {
+					continue
+				}
+
+				if lit, ok := ret.Results[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if table, err := strconv.Unquote(lit.Value); err == nil {
+						return table
+					}
+				}
+			}
+		}
+	}
+
+	return plural(snakeCase(typeName))
+}
+
+func receiverName(recv *ast.FieldList) string {
+	expr := recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	return ""
+}
+
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+
+		name := filepath.Base(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imports[name] = path
+	}
+
+	return imports
+}
+
+func (e *entity) readFields(st *ast.StructType) error {
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			e.readEmbedded(f.Type)
+			continue
+		}
+
+		tag := reflect.StructTag("")
+		if f.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(f.Tag.Value, "`"))
+		}
+
+		if tag.Get("gorm") == "-" || tag.Get("relation") != "" {
+			continue
+		}
+
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			_, pointer := f.Type.(*ast.StarExpr)
+			e.Fields = append(e.Fields, field{
+				Name:     name.Name,
+				Column:   columnName(name.Name, tag),
+				Type:     f.Type,
+				Nullable: pointer,
+			})
+		}
+	}
+
+	return nil
+}
+
+// readEmbedded picks the ID type up from database.BaseEntity[I] and the
+// tenant column from database.TenantModel.
+func (e *entity) readEmbedded(expr ast.Expr) {
+	var args []ast.Expr
+	if index, ok := expr.(*ast.IndexExpr); ok {
+		expr, args = index.X, []ast.Expr{index.Index}
+	}
+
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	switch sel.Sel.Name {
+	case "BaseEntity":
+		if len(args) == 1 {
+			e.IDType = render(args[0])
+		}
+	case "TenantModel":
+		e.Tenant = true
+	}
+}
+
+// columnName returns the column a field is stored in, which is also its key
+// in BSON documents and repository filters.
+func columnName(name string, tag reflect.StructTag) string {
+	for _, opt := range strings.Split(tag.Get("gorm"), ";") {
+		if column, ok := strings.CutPrefix(opt, "column:"); ok {
+			return column
+		}
+	}
+
+	for _, key := range []string{"bson", "json"} {
+		if column, _, _ := strings.Cut(tag.Get(key), ","); column != "" && column != "-" {
+			return column
+		}
+	}
+
+	return snakeCase(name)
+}
+
+func (e *entity) readFinders(doc *ast.CommentGroup) error {
+	if doc == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, comment := range doc.List {
+		args, ok := strings.CutPrefix(comment.Text, finderDirective)
+		if !ok {
+			continue
+		}
+
+		var f finder
+		for _, name := range strings.Fields(strings.ReplaceAll(args, ",", " ")) {
+			if name == "unique" {
+				f.Unique = true
+				continue
+			}
+
+			fd, ok := e.field(name)
+			if !ok {
+				return fmt.Errorf("%s: %s has no field %s", comment.Text, e.Name, name)
+			}
+			f.Fields = append(f.Fields, fd)
+		}
+
+		if len(f.Fields) == 0 {
+			return fmt.Errorf("%s: no fields to find by", comment.Text)
+		}
+
+		if seen[f.Method()] {
+			return fmt.Errorf("%s: %s is declared twice", comment.Text, f.Method())
+		}
+		seen[f.Method()] = true
+
+		e.Finders = append(e.Finders, f)
+	}
+
+	return nil
+}
+
+func (e *entity) field(name string) (field, bool) {
+	for _, f := range e.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+
+	return field{}, false
+}
+
+// render prints a type expression as it is written in the entity package.
+func render(expr ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+// qualify prints a type expression as it is written outside of the entity
+// package, prefixing the types it declares with pkg.
+func qualify(expr ast.Expr, pkg string) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if t.IsExported() {
+			return pkg + "." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + qualify(t.X, pkg)
+	case *ast.ArrayType:
+		return "[" + renderLen(t.Len) + "]" + qualify(t.Elt, pkg)
+	case *ast.MapType:
+		return "map[" + qualify(t.Key, pkg) + "]" + qualify(t.Value, pkg)
+	default:
+		return render(expr)
+	}
+}
+
+func renderLen(expr ast.Expr) string {
+	if expr == nil {
+		return ""
+	}
+
+	return render(expr)
+}
+
+// snakeCase converts CustomerID to customer_id and OrderItem to order_item.
+func snakeCase(s string) string {
+	runes := []rune(s)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
+
+// paramName returns the name of the finder argument matching a field.
+func paramName(field string) string {
+	name := lowerCamel(field)
+	if token.IsKeyword(name) {
+		return name + "Value"
+	}
+
+	return name
+}
+
+// usedPackages returns the names of the packages a type expression refers
+// to.
+func usedPackages(expr ast.Expr) []string {
+	var pkgs []string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				pkgs = append(pkgs, pkg.Name)
+			}
+		}
+		return true
+	})
+
+	return pkgs
+}
+
+// plural returns the English plural of a snake case noun, e.g. order_items
+// or customer_addresses.
+func plural(s string) string {
+	switch {
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "z"),
+		strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiou", rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
+	default:
+		return s + "s"
+	}
+}
+
+// lowerCamel converts CustomerID to customerID, ID to id and OrderItem to
+// orderItem.
+func lowerCamel(s string) string {
+	runes := []rune(s)
+
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+
+	switch {
+	case upper == len(runes):
+		return strings.ToLower(s)
+	case upper > 1:
+		upper--
+	}
+
+	return strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaming(t *testing.T) {
+	cases := []struct {
+		name   string
+		snake  string
+		plural string
+		camel  string
+	}{
+		{name: "Customer", snake: "customer", plural: "customers", camel: "customer"},
+		{name: "OrderItem", snake: "order_item", plural: "order_items", camel: "orderItem"},
+		{name: "CustomerID", snake: "customer_id", plural: "customer_ids", camel: "customerID"},
+		{name: "ID", snake: "id", plural: "ids", camel: "id"},
+		{name: "HTTPRequest", snake: "http_request", plural: "http_requests", camel: "httpRequest"},
+		{name: "Address", snake: "address", plural: "addresses", camel: "address"},
+		{name: "Category", snake: "category", plural: "categories", camel: "category"},
+		{name: "Survey", snake: "survey", plural: "surveys", camel: "survey"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.snake, snakeCase(tc.name))
+			assert.Equal(t, tc.plural, plural(snakeCase(tc.name)))
+			assert.Equal(t, tc.camel, lowerCamel(tc.name))
+		})
+	}
+}
+
+func TestParamName(t *testing.T) {
+	assert.Equal(t, "customerID", paramName("CustomerID"))
+	assert.Equal(t, "typeValue", paramName("Type"))
+}
+
+// writeEntity writes the entity file of package shop in a new directory.
+func writeEntity(t *testing.T, src string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shop.entity.go"), []byte(src), 0o644))
+
+	return dir
+}
+
+func TestParseEntity(t *testing.T) {
+	dir := writeEntity(t, `package shop
+
+import (
+	"time"
+
+	"example.com/shop/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+//repogen:finder Email unique
+//repogen:finder Status, SignedUpAt
+type Member struct {
+	database.BaseEntity[uuid.UUID]
+	database.TenantModel
+	Email      string    `+"`json:\"email\"`"+`
+	Status     string    `+"`gorm:\"column:state\"`"+`
+	SignedUpAt time.Time
+	Nickname   *string
+	Orders     []string  `+"`gorm:\"-\" relation:\"has_many:member_id\"`"+`
+	secret     string
+}
+
+func (Member) TableName() string {
+	return "shop_members"
+}
+`)
+
+	e, err := parseEntity(dir, "Member")
+	require.NoError(t, err)
+
+	assert.Equal(t, "shop", e.Package)
+	assert.Equal(t, "shop_members", e.Table)
+	assert.Equal(t, "uuid.UUID", e.IDType)
+	assert.True(t, e.Tenant)
+
+	columns := map[string]string{}
+	for _, f := range e.Fields {
+		columns[f.Name] = f.Column
+	}
+	assert.Equal(t, map[string]string{
+		"Email":      "email",
+		"Status":     "state",
+		"SignedUpAt": "signed_up_at",
+		"Nickname":   "nickname",
+	}, columns)
+
+	nickname, _ := e.field("Nickname")
+	assert.True(t, nickname.Nullable)
+
+	require.Len(t, e.Finders, 2)
+	assert.Equal(t, "FindByEmail", e.Finders[0].Method())
+	assert.True(t, e.Finders[0].Unique)
+	assert.Equal(t, "FindByStatusAndSignedUpAt", e.Finders[1].Method())
+	assert.Equal(t, []string{"state", "signed_up_at"}, e.Finders[1].Columns())
+}
+
+func TestParseEntity_TableFallback(t *testing.T) {
+	dir := writeEntity(t, `package shop
+
+type OrderItem struct {
+	Quantity int
+}
+`)
+
+	e, err := parseEntity(dir, "OrderItem")
+	require.NoError(t, err)
+
+	assert.Equal(t, "order_items", e.Table)
+	assert.Empty(t, e.Finders)
+}
+
+func TestParseEntity_Invalid(t *testing.T) {
+	cases := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			src:     "package shop\n\n//repogen:finder Email\ntype Member struct {\n\tName string\n}\n",
+			wantErr: "//repogen:finder Email: Member has no field Email",
+		},
+		{
+			name:    "no fields",
+			src:     "package shop\n\n//repogen:finder unique\ntype Member struct {\n\tName string\n}\n",
+			wantErr: "//repogen:finder unique: no fields to find by",
+		},
+		{
+			name:    "declared twice",
+			src:     "package shop\n\n//repogen:finder Name\n//repogen:finder Name unique\ntype Member struct {\n\tName string\n}\n",
+			wantErr: "//repogen:finder Name unique: FindByName is declared twice",
+		},
+		{
+			name:    "not a struct",
+			src:     "package shop\n\ntype Member string\n",
+			wantErr: "Member is not a struct",
+		},
+		{
+			name:    "missing",
+			src:     "package shop\n\ntype Customer struct{}\n",
+			wantErr: "type Member not found in ",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeEntity(t, tc.src)
+
+			_, err := parseEntity(dir, "Member")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const header = "// Code generated by repogen. DO NOT EDIT.\n\n"
+
+var finderInterfaceTemplate = template.Must(template.New("interface").Parse(header + `package {{.Package}}
+
+{{.Imports}}
+
+// {{.Name}}Finder holds the finders declared on {{.Name}}.
+type {{.Name}}Finder interface {
+{{- range .Finders}}
+	{{.Method}}(ctx context.Context, {{.Params}}) ({{.Result}}, error)
+{{- end}}
+}
+`))
+
+var finderImplTemplate = template.Must(template.New("impl").Parse(header + `package repository
+
+{{.Imports}}
+{{range .Finders}}
+{{- if .Unique}}
+// {{.Method}} returns the {{$.Noun}} matching {{.Args}}, nil when there is none.
+func (r *{{$.Receiver}}) {{.Method}}(ctx context.Context, {{.Params}}) (*{{$.Type}}, error) {
+	res, err := r.FindAll(ctx, map[string]any{
+	{{- range .Filter}}
+		{{.}},
+	{{- end}}
+	})
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+{{else}}
+// {{.Method}} returns the {{$.Noun}}s matching {{.Args}}.
+func (r *{{$.Receiver}}) {{.Method}}(ctx context.Context, {{.Params}}) ([]{{$.Type}}, error) {
+	return r.FindAll(ctx, map[string]any{
+	{{- range .Filter}}
+		{{.}},
+	{{- end}}
+	})
+}
+{{end}}
+{{- end}}`))
+
+type finderView struct {
+	Method string
+	Params string
+	Args   string
+	Result string
+	Filter []string
+	Unique bool
+}
+
+// writeFinders writes the finder interface next to the entity and its
+// implementation on the concrete repository, built on FindAll so that the
+// base repository translates the filter to squirrel or BSON and applies
+// soft deletes, tenants and relations as usual.
+func (p project) writeFinders(e *entity) ([]string, error) {
+	domainPath, err := p.importPath(e.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	snake := snakeCase(e.Name)
+	iface := filepath.Join(e.Dir, snake+".finder_gen.go")
+	impl := filepath.Join(p.Root, "internal", "application", e.Package, "repository", snake+".finder_gen.go")
+
+	var (
+		domainFinders []finderView
+		implFinders   []finderView
+		domainPkgs    = map[string]bool{}
+		implPkgs      = map[string]bool{}
+	)
+
+	for _, f := range e.Finders {
+		var domainParams, implParams, args, filter []string
+		for _, fd := range f.Fields {
+			name := paramName(fd.Name)
+
+			domainParams = append(domainParams, name+" "+render(fd.Type))
+			implParams = append(implParams, name+" "+qualify(fd.Type, e.Package))
+			args = append(args, name)
+			filter = append(filter, fmt.Sprintf("%q: %s", fd.Column, name))
+
+			for _, pkg := range usedPackages(fd.Type) {
+				domainPkgs[pkg] = true
+				implPkgs[pkg] = true
+			}
+		}
+
+		result := "[]" + e.Name
+		if f.Unique {
+			result = "*" + e.Name
+		}
+
+		view := finderView{
+			Method: f.Method(),
+			Args:   joinWords(args),
+			Filter: filter,
+			Unique: f.Unique,
+		}
+
+		view.Params, view.Result = strings.Join(domainParams, ", "), result
+		domainFinders = append(domainFinders, view)
+
+		view.Params = strings.Join(implParams, ", ")
+		implFinders = append(implFinders, view)
+	}
+
+	var std []string
+	if len(e.Finders) > 0 {
+		std = []string{"context"}
+	}
+
+	domainImports, err := e.importBlock(std, domainPkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeSource(iface, finderInterfaceTemplate, map[string]any{
+		"Package": e.Package,
+		"Name":    e.Name,
+		"Imports": domainImports,
+		"Finders": domainFinders,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without finders the interface is empty and the concrete repository has
+	// nothing to implement.
+	if len(e.Finders) == 0 {
+		if err := os.Remove(impl); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return []string{iface}, nil
+	}
+
+	implPkgs[e.Package] = true
+	e.Imports[e.Package] = domainPath
+
+	implImports, err := e.importBlock(std, implPkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeSource(impl, finderImplTemplate, map[string]any{
+		"Imports":  implImports,
+		"Receiver": lowerCamel(e.Name) + "Repository",
+		"Type":     e.Package + "." + e.Name,
+		"Noun":     strings.ReplaceAll(snakeCase(e.Name), "_", " "),
+		"Finders":  implFinders,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{iface, impl}, nil
+}
+
+// importPath returns the import path of a directory of the module.
+func (p project) importPath(dir string) (string, error) {
+	rel, err := filepath.Rel(p.Root, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside of module %s", dir, p.Module)
+	}
+
+	return p.Module + "/" + filepath.ToSlash(rel), nil
+}
+
+// importBlock renders the import declaration of the standard packages std
+// and of the packages pkgs, resolved through the imports of the entity file.
+func (e *entity) importBlock(std []string, pkgs map[string]bool) (string, error) {
+	var others []string
+	for pkg := range pkgs {
+		path, ok := e.Imports[pkg]
+		if !ok {
+			return "", fmt.Errorf("package %s of %s is not imported", pkg, e.Name)
+		}
+
+		spec := fmt.Sprintf("%q", path)
+		if filepath.Base(path) != pkg {
+			spec = pkg + " " + spec
+		}
+		others = append(others, spec)
+	}
+	sort.Strings(others)
+
+	var groups []string
+	for _, group := range [][]string{quoteAll(std), others} {
+		if len(group) > 0 {
+			groups = append(groups, "\t"+strings.Join(group, "\n\t"))
+		}
+	}
+
+	if len(groups) == 0 {
+		return "", nil
+	}
+
+	return "import (\n" + strings.Join(groups, "\n\n") + "\n)", nil
+}
+
+func quoteAll(items []string) []string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+
+	return quoted
+}
+
+// joinWords joins items as prose, e.g. "status and customerID".
+func joinWords(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// writeSource renders a Go file, formats it and writes it to path.
+func writeSource(path string, tmpl *template.Template, data any) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, src, 0o644)
+}
@@ -0,0 +1,156 @@
+// Command repogen generates the typed finders of an entity repository, the
+// matching mocks and migration stubs. It runs through go generate from the
+// entity file:
+//
+//	//go:generate go run github.com/goodone-dev/go-boilerplate/cmd/repogen -type=Customer
+//
+// Finders are declared above the entity struct, one per line, by the fields
+// they match on. Unique finders return a single record:
+//
+//	//repogen:finder Email unique
+//	//repogen:finder Status CustomerID
+//
+// With -new it scaffolds a new entity, its repository interface and its
+// implementation instead, then generates them.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		typeName   = flag.String("type", "", "entity struct to generate the repository of")
+		newName    = flag.String("new", "", "scaffold a new entity and its repository")
+		migrations = flag.String("migrations", "postgres,mysql", "comma separated drivers to write migration stubs for, empty to skip")
+		mock       = flag.Bool("mock", true, "regenerate the repository mocks with mockery")
+	)
+	flag.Parse()
+
+	root, module, err := findModule()
+	if err != nil {
+		fail(err)
+	}
+
+	p := project{
+		Root:    root,
+		Module:  module,
+		Drivers: splitList(*migrations),
+		Mock:    *mock,
+	}
+
+	switch {
+	case *newName != "":
+		err = p.scaffold(*newName)
+	case *typeName != "":
+		dir, _ := os.Getwd()
+		err = p.generate(dir, *typeName)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// project holds where the generated files go and what to generate.
+type project struct {
+	Root    string
+	Module  string
+	Drivers []string
+	Mock    bool
+}
+
+// generate writes the finders of the entity declared in dir, then their
+// migration stubs and mocks.
+func (p project) generate(dir string, typeName string) error {
+	e, err := parseEntity(dir, typeName)
+	if err != nil {
+		return err
+	}
+
+	files, err := p.writeFinders(e)
+	if err != nil {
+		return err
+	}
+
+	for _, driver := range p.Drivers {
+		stubs, err := p.writeMigrations(e, driver)
+		if err != nil {
+			return err
+		}
+		files = append(files, stubs...)
+	}
+
+	for _, file := range files {
+		fmt.Printf("- %s\n", p.rel(file))
+	}
+	fmt.Printf("✅ Generated %s repository\n", e.Name)
+
+	if p.Mock {
+		return p.mock(e)
+	}
+
+	return nil
+}
+
+func (p project) rel(path string) string {
+	if rel, err := filepath.Rel(p.Root, path); err == nil {
+		return rel
+	}
+
+	return path
+}
+
+// findModule walks up from the working directory to the go.mod and returns
+// its directory and module path.
+func findModule() (string, string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		f, err := os.Open(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			defer f.Close()
+
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+					return dir, strings.TrimSpace(module), nil
+				}
+			}
+
+			return "", "", fmt.Errorf("no module declared in %s", filepath.Join(dir, "go.mod"))
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", fmt.Errorf("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "❌ repogen: %v\n", err)
+	os.Exit(1)
+}
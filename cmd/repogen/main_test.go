@@ -0,0 +1,236 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const memberEntity = `package shop
+
+import (
+	"example.com/shop/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+//repogen:finder Email unique
+//repogen:finder Status CustomerID
+type Member struct {
+	database.BaseEntity[uuid.UUID]
+	Email      string
+	Status     string
+	CustomerID uuid.UUID
+}
+`
+
+// newTestProject returns a module holding the entity Member of package
+// shop, written from src.
+func newTestProject(t *testing.T, src string) (project, string) {
+	t.Helper()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "internal", "domain", "shop")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	writeFile(t, filepath.Join(dir, "shop.entity.go"), src)
+
+	return project{
+		Root:    root,
+		Module:  "example.com/shop",
+		Drivers: []string{"postgres", "mysql"},
+	}, dir
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+// migrations returns the contents of the migrations of driver by file name.
+func migrations(t *testing.T, p project, driver string) map[string]string {
+	t.Helper()
+
+	dir := filepath.Join(p.Root, "migrations", driver)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	res := map[string]string{}
+	for _, entry := range entries {
+		res[entry.Name()] = readFile(t, filepath.Join(dir, entry.Name()))
+	}
+
+	return res
+}
+
+func TestProject_Generate(t *testing.T) {
+	p, dir := newTestProject(t, memberEntity)
+
+	require.NoError(t, p.generate(dir, "Member"))
+
+	assert.Equal(t, `// Code generated by repogen. DO NOT EDIT.
+
+package shop
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// MemberFinder holds the finders declared on Member.
+type MemberFinder interface {
+	FindByEmail(ctx context.Context, email string) (*Member, error)
+	FindByStatusAndCustomerID(ctx context.Context, status string, customerID uuid.UUID) ([]Member, error)
+}
+`, readFile(t, filepath.Join(dir, "member.finder_gen.go")))
+
+	assert.Equal(t, `// Code generated by repogen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"example.com/shop/internal/domain/shop"
+	"github.com/google/uuid"
+)
+
+// FindByEmail returns the member matching email, nil when there is none.
+func (r *memberRepository) FindByEmail(ctx context.Context, email string) (*shop.Member, error) {
+	res, err := r.FindAll(ctx, map[string]any{
+		"email": email,
+	})
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// FindByStatusAndCustomerID returns the members matching status and customerID.
+func (r *memberRepository) FindByStatusAndCustomerID(ctx context.Context, status string, customerID uuid.UUID) ([]shop.Member, error) {
+	return r.FindAll(ctx, map[string]any{
+		"status":      status,
+		"customer_id": customerID,
+	})
+}
+`, readFile(t, filepath.Join(p.Root, "internal", "application", "shop", "repository", "member.finder_gen.go")))
+
+	for _, driver := range p.Drivers {
+		files := migrations(t, p, driver)
+		require.Len(t, files, 2, driver)
+
+		for name, content := range files {
+			assert.Regexp(t, `^\d{14}_create_members_table\.(up|down)\.sql$`, name)
+			if strings.HasSuffix(name, ".down.sql") {
+				assert.Equal(t, "DROP TABLE IF EXISTS members;\n", content)
+				continue
+			}
+
+			assert.Contains(t, content, "CREATE TABLE IF NOT EXISTS members (")
+			assert.Contains(t, content, "idx_members_email ON members (email)")
+			assert.Contains(t, content, "idx_members_status_customer_id ON members (status, customer_id)")
+		}
+	}
+
+	postgres := migrations(t, p, "postgres")
+	for name, content := range postgres {
+		if strings.HasSuffix(name, ".up.sql") {
+			assert.Contains(t, content, "id UUID PRIMARY KEY DEFAULT gen_random_uuid()")
+			assert.Contains(t, content, "CREATE UNIQUE INDEX IF NOT EXISTS idx_members_email ON members (email) WHERE deleted_at IS NULL;")
+		}
+	}
+}
+
+func TestProject_Generate_Idempotent(t *testing.T) {
+	p, dir := newTestProject(t, memberEntity)
+
+	require.NoError(t, p.generate(dir, "Member"))
+
+	iface := readFile(t, filepath.Join(dir, "member.finder_gen.go"))
+	before := map[string]map[string]string{}
+	for _, driver := range p.Drivers {
+		before[driver] = migrations(t, p, driver)
+	}
+
+	require.NoError(t, p.generate(dir, "Member"))
+
+	assert.Equal(t, iface, readFile(t, filepath.Join(dir, "member.finder_gen.go")))
+	for _, driver := range p.Drivers {
+		assert.Equal(t, before[driver], migrations(t, p, driver), driver)
+	}
+}
+
+func TestProject_Generate_NewFinder(t *testing.T) {
+	p, dir := newTestProject(t, memberEntity)
+	require.NoError(t, p.generate(dir, "Member"))
+
+	// A finder on a prefix of the columns of an indexed finder still needs
+	// an index of its own.
+	writeFile(t, filepath.Join(dir, "shop.entity.go"), `package shop
+
+import (
+	"example.com/shop/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+//repogen:finder Email unique
+//repogen:finder Status CustomerID
+//repogen:finder Status
+type Member struct {
+	database.BaseEntity[uuid.UUID]
+	Email      string
+	Status     string
+	CustomerID uuid.UUID
+}
+`)
+	require.NoError(t, p.generate(dir, "Member"))
+
+	for _, driver := range p.Drivers {
+		files := migrations(t, p, driver)
+		require.Len(t, files, 4, driver)
+
+		var added []string
+		for name, content := range files {
+			if strings.HasSuffix(name, "_add_idx_members_status.up.sql") {
+				added = append(added, content)
+			}
+		}
+
+		require.Len(t, added, 1, driver)
+		assert.Contains(t, added[0], "idx_members_status ON members (status);")
+	}
+}
+
+func TestProject_Generate_NoFinders(t *testing.T) {
+	p, dir := newTestProject(t, memberEntity)
+	p.Drivers = nil
+	require.NoError(t, p.generate(dir, "Member"))
+
+	impl := filepath.Join(p.Root, "internal", "application", "shop", "repository", "member.finder_gen.go")
+	require.FileExists(t, impl)
+
+	writeFile(t, filepath.Join(dir, "shop.entity.go"), `package shop
+
+type Member struct {
+	Email string
+}
+`)
+	require.NoError(t, p.generate(dir, "Member"))
+
+	// Nothing is left to implement once the finders are gone.
+	assert.NoFileExists(t, impl)
+	assert.Contains(t, readFile(t, filepath.Join(dir, "member.finder_gen.go")), "type MemberFinder interface {\n}")
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// dialect describes how the migration stubs of a driver are written.
+type dialect struct {
+	types     map[string]string
+	fallback  string
+	uuidID    string
+	serialID  string
+	timestamp string
+	now       string
+	// partialUnique is set when unique indexes can skip soft-deleted rows.
+	partialUnique bool
+	ifNotExists   bool
+	dropIndex     string
+	rls           bool
+}
+
+var dialects = map[string]dialect{
+	"postgres": {
+		types: map[string]string{
+			"string":    "VARCHAR",
+			"int":       "INT",
+			"int16":     "SMALLINT",
+			"int32":     "INT",
+			"int64":     "BIGINT",
+			"uint":      "BIGINT",
+			"float32":   "DECIMAL(10, 2)",
+			"float64":   "DECIMAL(10, 2)",
+			"bool":      "BOOLEAN",
+			"time.Time": "TIMESTAMPTZ",
+			"uuid.UUID": "UUID",
+			"[]byte":    "BYTEA",
+		},
+		fallback:      "JSONB",
+		uuidID:        "UUID PRIMARY KEY DEFAULT gen_random_uuid()",
+		serialID:      "BIGSERIAL PRIMARY KEY",
+		timestamp:     "TIMESTAMPTZ",
+		now:           "NOW()",
+		partialUnique: true,
+		ifNotExists:   true,
+		dropIndex:     "DROP INDEX IF EXISTS %[1]s;",
+		rls:           true,
+	},
+	"mysql": {
+		types: map[string]string{
+			"string":    "VARCHAR(255)",
+			"int":       "INT",
+			"int16":     "SMALLINT",
+			"int32":     "INT",
+			"int64":     "BIGINT",
+			"uint":      "BIGINT UNSIGNED",
+			"float32":   "DECIMAL(10, 2)",
+			"float64":   "DECIMAL(10, 2)",
+			"bool":      "BOOLEAN",
+			"time.Time": "DATETIME(6)",
+			"uuid.UUID": "BINARY(16)",
+			"[]byte":    "BLOB",
+		},
+		fallback:  "JSON",
+		uuidID:    "BINARY(16) PRIMARY KEY DEFAULT (UUID_TO_BIN(UUID()))",
+		serialID:  "BIGINT AUTO_INCREMENT PRIMARY KEY",
+		timestamp: "DATETIME(6)",
+		now:       "CURRENT_TIMESTAMP(6)",
+		dropIndex: "DROP INDEX %[1]s ON %[2]s;",
+	},
+}
+
+const rlsPolicy = `ALTER TABLE %[1]s ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON %[1]s
+    USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+`
+
+// writeMigrations writes a create table stub when the entity has no table
+// yet, or an index stub for every finder whose index no migration creates.
+func (p project) writeMigrations(e *entity, driver string) ([]string, error) {
+	d, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("no migration stubs for driver %s", driver)
+	}
+
+	dir := filepath.Join(p.Root, "migrations", driver)
+
+	existing, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var sources strings.Builder
+	created := false
+	for _, entry := range existing {
+		if !strings.HasSuffix(entry.Name(), ".up.sql") {
+			continue
+		}
+
+		if strings.HasSuffix(entry.Name(), "_create_"+e.Table+"_table.up.sql") {
+			created = true
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sources.Write(data)
+	}
+
+	stamp := newStamper()
+
+	if !created {
+		return writeMigration(dir, stamp(), "create_"+e.Table+"_table", d.createTable(e), fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", e.Table))
+	}
+
+	var files []string
+	for _, f := range e.Finders {
+		name := indexName(e, f)
+		if indexed(sources.String(), name) {
+			continue
+		}
+
+		up := d.createIndex(e, f)
+		down := fmt.Sprintf(d.dropIndex, name, e.Table) + "\n"
+
+		written, err := writeMigration(dir, stamp(), "add_"+name, up, down)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, written...)
+	}
+
+	return files, nil
+}
+
+func (d dialect) createTable(e *entity) string {
+	id := d.uuidID
+	if e.IDType != "uuid.UUID" {
+		id = d.serialID
+	}
+
+	columns := []string{"id " + id}
+	if e.Tenant {
+		columns = append(columns, "tenant_id VARCHAR(63) NOT NULL DEFAULT ''")
+	}
+
+	for _, f := range e.Fields {
+		typ := strings.TrimPrefix(render(f.Type), "*")
+
+		column := f.Column + " " + d.columnType(typ)
+		if !f.Nullable {
+			column += " NOT NULL"
+		}
+		columns = append(columns, column)
+	}
+
+	columns = append(columns,
+		fmt.Sprintf("created_at %s NOT NULL DEFAULT %s", d.timestamp, d.now),
+		fmt.Sprintf("updated_at %s NOT NULL DEFAULT %s", d.timestamp, d.now),
+		fmt.Sprintf("deleted_at %s", d.timestamp),
+	)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (\n    %s\n);\n", e.Table, strings.Join(columns, ",\n    "))
+
+	if len(e.Finders) > 0 || e.Tenant {
+		b.WriteString("\n")
+	}
+
+	if e.Tenant {
+		fmt.Fprintf(&b, "CREATE INDEX idx_%[1]s_tenant_id ON %[1]s (tenant_id);\n", e.Table)
+	}
+
+	for _, f := range e.Finders {
+		b.WriteString(d.createIndex(e, f))
+	}
+
+	if e.Tenant && d.rls {
+		b.WriteString("\n-- Policies bind every role but the owner of the tables: with TENANT_MODE=rls the application connects\n" +
+			"-- as such a role, and sees the rows of the tenant it pins in app.tenant_id only, none without one, or\n" +
+			"-- those of every tenant once it pins app.all_tenants for cross-tenant jobs.\n")
+		fmt.Fprintf(&b, rlsPolicy, e.Table)
+	}
+
+	return b.String()
+}
+
+func (d dialect) columnType(typ string) string {
+	if t, ok := d.types[typ]; ok {
+		return t
+	}
+
+	return d.fallback
+}
+
+// createIndex indexes the columns of a finder, after the tenant so that the
+// index also serves, and unique finders stay unique, per tenant.
+func (d dialect) createIndex(e *entity, f finder) string {
+	columns := f.Columns()
+	if e.Tenant {
+		columns = append([]string{"tenant_id"}, columns...)
+	}
+
+	kind := "INDEX"
+	if f.Unique {
+		kind = "UNIQUE INDEX"
+	}
+
+	ifNotExists := ""
+	if d.ifNotExists {
+		ifNotExists = "IF NOT EXISTS "
+	}
+
+	where := ""
+	if f.Unique && d.partialUnique {
+		where = " WHERE deleted_at IS NULL"
+	}
+
+	return fmt.Sprintf("CREATE %s %s%s ON %s (%s)%s;\n", kind, ifNotExists, indexName(e, f), e.Table, strings.Join(columns, ", "), where)
+}
+
+func indexName(e *entity, f finder) string {
+	return "idx_" + e.Table + "_" + strings.Join(f.Columns(), "_")
+}
+
+// indexed reports whether the migrations in sources mention the index name
+// as a whole word, so that idx_orders_status is not taken for
+// idx_orders_status_customer_id.
+func indexed(sources string, name string) bool {
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(sources)
+}
+
+// newStamper returns migration versions in the format of make
+// db-migrate-new, one second apart so that stubs written together keep
+// their order.
+func newStamper() func() string {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.Local
+	}
+
+	now := time.Now().In(loc)
+	return func() string {
+		version := now.Format("20060102150405")
+		now = now.Add(time.Second)
+		return version
+	}
+}
+
+func writeMigration(dir string, version string, name string, up string, down string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	base := filepath.Join(dir, version+"_"+name)
+
+	files := []string{base + ".up.sql", base + ".down.sql"}
+	for i, content := range []string{up, down} {
+		if err := os.WriteFile(files[i], []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const mockeryEntry = `
+  %s:
+    interfaces:
+      %s:
+        config:
+          dir: "{{.InterfaceDir}}/mocks"
+          filename: "%s.repository_mock.go"
+`
+
+// mock registers the repository interface of the entity in .mockery.yml
+// when missing and regenerates the mocks, which then include the finders.
+func (p project) mock(e *entity) error {
+	pkg, err := p.importPath(e.Dir)
+	if err != nil {
+		return err
+	}
+
+	config := filepath.Join(p.Root, ".mockery.yml")
+
+	data, err := os.ReadFile(config)
+	if err != nil {
+		return err
+	}
+
+	iface := e.Name + "Repository"
+
+	switch {
+	case !strings.Contains(string(data), "\n  "+pkg+":"):
+		entry := fmt.Sprintf(mockeryEntry, pkg, iface, snakeCase(e.Name))
+
+		f, err := os.OpenFile(config, os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if _, err := f.WriteString(entry); err != nil {
+			return err
+		}
+	case !strings.Contains(string(data), "\n      "+iface+":"):
+		fmt.Printf("⚠️ Add %s to the %s package of .mockery.yml to mock it\n", iface, pkg)
+	}
+
+	path, err := exec.LookPath("mockery")
+	if err != nil {
+		fmt.Println("⚠️ mockery is not installed, run make mock after installing it")
+		return nil
+	}
+
+	cmd := exec.Command(path, "--log-level=ERROR")
+	cmd.Dir = p.Root
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("mockery: %w", err)
+	}
+
+	fmt.Println("✅ Mock files generated successfully!")
+
+	return nil
+}
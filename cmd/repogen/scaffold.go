@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+var entityNameRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+var entityTemplate = template.Must(template.New("entity").Parse(`package {{.Package}}
+
+import (
+	"{{.Module}}/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+//go:generate go run {{.Module}}/cmd/repogen -type={{.Name}}
+
+// Declare finders by the fields they match on, e.g.
+//
+//	//repogen:finder Email unique
+type {{.Name}} struct {
+	database.BaseEntity[uuid.UUID] ` + "`" + `bson:",inline"` + "`" + `
+	// Add your fields here
+}
+
+func ({{.Name}}) TableName() string {
+	return "{{.Table}}"
+}
+
+func ({{.Name}}) RepositoryName() string {
+	return "{{.Name}}Repository"
+}
+`))
+
+var repositoryInterfaceTemplate = template.Must(template.New("repository").Parse(`package {{.Package}}
+
+import (
+	"{{.Module}}/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+type {{.Name}}Repository interface {
+	database.BaseRepository[uuid.UUID, {{.Name}}]
+	{{.Name}}Finder
+}
+`))
+
+var repositoryTemplate = template.Must(template.New("implementation").Parse(`package repository
+
+import (
+	"{{.Module}}/internal/domain/{{.Package}}"
+	"{{.Module}}/internal/infrastructure/database"
+	"github.com/google/uuid"
+)
+
+type {{.Camel}}Repository struct {
+	database.BaseRepository[uuid.UUID, {{.Package}}.{{.Name}}]
+}
+
+func New{{.Name}}Repository(baseRepo database.BaseRepository[uuid.UUID, {{.Package}}.{{.Name}}]) {{.Package}}.{{.Name}}Repository {
+	return &{{.Camel}}Repository{
+		baseRepo,
+	}
+}
+`))
+
+// scaffold writes a new entity, its repository interface and its
+// implementation, then generates them. The entity lives in the domain named
+// after its first word, e.g. CustomerAddress in internal/domain/customer.
+func (p project) scaffold(name string) error {
+	if !entityNameRegex.MatchString(name) {
+		return fmt.Errorf("entity name %q must be in PascalCase, e.g. CustomerAddress", name)
+	}
+
+	snake := snakeCase(name)
+	pkg, _, _ := strings.Cut(snake, "_")
+
+	domainDir := filepath.Join(p.Root, "internal", "domain", pkg)
+	repoDir := filepath.Join(p.Root, "internal", "application", pkg, "repository")
+
+	data := map[string]string{
+		"Module":  p.Module,
+		"Package": pkg,
+		"Name":    name,
+		"Table":   plural(snake),
+		"Camel":   lowerCamel(name),
+	}
+
+	files := []struct {
+		path string
+		tmpl *template.Template
+	}{
+		{filepath.Join(domainDir, snake+".entity.go"), entityTemplate},
+		{filepath.Join(domainDir, snake+".repository.go"), repositoryInterfaceTemplate},
+		{filepath.Join(repoDir, snake+".repository.go"), repositoryTemplate},
+	}
+
+	for _, file := range files {
+		if exists(file.path) {
+			return fmt.Errorf("%s already exists", p.rel(file.path))
+		}
+	}
+
+	for _, file := range files {
+		if err := writeSource(file.path, file.tmpl, data); err != nil {
+			return err
+		}
+		fmt.Printf("- %s\n", p.rel(file.path))
+	}
+
+	// The table is stubbed on the next go generate, once the entity has its
+	// fields.
+	p.Drivers = nil
+
+	if err := p.generate(domainDir, name); err != nil {
+		return err
+	}
+
+	fmt.Println("")
+	fmt.Println("Don't forget to:")
+	fmt.Println("1. Define your fields and finders on the entity struct")
+	fmt.Println("2. Run go generate ./... to generate the finders and migration stubs")
+	fmt.Println("3. Setup repository in main.go")
+	fmt.Println("4. Add the repository to your dependency injection")
+
+	return nil
+}
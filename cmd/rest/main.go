@@ -21,17 +21,21 @@ import (
 	"github.com/goodone-dev/go-boilerplate/internal/domain/customer"
 	"github.com/goodone-dev/go-boilerplate/internal/domain/order"
 	"github.com/goodone-dev/go-boilerplate/internal/domain/product"
-	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/redis"
-	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/postgres"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/tiered"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/driver"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/lock"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 	mailsender "github.com/goodone-dev/go-boilerplate/internal/infrastructure/mail"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/messaging/rabbitmq"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/messaging/rabbitmq/direct"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	"github.com/goodone-dev/go-boilerplate/internal/presentation/rest/middleware"
 	"github.com/goodone-dev/go-boilerplate/internal/presentation/rest/router"
 	"github.com/goodone-dev/go-boilerplate/internal/presentation/worker/consumer"
+	"github.com/goodone-dev/go-boilerplate/internal/presentation/worker/scheduler"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 func main() {
@@ -43,24 +47,32 @@ func main() {
 		l.Fatal("❌ Could not load environment variables", err)
 	}
 
+	// ========== Schema Setup ==========
+	database.RegisterSchema(customer.Customer{}, product.Product{}, order.Order{}, order.OrderItem{}, database.AuditLog{})
+
+	// ========== Migration Command ==========
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(ctx, os.Args[2:]))
+	}
+
 	// ========== Observability Setup ==========
 	loggerProvider := logger.NewProvider(ctx)
 	tracerProvider := tracer.NewProvider(ctx)
 
 	// ========== Infrastructure Setup ==========
-	postgresConn := postgres.Open(ctx)
-	redisClient := redis.NewClient(ctx)
+	dbConn := driver.Open(ctx)
+	cacheClient := tiered.NewClient(ctx)
 	mailSender := mailsender.NewMailSender()
 	rmqClient := rabbitmq.NewClient(ctx)
 
 	// ========== Repositories Setup ==========
-	customerBaseRepo := postgres.NewBaseRepository[gorm.DB, uuid.UUID, customer.Customer](postgresConn)
+	customerBaseRepo := newBaseRepository[uuid.UUID, customer.Customer](ctx, dbConn)
 	customerRepo := customerrepo.NewCustomerRepository(customerBaseRepo)
-	productBaseRepo := postgres.NewBaseRepository[gorm.DB, uuid.UUID, product.Product](postgresConn)
+	productBaseRepo := database.NewCachedRepository(newBaseRepository[uuid.UUID, product.Product](ctx, dbConn), cacheClient)
 	productRepo := productrepo.NewProductRepository(productBaseRepo)
-	orderBaseRepo := postgres.NewBaseRepository[gorm.DB, uuid.UUID, order.Order](postgresConn)
+	orderBaseRepo := newBaseRepository[uuid.UUID, order.Order](ctx, dbConn)
 	orderRepo := orderrepo.NewOrderRepository(orderBaseRepo)
-	orderItemBaseRepo := postgres.NewBaseRepository[gorm.DB, uuid.UUID, order.OrderItem](postgresConn)
+	orderItemBaseRepo := newBaseRepository[uuid.UUID, order.OrderItem](ctx, dbConn)
 	orderItemRepo := orderrepo.NewOrderItemRepository(orderItemBaseRepo)
 
 	// ========== Publisher Setup ==========
@@ -74,18 +86,29 @@ func main() {
 		orderRepo,
 		orderItemRepo,
 		rmqDirectPub,
+		cache.NewTagPurger(cacheClient),
 	)
 
 	// ========== HTTP Handler Setup ==========
-	healthHandler := healthhandler.NewHealthHandler(postgresConn, redisClient, rmqClient)
+	healthHandler := healthhandler.NewHealthHandler(dbConn, cacheClient, rmqClient)
 	orderHandler := orderhandler.NewOrderHandler(orderUsecase)
 
 	// ========== Consumer Setup ==========
 	consumer := consumer.NewConsumer(rmqClient, mailUsecase)
 	consumer.Consume(ctx)
 
+	// ========== Scheduler Setup ==========
+	locker := lock.NewLocker(cacheClient)
+	scheduler := scheduler.NewScheduler(locker, orderItemRepo, orderRepo, customerRepo, productRepo)
+	scheduler.Schedule(ctx)
+
 	// ========== HTTP Server Setup ==========
-	r := router.NewRouter(healthHandler, orderHandler, redisClient)
+	rateLimitPolicies, err := middleware.LoadRateLimitPolicies()
+	if err != nil {
+		logger.Fatal(ctx, err, "❌ Failed to load rate limit policies").Write()
+	}
+
+	r := router.NewRouter(healthHandler, orderHandler, cacheClient, rateLimitPolicies)
 	addr := fmt.Sprintf(":%d", config.Application.Port)
 
 	srv := &http.Server{
@@ -122,5 +145,16 @@ func main() {
 
 	logger.Info(ctx, "✅ Server shutdown gracefully").Write()
 
-	utils.GracefulShutdown(ctx, loggerProvider, tracerProvider, postgresConn, redisClient, rmqClient)
+	utils.GracefulShutdown(ctx, loggerProvider, tracerProvider, dbConn, cacheClient, rmqClient)
+}
+
+// newBaseRepository returns the base repository of E on conn, stopping the
+// application when conn is of no supported driver.
+func newBaseRepository[I any, E database.Entity](ctx context.Context, conn driver.Connection) database.BaseRepository[I, E] {
+	repo, err := driver.NewBaseRepository[I, E](conn)
+	if err != nil {
+		logger.Fatal(ctx, err, "❌ Failed to set up the repositories").Write()
+	}
+
+	return repo
 }
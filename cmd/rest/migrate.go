@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/driver"
+)
+
+const migrateUsage = `Usage: main migrate <command> [arg]
+
+Runs the migrations embedded in the binary against the database selected by
+DB_DRIVER.
+
+Commands:
+  up [N]        Apply all pending migrations, or the next N, then the
+                indexes and validators declared by entities
+  down [N|all]  Roll back the last N migrations (default 1), or all of them
+  goto V        Migrate up or down to version V, then apply the declared
+                indexes and validators when migrating up
+  force V       Set the version to V without running migrations, clearing
+                the dirty flag after a failed migration was fixed by hand
+  version       Print the current version
+  status        List the migrations and whether they are applied, and how
+                the database drifted from the declared indexes and validators
+`
+
+// runMigrate runs the migrate subcommand and returns the exit code.
+func runMigrate(ctx context.Context, args []string) int {
+	command, err := parseMigrate(args)
+	if err != nil {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	m, err := driver.NewMigrator(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to create migration instance: %v\n", err)
+		return 1
+	}
+	defer m.Close()
+
+	// Stop after the running migration on interrupt, leaving the database
+	// at a clean version.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-quit
+		m.GracefulStop <- true
+	}()
+
+	err = command(m)
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("✅ No change")
+		err = nil
+	}
+	if err == nil {
+		err = printVersion(m)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Migration failed: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+var errUsage = errors.New("invalid usage")
+
+// parseMigrate returns the migration run by args, checked before connecting.
+func parseMigrate(args []string) (func(m *database.Migrator) error, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return nil, errUsage
+	}
+
+	command, args := args[0], args[1:]
+
+	switch {
+	case command == "up" && len(args) == 0:
+		return func(m *database.Migrator) error { return m.Up() }, nil
+
+	case command == "up" && len(args) == 1:
+		n, err := positive(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return func(m *database.Migrator) error { return m.Steps(n) }, nil
+
+	case command == "down" && len(args) == 0:
+		return func(m *database.Migrator) error { return m.Steps(-1) }, nil
+
+	case command == "down" && len(args) == 1 && args[0] == "all":
+		return func(m *database.Migrator) error { return m.Down() }, nil
+
+	case command == "down" && len(args) == 1:
+		n, err := positive(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return func(m *database.Migrator) error { return m.Steps(-n) }, nil
+
+	case command == "goto" && len(args) == 1:
+		version, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return nil, errUsage
+		}
+		return func(m *database.Migrator) error { return m.Goto(uint(version)) }, nil
+
+	case command == "force" && len(args) == 1:
+		version, err := strconv.Atoi(args[0])
+		if err != nil || version < -1 {
+			return nil, errUsage
+		}
+		return func(m *database.Migrator) error { return m.Force(version) }, nil
+
+	case command == "version" && len(args) == 0:
+		return func(m *database.Migrator) error { return nil }, nil
+
+	case command == "status" && len(args) == 0:
+		return printStatus, nil
+	}
+
+	return nil, errUsage
+}
+
+func positive(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n <= 0 {
+		return 0, errUsage
+	}
+
+	return n, nil
+}
+
+func printVersion(m *database.Migrator) error {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		fmt.Println("No migration applied")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		fmt.Printf("⚠️ Version %d (dirty)\n", version)
+		return nil
+	}
+
+	fmt.Printf("✅ Version %d\n", version)
+
+	return nil
+}
+
+func printStatus(m *database.Migrator) error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, s := range statuses {
+		status := "pending"
+		switch {
+		case s.Dirty:
+			status = "dirty"
+		case s.Applied:
+			status = "applied"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, status)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	drifts, err := m.Drift()
+	if err != nil {
+		return err
+	}
+
+	for _, drift := range drifts {
+		fmt.Printf("⚠️ Schema drift: %s\n", drift)
+	}
+
+	return nil
+}
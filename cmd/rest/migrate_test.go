@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/database/stub"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+// schemaSpy counts the times the declared schemas were applied.
+type schemaSpy struct {
+	applied int
+}
+
+func (s *schemaSpy) ApplySchema(ctx context.Context) error {
+	s.applied++
+	return nil
+}
+
+func (s *schemaSpy) SchemaDrift(ctx context.Context) ([]database.SchemaDrift, error) {
+	return nil, nil
+}
+
+func TestParseMigrate_Usage(t *testing.T) {
+	cases := [][]string{
+		nil,
+		{"sideways"},
+		{"up", "0"},
+		{"up", "-1"},
+		{"up", "two"},
+		{"down", "none"},
+		{"goto"},
+		{"goto", "-3"},
+		{"force", "-2"},
+		{"version", "1"},
+		{"status", "all"},
+		{"up", "1", "2"},
+	}
+
+	for _, args := range cases {
+		_, err := parseMigrate(args)
+		assert.ErrorIs(t, err, errUsage, "%q", args)
+	}
+}
+
+func TestParseMigrate(t *testing.T) {
+	// A zero version stands for the latest migration.
+	cases := []struct {
+		name    string
+		args    []string
+		version uint
+		applied int
+	}{
+		{name: "up", args: []string{"up"}, applied: 1},
+		{name: "up N", args: []string{"up", "2"}, version: 20250927191111, applied: 1},
+		{name: "goto", args: []string{"goto", "20250927191127"}, version: 20250927191127, applied: 1},
+		{name: "force", args: []string{"force", "20250927191041"}, version: 20250927191041, applied: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			command, err := parseMigrate(tc.args)
+			require.NoError(t, err)
+
+			db, err := stub.WithInstance(nil, &stub.Config{})
+			require.NoError(t, err)
+
+			m, err := database.NewMigrator(context.Background(), "postgres", "stub", db)
+			require.NoError(t, err)
+
+			spy := &schemaSpy{}
+			m.Schema = spy
+
+			require.NoError(t, command(m))
+
+			want := tc.version
+			if want == 0 {
+				statuses, err := m.Status()
+				require.NoError(t, err)
+				want = statuses[len(statuses)-1].Version
+			}
+
+			version, _, err := m.Version()
+			require.NoError(t, err)
+			assert.Equal(t, want, version)
+			assert.Equal(t, tc.applied, spy.applied)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Code generated by repogen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"github.com/goodone-dev/go-boilerplate/internal/domain/customer"
+)
+
+// FindByEmail returns the customer matching email, nil when there is none.
+func (r *customerRepository) FindByEmail(ctx context.Context, email string) (*customer.Customer, error) {
+	res, err := r.FindAll(ctx, map[string]any{
+		"email": email,
+	})
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/goodone-dev/go-boilerplate/internal/domain/customer"
+	customermock "github.com/goodone-dev/go-boilerplate/internal/domain/customer/mocks"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,3 +26,65 @@ func TestNewCustomerRepository(t *testing.T) {
 		NewCustomerRepository(nil)
 	})
 }
+
+func TestCustomerRepository_FindByEmail_Success(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	email := "alice@example.com"
+
+	mockBaseRepo := customermock.NewCustomerRepositoryMock(t)
+
+	expected := customer.Customer{
+		Name:  "Alice",
+		Email: email,
+	}
+
+	// Mock expectations
+	mockBaseRepo.EXPECT().FindAll(ctx, map[string]any{"email": email}).Return([]customer.Customer{expected}, nil)
+
+	// Execute
+	repo := NewCustomerRepository(mockBaseRepo)
+	result, err := repo.FindByEmail(ctx, email)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, result)
+}
+
+func TestCustomerRepository_FindByEmail_NotFound(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+
+	mockBaseRepo := customermock.NewCustomerRepositoryMock(t)
+
+	// Mock expectations
+	mockBaseRepo.EXPECT().FindAll(ctx, map[string]any{"email": "nobody@example.com"}).Return(nil, nil)
+
+	// Execute
+	repo := NewCustomerRepository(mockBaseRepo)
+	result, err := repo.FindByEmail(ctx, "nobody@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCustomerRepository_FindByEmail_Error(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	expectedError := errors.New("database error")
+
+	mockBaseRepo := customermock.NewCustomerRepositoryMock(t)
+
+	// Mock expectations
+	mockBaseRepo.EXPECT().FindAll(ctx, map[string]any{"email": "alice@example.com"}).Return(nil, expectedError)
+
+	// Execute
+	repo := NewCustomerRepository(mockBaseRepo)
+	result, err := repo.FindByEmail(ctx, "alice@example.com")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
+}
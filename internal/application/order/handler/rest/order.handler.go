@@ -8,6 +8,7 @@ import (
 	"github.com/goodone-dev/go-boilerplate/internal/utils/http_response/success"
 	"github.com/goodone-dev/go-boilerplate/internal/utils/sanitizer"
 	"github.com/goodone-dev/go-boilerplate/internal/utils/validator"
+	"github.com/google/uuid"
 )
 
 type orderHandler struct {
@@ -52,3 +53,26 @@ func (h *orderHandler) Create(c *gin.Context) {
 
 	success.Send(c, order)
 }
+
+func (h *orderHandler) GetById(c *gin.Context) {
+	var err error
+
+	ctx, span := tracer.Start(c.Request.Context())
+	defer func() {
+		span.End(err)
+	}()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(httperror.NewBadRequestError("invalid order ID format", err.Error()))
+		return
+	}
+
+	order, err := h.orderUsecase.GetById(ctx, ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	success.Send(c, order)
+}
@@ -178,3 +178,71 @@ func TestOrderHandler_Create_ValidationError_EmptyOrderItems(t *testing.T) {
 
 	assert.NotEmpty(t, c.Errors, "Expected validation errors to be set in context")
 }
+
+func TestOrderHandler_GetById_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := ordermock.NewOrderUsecaseMock(t)
+	handler := NewOrderHandler(mockUsecase)
+
+	orderID := uuid.New()
+
+	expectedResponse := &order.GetOrderResponse{
+		ID:          orderID,
+		CustomerID:  uuid.New(),
+		TotalAmount: 200.0,
+		Status:      "paid",
+	}
+
+	mockUsecase.On("GetById", mock.Anything, orderID).Return(expectedResponse, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: orderID.String()}}
+
+	handler.GetById(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetById_InvalidID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := ordermock.NewOrderUsecaseMock(t)
+	handler := NewOrderHandler(mockUsecase)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/invalid", nil)
+	c.Params = gin.Params{{Key: "id", Value: "invalid"}}
+
+	handler.GetById(c)
+
+	assert.NotEmpty(t, c.Errors, "Expected errors to be set in context")
+	assert.Len(t, c.Errors, 1, "Expected exactly one error")
+	mockUsecase.AssertNotCalled(t, "GetById", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_GetById_UsecaseError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := ordermock.NewOrderUsecaseMock(t)
+	handler := NewOrderHandler(mockUsecase)
+
+	orderID := uuid.New()
+
+	mockUsecase.On("GetById", mock.Anything, orderID).Return(nil, errors.New("usecase error"))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String(), nil)
+	c.Params = gin.Params{{Key: "id", Value: orderID.String()}}
+
+	handler.GetById(c)
+
+	assert.NotEmpty(t, c.Errors, "Expected errors to be set in context")
+	assert.Len(t, c.Errors, 1, "Expected exactly one error")
+	mockUsecase.AssertExpectations(t)
+}
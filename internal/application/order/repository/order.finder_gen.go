@@ -0,0 +1,18 @@
+// Code generated by repogen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+
+	"github.com/goodone-dev/go-boilerplate/internal/domain/order"
+	"github.com/google/uuid"
+)
+
+// FindByStatusAndCustomerID returns the orders matching status and customerID.
+func (r *orderRepository) FindByStatusAndCustomerID(ctx context.Context, status string, customerID uuid.UUID) ([]order.Order, error) {
+	return r.FindAll(ctx, map[string]any{
+		"status":      status,
+		"customer_id": customerID,
+	})
+}
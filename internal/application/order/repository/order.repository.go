@@ -1,18 +1,32 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/goodone-dev/go-boilerplate/internal/domain/order"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type orderRepository struct {
-	database.BaseRepository[gorm.DB, uuid.UUID, order.Order]
+	database.BaseRepository[uuid.UUID, order.Order]
 }
 
-func NewOrderRepository(baseRepo database.BaseRepository[gorm.DB, uuid.UUID, order.Order]) order.OrderRepository {
+func NewOrderRepository(baseRepo database.BaseRepository[uuid.UUID, order.Order]) order.OrderRepository {
 	return &orderRepository{
 		baseRepo,
 	}
 }
+
+// RevenuePerCustomer sums the order amounts of each customer, highest first.
+func (r *orderRepository) RevenuePerCustomer(ctx context.Context, filter map[string]any) ([]order.CustomerRevenue, error) {
+	return database.AggregateInto[order.CustomerRevenue](ctx, r.BaseRepository, database.AggregateQuery{
+		Filter:  filter,
+		GroupBy: []string{"customer_id"},
+		Aggregations: []database.Aggregation{
+			database.CountOf("order_count"),
+			database.SumOf("total_amount", "revenue"),
+		},
+		Sort: []string{"revenue DESC"},
+	})
+}
@@ -1,11 +1,18 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/goodone-dev/go-boilerplate/internal/domain/order"
+	ordermock "github.com/goodone-dev/go-boilerplate/internal/domain/order/mocks"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestMain(m *testing.M) {
@@ -22,3 +29,87 @@ func TestNewOrderRepository(t *testing.T) {
 		NewOrderRepository(nil)
 	})
 }
+
+func TestOrderRepository_RevenuePerCustomer_Success(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	customerID := uuid.New()
+	filter := map[string]any{"status": "paid"}
+
+	mockBaseRepo := ordermock.NewOrderRepositoryMock(t)
+
+	expected := []order.CustomerRevenue{
+		{
+			CustomerID: customerID,
+			OrderCount: 2,
+			Revenue:    500.0,
+		},
+	}
+
+	// Mock expectations
+	mockBaseRepo.EXPECT().Aggregate(ctx, mock.MatchedBy(func(q database.AggregateQuery) bool {
+		return q.Filter["status"] == "paid" &&
+			assert.ObjectsAreEqual([]string{"customer_id"}, q.GroupBy) &&
+			assert.ObjectsAreEqual([]string{"revenue DESC"}, q.Sort) &&
+			len(q.Aggregations) == 2
+	}), mock.Anything).Run(func(_ context.Context, _ database.AggregateQuery, dest any) {
+		*dest.(*[]order.CustomerRevenue) = expected
+	}).Return(nil)
+
+	// Execute
+	repo := NewOrderRepository(mockBaseRepo)
+	result, err := repo.RevenuePerCustomer(ctx, filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestOrderRepository_RevenuePerCustomer_Error(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	expectedError := errors.New("aggregate error")
+
+	mockBaseRepo := ordermock.NewOrderRepositoryMock(t)
+
+	// Mock expectations
+	mockBaseRepo.EXPECT().Aggregate(ctx, mock.Anything, mock.Anything).Return(expectedError)
+
+	// Execute
+	repo := NewOrderRepository(mockBaseRepo)
+	result, err := repo.RevenuePerCustomer(ctx, nil)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
+}
+
+func TestOrderRepository_FindByStatusAndCustomerID_Success(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	mockBaseRepo := ordermock.NewOrderRepositoryMock(t)
+
+	expected := []order.Order{
+		{
+			CustomerID: customerID,
+			Status:     "paid",
+		},
+	}
+
+	// Mock expectations
+	mockBaseRepo.EXPECT().FindAll(ctx, map[string]any{
+		"status":      "paid",
+		"customer_id": customerID,
+	}).Return(expected, nil)
+
+	// Execute
+	repo := NewOrderRepository(mockBaseRepo)
+	result, err := repo.FindByStatusAndCustomerID(ctx, "paid", customerID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/goodone-dev/go-boilerplate/internal/domain/mail"
 	"github.com/goodone-dev/go-boilerplate/internal/domain/order"
 	"github.com/goodone-dev/go-boilerplate/internal/domain/product"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/messaging/rabbitmq/direct"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
@@ -23,6 +25,7 @@ type orderUsecase struct {
 	orderRepo     order.OrderRepository
 	orderItemRepo order.OrderItemRepository
 	rmqDirectPub  *direct.Publisher
+	tagPurger     cache.TagPurger
 }
 
 func NewOrderUsecase(
@@ -31,6 +34,7 @@ func NewOrderUsecase(
 	orderRepo order.OrderRepository,
 	orderItemRepo order.OrderItemRepository,
 	rmqDirectPub *direct.Publisher,
+	tagPurger cache.TagPurger,
 ) order.OrderUsecase {
 	return &orderUsecase{
 		customerRepo:  customerRepo,
@@ -38,6 +42,7 @@ func NewOrderUsecase(
 		orderRepo:     orderRepo,
 		orderItemRepo: orderItemRepo,
 		rmqDirectPub:  rmqDirectPub,
+		tagPurger:     tagPurger,
 	}
 }
 
@@ -93,34 +98,26 @@ func (u *orderUsecase) Create(ctx context.Context, req order.CreateOrderRequest)
 		})
 	}
 
-	trx, err := u.orderRepo.Begin(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
+	var createdOrder order.Order
+	err = database.WithTransaction(ctx, func(ctx context.Context) (err error) {
+		createdOrder, err = u.orderRepo.Insert(ctx, order.Order{
+			CustomerID:  req.CustomerID,
+			TotalAmount: totalAmount,
+			Status:      "paid",
+		}, nil)
 		if err != nil {
-			u.orderRepo.Rollback(trx)
-			return
+			return err
 		}
 
-		u.orderRepo.Commit(trx)
-	}()
-
-	createdOrder, err := u.orderRepo.Insert(ctx, order.Order{
-		CustomerID:  req.CustomerID,
-		TotalAmount: totalAmount,
-		Status:      "paid",
-	}, trx)
-	if err != nil {
-		return nil, err
-	}
+		for i := range orderItems {
+			orderItems[i].OrderID = createdOrder.ID
+		}
 
-	for i := range orderItems {
-		orderItems[i].OrderID = createdOrder.ID
-	}
+		u.purgeOrder(ctx, createdOrder.ID)
 
-	_, err = u.orderItemRepo.InsertMany(ctx, orderItems, trx)
+		_, err = u.orderItemRepo.InsertMany(ctx, orderItems, nil)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -148,3 +145,60 @@ func (u *orderUsecase) Create(ctx context.Context, req order.CreateOrderRequest)
 		Status:      createdOrder.Status,
 	}, nil
 }
+
+// purgeOrder drops the cached responses showing the order once the
+// transaction writing it commits.
+func (u *orderUsecase) purgeOrder(ctx context.Context, ID uuid.UUID) {
+	ctx = context.WithoutCancel(ctx)
+	database.AfterCommit(ctx, nil, func() {
+		if err := u.tagPurger.PurgeTags(ctx, "order:"+ID.String()); err != nil {
+			logger.Errorf(ctx, err, "❌ Failed to purge the cached responses of order %s", ID).Write()
+		}
+	})
+}
+
+func (u *orderUsecase) GetById(ctx context.Context, ID uuid.UUID) (res *order.GetOrderResponse, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"id": ID,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"response": res,
+		}).End(err)
+	}()
+
+	foundOrder, err := u.orderRepo.Preload("Items.Product").FindById(ctx, ID)
+	if err != nil {
+		return nil, err
+	} else if foundOrder == nil {
+		return nil, httperror.NewNotFoundError("order with the provided ID was not found")
+	}
+
+	orderItems := make([]order.OrderItemResponse, 0, len(foundOrder.Items))
+	for _, item := range foundOrder.Items {
+		productName := item.ProductName
+		if item.Product != nil {
+			productName = item.Product.Name
+		}
+
+		orderItems = append(orderItems, order.OrderItemResponse{
+			ID:          item.ID,
+			ProductID:   item.ProductID,
+			ProductName: productName,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Total:       item.Price * float64(item.Quantity),
+		})
+	}
+
+	return &order.GetOrderResponse{
+		ID:          foundOrder.ID,
+		CustomerID:  foundOrder.CustomerID,
+		TotalAmount: foundOrder.TotalAmount,
+		Status:      foundOrder.Status,
+		OrderItems:  orderItems,
+		CreatedAt:   foundOrder.CreatedAt,
+	}, nil
+}
@@ -14,14 +14,16 @@ import (
 	ordermock "github.com/goodone-dev/go-boilerplate/internal/domain/order/mocks"
 	"github.com/goodone-dev/go-boilerplate/internal/domain/product"
 	productmock "github.com/goodone-dev/go-boilerplate/internal/domain/product/mocks"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/messaging/rabbitmq"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/messaging/rabbitmq/direct"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
+	"github.com/stretchr/testify/require"
 
+	cachemock "github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/mocks"
 	rabbitmqmock "github.com/goodone-dev/go-boilerplate/internal/infrastructure/messaging/rabbitmq/mocks"
 )
 
@@ -32,6 +34,32 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// transactorStub runs the transaction function in place, failing before or
+// after it to simulate begin and commit errors.
+type transactorStub struct {
+	beginErr  error
+	commitErr error
+}
+
+func (s transactorStub) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.beginErr != nil {
+		return s.beginErr
+	}
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	return s.commitErr
+}
+
+func setTransactor(t *testing.T, transactor database.Transactor) {
+	require.NoError(t, database.SetTransactor(transactor))
+	t.Cleanup(func() {
+		database.SetTransactor(nil)
+	})
+}
+
 func TestNewOrderUsecase(t *testing.T) {
 	mockCustomerRepo := customermock.NewCustomerRepositoryMock(t)
 	mockProductRepo := productmock.NewProductRepositoryMock(t)
@@ -42,6 +70,7 @@ func TestNewOrderUsecase(t *testing.T) {
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 
 	directPub := direct.NewPublisher(context.Background(), mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	usecase := NewOrderUsecase(
 		mockCustomerRepo,
@@ -49,6 +78,7 @@ func TestNewOrderUsecase(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	assert.NotNil(t, usecase)
@@ -70,6 +100,7 @@ func TestOrderUsecase_Create_Success(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	// Mock data
 	mockCustomer := &customer.Customer{
@@ -112,19 +143,17 @@ func TestOrderUsecase_Create_Success(t *testing.T) {
 		},
 	}
 
-	mockTrx := &gorm.DB{}
+	setTransactor(t, transactorStub{})
 
 	// Mock expectations
 	mockCustomerRepo.EXPECT().FindById(ctx, customerID).Return(mockCustomer, nil)
 	mockProductRepo.EXPECT().FindByIds(ctx, []uuid.UUID{productID1, productID2}).Return(mockProducts, nil)
-	mockOrderRepo.EXPECT().Begin(ctx).Return(mockTrx, nil)
 	mockOrderRepo.EXPECT().Insert(ctx, mock.MatchedBy(func(o order.Order) bool {
 		return o.CustomerID == customerID && o.TotalAmount == 400.0 && o.Status == "paid"
-	}), mockTrx).Return(mockOrder, nil)
+	}), mock.Anything).Return(mockOrder, nil)
 	mockOrderItemRepo.EXPECT().InsertMany(ctx, mock.MatchedBy(func(items []order.OrderItem) bool {
 		return len(items) == 2 && items[0].OrderID == orderID && items[1].OrderID == orderID
-	}), mockTrx).Return([]order.OrderItem{}, nil)
-	mockOrderRepo.EXPECT().Commit(mockTrx).Return(mockTrx)
+	}), mock.Anything).Return([]order.OrderItem{}, nil)
 
 	// Expect Publish call
 	mockRmqClient.On("Publish", ctx, mock.MatchedBy(func(cfg rabbitmq.PublishConfig) bool {
@@ -142,6 +171,7 @@ func TestOrderUsecase_Create_Success(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -169,6 +199,7 @@ func TestOrderUsecase_Create_CustomerNotFound(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	req := order.CreateOrderRequest{
 		CustomerID: customerID,
@@ -190,6 +221,7 @@ func TestOrderUsecase_Create_CustomerNotFound(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -214,6 +246,7 @@ func TestOrderUsecase_Create_CustomerRepoError(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	req := order.CreateOrderRequest{
 		CustomerID: customerID,
@@ -237,6 +270,7 @@ func TestOrderUsecase_Create_CustomerRepoError(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -262,6 +296,7 @@ func TestOrderUsecase_Create_ProductsNotFound(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	mockCustomer := &customer.Customer{
 		Name:  "John Doe",
@@ -303,6 +338,7 @@ func TestOrderUsecase_Create_ProductsNotFound(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -327,6 +363,7 @@ func TestOrderUsecase_Create_ProductRepoError(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	mockCustomer := &customer.Customer{
 		Name:  "John Doe",
@@ -357,6 +394,7 @@ func TestOrderUsecase_Create_ProductRepoError(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -367,7 +405,7 @@ func TestOrderUsecase_Create_ProductRepoError(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 }
 
-func TestOrderUsecase_Create_BeginTransactionError(t *testing.T) {
+func TestOrderUsecase_Create_TransactionError(t *testing.T) {
 	// Setup
 	ctx := context.Background()
 	customerID := uuid.New()
@@ -381,6 +419,7 @@ func TestOrderUsecase_Create_BeginTransactionError(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	mockCustomer := &customer.Customer{
 		Name:  "John Doe",
@@ -407,11 +446,11 @@ func TestOrderUsecase_Create_BeginTransactionError(t *testing.T) {
 	}
 
 	expectedError := errors.New("transaction begin error")
+	setTransactor(t, transactorStub{beginErr: expectedError})
 
 	// Mock expectations
 	mockCustomerRepo.EXPECT().FindById(ctx, customerID).Return(mockCustomer, nil)
 	mockProductRepo.EXPECT().FindByIds(ctx, []uuid.UUID{productID}).Return(mockProducts, nil)
-	mockOrderRepo.EXPECT().Begin(ctx).Return(nil, expectedError)
 
 	// Execute
 	usecase := NewOrderUsecase(
@@ -420,6 +459,7 @@ func TestOrderUsecase_Create_BeginTransactionError(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -444,6 +484,7 @@ func TestOrderUsecase_Create_InsertOrderError(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	mockCustomer := &customer.Customer{
 		Name:  "John Doe",
@@ -469,15 +510,14 @@ func TestOrderUsecase_Create_InsertOrderError(t *testing.T) {
 		},
 	}
 
-	mockTrx := &gorm.DB{}
 	expectedError := errors.New("insert order error")
 
+	setTransactor(t, transactorStub{})
+
 	// Mock expectations
 	mockCustomerRepo.EXPECT().FindById(ctx, customerID).Return(mockCustomer, nil)
 	mockProductRepo.EXPECT().FindByIds(ctx, []uuid.UUID{productID}).Return(mockProducts, nil)
-	mockOrderRepo.EXPECT().Begin(ctx).Return(mockTrx, nil)
-	mockOrderRepo.EXPECT().Insert(ctx, mock.Anything, mockTrx).Return(order.Order{}, expectedError)
-	mockOrderRepo.EXPECT().Rollback(mockTrx).Return(mockTrx)
+	mockOrderRepo.EXPECT().Insert(ctx, mock.Anything, mock.Anything).Return(order.Order{}, expectedError)
 
 	// Execute
 	usecase := NewOrderUsecase(
@@ -486,6 +526,7 @@ func TestOrderUsecase_Create_InsertOrderError(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -511,6 +552,7 @@ func TestOrderUsecase_Create_InsertOrderItemsError(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	mockCustomer := &customer.Customer{
 		Name:  "John Doe",
@@ -543,16 +585,15 @@ func TestOrderUsecase_Create_InsertOrderItemsError(t *testing.T) {
 		},
 	}
 
-	mockTrx := &gorm.DB{}
 	expectedError := errors.New("insert order items error")
 
+	setTransactor(t, transactorStub{})
+
 	// Mock expectations
 	mockCustomerRepo.EXPECT().FindById(ctx, customerID).Return(mockCustomer, nil)
 	mockProductRepo.EXPECT().FindByIds(ctx, []uuid.UUID{productID}).Return(mockProducts, nil)
-	mockOrderRepo.EXPECT().Begin(ctx).Return(mockTrx, nil)
-	mockOrderRepo.EXPECT().Insert(ctx, mock.Anything, mockTrx).Return(mockOrder, nil)
-	mockOrderItemRepo.EXPECT().InsertMany(ctx, mock.Anything, mockTrx).Return(nil, expectedError)
-	mockOrderRepo.EXPECT().Rollback(mockTrx).Return(mockTrx)
+	mockOrderRepo.EXPECT().Insert(ctx, mock.Anything, mock.Anything).Return(mockOrder, nil)
+	mockOrderItemRepo.EXPECT().InsertMany(ctx, mock.Anything, mock.Anything).Return(nil, expectedError)
 
 	// Execute
 	usecase := NewOrderUsecase(
@@ -561,6 +602,7 @@ func TestOrderUsecase_Create_InsertOrderItemsError(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -571,6 +613,82 @@ func TestOrderUsecase_Create_InsertOrderItemsError(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 }
 
+func TestOrderUsecase_Create_CommitError(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	customerID := uuid.New()
+	productID := uuid.New()
+	orderID := uuid.New()
+
+	mockCustomerRepo := customermock.NewCustomerRepositoryMock(t)
+	mockProductRepo := productmock.NewProductRepositoryMock(t)
+	mockOrderRepo := ordermock.NewOrderRepositoryMock(t)
+	mockOrderItemRepo := ordermock.NewOrderItemRepositoryMock(t)
+
+	mockRmqClient := rabbitmqmock.NewClientMock(t)
+	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
+	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
+
+	mockCustomer := &customer.Customer{
+		Name:  "John Doe",
+		Email: "john@example.com",
+	}
+	mockCustomer.ID = customerID
+
+	mockProducts := []product.Product{
+		{
+			Name:  "Product 1",
+			Price: 100.0,
+		},
+	}
+	mockProducts[0].ID = productID
+
+	mockOrder := order.Order{
+		CustomerID:  customerID,
+		TotalAmount: 200.0,
+		Status:      "paid",
+	}
+	mockOrder.ID = orderID
+
+	req := order.CreateOrderRequest{
+		CustomerID: customerID,
+		OrderItems: []order.OrderItemRequest{
+			{
+				ProductID: productID,
+				Quantity:  2,
+			},
+		},
+	}
+
+	expectedError := errors.New("commit error")
+	setTransactor(t, transactorStub{commitErr: expectedError})
+
+	// Mock expectations
+	mockCustomerRepo.EXPECT().FindById(ctx, customerID).Return(mockCustomer, nil)
+	mockProductRepo.EXPECT().FindByIds(ctx, []uuid.UUID{productID}).Return(mockProducts, nil)
+	mockOrderRepo.EXPECT().Insert(ctx, mock.Anything, mock.Anything).Return(mockOrder, nil)
+	mockOrderItemRepo.EXPECT().InsertMany(ctx, mock.Anything, mock.Anything).Return([]order.OrderItem{}, nil)
+
+	// Execute
+	usecase := NewOrderUsecase(
+		mockCustomerRepo,
+		mockProductRepo,
+		mockOrderRepo,
+		mockOrderItemRepo,
+		directPub,
+		mockTagPurger,
+	)
+
+	result, err := usecase.Create(ctx, req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
+	mockRmqClient.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestOrderUsecase_Create_CalculatesTotalAmountCorrectly(t *testing.T) {
 	// Setup
 	ctx := context.Background()
@@ -588,6 +706,7 @@ func TestOrderUsecase_Create_CalculatesTotalAmountCorrectly(t *testing.T) {
 	mockRmqClient := rabbitmqmock.NewClientMock(t)
 	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
 	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
 
 	mockCustomer := &customer.Customer{
 		Name:  "John Doe",
@@ -638,24 +757,22 @@ func TestOrderUsecase_Create_CalculatesTotalAmountCorrectly(t *testing.T) {
 		},
 	}
 
-	mockTrx := &gorm.DB{}
+	setTransactor(t, transactorStub{})
 
 	// Mock expectations
 	mockCustomerRepo.EXPECT().FindById(ctx, customerID).Return(mockCustomer, nil)
 	mockProductRepo.EXPECT().FindByIds(ctx, []uuid.UUID{productID1, productID2, productID3}).Return(mockProducts, nil)
-	mockOrderRepo.EXPECT().Begin(ctx).Return(mockTrx, nil)
 	mockOrderRepo.EXPECT().Insert(ctx, mock.MatchedBy(func(o order.Order) bool {
 		// Verify total amount calculation: (50*3) + (75.5*2) + (120.25*1) = 421.25
 		return o.TotalAmount == 421.25
-	}), mockTrx).Return(mockOrder, nil)
+	}), mock.Anything).Return(mockOrder, nil)
 	mockOrderItemRepo.EXPECT().InsertMany(ctx, mock.MatchedBy(func(items []order.OrderItem) bool {
 		if len(items) != 3 {
 			return false
 		}
 		// Verify individual item totals
 		return items[0].Total == 150.0 && items[1].Total == 151.0 && items[2].Total == 120.25
-	}), mockTrx).Return([]order.OrderItem{}, nil)
-	mockOrderRepo.EXPECT().Commit(mockTrx).Return(mockTrx)
+	}), mock.Anything).Return([]order.OrderItem{}, nil)
 
 	// Expect Publish call
 	mockRmqClient.On("Publish", ctx, mock.MatchedBy(func(cfg rabbitmq.PublishConfig) bool {
@@ -665,6 +782,7 @@ func TestOrderUsecase_Create_CalculatesTotalAmountCorrectly(t *testing.T) {
 		_ = json.Unmarshal(msg.Body, &payload)
 		return payload.To == "john@example.com" && payload.Subject == "Thank You for Your Purchase!"
 	})).Return(nil)
+	mockTagPurger.EXPECT().PurgeTags(mock.Anything, "order:"+orderID.String()).Return(nil)
 
 	// Execute
 	usecase := NewOrderUsecase(
@@ -673,6 +791,7 @@ func TestOrderUsecase_Create_CalculatesTotalAmountCorrectly(t *testing.T) {
 		mockOrderRepo,
 		mockOrderItemRepo,
 		directPub,
+		mockTagPurger,
 	)
 
 	result, err := usecase.Create(ctx, req)
@@ -682,3 +801,142 @@ func TestOrderUsecase_Create_CalculatesTotalAmountCorrectly(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, 421.25, result.TotalAmount)
 }
+
+func TestOrderUsecase_GetById_Success(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	orderID := uuid.New()
+	productID := uuid.New()
+
+	mockCustomerRepo := customermock.NewCustomerRepositoryMock(t)
+	mockProductRepo := productmock.NewProductRepositoryMock(t)
+	mockOrderRepo := ordermock.NewOrderRepositoryMock(t)
+	mockOrderItemRepo := ordermock.NewOrderItemRepositoryMock(t)
+
+	mockRmqClient := rabbitmqmock.NewClientMock(t)
+	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
+	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
+
+	mockProduct := &product.Product{
+		Name:  "Product 1",
+		Price: 100.0,
+	}
+	mockProduct.ID = productID
+
+	mockOrder := &order.Order{
+		CustomerID:  uuid.New(),
+		TotalAmount: 200.0,
+		Status:      "paid",
+		Items: []order.OrderItem{
+			{
+				OrderID:   orderID,
+				ProductID: productID,
+				Quantity:  2,
+				Price:     100.0,
+				Product:   mockProduct,
+			},
+		},
+	}
+	mockOrder.ID = orderID
+
+	// Mock expectations
+	mockOrderRepo.EXPECT().Preload("Items.Product").Return(mockOrderRepo)
+	mockOrderRepo.EXPECT().FindById(ctx, orderID).Return(mockOrder, nil)
+
+	// Execute
+	usecase := NewOrderUsecase(
+		mockCustomerRepo,
+		mockProductRepo,
+		mockOrderRepo,
+		mockOrderItemRepo,
+		directPub,
+		mockTagPurger,
+	)
+
+	result, err := usecase.GetById(ctx, orderID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, orderID, result.ID)
+	assert.Len(t, result.OrderItems, 1)
+	assert.Equal(t, "Product 1", result.OrderItems[0].ProductName)
+	assert.Equal(t, 200.0, result.OrderItems[0].Total)
+}
+
+func TestOrderUsecase_GetById_NotFound(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	orderID := uuid.New()
+
+	mockCustomerRepo := customermock.NewCustomerRepositoryMock(t)
+	mockProductRepo := productmock.NewProductRepositoryMock(t)
+	mockOrderRepo := ordermock.NewOrderRepositoryMock(t)
+	mockOrderItemRepo := ordermock.NewOrderItemRepositoryMock(t)
+
+	mockRmqClient := rabbitmqmock.NewClientMock(t)
+	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
+	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
+
+	// Mock expectations
+	mockOrderRepo.EXPECT().Preload("Items.Product").Return(mockOrderRepo)
+	mockOrderRepo.EXPECT().FindById(ctx, orderID).Return(nil, nil)
+
+	// Execute
+	usecase := NewOrderUsecase(
+		mockCustomerRepo,
+		mockProductRepo,
+		mockOrderRepo,
+		mockOrderItemRepo,
+		directPub,
+		mockTagPurger,
+	)
+
+	result, err := usecase.GetById(ctx, orderID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "order with the provided ID was not found")
+}
+
+func TestOrderUsecase_GetById_RepositoryError(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	orderID := uuid.New()
+
+	mockCustomerRepo := customermock.NewCustomerRepositoryMock(t)
+	mockProductRepo := productmock.NewProductRepositoryMock(t)
+	mockOrderRepo := ordermock.NewOrderRepositoryMock(t)
+	mockOrderItemRepo := ordermock.NewOrderItemRepositoryMock(t)
+
+	mockRmqClient := rabbitmqmock.NewClientMock(t)
+	mockRmqClient.On("DeclareExchange", mock.Anything).Return(nil)
+	directPub := direct.NewPublisher(ctx, mockRmqClient, "test.exchange")
+	mockTagPurger := cachemock.NewTagPurgerMock(t)
+
+	expectedError := errors.New("database error")
+
+	// Mock expectations
+	mockOrderRepo.EXPECT().Preload("Items.Product").Return(mockOrderRepo)
+	mockOrderRepo.EXPECT().FindById(ctx, orderID).Return(nil, expectedError)
+
+	// Execute
+	usecase := NewOrderUsecase(
+		mockCustomerRepo,
+		mockProductRepo,
+		mockOrderRepo,
+		mockOrderItemRepo,
+		directPub,
+		mockTagPurger,
+	)
+
+	result, err := usecase.GetById(ctx, orderID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, expectedError, err)
+}
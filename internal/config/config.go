@@ -1,16 +1,18 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
 var ContextTimeout time.Duration
-var IdempotencyDuration time.Duration
 var Cors CorsConfig
 var Application ApplicationConfig
 var Redis RedisConfig
+var Cache CacheConfig
+var Database DatabaseConfig
 var Postgres PostgresConfig
 var MySQL MySQLConfig
 var Mongo MongoConfig
@@ -23,6 +25,12 @@ var HttpClient HttpClientConfig
 var CircuitBreaker CircuitBreakerConfig
 var RateLimiter RateLimiterConfig
 var RetryBackoff RetryBackoffConfig
+var Tenant TenantConfig
+var JWT JWTConfig
+var Audit AuditConfig
+var Idempotency IdempotencyConfig
+var Lock LockConfig
+var ResponseCache ResponseCacheConfig
 
 type Environment string
 
@@ -41,11 +49,41 @@ type ApplicationConfig struct {
 }
 
 type RedisConfig struct {
-	Host     string `mapstructure:"REDIS_HOST"`
-	Port     int    `mapstructure:"REDIS_PORT"`
-	TLS      bool   `mapstructure:"REDIS_TLS"`
-	Password string `mapstructure:"REDIS_PASSWORD"`
-	DB       int    `mapstructure:"REDIS_DB"`
+	Mode             string   `mapstructure:"REDIS_MODE"`
+	Host             string   `mapstructure:"REDIS_HOST"`
+	Port             int      `mapstructure:"REDIS_PORT"`
+	Addrs            []string `mapstructure:"REDIS_ADDRS"`
+	MasterName       string   `mapstructure:"REDIS_MASTER_NAME"`
+	SentinelPassword string   `mapstructure:"REDIS_SENTINEL_PASSWORD"`
+	TLS              bool     `mapstructure:"REDIS_TLS"`
+	Password         string   `mapstructure:"REDIS_PASSWORD"`
+	DB               int      `mapstructure:"REDIS_DB"`
+}
+
+type CacheConfig struct {
+	Driver               string        `mapstructure:"CACHE_DRIVER"`
+	L1MaxEntries         int           `mapstructure:"CACHE_L1_MAX_ENTRIES"`
+	L1MaxBytes           int64         `mapstructure:"CACHE_L1_MAX_BYTES"`
+	L1TTL                time.Duration `mapstructure:"CACHE_L1_TTL"`
+	InvalidationChannel  string        `mapstructure:"CACHE_INVALIDATION_CHANNEL"`
+	Compression          string        `mapstructure:"CACHE_COMPRESSION"`
+	CompressionThreshold int           `mapstructure:"CACHE_COMPRESSION_THRESHOLD"`
+}
+
+type DatabaseConfig struct {
+	Driver                string        `mapstructure:"DB_DRIVER"`
+	SoftDeleteRetention   time.Duration `mapstructure:"DB_SOFT_DELETE_RETENTION"`
+	PurgeInterval         time.Duration `mapstructure:"DB_PURGE_INTERVAL"`
+	StickyPrimaryDuration time.Duration `mapstructure:"DB_STICKY_PRIMARY_DURATION"`
+	MaxReplicaLag         time.Duration `mapstructure:"DB_MAX_REPLICA_LAG"`
+	ReplicaBalancer       string        `mapstructure:"DB_REPLICA_BALANCER"`
+	StreamBatchSize       int           `mapstructure:"DB_STREAM_BATCH_SIZE"`
+	MigrationLockTimeout  time.Duration `mapstructure:"DB_MIGRATION_LOCK_TIMEOUT"`
+	CacheTTL              time.Duration `mapstructure:"DB_CACHE_TTL"`
+	CacheNegativeTTL      time.Duration `mapstructure:"DB_CACHE_NEGATIVE_TTL"`
+	TxMaxRetries          int           `mapstructure:"DB_TX_MAX_RETRIES"`
+	TxInitialBackoff      time.Duration `mapstructure:"DB_TX_INITIAL_BACKOFF"`
+	TxMaxBackoff          time.Duration `mapstructure:"DB_TX_MAX_BACKOFF"`
 }
 
 type PostgresConfig struct {
@@ -55,6 +93,7 @@ type PostgresConfig struct {
 	MasterPort         int           `mapstructure:"POSTGRES_MASTER_PORT"`
 	MasterSSLMode      string        `mapstructure:"POSTGRES_MASTER_SSL_MODE"`
 	SlaveHost          string        `mapstructure:"POSTGRES_SLAVE_HOST"`
+	SlaveHosts         []string      `mapstructure:"POSTGRES_SLAVE_HOSTS"`
 	SlaveUsername      string        `mapstructure:"POSTGRES_SLAVE_USERNAME"`
 	SlavePassword      string        `mapstructure:"POSTGRES_SLAVE_PASSWORD"`
 	SlavePort          int           `mapstructure:"POSTGRES_SLAVE_PORT"`
@@ -79,6 +118,7 @@ type MySQLConfig struct {
 	MasterPassword     string        `mapstructure:"MYSQL_MASTER_PASSWORD"`
 	MasterPort         int           `mapstructure:"MYSQL_MASTER_PORT"`
 	SlaveHost          string        `mapstructure:"MYSQL_SLAVE_HOST"`
+	SlaveHosts         []string      `mapstructure:"MYSQL_SLAVE_HOSTS"`
 	SlaveUsername      string        `mapstructure:"MYSQL_SLAVE_USERNAME"`
 	SlavePassword      string        `mapstructure:"MYSQL_SLAVE_PASSWORD"`
 	SlavePort          int           `mapstructure:"MYSQL_SLAVE_PORT"`
@@ -92,27 +132,29 @@ type MySQLConfig struct {
 	MaxIdleConnections int           `mapstructure:"MYSQL_MAX_IDLE_CONNECTIONS"`
 	ConnMaxLifetime    time.Duration `mapstructure:"MYSQL_CONN_MAX_LIFETIME"`
 	InsertBatchSize    int           `mapstructure:"MYSQL_INSERT_BATCH_SIZE"`
+	UUIDFormat         string        `mapstructure:"MYSQL_UUID_FORMAT"`
 }
 
 type MongoConfig struct {
-	MasterHost        string `mapstructure:"MONGO_MASTER_HOST"`
-	MasterPort        int    `mapstructure:"MONGO_MASTER_PORT"`
-	MasterUsername    string `mapstructure:"MONGO_MASTER_USERNAME"`
-	MasterPassword    string `mapstructure:"MONGO_MASTER_PASSWORD"`
-	SlaveHost         string `mapstructure:"MONGO_SLAVE_HOST"`
-	SlavePort         int    `mapstructure:"MONGO_SLAVE_PORT"`
-	SlaveUsername     string `mapstructure:"MONGO_SLAVE_USERNAME"`
-	SlavePassword     string `mapstructure:"MONGO_SLAVE_PASSWORD"`
-	Host              string `mapstructure:"MONGO_HOST"`
-	Port              int    `mapstructure:"MONGO_PORT"`
-	Username          string `mapstructure:"MONGO_USERNAME"`
-	Password          string `mapstructure:"MONGO_PASSWORD"`
-	Database          string `mapstructure:"MONGO_DATABASE"`
-	AutoMigrate       bool   `mapstructure:"MONGO_AUTO_MIGRATE"`
-	MaxConnPoolSize   int    `mapstructure:"MONGO_MAX_CONN_POOL_SIZE"`
-	MinConnPoolSize   int    `mapstructure:"MONGO_MIN_CONN_POOL_SIZE"`
-	ConnIdleTimeoutMS int    `mapstructure:"MONGO_CONN_IDLE_TIMEOUT_MS"`
-	InsertBatchSize   int    `mapstructure:"MONGO_INSERT_BATCH_SIZE"`
+	MasterHost        string   `mapstructure:"MONGO_MASTER_HOST"`
+	MasterPort        int      `mapstructure:"MONGO_MASTER_PORT"`
+	MasterUsername    string   `mapstructure:"MONGO_MASTER_USERNAME"`
+	MasterPassword    string   `mapstructure:"MONGO_MASTER_PASSWORD"`
+	SlaveHost         string   `mapstructure:"MONGO_SLAVE_HOST"`
+	SlaveHosts        []string `mapstructure:"MONGO_SLAVE_HOSTS"`
+	SlavePort         int      `mapstructure:"MONGO_SLAVE_PORT"`
+	SlaveUsername     string   `mapstructure:"MONGO_SLAVE_USERNAME"`
+	SlavePassword     string   `mapstructure:"MONGO_SLAVE_PASSWORD"`
+	Host              string   `mapstructure:"MONGO_HOST"`
+	Port              int      `mapstructure:"MONGO_PORT"`
+	Username          string   `mapstructure:"MONGO_USERNAME"`
+	Password          string   `mapstructure:"MONGO_PASSWORD"`
+	Database          string   `mapstructure:"MONGO_DATABASE"`
+	AutoMigrate       bool     `mapstructure:"MONGO_AUTO_MIGRATE"`
+	MaxConnPoolSize   int      `mapstructure:"MONGO_MAX_CONN_POOL_SIZE"`
+	MinConnPoolSize   int      `mapstructure:"MONGO_MIN_CONN_POOL_SIZE"`
+	ConnIdleTimeoutMS int      `mapstructure:"MONGO_CONN_IDLE_TIMEOUT_MS"`
+	InsertBatchSize   int      `mapstructure:"MONGO_INSERT_BATCH_SIZE"`
 }
 
 type RabbitMQConfig struct {
@@ -177,6 +219,15 @@ type RateLimiterConfig struct {
 	SingleDuration time.Duration `mapstructure:"RATE_LIMITER_SINGLE_DURATION"`
 	GlobalLimit    int           `mapstructure:"RATE_LIMITER_GLOBAL_LIMIT"`
 	GlobalDuration time.Duration `mapstructure:"RATE_LIMITER_GLOBAL_DURATION"`
+	Algorithm      string        `mapstructure:"RATE_LIMITER_ALGORITHM"`
+	Burst          int           `mapstructure:"RATE_LIMITER_BURST"`
+	PolicyFile     string        `mapstructure:"RATE_LIMITER_POLICY_FILE"`
+	AllowList      []string      `mapstructure:"RATE_LIMITER_ALLOW_LIST"`
+	PrincipalClaim string        `mapstructure:"RATE_LIMITER_PRINCIPAL_CLAIM"`
+	TierClaim      string        `mapstructure:"RATE_LIMITER_TIER_CLAIM"`
+	DefaultTier    string        `mapstructure:"RATE_LIMITER_DEFAULT_TIER"`
+	APIKeyHeader   string        `mapstructure:"RATE_LIMITER_API_KEY_HEADER"`
+	APIKeys        []string      `mapstructure:"RATE_LIMITER_API_KEYS"`
 }
 
 type RetryBackoffConfig struct {
@@ -185,6 +236,42 @@ type RetryBackoffConfig struct {
 	MaxBackoff     time.Duration `mapstructure:"RETRY_MAX_BACKOFF"`
 }
 
+type TenantConfig struct {
+	Mode         string   `mapstructure:"TENANT_MODE"`
+	Sources      []string `mapstructure:"TENANT_SOURCES"`
+	Header       string   `mapstructure:"TENANT_HEADER"`
+	BaseDomain   string   `mapstructure:"TENANT_BASE_DOMAIN"`
+	JWTClaim     string   `mapstructure:"TENANT_JWT_CLAIM"`
+	SchemaPrefix string   `mapstructure:"TENANT_SCHEMA_PREFIX"`
+}
+
+type JWTConfig struct {
+	Secret string `mapstructure:"JWT_SECRET"`
+}
+
+type AuditConfig struct {
+	Enabled     bool   `mapstructure:"AUDIT_ENABLED"`
+	ActorHeader string `mapstructure:"AUDIT_ACTOR_HEADER"`
+	ActorClaim  string `mapstructure:"AUDIT_ACTOR_CLAIM"`
+}
+
+type IdempotencyConfig struct {
+	Duration       time.Duration `mapstructure:"IDEMPOTENCY_DURATION"`
+	LockDuration   time.Duration `mapstructure:"IDEMPOTENCY_LOCK_DURATION"`
+	PrincipalClaim string        `mapstructure:"IDEMPOTENCY_PRINCIPAL_CLAIM"`
+}
+
+type LockConfig struct {
+	TTL           time.Duration `mapstructure:"LOCK_TTL"`
+	RetryInterval time.Duration `mapstructure:"LOCK_RETRY_INTERVAL"`
+}
+
+type ResponseCacheConfig struct {
+	TTL                  time.Duration `mapstructure:"RESPONSE_CACHE_TTL"`
+	StaleWhileRevalidate time.Duration `mapstructure:"RESPONSE_CACHE_STALE_WHILE_REVALIDATE"`
+	Vary                 []string      `mapstructure:"RESPONSE_CACHE_VARY"`
+}
+
 func Load() (err error) {
 	viper.AddConfigPath("./")
 	viper.AddConfigPath("../")
@@ -203,6 +290,9 @@ func Load() (err error) {
 	if err = viper.Unmarshal(&Application); err != nil {
 		return
 	}
+	if err = viper.Unmarshal(&Database); err != nil {
+		return
+	}
 	if err = viper.Unmarshal(&Postgres); err != nil {
 		return
 	}
@@ -215,6 +305,9 @@ func Load() (err error) {
 	if err = viper.Unmarshal(&Redis); err != nil {
 		return
 	}
+	if err = viper.Unmarshal(&Cache); err != nil {
+		return
+	}
 	if err = viper.Unmarshal(&MySQL); err != nil {
 		return
 	}
@@ -245,11 +338,43 @@ func Load() (err error) {
 	if err = viper.Unmarshal(&RetryBackoff); err != nil {
 		return
 	}
+	if err = viper.Unmarshal(&Tenant); err != nil {
+		return
+	}
+	if err = viper.Unmarshal(&JWT); err != nil {
+		return
+	}
+	if err = viper.Unmarshal(&Audit); err != nil {
+		return
+	}
+	if err = viper.Unmarshal(&Idempotency); err != nil {
+		return
+	}
+	if err = viper.Unmarshal(&Lock); err != nil {
+		return
+	}
+	if err = viper.Unmarshal(&ResponseCache); err != nil {
+		return
+	}
 
 	ContextTimeout = viper.GetDuration("CONTEXT_TIMEOUT")
-	IdempotencyDuration = viper.GetDuration("IDEMPOTENCY_DURATION")
 
-	return
+	return validate()
+}
+
+// validate rejects settings the application cannot run with, such as the
+// periods of the background tasks.
+func validate() error {
+	if Database.SoftDeleteRetention > 0 && Database.PurgeInterval <= 0 {
+		return fmt.Errorf("DB_PURGE_INTERVAL must be positive to purge trashed records, got %v", Database.PurgeInterval)
+	}
+
+	// Locks are extended every third of their TTL.
+	if Lock.TTL < time.Second {
+		return fmt.Errorf("LOCK_TTL must be at least 1s, got %v", Lock.TTL)
+	}
+
+	return nil
 }
 
 func setDefaultConfig() {
@@ -262,6 +387,36 @@ func setDefaultConfig() {
 	viper.SetDefault("CORS_ALLOW_ORIGINS", "*")
 	viper.SetDefault("CORS_ALLOW_METHODS", "GET,POST,PUT,PATCH,DELETE,HEAD,OPTIONS")
 
+	// Redis defaults
+	viper.SetDefault("REDIS_MODE", "standalone")
+	viper.SetDefault("REDIS_ADDRS", "")
+	viper.SetDefault("REDIS_MASTER_NAME", "")
+	viper.SetDefault("REDIS_SENTINEL_PASSWORD", "")
+
+	// Cache defaults
+	viper.SetDefault("CACHE_DRIVER", "redis")
+	viper.SetDefault("CACHE_L1_MAX_ENTRIES", 10000)
+	viper.SetDefault("CACHE_L1_MAX_BYTES", 64<<20)
+	viper.SetDefault("CACHE_L1_TTL", "30s")
+	viper.SetDefault("CACHE_INVALIDATION_CHANNEL", "cache:invalidation")
+	viper.SetDefault("CACHE_COMPRESSION", "zstd")
+	viper.SetDefault("CACHE_COMPRESSION_THRESHOLD", 1024)
+
+	// Database defaults
+	viper.SetDefault("DB_DRIVER", "postgres")
+	viper.SetDefault("DB_SOFT_DELETE_RETENTION", "0s")
+	viper.SetDefault("DB_PURGE_INTERVAL", "1h")
+	viper.SetDefault("DB_STICKY_PRIMARY_DURATION", "5s")
+	viper.SetDefault("DB_MAX_REPLICA_LAG", "10s")
+	viper.SetDefault("DB_REPLICA_BALANCER", "round_robin")
+	viper.SetDefault("DB_STREAM_BATCH_SIZE", 1000)
+	viper.SetDefault("DB_MIGRATION_LOCK_TIMEOUT", "5m")
+	viper.SetDefault("DB_CACHE_TTL", "5m")
+	viper.SetDefault("DB_CACHE_NEGATIVE_TTL", "30s")
+	viper.SetDefault("DB_TX_MAX_RETRIES", 3)
+	viper.SetDefault("DB_TX_INITIAL_BACKOFF", "10ms")
+	viper.SetDefault("DB_TX_MAX_BACKOFF", "100ms")
+
 	// PostgreSQL defaults
 	viper.SetDefault("POSTGRES_TIMEZONE", "Asia/Jakarta")
 	viper.SetDefault("POSTGRES_MAX_OPEN_CONNECTIONS", 10)
@@ -274,6 +429,7 @@ func setDefaultConfig() {
 	viper.SetDefault("MYSQL_MAX_IDLE_CONNECTIONS", 10)
 	viper.SetDefault("MYSQL_CONN_MAX_LIFETIME", "300s")
 	viper.SetDefault("MYSQL_INSERT_BATCH_SIZE", 100)
+	viper.SetDefault("MYSQL_UUID_FORMAT", "binary")
 
 	// MongoDB defaults
 	viper.SetDefault("MONGO_MIN_CONN_POOL_SIZE", 2)
@@ -309,12 +465,42 @@ func setDefaultConfig() {
 	viper.SetDefault("RATE_LIMITER_SINGLE_DURATION", "60s")
 	viper.SetDefault("RATE_LIMITER_GLOBAL_LIMIT", 1000)
 	viper.SetDefault("RATE_LIMITER_GLOBAL_DURATION", "60s")
+	viper.SetDefault("RATE_LIMITER_ALGORITHM", "sliding_window")
+	viper.SetDefault("RATE_LIMITER_BURST", 0)
+	viper.SetDefault("RATE_LIMITER_POLICY_FILE", "")
+	viper.SetDefault("RATE_LIMITER_ALLOW_LIST", "")
+	viper.SetDefault("RATE_LIMITER_PRINCIPAL_CLAIM", "sub")
+	viper.SetDefault("RATE_LIMITER_TIER_CLAIM", "tier")
+	viper.SetDefault("RATE_LIMITER_DEFAULT_TIER", "free")
+	viper.SetDefault("RATE_LIMITER_API_KEY_HEADER", "X-API-Key")
+	viper.SetDefault("RATE_LIMITER_API_KEYS", "")
 
 	// Idempotency defaults
 	viper.SetDefault("IDEMPOTENCY_DURATION", "300s")
+	viper.SetDefault("IDEMPOTENCY_LOCK_DURATION", "60s")
+	viper.SetDefault("IDEMPOTENCY_PRINCIPAL_CLAIM", "sub")
+
+	// Lock defaults
+	viper.SetDefault("LOCK_TTL", "30s")
+	viper.SetDefault("LOCK_RETRY_INTERVAL", "1s")
+
+	// Response cache defaults
+	viper.SetDefault("RESPONSE_CACHE_TTL", "60s")
+	viper.SetDefault("RESPONSE_CACHE_STALE_WHILE_REVALIDATE", "30s")
+	viper.SetDefault("RESPONSE_CACHE_VARY", "Accept,Accept-Language")
 
 	// Retry defaults
 	viper.SetDefault("RETRY_MAX_RETRIES", 5)
 	viper.SetDefault("RETRY_INITIAL_BACKOFF", "1s")
 	viper.SetDefault("RETRY_MAX_BACKOFF", "30s")
+
+	// Tenant defaults
+	viper.SetDefault("TENANT_SOURCES", "header")
+	viper.SetDefault("TENANT_HEADER", "X-Tenant-ID")
+	viper.SetDefault("TENANT_JWT_CLAIM", "tenant_id")
+	viper.SetDefault("TENANT_SCHEMA_PREFIX", "tenant_")
+
+	// Audit defaults
+	viper.SetDefault("AUDIT_ACTOR_HEADER", "X-Actor-ID")
+	viper.SetDefault("AUDIT_ACTOR_CLAIM", "sub")
 }
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		database DatabaseConfig
+		lock     LockConfig
+		wantErr  string
+	}{
+		{
+			name:     "defaults",
+			database: DatabaseConfig{PurgeInterval: time.Hour},
+			lock:     LockConfig{TTL: 30 * time.Second},
+		},
+		{
+			name:     "purge disabled",
+			database: DatabaseConfig{PurgeInterval: 0},
+			lock:     LockConfig{TTL: 30 * time.Second},
+		},
+		{
+			name:     "purge without interval",
+			database: DatabaseConfig{SoftDeleteRetention: 24 * time.Hour},
+			lock:     LockConfig{TTL: 30 * time.Second},
+			wantErr:  "DB_PURGE_INTERVAL must be positive to purge trashed records, got 0s",
+		},
+		{
+			name:    "short lock ttl",
+			lock:    LockConfig{TTL: 2 * time.Nanosecond},
+			wantErr: "LOCK_TTL must be at least 1s, got 2ns",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			previousDatabase, previousLock := Database, Lock
+			t.Cleanup(func() {
+				Database, Lock = previousDatabase, previousLock
+			})
+			Database, Lock = tc.database, tc.lock
+
+			err := validate()
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
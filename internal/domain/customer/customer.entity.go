@@ -5,8 +5,12 @@ import (
 	"github.com/google/uuid"
 )
 
+//go:generate go run github.com/goodone-dev/go-boilerplate/cmd/repogen -type=Customer
+
+//repogen:finder Email unique
 type Customer struct {
 	database.BaseEntity[uuid.UUID] `bson:",inline"`
+	database.TenantModel           `bson:",inline"`
 	Name                           string `json:"name" bson:"name"`
 	Email                          string `json:"email" bson:"email"`
 }
@@ -18,3 +22,27 @@ func (Customer) TableName() string {
 func (Customer) RepositoryName() string {
 	return "CustomerRepository"
 }
+
+// Indexes keeps emails unique among the live customers of a tenant, like
+// the unique (tenant_id, email) key of the SQL tables.
+func (Customer) Indexes() []database.Index {
+	return []database.Index{
+		{
+			Name:    "idx_customers_email",
+			Keys:    []string{"tenant_id", "email"},
+			Unique:  true,
+			Partial: map[string]any{"deleted_at": map[string]any{"$type": "null"}},
+		},
+	}
+}
+
+func (Customer) JSONSchema() map[string]any {
+	return map[string]any{
+		"bsonType": "object",
+		"required": []string{"name", "email"},
+		"properties": map[string]any{
+			"name":  map[string]any{"bsonType": "string", "minLength": 1},
+			"email": map[string]any{"bsonType": "string", "pattern": "^[^@\\s]+@[^@\\s]+$"},
+		},
+	}
+}
@@ -0,0 +1,12 @@
+// Code generated by repogen. DO NOT EDIT.
+
+package customer
+
+import (
+	"context"
+)
+
+// CustomerFinder holds the finders declared on Customer.
+type CustomerFinder interface {
+	FindByEmail(ctx context.Context, email string) (*Customer, error)
+}
@@ -6,12 +6,13 @@ package customer
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/goodone-dev/go-boilerplate/internal/domain/customer"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 )
 
 // NewCustomerRepositoryMock creates a new instance of CustomerRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -41,24 +42,87 @@ func (_m *CustomerRepositoryMock) EXPECT() *CustomerRepositoryMock_Expecter {
 	return &CustomerRepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// Aggregate provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) Aggregate(ctx context.Context, query database.AggregateQuery, dest any) error {
+	ret := _mock.Called(ctx, query, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, database.AggregateQuery, any) error); ok {
+		r0 = returnFunc(ctx, query, dest)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// CustomerRepositoryMock_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type CustomerRepositoryMock_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query database.AggregateQuery
+//   - dest any
+func (_e *CustomerRepositoryMock_Expecter) Aggregate(ctx interface{}, query interface{}, dest interface{}) *CustomerRepositoryMock_Aggregate_Call {
+	return &CustomerRepositoryMock_Aggregate_Call{Call: _e.mock.On("Aggregate", ctx, query, dest)}
+}
+
+func (_c *CustomerRepositoryMock_Aggregate_Call) Run(run func(ctx context.Context, query database.AggregateQuery, dest any)) *CustomerRepositoryMock_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 database.AggregateQuery
+		if args[1] != nil {
+			arg1 = args[1].(database.AggregateQuery)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Aggregate_Call) Return(err error) *CustomerRepositoryMock_Aggregate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Aggregate_Call) RunAndReturn(run func(ctx context.Context, query database.AggregateQuery, dest any) error) *CustomerRepositoryMock_Aggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Begin provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) Begin(ctx context.Context) (*gorm.DB, error) {
+func (_mock *CustomerRepositoryMock) Begin(ctx context.Context) (*database.Tx, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Begin")
 	}
 
-	var r0 *gorm.DB
+	var r0 *database.Tx
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*gorm.DB, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*database.Tx, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *database.Tx); ok {
 		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(*database.Tx)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -93,31 +157,101 @@ func (_c *CustomerRepositoryMock_Begin_Call) Run(run func(ctx context.Context))
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_Begin_Call) Return(dB *gorm.DB, err error) *CustomerRepositoryMock_Begin_Call {
-	_c.Call.Return(dB, err)
+func (_c *CustomerRepositoryMock_Begin_Call) Return(tx *database.Tx, err error) *CustomerRepositoryMock_Begin_Call {
+	_c.Call.Return(tx, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Begin_Call) RunAndReturn(run func(ctx context.Context) (*database.Tx, error)) *CustomerRepositoryMock_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkWrite provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) BulkWrite(ctx context.Context, ops []database.WriteOperation[customer.Customer], trx *database.Tx) (database.BulkWriteResult, error) {
+	ret := _mock.Called(ctx, ops, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkWrite")
+	}
+
+	var r0 database.BulkWriteResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []database.WriteOperation[customer.Customer], *database.Tx) (database.BulkWriteResult, error)); ok {
+		return returnFunc(ctx, ops, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []database.WriteOperation[customer.Customer], *database.Tx) database.BulkWriteResult); ok {
+		r0 = returnFunc(ctx, ops, trx)
+	} else {
+		r0 = ret.Get(0).(database.BulkWriteResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []database.WriteOperation[customer.Customer], *database.Tx) error); ok {
+		r1 = returnFunc(ctx, ops, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_BulkWrite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkWrite'
+type CustomerRepositoryMock_BulkWrite_Call struct {
+	*mock.Call
+}
+
+// BulkWrite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ops []database.WriteOperation[customer.Customer]
+//   - trx *database.Tx
+func (_e *CustomerRepositoryMock_Expecter) BulkWrite(ctx interface{}, ops interface{}, trx interface{}) *CustomerRepositoryMock_BulkWrite_Call {
+	return &CustomerRepositoryMock_BulkWrite_Call{Call: _e.mock.On("BulkWrite", ctx, ops, trx)}
+}
+
+func (_c *CustomerRepositoryMock_BulkWrite_Call) Run(run func(ctx context.Context, ops []database.WriteOperation[customer.Customer], trx *database.Tx)) *CustomerRepositoryMock_BulkWrite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []database.WriteOperation[customer.Customer]
+		if args[1] != nil {
+			arg1 = args[1].([]database.WriteOperation[customer.Customer])
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_BulkWrite_Call) Return(bulkWriteResult database.BulkWriteResult, err error) *CustomerRepositoryMock_BulkWrite_Call {
+	_c.Call.Return(bulkWriteResult, err)
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_Begin_Call) RunAndReturn(run func(ctx context.Context) (*gorm.DB, error)) *CustomerRepositoryMock_Begin_Call {
+func (_c *CustomerRepositoryMock_BulkWrite_Call) RunAndReturn(run func(ctx context.Context, ops []database.WriteOperation[customer.Customer], trx *database.Tx) (database.BulkWriteResult, error)) *CustomerRepositoryMock_BulkWrite_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Commit provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) Commit(trx *gorm.DB) *gorm.DB {
+func (_mock *CustomerRepositoryMock) Commit(trx *database.Tx) error {
 	ret := _mock.Called(trx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Commit")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) *gorm.DB); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.Tx) error); ok {
 		r0 = returnFunc(trx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
@@ -128,36 +262,102 @@ type CustomerRepositoryMock_Commit_Call struct {
 }
 
 // Commit is a helper method to define mock.On call
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) Commit(trx interface{}) *CustomerRepositoryMock_Commit_Call {
 	return &CustomerRepositoryMock_Commit_Call{Call: _e.mock.On("Commit", trx)}
 }
 
-func (_c *CustomerRepositoryMock_Commit_Call) Run(run func(trx *gorm.DB)) *CustomerRepositoryMock_Commit_Call {
+func (_c *CustomerRepositoryMock_Commit_Call) Run(run func(trx *database.Tx)) *CustomerRepositoryMock_Commit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *database.Tx
+		if args[0] != nil {
+			arg0 = args[0].(*database.Tx)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Commit_Call) Return(err error) *CustomerRepositoryMock_Commit_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Commit_Call) RunAndReturn(run func(trx *database.Tx) error) *CustomerRepositoryMock_Commit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) Count(ctx context.Context, filter map[string]any) (int64, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) (int64, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) int64); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type CustomerRepositoryMock_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *CustomerRepositoryMock_Expecter) Count(ctx interface{}, filter interface{}) *CustomerRepositoryMock_Count_Call {
+	return &CustomerRepositoryMock_Count_Call{Call: _e.mock.On("Count", ctx, filter)}
+}
+
+func (_c *CustomerRepositoryMock_Count_Call) Run(run func(ctx context.Context, filter map[string]any)) *CustomerRepositoryMock_Count_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_Commit_Call) Return(dB *gorm.DB) *CustomerRepositoryMock_Commit_Call {
-	_c.Call.Return(dB)
+func (_c *CustomerRepositoryMock_Count_Call) Return(n int64, err error) *CustomerRepositoryMock_Count_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_Commit_Call) RunAndReturn(run func(trx *gorm.DB) *gorm.DB) *CustomerRepositoryMock_Commit_Call {
+func (_c *CustomerRepositoryMock_Count_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) (int64, error)) *CustomerRepositoryMock_Count_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteById provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID, trx *gorm.DB) error {
+func (_mock *CustomerRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
 	ret := _mock.Called(ctx, ID, trx)
 
 	if len(ret) == 0 {
@@ -165,7 +365,7 @@ func (_mock *CustomerRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUI
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, ID, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -181,12 +381,12 @@ type CustomerRepositoryMock_DeleteById_Call struct {
 // DeleteById is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ID uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) DeleteById(ctx interface{}, ID interface{}, trx interface{}) *CustomerRepositoryMock_DeleteById_Call {
 	return &CustomerRepositoryMock_DeleteById_Call{Call: _e.mock.On("DeleteById", ctx, ID, trx)}
 }
 
-func (_c *CustomerRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB)) *CustomerRepositoryMock_DeleteById_Call {
+func (_c *CustomerRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *CustomerRepositoryMock_DeleteById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -196,9 +396,9 @@ func (_c *CustomerRepositoryMock_DeleteById_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -214,13 +414,13 @@ func (_c *CustomerRepositoryMock_DeleteById_Call) Return(err error) *CustomerRep
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_DeleteById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB) error) *CustomerRepositoryMock_DeleteById_Call {
+func (_c *CustomerRepositoryMock_DeleteById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *CustomerRepositoryMock_DeleteById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteByIds provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB) error {
+func (_mock *CustomerRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UUID, trx *database.Tx) error {
 	ret := _mock.Called(ctx, IDs, trx)
 
 	if len(ret) == 0 {
@@ -228,7 +428,7 @@ func (_mock *CustomerRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, IDs, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -244,12 +444,12 @@ type CustomerRepositoryMock_DeleteByIds_Call struct {
 // DeleteByIds is a helper method to define mock.On call
 //   - ctx context.Context
 //   - IDs []uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) DeleteByIds(ctx interface{}, IDs interface{}, trx interface{}) *CustomerRepositoryMock_DeleteByIds_Call {
 	return &CustomerRepositoryMock_DeleteByIds_Call{Call: _e.mock.On("DeleteByIds", ctx, IDs, trx)}
 }
 
-func (_c *CustomerRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB)) *CustomerRepositoryMock_DeleteByIds_Call {
+func (_c *CustomerRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, trx *database.Tx)) *CustomerRepositoryMock_DeleteByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -259,9 +459,9 @@ func (_c *CustomerRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].([]uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -277,13 +477,13 @@ func (_c *CustomerRepositoryMock_DeleteByIds_Call) Return(err error) *CustomerRe
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_DeleteByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB) error) *CustomerRepositoryMock_DeleteByIds_Call {
+func (_c *CustomerRepositoryMock_DeleteByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, trx *database.Tx) error) *CustomerRepositoryMock_DeleteByIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteMany provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) DeleteMany(ctx context.Context, filter map[string]any, trx *gorm.DB) error {
+func (_mock *CustomerRepositoryMock) DeleteMany(ctx context.Context, filter map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, filter, trx)
 
 	if len(ret) == 0 {
@@ -291,7 +491,7 @@ func (_mock *CustomerRepositoryMock) DeleteMany(ctx context.Context, filter map[
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, filter, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -307,12 +507,12 @@ type CustomerRepositoryMock_DeleteMany_Call struct {
 // DeleteMany is a helper method to define mock.On call
 //   - ctx context.Context
 //   - filter map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) DeleteMany(ctx interface{}, filter interface{}, trx interface{}) *CustomerRepositoryMock_DeleteMany_Call {
 	return &CustomerRepositoryMock_DeleteMany_Call{Call: _e.mock.On("DeleteMany", ctx, filter, trx)}
 }
 
-func (_c *CustomerRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context, filter map[string]any, trx *gorm.DB)) *CustomerRepositoryMock_DeleteMany_Call {
+func (_c *CustomerRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context, filter map[string]any, trx *database.Tx)) *CustomerRepositoryMock_DeleteMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -322,9 +522,9 @@ func (_c *CustomerRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].(map[string]any)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -340,7 +540,73 @@ func (_c *CustomerRepositoryMock_DeleteMany_Call) Return(err error) *CustomerRep
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, trx *gorm.DB) error) *CustomerRepositoryMock_DeleteMany_Call {
+func (_c *CustomerRepositoryMock_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, trx *database.Tx) error) *CustomerRepositoryMock_DeleteMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) Exists(ctx context.Context, filter map[string]any) (bool, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) (bool, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) bool); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type CustomerRepositoryMock_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *CustomerRepositoryMock_Expecter) Exists(ctx interface{}, filter interface{}) *CustomerRepositoryMock_Exists_Call {
+	return &CustomerRepositoryMock_Exists_Call{Call: _e.mock.On("Exists", ctx, filter)}
+}
+
+func (_c *CustomerRepositoryMock_Exists_Call) Run(run func(ctx context.Context, filter map[string]any)) *CustomerRepositoryMock_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Exists_Call) Return(b bool, err error) *CustomerRepositoryMock_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Exists_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) (bool, error)) *CustomerRepositoryMock_Exists_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -497,6 +763,74 @@ func (_c *CustomerRepositoryMock_FindByCursor_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
+// FindByEmail provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) FindByEmail(ctx context.Context, email string) (*customer.Customer, error) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByEmail")
+	}
+
+	var r0 *customer.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*customer.Customer, error)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *customer.Customer); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*customer.Customer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_FindByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByEmail'
+type CustomerRepositoryMock_FindByEmail_Call struct {
+	*mock.Call
+}
+
+// FindByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *CustomerRepositoryMock_Expecter) FindByEmail(ctx interface{}, email interface{}) *CustomerRepositoryMock_FindByEmail_Call {
+	return &CustomerRepositoryMock_FindByEmail_Call{Call: _e.mock.On("FindByEmail", ctx, email)}
+}
+
+func (_c *CustomerRepositoryMock_FindByEmail_Call) Run(run func(ctx context.Context, email string)) *CustomerRepositoryMock_FindByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_FindByEmail_Call) Return(customer1 *customer.Customer, err error) *CustomerRepositoryMock_FindByEmail_Call {
+	_c.Call.Return(customer1, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_FindByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (*customer.Customer, error)) *CustomerRepositoryMock_FindByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindById provides a mock function for the type CustomerRepositoryMock
 func (_mock *CustomerRepositoryMock) FindById(ctx context.Context, ID uuid.UUID) (*customer.Customer, error) {
 	ret := _mock.Called(ctx, ID)
@@ -566,7 +900,7 @@ func (_c *CustomerRepositoryMock_FindById_Call) RunAndReturn(run func(ctx contex
 }
 
 // FindByIdAndLock provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.UUID, trx *gorm.DB) (*customer.Customer, error) {
+func (_mock *CustomerRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.UUID, trx *database.Tx) (*customer.Customer, error) {
 	ret := _mock.Called(ctx, ID, trx)
 
 	if len(ret) == 0 {
@@ -575,17 +909,17 @@ func (_mock *CustomerRepositoryMock) FindByIdAndLock(ctx context.Context, ID uui
 
 	var r0 *customer.Customer
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) (*customer.Customer, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) (*customer.Customer, error)); ok {
 		return returnFunc(ctx, ID, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) *customer.Customer); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) *customer.Customer); ok {
 		r0 = returnFunc(ctx, ID, trx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*customer.Customer)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, ID, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -601,12 +935,12 @@ type CustomerRepositoryMock_FindByIdAndLock_Call struct {
 // FindByIdAndLock is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ID uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) FindByIdAndLock(ctx interface{}, ID interface{}, trx interface{}) *CustomerRepositoryMock_FindByIdAndLock_Call {
 	return &CustomerRepositoryMock_FindByIdAndLock_Call{Call: _e.mock.On("FindByIdAndLock", ctx, ID, trx)}
 }
 
-func (_c *CustomerRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB)) *CustomerRepositoryMock_FindByIdAndLock_Call {
+func (_c *CustomerRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *CustomerRepositoryMock_FindByIdAndLock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -616,9 +950,9 @@ func (_c *CustomerRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -634,7 +968,7 @@ func (_c *CustomerRepositoryMock_FindByIdAndLock_Call) Return(customer1 *custome
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_FindByIdAndLock_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB) (*customer.Customer, error)) *CustomerRepositoryMock_FindByIdAndLock_Call {
+func (_c *CustomerRepositoryMock_FindByIdAndLock_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) (*customer.Customer, error)) *CustomerRepositoryMock_FindByIdAndLock_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -791,25 +1125,361 @@ func (_c *CustomerRepositoryMock_FindByOffset_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
-// Insert provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) Insert(ctx context.Context, model customer.Customer, trx *gorm.DB) (customer.Customer, error) {
-	ret := _mock.Called(ctx, model, trx)
+// FindOnlyTrashed provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) FindOnlyTrashed(ctx context.Context, filter map[string]any) ([]customer.Customer, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Insert")
+		panic("no return value specified for FindOnlyTrashed")
 	}
 
-	var r0 customer.Customer
+	var r0 []customer.Customer
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, customer.Customer, *gorm.DB) (customer.Customer, error)); ok {
-		return returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]customer.Customer, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, customer.Customer, *gorm.DB) customer.Customer); ok {
-		r0 = returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []customer.Customer); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
-		r0 = ret.Get(0).(customer.Customer)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]customer.Customer)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, customer.Customer, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_FindOnlyTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOnlyTrashed'
+type CustomerRepositoryMock_FindOnlyTrashed_Call struct {
+	*mock.Call
+}
+
+// FindOnlyTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *CustomerRepositoryMock_Expecter) FindOnlyTrashed(ctx interface{}, filter interface{}) *CustomerRepositoryMock_FindOnlyTrashed_Call {
+	return &CustomerRepositoryMock_FindOnlyTrashed_Call{Call: _e.mock.On("FindOnlyTrashed", ctx, filter)}
+}
+
+func (_c *CustomerRepositoryMock_FindOnlyTrashed_Call) Run(run func(ctx context.Context, filter map[string]any)) *CustomerRepositoryMock_FindOnlyTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_FindOnlyTrashed_Call) Return(customers []customer.Customer, err error) *CustomerRepositoryMock_FindOnlyTrashed_Call {
+	_c.Call.Return(customers, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_FindOnlyTrashed_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]customer.Customer, error)) *CustomerRepositoryMock_FindOnlyTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindProjected provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) FindProjected(ctx context.Context, filter map[string]any, fields []string, dest any) error {
+	ret := _mock.Called(ctx, filter, fields, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProjected")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, []string, any) error); ok {
+		r0 = returnFunc(ctx, filter, fields, dest)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// CustomerRepositoryMock_FindProjected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProjected'
+type CustomerRepositoryMock_FindProjected_Call struct {
+	*mock.Call
+}
+
+// FindProjected is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+//   - fields []string
+//   - dest any
+func (_e *CustomerRepositoryMock_Expecter) FindProjected(ctx interface{}, filter interface{}, fields interface{}, dest interface{}) *CustomerRepositoryMock_FindProjected_Call {
+	return &CustomerRepositoryMock_FindProjected_Call{Call: _e.mock.On("FindProjected", ctx, filter, fields, dest)}
+}
+
+func (_c *CustomerRepositoryMock_FindProjected_Call) Run(run func(ctx context.Context, filter map[string]any, fields []string, dest any)) *CustomerRepositoryMock_FindProjected_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 any
+		if args[3] != nil {
+			arg3 = args[3].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_FindProjected_Call) Return(err error) *CustomerRepositoryMock_FindProjected_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_FindProjected_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, fields []string, dest any) error) *CustomerRepositoryMock_FindProjected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWithTrashed provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) FindWithTrashed(ctx context.Context, filter map[string]any) ([]customer.Customer, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWithTrashed")
+	}
+
+	var r0 []customer.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]customer.Customer, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []customer.Customer); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]customer.Customer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_FindWithTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWithTrashed'
+type CustomerRepositoryMock_FindWithTrashed_Call struct {
+	*mock.Call
+}
+
+// FindWithTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *CustomerRepositoryMock_Expecter) FindWithTrashed(ctx interface{}, filter interface{}) *CustomerRepositoryMock_FindWithTrashed_Call {
+	return &CustomerRepositoryMock_FindWithTrashed_Call{Call: _e.mock.On("FindWithTrashed", ctx, filter)}
+}
+
+func (_c *CustomerRepositoryMock_FindWithTrashed_Call) Run(run func(ctx context.Context, filter map[string]any)) *CustomerRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_FindWithTrashed_Call) Return(customers []customer.Customer, err error) *CustomerRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Return(customers, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_FindWithTrashed_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]customer.Customer, error)) *CustomerRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForceDelete provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) ForceDelete(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
+	ret := _mock.Called(ctx, ID, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
+		r0 = returnFunc(ctx, ID, trx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// CustomerRepositoryMock_ForceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceDelete'
+type CustomerRepositoryMock_ForceDelete_Call struct {
+	*mock.Call
+}
+
+// ForceDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - trx *database.Tx
+func (_e *CustomerRepositoryMock_Expecter) ForceDelete(ctx interface{}, ID interface{}, trx interface{}) *CustomerRepositoryMock_ForceDelete_Call {
+	return &CustomerRepositoryMock_ForceDelete_Call{Call: _e.mock.On("ForceDelete", ctx, ID, trx)}
+}
+
+func (_c *CustomerRepositoryMock_ForceDelete_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *CustomerRepositoryMock_ForceDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_ForceDelete_Call) Return(err error) *CustomerRepositoryMock_ForceDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_ForceDelete_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *CustomerRepositoryMock_ForceDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) History(ctx context.Context, ID uuid.UUID) ([]database.AuditLog, error) {
+	ret := _mock.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []database.AuditLog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]database.AuditLog, error)); ok {
+		return returnFunc(ctx, ID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []database.AuditLog); ok {
+		r0 = returnFunc(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.AuditLog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type CustomerRepositoryMock_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *CustomerRepositoryMock_Expecter) History(ctx interface{}, ID interface{}) *CustomerRepositoryMock_History_Call {
+	return &CustomerRepositoryMock_History_Call{Call: _e.mock.On("History", ctx, ID)}
+}
+
+func (_c *CustomerRepositoryMock_History_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *CustomerRepositoryMock_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_History_Call) Return(auditLogs []database.AuditLog, err error) *CustomerRepositoryMock_History_Call {
+	_c.Call.Return(auditLogs, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_History_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID) ([]database.AuditLog, error)) *CustomerRepositoryMock_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) Insert(ctx context.Context, model customer.Customer, trx *database.Tx) (customer.Customer, error) {
+	ret := _mock.Called(ctx, model, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 customer.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, customer.Customer, *database.Tx) (customer.Customer, error)); ok {
+		return returnFunc(ctx, model, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, customer.Customer, *database.Tx) customer.Customer); ok {
+		r0 = returnFunc(ctx, model, trx)
+	} else {
+		r0 = ret.Get(0).(customer.Customer)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, customer.Customer, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, model, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -825,12 +1495,12 @@ type CustomerRepositoryMock_Insert_Call struct {
 // Insert is a helper method to define mock.On call
 //   - ctx context.Context
 //   - model customer.Customer
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) Insert(ctx interface{}, model interface{}, trx interface{}) *CustomerRepositoryMock_Insert_Call {
 	return &CustomerRepositoryMock_Insert_Call{Call: _e.mock.On("Insert", ctx, model, trx)}
 }
 
-func (_c *CustomerRepositoryMock_Insert_Call) Run(run func(ctx context.Context, model customer.Customer, trx *gorm.DB)) *CustomerRepositoryMock_Insert_Call {
+func (_c *CustomerRepositoryMock_Insert_Call) Run(run func(ctx context.Context, model customer.Customer, trx *database.Tx)) *CustomerRepositoryMock_Insert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -840,9 +1510,9 @@ func (_c *CustomerRepositoryMock_Insert_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(customer.Customer)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -858,13 +1528,13 @@ func (_c *CustomerRepositoryMock_Insert_Call) Return(customer1 customer.Customer
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_Insert_Call) RunAndReturn(run func(ctx context.Context, model customer.Customer, trx *gorm.DB) (customer.Customer, error)) *CustomerRepositoryMock_Insert_Call {
+func (_c *CustomerRepositoryMock_Insert_Call) RunAndReturn(run func(ctx context.Context, model customer.Customer, trx *database.Tx) (customer.Customer, error)) *CustomerRepositoryMock_Insert_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // InsertMany provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) InsertMany(ctx context.Context, models []customer.Customer, trx *gorm.DB) ([]customer.Customer, error) {
+func (_mock *CustomerRepositoryMock) InsertMany(ctx context.Context, models []customer.Customer, trx *database.Tx) ([]customer.Customer, error) {
 	ret := _mock.Called(ctx, models, trx)
 
 	if len(ret) == 0 {
@@ -873,17 +1543,17 @@ func (_mock *CustomerRepositoryMock) InsertMany(ctx context.Context, models []cu
 
 	var r0 []customer.Customer
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []customer.Customer, *gorm.DB) ([]customer.Customer, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []customer.Customer, *database.Tx) ([]customer.Customer, error)); ok {
 		return returnFunc(ctx, models, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []customer.Customer, *gorm.DB) []customer.Customer); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []customer.Customer, *database.Tx) []customer.Customer); ok {
 		r0 = returnFunc(ctx, models, trx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]customer.Customer)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []customer.Customer, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []customer.Customer, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, models, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -899,12 +1569,12 @@ type CustomerRepositoryMock_InsertMany_Call struct {
 // InsertMany is a helper method to define mock.On call
 //   - ctx context.Context
 //   - models []customer.Customer
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) InsertMany(ctx interface{}, models interface{}, trx interface{}) *CustomerRepositoryMock_InsertMany_Call {
 	return &CustomerRepositoryMock_InsertMany_Call{Call: _e.mock.On("InsertMany", ctx, models, trx)}
 }
 
-func (_c *CustomerRepositoryMock_InsertMany_Call) Run(run func(ctx context.Context, models []customer.Customer, trx *gorm.DB)) *CustomerRepositoryMock_InsertMany_Call {
+func (_c *CustomerRepositoryMock_InsertMany_Call) Run(run func(ctx context.Context, models []customer.Customer, trx *database.Tx)) *CustomerRepositoryMock_InsertMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -914,9 +1584,9 @@ func (_c *CustomerRepositoryMock_InsertMany_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].([]customer.Customer)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -932,72 +1602,215 @@ func (_c *CustomerRepositoryMock_InsertMany_Call) Return(customers []customer.Cu
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_InsertMany_Call) RunAndReturn(run func(ctx context.Context, models []customer.Customer, trx *gorm.DB) ([]customer.Customer, error)) *CustomerRepositoryMock_InsertMany_Call {
+func (_c *CustomerRepositoryMock_InsertMany_Call) RunAndReturn(run func(ctx context.Context, models []customer.Customer, trx *database.Tx) ([]customer.Customer, error)) *CustomerRepositoryMock_InsertMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MasterDB provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) MasterDB() *gorm.DB {
-	ret := _mock.Called()
+// Preload provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) Preload(relations ...string) database.BaseRepository[uuid.UUID, customer.Customer] {
+	var tmpRet mock.Arguments
+	if len(relations) > 0 {
+		tmpRet = _mock.Called(relations)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for MasterDB")
+		panic("no return value specified for Preload")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = returnFunc()
+	var r0 database.BaseRepository[uuid.UUID, customer.Customer]
+	if returnFunc, ok := ret.Get(0).(func(...string) database.BaseRepository[uuid.UUID, customer.Customer]); ok {
+		r0 = returnFunc(relations...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(database.BaseRepository[uuid.UUID, customer.Customer])
 		}
 	}
 	return r0
 }
 
-// CustomerRepositoryMock_MasterDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MasterDB'
-type CustomerRepositoryMock_MasterDB_Call struct {
+// CustomerRepositoryMock_Preload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Preload'
+type CustomerRepositoryMock_Preload_Call struct {
 	*mock.Call
 }
 
-// MasterDB is a helper method to define mock.On call
-func (_e *CustomerRepositoryMock_Expecter) MasterDB() *CustomerRepositoryMock_MasterDB_Call {
-	return &CustomerRepositoryMock_MasterDB_Call{Call: _e.mock.On("MasterDB")}
+// Preload is a helper method to define mock.On call
+//   - relations ...string
+func (_e *CustomerRepositoryMock_Expecter) Preload(relations ...interface{}) *CustomerRepositoryMock_Preload_Call {
+	return &CustomerRepositoryMock_Preload_Call{Call: _e.mock.On("Preload",
+		append([]interface{}{}, relations...)...)}
 }
 
-func (_c *CustomerRepositoryMock_MasterDB_Call) Run(run func()) *CustomerRepositoryMock_MasterDB_Call {
+func (_c *CustomerRepositoryMock_Preload_Call) Run(run func(relations ...string)) *CustomerRepositoryMock_Preload_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 []string
+		var variadicArgs []string
+		if len(args) > 0 {
+			variadicArgs = args[0].([]string)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
 	})
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_MasterDB_Call) Return(dB *gorm.DB) *CustomerRepositoryMock_MasterDB_Call {
-	_c.Call.Return(dB)
+func (_c *CustomerRepositoryMock_Preload_Call) Return(baseRepository database.BaseRepository[uuid.UUID, customer.Customer]) *CustomerRepositoryMock_Preload_Call {
+	_c.Call.Return(baseRepository)
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_MasterDB_Call) RunAndReturn(run func() *gorm.DB) *CustomerRepositoryMock_MasterDB_Call {
+func (_c *CustomerRepositoryMock_Preload_Call) RunAndReturn(run func(relations ...string) database.BaseRepository[uuid.UUID, customer.Customer]) *CustomerRepositoryMock_Preload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrashed provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrashed")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_PurgeTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrashed'
+type CustomerRepositoryMock_PurgeTrashed_Call struct {
+	*mock.Call
+}
+
+// PurgeTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *CustomerRepositoryMock_Expecter) PurgeTrashed(ctx interface{}, before interface{}) *CustomerRepositoryMock_PurgeTrashed_Call {
+	return &CustomerRepositoryMock_PurgeTrashed_Call{Call: _e.mock.On("PurgeTrashed", ctx, before)}
+}
+
+func (_c *CustomerRepositoryMock_PurgeTrashed_Call) Run(run func(ctx context.Context, before time.Time)) *CustomerRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_PurgeTrashed_Call) Return(n int64, err error) *CustomerRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_PurgeTrashed_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *CustomerRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) Restore(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
+	ret := _mock.Called(ctx, ID, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
+		r0 = returnFunc(ctx, ID, trx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// CustomerRepositoryMock_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type CustomerRepositoryMock_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - trx *database.Tx
+func (_e *CustomerRepositoryMock_Expecter) Restore(ctx interface{}, ID interface{}, trx interface{}) *CustomerRepositoryMock_Restore_Call {
+	return &CustomerRepositoryMock_Restore_Call{Call: _e.mock.On("Restore", ctx, ID, trx)}
+}
+
+func (_c *CustomerRepositoryMock_Restore_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *CustomerRepositoryMock_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Restore_Call) Return(err error) *CustomerRepositoryMock_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Restore_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *CustomerRepositoryMock_Restore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Rollback provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) Rollback(trx *gorm.DB) *gorm.DB {
+func (_mock *CustomerRepositoryMock) Rollback(trx *database.Tx) error {
 	ret := _mock.Called(trx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Rollback")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) *gorm.DB); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.Tx) error); ok {
 		r0 = returnFunc(trx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
@@ -1008,16 +1821,16 @@ type CustomerRepositoryMock_Rollback_Call struct {
 }
 
 // Rollback is a helper method to define mock.On call
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) Rollback(trx interface{}) *CustomerRepositoryMock_Rollback_Call {
 	return &CustomerRepositoryMock_Rollback_Call{Call: _e.mock.On("Rollback", trx)}
 }
 
-func (_c *CustomerRepositoryMock_Rollback_Call) Run(run func(trx *gorm.DB)) *CustomerRepositoryMock_Rollback_Call {
+func (_c *CustomerRepositoryMock_Rollback_Call) Run(run func(trx *database.Tx)) *CustomerRepositoryMock_Rollback_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 *database.Tx
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1026,64 +1839,83 @@ func (_c *CustomerRepositoryMock_Rollback_Call) Run(run func(trx *gorm.DB)) *Cus
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_Rollback_Call) Return(dB *gorm.DB) *CustomerRepositoryMock_Rollback_Call {
-	_c.Call.Return(dB)
+func (_c *CustomerRepositoryMock_Rollback_Call) Return(err error) *CustomerRepositoryMock_Rollback_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_Rollback_Call) RunAndReturn(run func(trx *gorm.DB) *gorm.DB) *CustomerRepositoryMock_Rollback_Call {
+func (_c *CustomerRepositoryMock_Rollback_Call) RunAndReturn(run func(trx *database.Tx) error) *CustomerRepositoryMock_Rollback_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SlaveDB provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) SlaveDB() *gorm.DB {
-	ret := _mock.Called()
+// Stream provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) Stream(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[customer.Customer, error] {
+	ret := _mock.Called(ctx, filter, batchSize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SlaveDB")
+		panic("no return value specified for Stream")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = returnFunc()
+	var r0 iter.Seq2[customer.Customer, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, int) iter.Seq2[customer.Customer, error]); ok {
+		r0 = returnFunc(ctx, filter, batchSize)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(iter.Seq2[customer.Customer, error])
 		}
 	}
 	return r0
 }
 
-// CustomerRepositoryMock_SlaveDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SlaveDB'
-type CustomerRepositoryMock_SlaveDB_Call struct {
+// CustomerRepositoryMock_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type CustomerRepositoryMock_Stream_Call struct {
 	*mock.Call
 }
 
-// SlaveDB is a helper method to define mock.On call
-func (_e *CustomerRepositoryMock_Expecter) SlaveDB() *CustomerRepositoryMock_SlaveDB_Call {
-	return &CustomerRepositoryMock_SlaveDB_Call{Call: _e.mock.On("SlaveDB")}
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+//   - batchSize int
+func (_e *CustomerRepositoryMock_Expecter) Stream(ctx interface{}, filter interface{}, batchSize interface{}) *CustomerRepositoryMock_Stream_Call {
+	return &CustomerRepositoryMock_Stream_Call{Call: _e.mock.On("Stream", ctx, filter, batchSize)}
 }
 
-func (_c *CustomerRepositoryMock_SlaveDB_Call) Run(run func()) *CustomerRepositoryMock_SlaveDB_Call {
+func (_c *CustomerRepositoryMock_Stream_Call) Run(run func(ctx context.Context, filter map[string]any, batchSize int)) *CustomerRepositoryMock_Stream_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_SlaveDB_Call) Return(dB *gorm.DB) *CustomerRepositoryMock_SlaveDB_Call {
-	_c.Call.Return(dB)
+func (_c *CustomerRepositoryMock_Stream_Call) Return(seq2 iter.Seq2[customer.Customer, error]) *CustomerRepositoryMock_Stream_Call {
+	_c.Call.Return(seq2)
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_SlaveDB_Call) RunAndReturn(run func() *gorm.DB) *CustomerRepositoryMock_SlaveDB_Call {
+func (_c *CustomerRepositoryMock_Stream_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[customer.Customer, error]) *CustomerRepositoryMock_Stream_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) Update(ctx context.Context, model customer.Customer, trx *gorm.DB) error {
+func (_mock *CustomerRepositoryMock) Update(ctx context.Context, model customer.Customer, trx *database.Tx) error {
 	ret := _mock.Called(ctx, model, trx)
 
 	if len(ret) == 0 {
@@ -1091,7 +1923,7 @@ func (_mock *CustomerRepositoryMock) Update(ctx context.Context, model customer.
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, customer.Customer, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, customer.Customer, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, model, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1107,12 +1939,12 @@ type CustomerRepositoryMock_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
 //   - model customer.Customer
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) Update(ctx interface{}, model interface{}, trx interface{}) *CustomerRepositoryMock_Update_Call {
 	return &CustomerRepositoryMock_Update_Call{Call: _e.mock.On("Update", ctx, model, trx)}
 }
 
-func (_c *CustomerRepositoryMock_Update_Call) Run(run func(ctx context.Context, model customer.Customer, trx *gorm.DB)) *CustomerRepositoryMock_Update_Call {
+func (_c *CustomerRepositoryMock_Update_Call) Run(run func(ctx context.Context, model customer.Customer, trx *database.Tx)) *CustomerRepositoryMock_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1122,9 +1954,9 @@ func (_c *CustomerRepositoryMock_Update_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(customer.Customer)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1140,13 +1972,13 @@ func (_c *CustomerRepositoryMock_Update_Call) Return(err error) *CustomerReposit
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, model customer.Customer, trx *gorm.DB) error) *CustomerRepositoryMock_Update_Call {
+func (_c *CustomerRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, model customer.Customer, trx *database.Tx) error) *CustomerRepositoryMock_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateById provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB) (customer.Customer, error) {
+func (_mock *CustomerRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx) (customer.Customer, error) {
 	ret := _mock.Called(ctx, ID, payload, trx)
 
 	if len(ret) == 0 {
@@ -1155,15 +1987,15 @@ func (_mock *CustomerRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUI
 
 	var r0 customer.Customer
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) (customer.Customer, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) (customer.Customer, error)); ok {
 		return returnFunc(ctx, ID, payload, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) customer.Customer); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) customer.Customer); ok {
 		r0 = returnFunc(ctx, ID, payload, trx)
 	} else {
 		r0 = ret.Get(0).(customer.Customer)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, ID, payload, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -1180,12 +2012,12 @@ type CustomerRepositoryMock_UpdateById_Call struct {
 //   - ctx context.Context
 //   - ID uuid.UUID
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) UpdateById(ctx interface{}, ID interface{}, payload interface{}, trx interface{}) *CustomerRepositoryMock_UpdateById_Call {
 	return &CustomerRepositoryMock_UpdateById_Call{Call: _e.mock.On("UpdateById", ctx, ID, payload, trx)}
 }
 
-func (_c *CustomerRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB)) *CustomerRepositoryMock_UpdateById_Call {
+func (_c *CustomerRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx)) *CustomerRepositoryMock_UpdateById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1199,9 +2031,9 @@ func (_c *CustomerRepositoryMock_UpdateById_Call) Run(run func(ctx context.Conte
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1218,13 +2050,13 @@ func (_c *CustomerRepositoryMock_UpdateById_Call) Return(customer1 customer.Cust
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_UpdateById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB) (customer.Customer, error)) *CustomerRepositoryMock_UpdateById_Call {
+func (_c *CustomerRepositoryMock_UpdateById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx) (customer.Customer, error)) *CustomerRepositoryMock_UpdateById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateByIds provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB) error {
+func (_mock *CustomerRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, IDs, payload, trx)
 
 	if len(ret) == 0 {
@@ -1232,7 +2064,7 @@ func (_mock *CustomerRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, IDs, payload, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1249,12 +2081,12 @@ type CustomerRepositoryMock_UpdateByIds_Call struct {
 //   - ctx context.Context
 //   - IDs []uuid.UUID
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) UpdateByIds(ctx interface{}, IDs interface{}, payload interface{}, trx interface{}) *CustomerRepositoryMock_UpdateByIds_Call {
 	return &CustomerRepositoryMock_UpdateByIds_Call{Call: _e.mock.On("UpdateByIds", ctx, IDs, payload, trx)}
 }
 
-func (_c *CustomerRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB)) *CustomerRepositoryMock_UpdateByIds_Call {
+func (_c *CustomerRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx)) *CustomerRepositoryMock_UpdateByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1268,9 +2100,9 @@ func (_c *CustomerRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Cont
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1287,13 +2119,13 @@ func (_c *CustomerRepositoryMock_UpdateByIds_Call) Return(err error) *CustomerRe
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_UpdateByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB) error) *CustomerRepositoryMock_UpdateByIds_Call {
+func (_c *CustomerRepositoryMock_UpdateByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx) error) *CustomerRepositoryMock_UpdateByIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateMany provides a mock function for the type CustomerRepositoryMock
-func (_mock *CustomerRepositoryMock) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB) error {
+func (_mock *CustomerRepositoryMock) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, filter, payload, trx)
 
 	if len(ret) == 0 {
@@ -1301,7 +2133,7 @@ func (_mock *CustomerRepositoryMock) UpdateMany(ctx context.Context, filter map[
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, filter, payload, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1318,12 +2150,12 @@ type CustomerRepositoryMock_UpdateMany_Call struct {
 //   - ctx context.Context
 //   - filter map[string]any
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *CustomerRepositoryMock_Expecter) UpdateMany(ctx interface{}, filter interface{}, payload interface{}, trx interface{}) *CustomerRepositoryMock_UpdateMany_Call {
 	return &CustomerRepositoryMock_UpdateMany_Call{Call: _e.mock.On("UpdateMany", ctx, filter, payload, trx)}
 }
 
-func (_c *CustomerRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB)) *CustomerRepositoryMock_UpdateMany_Call {
+func (_c *CustomerRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx)) *CustomerRepositoryMock_UpdateMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1337,9 +2169,9 @@ func (_c *CustomerRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Conte
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1356,7 +2188,165 @@ func (_c *CustomerRepositoryMock_UpdateMany_Call) Return(err error) *CustomerRep
 	return _c
 }
 
-func (_c *CustomerRepositoryMock_UpdateMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB) error) *CustomerRepositoryMock_UpdateMany_Call {
+func (_c *CustomerRepositoryMock_UpdateMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) error) *CustomerRepositoryMock_UpdateMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) Upsert(ctx context.Context, model customer.Customer, opts database.UpsertOptions, trx *database.Tx) (customer.Customer, error) {
+	ret := _mock.Called(ctx, model, opts, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 customer.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, customer.Customer, database.UpsertOptions, *database.Tx) (customer.Customer, error)); ok {
+		return returnFunc(ctx, model, opts, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, customer.Customer, database.UpsertOptions, *database.Tx) customer.Customer); ok {
+		r0 = returnFunc(ctx, model, opts, trx)
+	} else {
+		r0 = ret.Get(0).(customer.Customer)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, customer.Customer, database.UpsertOptions, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, model, opts, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type CustomerRepositoryMock_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - model customer.Customer
+//   - opts database.UpsertOptions
+//   - trx *database.Tx
+func (_e *CustomerRepositoryMock_Expecter) Upsert(ctx interface{}, model interface{}, opts interface{}, trx interface{}) *CustomerRepositoryMock_Upsert_Call {
+	return &CustomerRepositoryMock_Upsert_Call{Call: _e.mock.On("Upsert", ctx, model, opts, trx)}
+}
+
+func (_c *CustomerRepositoryMock_Upsert_Call) Run(run func(ctx context.Context, model customer.Customer, opts database.UpsertOptions, trx *database.Tx)) *CustomerRepositoryMock_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 customer.Customer
+		if args[1] != nil {
+			arg1 = args[1].(customer.Customer)
+		}
+		var arg2 database.UpsertOptions
+		if args[2] != nil {
+			arg2 = args[2].(database.UpsertOptions)
+		}
+		var arg3 *database.Tx
+		if args[3] != nil {
+			arg3 = args[3].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Upsert_Call) Return(customer1 customer.Customer, err error) *CustomerRepositoryMock_Upsert_Call {
+	_c.Call.Return(customer1, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_Upsert_Call) RunAndReturn(run func(ctx context.Context, model customer.Customer, opts database.UpsertOptions, trx *database.Tx) (customer.Customer, error)) *CustomerRepositoryMock_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertMany provides a mock function for the type CustomerRepositoryMock
+func (_mock *CustomerRepositoryMock) UpsertMany(ctx context.Context, models []customer.Customer, opts database.UpsertOptions, trx *database.Tx) ([]customer.Customer, error) {
+	ret := _mock.Called(ctx, models, opts, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertMany")
+	}
+
+	var r0 []customer.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []customer.Customer, database.UpsertOptions, *database.Tx) ([]customer.Customer, error)); ok {
+		return returnFunc(ctx, models, opts, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []customer.Customer, database.UpsertOptions, *database.Tx) []customer.Customer); ok {
+		r0 = returnFunc(ctx, models, opts, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]customer.Customer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []customer.Customer, database.UpsertOptions, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, models, opts, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// CustomerRepositoryMock_UpsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertMany'
+type CustomerRepositoryMock_UpsertMany_Call struct {
+	*mock.Call
+}
+
+// UpsertMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - models []customer.Customer
+//   - opts database.UpsertOptions
+//   - trx *database.Tx
+func (_e *CustomerRepositoryMock_Expecter) UpsertMany(ctx interface{}, models interface{}, opts interface{}, trx interface{}) *CustomerRepositoryMock_UpsertMany_Call {
+	return &CustomerRepositoryMock_UpsertMany_Call{Call: _e.mock.On("UpsertMany", ctx, models, opts, trx)}
+}
+
+func (_c *CustomerRepositoryMock_UpsertMany_Call) Run(run func(ctx context.Context, models []customer.Customer, opts database.UpsertOptions, trx *database.Tx)) *CustomerRepositoryMock_UpsertMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []customer.Customer
+		if args[1] != nil {
+			arg1 = args[1].([]customer.Customer)
+		}
+		var arg2 database.UpsertOptions
+		if args[2] != nil {
+			arg2 = args[2].(database.UpsertOptions)
+		}
+		var arg3 *database.Tx
+		if args[3] != nil {
+			arg3 = args[3].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_UpsertMany_Call) Return(customers []customer.Customer, err error) *CustomerRepositoryMock_UpsertMany_Call {
+	_c.Call.Return(customers, err)
+	return _c
+}
+
+func (_c *CustomerRepositoryMock_UpsertMany_Call) RunAndReturn(run func(ctx context.Context, models []customer.Customer, opts database.UpsertOptions, trx *database.Tx) ([]customer.Customer, error)) *CustomerRepositoryMock_UpsertMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7,6 +7,7 @@ import (
 
 type Employee struct {
 	database.BaseEntity[uuid.UUID] `bson:",inline"`
+	database.TenantModel           `bson:",inline"`
 	Name                           string `json:"name" bson:"name"`
 	Email                          string `json:"email" bson:"email"`
 	Role                           string `json:"role" bson:"role"`
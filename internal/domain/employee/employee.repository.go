@@ -3,9 +3,8 @@ package employee
 import (
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type EmployeeRepository interface {
-	database.BaseRepository[gorm.DB, uuid.UUID, Employee]
+	database.BaseRepository[uuid.UUID, Employee]
 }
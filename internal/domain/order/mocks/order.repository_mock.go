@@ -6,12 +6,13 @@ package order
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/goodone-dev/go-boilerplate/internal/domain/order"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 )
 
 // NewOrderRepositoryMock creates a new instance of OrderRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -41,24 +42,87 @@ func (_m *OrderRepositoryMock) EXPECT() *OrderRepositoryMock_Expecter {
 	return &OrderRepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// Aggregate provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) Aggregate(ctx context.Context, query database.AggregateQuery, dest any) error {
+	ret := _mock.Called(ctx, query, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, database.AggregateQuery, any) error); ok {
+		r0 = returnFunc(ctx, query, dest)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// OrderRepositoryMock_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type OrderRepositoryMock_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query database.AggregateQuery
+//   - dest any
+func (_e *OrderRepositoryMock_Expecter) Aggregate(ctx interface{}, query interface{}, dest interface{}) *OrderRepositoryMock_Aggregate_Call {
+	return &OrderRepositoryMock_Aggregate_Call{Call: _e.mock.On("Aggregate", ctx, query, dest)}
+}
+
+func (_c *OrderRepositoryMock_Aggregate_Call) Run(run func(ctx context.Context, query database.AggregateQuery, dest any)) *OrderRepositoryMock_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 database.AggregateQuery
+		if args[1] != nil {
+			arg1 = args[1].(database.AggregateQuery)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Aggregate_Call) Return(err error) *OrderRepositoryMock_Aggregate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Aggregate_Call) RunAndReturn(run func(ctx context.Context, query database.AggregateQuery, dest any) error) *OrderRepositoryMock_Aggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Begin provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) Begin(ctx context.Context) (*gorm.DB, error) {
+func (_mock *OrderRepositoryMock) Begin(ctx context.Context) (*database.Tx, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Begin")
 	}
 
-	var r0 *gorm.DB
+	var r0 *database.Tx
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*gorm.DB, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*database.Tx, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *database.Tx); ok {
 		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(*database.Tx)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -93,31 +157,101 @@ func (_c *OrderRepositoryMock_Begin_Call) Run(run func(ctx context.Context)) *Or
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Begin_Call) Return(dB *gorm.DB, err error) *OrderRepositoryMock_Begin_Call {
-	_c.Call.Return(dB, err)
+func (_c *OrderRepositoryMock_Begin_Call) Return(tx *database.Tx, err error) *OrderRepositoryMock_Begin_Call {
+	_c.Call.Return(tx, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Begin_Call) RunAndReturn(run func(ctx context.Context) (*database.Tx, error)) *OrderRepositoryMock_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkWrite provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) BulkWrite(ctx context.Context, ops []database.WriteOperation[order.Order], trx *database.Tx) (database.BulkWriteResult, error) {
+	ret := _mock.Called(ctx, ops, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkWrite")
+	}
+
+	var r0 database.BulkWriteResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []database.WriteOperation[order.Order], *database.Tx) (database.BulkWriteResult, error)); ok {
+		return returnFunc(ctx, ops, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []database.WriteOperation[order.Order], *database.Tx) database.BulkWriteResult); ok {
+		r0 = returnFunc(ctx, ops, trx)
+	} else {
+		r0 = ret.Get(0).(database.BulkWriteResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []database.WriteOperation[order.Order], *database.Tx) error); ok {
+		r1 = returnFunc(ctx, ops, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_BulkWrite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkWrite'
+type OrderRepositoryMock_BulkWrite_Call struct {
+	*mock.Call
+}
+
+// BulkWrite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ops []database.WriteOperation[order.Order]
+//   - trx *database.Tx
+func (_e *OrderRepositoryMock_Expecter) BulkWrite(ctx interface{}, ops interface{}, trx interface{}) *OrderRepositoryMock_BulkWrite_Call {
+	return &OrderRepositoryMock_BulkWrite_Call{Call: _e.mock.On("BulkWrite", ctx, ops, trx)}
+}
+
+func (_c *OrderRepositoryMock_BulkWrite_Call) Run(run func(ctx context.Context, ops []database.WriteOperation[order.Order], trx *database.Tx)) *OrderRepositoryMock_BulkWrite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []database.WriteOperation[order.Order]
+		if args[1] != nil {
+			arg1 = args[1].([]database.WriteOperation[order.Order])
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_BulkWrite_Call) Return(bulkWriteResult database.BulkWriteResult, err error) *OrderRepositoryMock_BulkWrite_Call {
+	_c.Call.Return(bulkWriteResult, err)
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Begin_Call) RunAndReturn(run func(ctx context.Context) (*gorm.DB, error)) *OrderRepositoryMock_Begin_Call {
+func (_c *OrderRepositoryMock_BulkWrite_Call) RunAndReturn(run func(ctx context.Context, ops []database.WriteOperation[order.Order], trx *database.Tx) (database.BulkWriteResult, error)) *OrderRepositoryMock_BulkWrite_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Commit provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) Commit(trx *gorm.DB) *gorm.DB {
+func (_mock *OrderRepositoryMock) Commit(trx *database.Tx) error {
 	ret := _mock.Called(trx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Commit")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) *gorm.DB); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.Tx) error); ok {
 		r0 = returnFunc(trx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
@@ -128,36 +262,102 @@ type OrderRepositoryMock_Commit_Call struct {
 }
 
 // Commit is a helper method to define mock.On call
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) Commit(trx interface{}) *OrderRepositoryMock_Commit_Call {
 	return &OrderRepositoryMock_Commit_Call{Call: _e.mock.On("Commit", trx)}
 }
 
-func (_c *OrderRepositoryMock_Commit_Call) Run(run func(trx *gorm.DB)) *OrderRepositoryMock_Commit_Call {
+func (_c *OrderRepositoryMock_Commit_Call) Run(run func(trx *database.Tx)) *OrderRepositoryMock_Commit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *database.Tx
+		if args[0] != nil {
+			arg0 = args[0].(*database.Tx)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Commit_Call) Return(err error) *OrderRepositoryMock_Commit_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Commit_Call) RunAndReturn(run func(trx *database.Tx) error) *OrderRepositoryMock_Commit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) Count(ctx context.Context, filter map[string]any) (int64, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) (int64, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) int64); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type OrderRepositoryMock_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *OrderRepositoryMock_Expecter) Count(ctx interface{}, filter interface{}) *OrderRepositoryMock_Count_Call {
+	return &OrderRepositoryMock_Count_Call{Call: _e.mock.On("Count", ctx, filter)}
+}
+
+func (_c *OrderRepositoryMock_Count_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderRepositoryMock_Count_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Commit_Call) Return(dB *gorm.DB) *OrderRepositoryMock_Commit_Call {
-	_c.Call.Return(dB)
+func (_c *OrderRepositoryMock_Count_Call) Return(n int64, err error) *OrderRepositoryMock_Count_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Commit_Call) RunAndReturn(run func(trx *gorm.DB) *gorm.DB) *OrderRepositoryMock_Commit_Call {
+func (_c *OrderRepositoryMock_Count_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) (int64, error)) *OrderRepositoryMock_Count_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteById provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID, trx *gorm.DB) error {
+func (_mock *OrderRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
 	ret := _mock.Called(ctx, ID, trx)
 
 	if len(ret) == 0 {
@@ -165,7 +365,7 @@ func (_mock *OrderRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID,
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, ID, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -181,12 +381,12 @@ type OrderRepositoryMock_DeleteById_Call struct {
 // DeleteById is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ID uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) DeleteById(ctx interface{}, ID interface{}, trx interface{}) *OrderRepositoryMock_DeleteById_Call {
 	return &OrderRepositoryMock_DeleteById_Call{Call: _e.mock.On("DeleteById", ctx, ID, trx)}
 }
 
-func (_c *OrderRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB)) *OrderRepositoryMock_DeleteById_Call {
+func (_c *OrderRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *OrderRepositoryMock_DeleteById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -196,9 +396,9 @@ func (_c *OrderRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -214,13 +414,13 @@ func (_c *OrderRepositoryMock_DeleteById_Call) Return(err error) *OrderRepositor
 	return _c
 }
 
-func (_c *OrderRepositoryMock_DeleteById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB) error) *OrderRepositoryMock_DeleteById_Call {
+func (_c *OrderRepositoryMock_DeleteById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *OrderRepositoryMock_DeleteById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteByIds provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB) error {
+func (_mock *OrderRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UUID, trx *database.Tx) error {
 	ret := _mock.Called(ctx, IDs, trx)
 
 	if len(ret) == 0 {
@@ -228,7 +428,7 @@ func (_mock *OrderRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UU
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, IDs, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -244,12 +444,12 @@ type OrderRepositoryMock_DeleteByIds_Call struct {
 // DeleteByIds is a helper method to define mock.On call
 //   - ctx context.Context
 //   - IDs []uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) DeleteByIds(ctx interface{}, IDs interface{}, trx interface{}) *OrderRepositoryMock_DeleteByIds_Call {
 	return &OrderRepositoryMock_DeleteByIds_Call{Call: _e.mock.On("DeleteByIds", ctx, IDs, trx)}
 }
 
-func (_c *OrderRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB)) *OrderRepositoryMock_DeleteByIds_Call {
+func (_c *OrderRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, trx *database.Tx)) *OrderRepositoryMock_DeleteByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -259,9 +459,9 @@ func (_c *OrderRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context
 		if args[1] != nil {
 			arg1 = args[1].([]uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -277,13 +477,13 @@ func (_c *OrderRepositoryMock_DeleteByIds_Call) Return(err error) *OrderReposito
 	return _c
 }
 
-func (_c *OrderRepositoryMock_DeleteByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB) error) *OrderRepositoryMock_DeleteByIds_Call {
+func (_c *OrderRepositoryMock_DeleteByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, trx *database.Tx) error) *OrderRepositoryMock_DeleteByIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteMany provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) DeleteMany(ctx context.Context, filter map[string]any, trx *gorm.DB) error {
+func (_mock *OrderRepositoryMock) DeleteMany(ctx context.Context, filter map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, filter, trx)
 
 	if len(ret) == 0 {
@@ -291,7 +491,7 @@ func (_mock *OrderRepositoryMock) DeleteMany(ctx context.Context, filter map[str
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, filter, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -307,12 +507,12 @@ type OrderRepositoryMock_DeleteMany_Call struct {
 // DeleteMany is a helper method to define mock.On call
 //   - ctx context.Context
 //   - filter map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) DeleteMany(ctx interface{}, filter interface{}, trx interface{}) *OrderRepositoryMock_DeleteMany_Call {
 	return &OrderRepositoryMock_DeleteMany_Call{Call: _e.mock.On("DeleteMany", ctx, filter, trx)}
 }
 
-func (_c *OrderRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context, filter map[string]any, trx *gorm.DB)) *OrderRepositoryMock_DeleteMany_Call {
+func (_c *OrderRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context, filter map[string]any, trx *database.Tx)) *OrderRepositoryMock_DeleteMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -322,9 +522,9 @@ func (_c *OrderRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(map[string]any)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -340,7 +540,73 @@ func (_c *OrderRepositoryMock_DeleteMany_Call) Return(err error) *OrderRepositor
 	return _c
 }
 
-func (_c *OrderRepositoryMock_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, trx *gorm.DB) error) *OrderRepositoryMock_DeleteMany_Call {
+func (_c *OrderRepositoryMock_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, trx *database.Tx) error) *OrderRepositoryMock_DeleteMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) Exists(ctx context.Context, filter map[string]any) (bool, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) (bool, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) bool); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type OrderRepositoryMock_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *OrderRepositoryMock_Expecter) Exists(ctx interface{}, filter interface{}) *OrderRepositoryMock_Exists_Call {
+	return &OrderRepositoryMock_Exists_Call{Call: _e.mock.On("Exists", ctx, filter)}
+}
+
+func (_c *OrderRepositoryMock_Exists_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderRepositoryMock_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Exists_Call) Return(b bool, err error) *OrderRepositoryMock_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Exists_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) (bool, error)) *OrderRepositoryMock_Exists_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -566,7 +832,7 @@ func (_c *OrderRepositoryMock_FindById_Call) RunAndReturn(run func(ctx context.C
 }
 
 // FindByIdAndLock provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.UUID, trx *gorm.DB) (*order.Order, error) {
+func (_mock *OrderRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.UUID, trx *database.Tx) (*order.Order, error) {
 	ret := _mock.Called(ctx, ID, trx)
 
 	if len(ret) == 0 {
@@ -575,17 +841,17 @@ func (_mock *OrderRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.U
 
 	var r0 *order.Order
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) (*order.Order, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) (*order.Order, error)); ok {
 		return returnFunc(ctx, ID, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) *order.Order); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) *order.Order); ok {
 		r0 = returnFunc(ctx, ID, trx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*order.Order)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, ID, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -601,12 +867,12 @@ type OrderRepositoryMock_FindByIdAndLock_Call struct {
 // FindByIdAndLock is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ID uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) FindByIdAndLock(ctx interface{}, ID interface{}, trx interface{}) *OrderRepositoryMock_FindByIdAndLock_Call {
 	return &OrderRepositoryMock_FindByIdAndLock_Call{Call: _e.mock.On("FindByIdAndLock", ctx, ID, trx)}
 }
 
-func (_c *OrderRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB)) *OrderRepositoryMock_FindByIdAndLock_Call {
+func (_c *OrderRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *OrderRepositoryMock_FindByIdAndLock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -616,9 +882,9 @@ func (_c *OrderRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -634,7 +900,7 @@ func (_c *OrderRepositoryMock_FindByIdAndLock_Call) Return(order1 *order.Order,
 	return _c
 }
 
-func (_c *OrderRepositoryMock_FindByIdAndLock_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB) (*order.Order, error)) *OrderRepositoryMock_FindByIdAndLock_Call {
+func (_c *OrderRepositoryMock_FindByIdAndLock_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) (*order.Order, error)) *OrderRepositoryMock_FindByIdAndLock_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -791,58 +1057,60 @@ func (_c *OrderRepositoryMock_FindByOffset_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
-// Insert provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) Insert(ctx context.Context, model order.Order, trx *gorm.DB) (order.Order, error) {
-	ret := _mock.Called(ctx, model, trx)
+// FindByStatusAndCustomerID provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) FindByStatusAndCustomerID(ctx context.Context, status string, customerID uuid.UUID) ([]order.Order, error) {
+	ret := _mock.Called(ctx, status, customerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Insert")
+		panic("no return value specified for FindByStatusAndCustomerID")
 	}
 
-	var r0 order.Order
+	var r0 []order.Order
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, order.Order, *gorm.DB) (order.Order, error)); ok {
-		return returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) ([]order.Order, error)); ok {
+		return returnFunc(ctx, status, customerID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, order.Order, *gorm.DB) order.Order); ok {
-		r0 = returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) []order.Order); ok {
+		r0 = returnFunc(ctx, status, customerID)
 	} else {
-		r0 = ret.Get(0).(order.Order)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, order.Order, *gorm.DB) error); ok {
-		r1 = returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, status, customerID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// OrderRepositoryMock_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
-type OrderRepositoryMock_Insert_Call struct {
+// OrderRepositoryMock_FindByStatusAndCustomerID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByStatusAndCustomerID'
+type OrderRepositoryMock_FindByStatusAndCustomerID_Call struct {
 	*mock.Call
 }
 
-// Insert is a helper method to define mock.On call
+// FindByStatusAndCustomerID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - model order.Order
-//   - trx *gorm.DB
-func (_e *OrderRepositoryMock_Expecter) Insert(ctx interface{}, model interface{}, trx interface{}) *OrderRepositoryMock_Insert_Call {
-	return &OrderRepositoryMock_Insert_Call{Call: _e.mock.On("Insert", ctx, model, trx)}
+//   - status string
+//   - customerID uuid.UUID
+func (_e *OrderRepositoryMock_Expecter) FindByStatusAndCustomerID(ctx interface{}, status interface{}, customerID interface{}) *OrderRepositoryMock_FindByStatusAndCustomerID_Call {
+	return &OrderRepositoryMock_FindByStatusAndCustomerID_Call{Call: _e.mock.On("FindByStatusAndCustomerID", ctx, status, customerID)}
 }
 
-func (_c *OrderRepositoryMock_Insert_Call) Run(run func(ctx context.Context, model order.Order, trx *gorm.DB)) *OrderRepositoryMock_Insert_Call {
+func (_c *OrderRepositoryMock_FindByStatusAndCustomerID_Call) Run(run func(ctx context.Context, status string, customerID uuid.UUID)) *OrderRepositoryMock_FindByStatusAndCustomerID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 order.Order
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(order.Order)
+			arg1 = args[1].(string)
 		}
-		var arg2 *gorm.DB
+		var arg2 uuid.UUID
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(uuid.UUID)
 		}
 		run(
 			arg0,
@@ -853,151 +1121,770 @@ func (_c *OrderRepositoryMock_Insert_Call) Run(run func(ctx context.Context, mod
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Insert_Call) Return(order1 order.Order, err error) *OrderRepositoryMock_Insert_Call {
-	_c.Call.Return(order1, err)
+func (_c *OrderRepositoryMock_FindByStatusAndCustomerID_Call) Return(orders []order.Order, err error) *OrderRepositoryMock_FindByStatusAndCustomerID_Call {
+	_c.Call.Return(orders, err)
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Insert_Call) RunAndReturn(run func(ctx context.Context, model order.Order, trx *gorm.DB) (order.Order, error)) *OrderRepositoryMock_Insert_Call {
+func (_c *OrderRepositoryMock_FindByStatusAndCustomerID_Call) RunAndReturn(run func(ctx context.Context, status string, customerID uuid.UUID) ([]order.Order, error)) *OrderRepositoryMock_FindByStatusAndCustomerID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertMany provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) InsertMany(ctx context.Context, models []order.Order, trx *gorm.DB) ([]order.Order, error) {
-	ret := _mock.Called(ctx, models, trx)
+// FindOnlyTrashed provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) FindOnlyTrashed(ctx context.Context, filter map[string]any) ([]order.Order, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertMany")
+		panic("no return value specified for FindOnlyTrashed")
 	}
 
 	var r0 []order.Order
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.Order, *gorm.DB) ([]order.Order, error)); ok {
-		return returnFunc(ctx, models, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]order.Order, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.Order, *gorm.DB) []order.Order); ok {
-		r0 = returnFunc(ctx, models, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []order.Order); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]order.Order)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []order.Order, *gorm.DB) error); ok {
-		r1 = returnFunc(ctx, models, trx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// OrderRepositoryMock_InsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMany'
-type OrderRepositoryMock_InsertMany_Call struct {
+// OrderRepositoryMock_FindOnlyTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOnlyTrashed'
+type OrderRepositoryMock_FindOnlyTrashed_Call struct {
 	*mock.Call
 }
 
-// InsertMany is a helper method to define mock.On call
+// FindOnlyTrashed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - models []order.Order
-//   - trx *gorm.DB
-func (_e *OrderRepositoryMock_Expecter) InsertMany(ctx interface{}, models interface{}, trx interface{}) *OrderRepositoryMock_InsertMany_Call {
-	return &OrderRepositoryMock_InsertMany_Call{Call: _e.mock.On("InsertMany", ctx, models, trx)}
+//   - filter map[string]any
+func (_e *OrderRepositoryMock_Expecter) FindOnlyTrashed(ctx interface{}, filter interface{}) *OrderRepositoryMock_FindOnlyTrashed_Call {
+	return &OrderRepositoryMock_FindOnlyTrashed_Call{Call: _e.mock.On("FindOnlyTrashed", ctx, filter)}
 }
 
-func (_c *OrderRepositoryMock_InsertMany_Call) Run(run func(ctx context.Context, models []order.Order, trx *gorm.DB)) *OrderRepositoryMock_InsertMany_Call {
+func (_c *OrderRepositoryMock_FindOnlyTrashed_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderRepositoryMock_FindOnlyTrashed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []order.Order
+		var arg1 map[string]any
 		if args[1] != nil {
-			arg1 = args[1].([]order.Order)
-		}
-		var arg2 *gorm.DB
-		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg1 = args[1].(map[string]any)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *OrderRepositoryMock_InsertMany_Call) Return(orders []order.Order, err error) *OrderRepositoryMock_InsertMany_Call {
+func (_c *OrderRepositoryMock_FindOnlyTrashed_Call) Return(orders []order.Order, err error) *OrderRepositoryMock_FindOnlyTrashed_Call {
 	_c.Call.Return(orders, err)
 	return _c
 }
 
-func (_c *OrderRepositoryMock_InsertMany_Call) RunAndReturn(run func(ctx context.Context, models []order.Order, trx *gorm.DB) ([]order.Order, error)) *OrderRepositoryMock_InsertMany_Call {
+func (_c *OrderRepositoryMock_FindOnlyTrashed_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]order.Order, error)) *OrderRepositoryMock_FindOnlyTrashed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MasterDB provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) MasterDB() *gorm.DB {
-	ret := _mock.Called()
+// FindProjected provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) FindProjected(ctx context.Context, filter map[string]any, fields []string, dest any) error {
+	ret := _mock.Called(ctx, filter, fields, dest)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MasterDB")
+		panic("no return value specified for FindProjected")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = returnFunc()
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, []string, any) error); ok {
+		r0 = returnFunc(ctx, filter, fields, dest)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// OrderRepositoryMock_MasterDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MasterDB'
-type OrderRepositoryMock_MasterDB_Call struct {
+// OrderRepositoryMock_FindProjected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProjected'
+type OrderRepositoryMock_FindProjected_Call struct {
 	*mock.Call
 }
 
-// MasterDB is a helper method to define mock.On call
-func (_e *OrderRepositoryMock_Expecter) MasterDB() *OrderRepositoryMock_MasterDB_Call {
-	return &OrderRepositoryMock_MasterDB_Call{Call: _e.mock.On("MasterDB")}
+// FindProjected is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+//   - fields []string
+//   - dest any
+func (_e *OrderRepositoryMock_Expecter) FindProjected(ctx interface{}, filter interface{}, fields interface{}, dest interface{}) *OrderRepositoryMock_FindProjected_Call {
+	return &OrderRepositoryMock_FindProjected_Call{Call: _e.mock.On("FindProjected", ctx, filter, fields, dest)}
 }
 
-func (_c *OrderRepositoryMock_MasterDB_Call) Run(run func()) *OrderRepositoryMock_MasterDB_Call {
+func (_c *OrderRepositoryMock_FindProjected_Call) Run(run func(ctx context.Context, filter map[string]any, fields []string, dest any)) *OrderRepositoryMock_FindProjected_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
-	})
-	return _c
-}
-
-func (_c *OrderRepositoryMock_MasterDB_Call) Return(dB *gorm.DB) *OrderRepositoryMock_MasterDB_Call {
-	_c.Call.Return(dB)
-	return _c
-}
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 any
+		if args[3] != nil {
+			arg3 = args[3].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_FindProjected_Call) Return(err error) *OrderRepositoryMock_FindProjected_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_FindProjected_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, fields []string, dest any) error) *OrderRepositoryMock_FindProjected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWithTrashed provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) FindWithTrashed(ctx context.Context, filter map[string]any) ([]order.Order, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWithTrashed")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]order.Order, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []order.Order); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_FindWithTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWithTrashed'
+type OrderRepositoryMock_FindWithTrashed_Call struct {
+	*mock.Call
+}
+
+// FindWithTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *OrderRepositoryMock_Expecter) FindWithTrashed(ctx interface{}, filter interface{}) *OrderRepositoryMock_FindWithTrashed_Call {
+	return &OrderRepositoryMock_FindWithTrashed_Call{Call: _e.mock.On("FindWithTrashed", ctx, filter)}
+}
+
+func (_c *OrderRepositoryMock_FindWithTrashed_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_FindWithTrashed_Call) Return(orders []order.Order, err error) *OrderRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Return(orders, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_FindWithTrashed_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]order.Order, error)) *OrderRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForceDelete provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) ForceDelete(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
+	ret := _mock.Called(ctx, ID, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
+		r0 = returnFunc(ctx, ID, trx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// OrderRepositoryMock_ForceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceDelete'
+type OrderRepositoryMock_ForceDelete_Call struct {
+	*mock.Call
+}
+
+// ForceDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - trx *database.Tx
+func (_e *OrderRepositoryMock_Expecter) ForceDelete(ctx interface{}, ID interface{}, trx interface{}) *OrderRepositoryMock_ForceDelete_Call {
+	return &OrderRepositoryMock_ForceDelete_Call{Call: _e.mock.On("ForceDelete", ctx, ID, trx)}
+}
+
+func (_c *OrderRepositoryMock_ForceDelete_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *OrderRepositoryMock_ForceDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_ForceDelete_Call) Return(err error) *OrderRepositoryMock_ForceDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_ForceDelete_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *OrderRepositoryMock_ForceDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) History(ctx context.Context, ID uuid.UUID) ([]database.AuditLog, error) {
+	ret := _mock.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []database.AuditLog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]database.AuditLog, error)); ok {
+		return returnFunc(ctx, ID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []database.AuditLog); ok {
+		r0 = returnFunc(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.AuditLog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type OrderRepositoryMock_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *OrderRepositoryMock_Expecter) History(ctx interface{}, ID interface{}) *OrderRepositoryMock_History_Call {
+	return &OrderRepositoryMock_History_Call{Call: _e.mock.On("History", ctx, ID)}
+}
+
+func (_c *OrderRepositoryMock_History_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *OrderRepositoryMock_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_History_Call) Return(auditLogs []database.AuditLog, err error) *OrderRepositoryMock_History_Call {
+	_c.Call.Return(auditLogs, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_History_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID) ([]database.AuditLog, error)) *OrderRepositoryMock_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) Insert(ctx context.Context, model order.Order, trx *database.Tx) (order.Order, error) {
+	ret := _mock.Called(ctx, model, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 order.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.Order, *database.Tx) (order.Order, error)); ok {
+		return returnFunc(ctx, model, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.Order, *database.Tx) order.Order); ok {
+		r0 = returnFunc(ctx, model, trx)
+	} else {
+		r0 = ret.Get(0).(order.Order)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, order.Order, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, model, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type OrderRepositoryMock_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - model order.Order
+//   - trx *database.Tx
+func (_e *OrderRepositoryMock_Expecter) Insert(ctx interface{}, model interface{}, trx interface{}) *OrderRepositoryMock_Insert_Call {
+	return &OrderRepositoryMock_Insert_Call{Call: _e.mock.On("Insert", ctx, model, trx)}
+}
+
+func (_c *OrderRepositoryMock_Insert_Call) Run(run func(ctx context.Context, model order.Order, trx *database.Tx)) *OrderRepositoryMock_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 order.Order
+		if args[1] != nil {
+			arg1 = args[1].(order.Order)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Insert_Call) Return(order1 order.Order, err error) *OrderRepositoryMock_Insert_Call {
+	_c.Call.Return(order1, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Insert_Call) RunAndReturn(run func(ctx context.Context, model order.Order, trx *database.Tx) (order.Order, error)) *OrderRepositoryMock_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertMany provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) InsertMany(ctx context.Context, models []order.Order, trx *database.Tx) ([]order.Order, error) {
+	ret := _mock.Called(ctx, models, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertMany")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.Order, *database.Tx) ([]order.Order, error)); ok {
+		return returnFunc(ctx, models, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.Order, *database.Tx) []order.Order); ok {
+		r0 = returnFunc(ctx, models, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []order.Order, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, models, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_InsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMany'
+type OrderRepositoryMock_InsertMany_Call struct {
+	*mock.Call
+}
+
+// InsertMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - models []order.Order
+//   - trx *database.Tx
+func (_e *OrderRepositoryMock_Expecter) InsertMany(ctx interface{}, models interface{}, trx interface{}) *OrderRepositoryMock_InsertMany_Call {
+	return &OrderRepositoryMock_InsertMany_Call{Call: _e.mock.On("InsertMany", ctx, models, trx)}
+}
+
+func (_c *OrderRepositoryMock_InsertMany_Call) Run(run func(ctx context.Context, models []order.Order, trx *database.Tx)) *OrderRepositoryMock_InsertMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []order.Order
+		if args[1] != nil {
+			arg1 = args[1].([]order.Order)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_InsertMany_Call) Return(orders []order.Order, err error) *OrderRepositoryMock_InsertMany_Call {
+	_c.Call.Return(orders, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_InsertMany_Call) RunAndReturn(run func(ctx context.Context, models []order.Order, trx *database.Tx) ([]order.Order, error)) *OrderRepositoryMock_InsertMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Preload provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) Preload(relations ...string) database.BaseRepository[uuid.UUID, order.Order] {
+	var tmpRet mock.Arguments
+	if len(relations) > 0 {
+		tmpRet = _mock.Called(relations)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Preload")
+	}
+
+	var r0 database.BaseRepository[uuid.UUID, order.Order]
+	if returnFunc, ok := ret.Get(0).(func(...string) database.BaseRepository[uuid.UUID, order.Order]); ok {
+		r0 = returnFunc(relations...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(database.BaseRepository[uuid.UUID, order.Order])
+		}
+	}
+	return r0
+}
+
+// OrderRepositoryMock_Preload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Preload'
+type OrderRepositoryMock_Preload_Call struct {
+	*mock.Call
+}
+
+// Preload is a helper method to define mock.On call
+//   - relations ...string
+func (_e *OrderRepositoryMock_Expecter) Preload(relations ...interface{}) *OrderRepositoryMock_Preload_Call {
+	return &OrderRepositoryMock_Preload_Call{Call: _e.mock.On("Preload",
+		append([]interface{}{}, relations...)...)}
+}
+
+func (_c *OrderRepositoryMock_Preload_Call) Run(run func(relations ...string)) *OrderRepositoryMock_Preload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		var variadicArgs []string
+		if len(args) > 0 {
+			variadicArgs = args[0].([]string)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Preload_Call) Return(baseRepository database.BaseRepository[uuid.UUID, order.Order]) *OrderRepositoryMock_Preload_Call {
+	_c.Call.Return(baseRepository)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Preload_Call) RunAndReturn(run func(relations ...string) database.BaseRepository[uuid.UUID, order.Order]) *OrderRepositoryMock_Preload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrashed provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrashed")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
 
-func (_c *OrderRepositoryMock_MasterDB_Call) RunAndReturn(run func() *gorm.DB) *OrderRepositoryMock_MasterDB_Call {
+// OrderRepositoryMock_PurgeTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrashed'
+type OrderRepositoryMock_PurgeTrashed_Call struct {
+	*mock.Call
+}
+
+// PurgeTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *OrderRepositoryMock_Expecter) PurgeTrashed(ctx interface{}, before interface{}) *OrderRepositoryMock_PurgeTrashed_Call {
+	return &OrderRepositoryMock_PurgeTrashed_Call{Call: _e.mock.On("PurgeTrashed", ctx, before)}
+}
+
+func (_c *OrderRepositoryMock_PurgeTrashed_Call) Run(run func(ctx context.Context, before time.Time)) *OrderRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_PurgeTrashed_Call) Return(n int64, err error) *OrderRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_PurgeTrashed_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *OrderRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) Restore(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
+	ret := _mock.Called(ctx, ID, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
+		r0 = returnFunc(ctx, ID, trx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// OrderRepositoryMock_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type OrderRepositoryMock_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - trx *database.Tx
+func (_e *OrderRepositoryMock_Expecter) Restore(ctx interface{}, ID interface{}, trx interface{}) *OrderRepositoryMock_Restore_Call {
+	return &OrderRepositoryMock_Restore_Call{Call: _e.mock.On("Restore", ctx, ID, trx)}
+}
+
+func (_c *OrderRepositoryMock_Restore_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *OrderRepositoryMock_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Restore_Call) Return(err error) *OrderRepositoryMock_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Restore_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *OrderRepositoryMock_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevenuePerCustomer provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) RevenuePerCustomer(ctx context.Context, filter map[string]any) ([]order.CustomerRevenue, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevenuePerCustomer")
+	}
+
+	var r0 []order.CustomerRevenue
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]order.CustomerRevenue, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []order.CustomerRevenue); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.CustomerRevenue)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_RevenuePerCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevenuePerCustomer'
+type OrderRepositoryMock_RevenuePerCustomer_Call struct {
+	*mock.Call
+}
+
+// RevenuePerCustomer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *OrderRepositoryMock_Expecter) RevenuePerCustomer(ctx interface{}, filter interface{}) *OrderRepositoryMock_RevenuePerCustomer_Call {
+	return &OrderRepositoryMock_RevenuePerCustomer_Call{Call: _e.mock.On("RevenuePerCustomer", ctx, filter)}
+}
+
+func (_c *OrderRepositoryMock_RevenuePerCustomer_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderRepositoryMock_RevenuePerCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_RevenuePerCustomer_Call) Return(customerRevenues []order.CustomerRevenue, err error) *OrderRepositoryMock_RevenuePerCustomer_Call {
+	_c.Call.Return(customerRevenues, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_RevenuePerCustomer_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]order.CustomerRevenue, error)) *OrderRepositoryMock_RevenuePerCustomer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Rollback provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) Rollback(trx *gorm.DB) *gorm.DB {
+func (_mock *OrderRepositoryMock) Rollback(trx *database.Tx) error {
 	ret := _mock.Called(trx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Rollback")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) *gorm.DB); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.Tx) error); ok {
 		r0 = returnFunc(trx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
@@ -1008,16 +1895,16 @@ type OrderRepositoryMock_Rollback_Call struct {
 }
 
 // Rollback is a helper method to define mock.On call
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) Rollback(trx interface{}) *OrderRepositoryMock_Rollback_Call {
 	return &OrderRepositoryMock_Rollback_Call{Call: _e.mock.On("Rollback", trx)}
 }
 
-func (_c *OrderRepositoryMock_Rollback_Call) Run(run func(trx *gorm.DB)) *OrderRepositoryMock_Rollback_Call {
+func (_c *OrderRepositoryMock_Rollback_Call) Run(run func(trx *database.Tx)) *OrderRepositoryMock_Rollback_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 *database.Tx
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1026,64 +1913,83 @@ func (_c *OrderRepositoryMock_Rollback_Call) Run(run func(trx *gorm.DB)) *OrderR
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Rollback_Call) Return(dB *gorm.DB) *OrderRepositoryMock_Rollback_Call {
-	_c.Call.Return(dB)
+func (_c *OrderRepositoryMock_Rollback_Call) Return(err error) *OrderRepositoryMock_Rollback_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Rollback_Call) RunAndReturn(run func(trx *gorm.DB) *gorm.DB) *OrderRepositoryMock_Rollback_Call {
+func (_c *OrderRepositoryMock_Rollback_Call) RunAndReturn(run func(trx *database.Tx) error) *OrderRepositoryMock_Rollback_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SlaveDB provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) SlaveDB() *gorm.DB {
-	ret := _mock.Called()
+// Stream provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) Stream(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[order.Order, error] {
+	ret := _mock.Called(ctx, filter, batchSize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SlaveDB")
+		panic("no return value specified for Stream")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = returnFunc()
+	var r0 iter.Seq2[order.Order, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, int) iter.Seq2[order.Order, error]); ok {
+		r0 = returnFunc(ctx, filter, batchSize)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(iter.Seq2[order.Order, error])
 		}
 	}
 	return r0
 }
 
-// OrderRepositoryMock_SlaveDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SlaveDB'
-type OrderRepositoryMock_SlaveDB_Call struct {
+// OrderRepositoryMock_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type OrderRepositoryMock_Stream_Call struct {
 	*mock.Call
 }
 
-// SlaveDB is a helper method to define mock.On call
-func (_e *OrderRepositoryMock_Expecter) SlaveDB() *OrderRepositoryMock_SlaveDB_Call {
-	return &OrderRepositoryMock_SlaveDB_Call{Call: _e.mock.On("SlaveDB")}
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+//   - batchSize int
+func (_e *OrderRepositoryMock_Expecter) Stream(ctx interface{}, filter interface{}, batchSize interface{}) *OrderRepositoryMock_Stream_Call {
+	return &OrderRepositoryMock_Stream_Call{Call: _e.mock.On("Stream", ctx, filter, batchSize)}
 }
 
-func (_c *OrderRepositoryMock_SlaveDB_Call) Run(run func()) *OrderRepositoryMock_SlaveDB_Call {
+func (_c *OrderRepositoryMock_Stream_Call) Run(run func(ctx context.Context, filter map[string]any, batchSize int)) *OrderRepositoryMock_Stream_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *OrderRepositoryMock_SlaveDB_Call) Return(dB *gorm.DB) *OrderRepositoryMock_SlaveDB_Call {
-	_c.Call.Return(dB)
+func (_c *OrderRepositoryMock_Stream_Call) Return(seq2 iter.Seq2[order.Order, error]) *OrderRepositoryMock_Stream_Call {
+	_c.Call.Return(seq2)
 	return _c
 }
 
-func (_c *OrderRepositoryMock_SlaveDB_Call) RunAndReturn(run func() *gorm.DB) *OrderRepositoryMock_SlaveDB_Call {
+func (_c *OrderRepositoryMock_Stream_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[order.Order, error]) *OrderRepositoryMock_Stream_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) Update(ctx context.Context, model order.Order, trx *gorm.DB) error {
+func (_mock *OrderRepositoryMock) Update(ctx context.Context, model order.Order, trx *database.Tx) error {
 	ret := _mock.Called(ctx, model, trx)
 
 	if len(ret) == 0 {
@@ -1091,7 +1997,7 @@ func (_mock *OrderRepositoryMock) Update(ctx context.Context, model order.Order,
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, order.Order, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.Order, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, model, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1107,12 +2013,12 @@ type OrderRepositoryMock_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
 //   - model order.Order
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) Update(ctx interface{}, model interface{}, trx interface{}) *OrderRepositoryMock_Update_Call {
 	return &OrderRepositoryMock_Update_Call{Call: _e.mock.On("Update", ctx, model, trx)}
 }
 
-func (_c *OrderRepositoryMock_Update_Call) Run(run func(ctx context.Context, model order.Order, trx *gorm.DB)) *OrderRepositoryMock_Update_Call {
+func (_c *OrderRepositoryMock_Update_Call) Run(run func(ctx context.Context, model order.Order, trx *database.Tx)) *OrderRepositoryMock_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1122,9 +2028,9 @@ func (_c *OrderRepositoryMock_Update_Call) Run(run func(ctx context.Context, mod
 		if args[1] != nil {
 			arg1 = args[1].(order.Order)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1140,13 +2046,13 @@ func (_c *OrderRepositoryMock_Update_Call) Return(err error) *OrderRepositoryMoc
 	return _c
 }
 
-func (_c *OrderRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, model order.Order, trx *gorm.DB) error) *OrderRepositoryMock_Update_Call {
+func (_c *OrderRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, model order.Order, trx *database.Tx) error) *OrderRepositoryMock_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateById provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB) (order.Order, error) {
+func (_mock *OrderRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx) (order.Order, error) {
 	ret := _mock.Called(ctx, ID, payload, trx)
 
 	if len(ret) == 0 {
@@ -1155,15 +2061,15 @@ func (_mock *OrderRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID,
 
 	var r0 order.Order
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) (order.Order, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) (order.Order, error)); ok {
 		return returnFunc(ctx, ID, payload, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) order.Order); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) order.Order); ok {
 		r0 = returnFunc(ctx, ID, payload, trx)
 	} else {
 		r0 = ret.Get(0).(order.Order)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, ID, payload, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -1180,12 +2086,12 @@ type OrderRepositoryMock_UpdateById_Call struct {
 //   - ctx context.Context
 //   - ID uuid.UUID
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) UpdateById(ctx interface{}, ID interface{}, payload interface{}, trx interface{}) *OrderRepositoryMock_UpdateById_Call {
 	return &OrderRepositoryMock_UpdateById_Call{Call: _e.mock.On("UpdateById", ctx, ID, payload, trx)}
 }
 
-func (_c *OrderRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB)) *OrderRepositoryMock_UpdateById_Call {
+func (_c *OrderRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx)) *OrderRepositoryMock_UpdateById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1199,9 +2105,9 @@ func (_c *OrderRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context,
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1218,13 +2124,13 @@ func (_c *OrderRepositoryMock_UpdateById_Call) Return(order1 order.Order, err er
 	return _c
 }
 
-func (_c *OrderRepositoryMock_UpdateById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB) (order.Order, error)) *OrderRepositoryMock_UpdateById_Call {
+func (_c *OrderRepositoryMock_UpdateById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx) (order.Order, error)) *OrderRepositoryMock_UpdateById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateByIds provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB) error {
+func (_mock *OrderRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, IDs, payload, trx)
 
 	if len(ret) == 0 {
@@ -1232,7 +2138,7 @@ func (_mock *OrderRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UU
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, IDs, payload, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1249,12 +2155,12 @@ type OrderRepositoryMock_UpdateByIds_Call struct {
 //   - ctx context.Context
 //   - IDs []uuid.UUID
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) UpdateByIds(ctx interface{}, IDs interface{}, payload interface{}, trx interface{}) *OrderRepositoryMock_UpdateByIds_Call {
 	return &OrderRepositoryMock_UpdateByIds_Call{Call: _e.mock.On("UpdateByIds", ctx, IDs, payload, trx)}
 }
 
-func (_c *OrderRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB)) *OrderRepositoryMock_UpdateByIds_Call {
+func (_c *OrderRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx)) *OrderRepositoryMock_UpdateByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1268,9 +2174,9 @@ func (_c *OrderRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1287,13 +2193,13 @@ func (_c *OrderRepositoryMock_UpdateByIds_Call) Return(err error) *OrderReposito
 	return _c
 }
 
-func (_c *OrderRepositoryMock_UpdateByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB) error) *OrderRepositoryMock_UpdateByIds_Call {
+func (_c *OrderRepositoryMock_UpdateByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx) error) *OrderRepositoryMock_UpdateByIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateMany provides a mock function for the type OrderRepositoryMock
-func (_mock *OrderRepositoryMock) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB) error {
+func (_mock *OrderRepositoryMock) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, filter, payload, trx)
 
 	if len(ret) == 0 {
@@ -1301,7 +2207,7 @@ func (_mock *OrderRepositoryMock) UpdateMany(ctx context.Context, filter map[str
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, filter, payload, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1318,12 +2224,12 @@ type OrderRepositoryMock_UpdateMany_Call struct {
 //   - ctx context.Context
 //   - filter map[string]any
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderRepositoryMock_Expecter) UpdateMany(ctx interface{}, filter interface{}, payload interface{}, trx interface{}) *OrderRepositoryMock_UpdateMany_Call {
 	return &OrderRepositoryMock_UpdateMany_Call{Call: _e.mock.On("UpdateMany", ctx, filter, payload, trx)}
 }
 
-func (_c *OrderRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB)) *OrderRepositoryMock_UpdateMany_Call {
+func (_c *OrderRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx)) *OrderRepositoryMock_UpdateMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1337,9 +2243,9 @@ func (_c *OrderRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context,
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1356,7 +2262,165 @@ func (_c *OrderRepositoryMock_UpdateMany_Call) Return(err error) *OrderRepositor
 	return _c
 }
 
-func (_c *OrderRepositoryMock_UpdateMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB) error) *OrderRepositoryMock_UpdateMany_Call {
+func (_c *OrderRepositoryMock_UpdateMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) error) *OrderRepositoryMock_UpdateMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) Upsert(ctx context.Context, model order.Order, opts database.UpsertOptions, trx *database.Tx) (order.Order, error) {
+	ret := _mock.Called(ctx, model, opts, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 order.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.Order, database.UpsertOptions, *database.Tx) (order.Order, error)); ok {
+		return returnFunc(ctx, model, opts, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.Order, database.UpsertOptions, *database.Tx) order.Order); ok {
+		r0 = returnFunc(ctx, model, opts, trx)
+	} else {
+		r0 = ret.Get(0).(order.Order)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, order.Order, database.UpsertOptions, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, model, opts, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type OrderRepositoryMock_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - model order.Order
+//   - opts database.UpsertOptions
+//   - trx *database.Tx
+func (_e *OrderRepositoryMock_Expecter) Upsert(ctx interface{}, model interface{}, opts interface{}, trx interface{}) *OrderRepositoryMock_Upsert_Call {
+	return &OrderRepositoryMock_Upsert_Call{Call: _e.mock.On("Upsert", ctx, model, opts, trx)}
+}
+
+func (_c *OrderRepositoryMock_Upsert_Call) Run(run func(ctx context.Context, model order.Order, opts database.UpsertOptions, trx *database.Tx)) *OrderRepositoryMock_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 order.Order
+		if args[1] != nil {
+			arg1 = args[1].(order.Order)
+		}
+		var arg2 database.UpsertOptions
+		if args[2] != nil {
+			arg2 = args[2].(database.UpsertOptions)
+		}
+		var arg3 *database.Tx
+		if args[3] != nil {
+			arg3 = args[3].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Upsert_Call) Return(order1 order.Order, err error) *OrderRepositoryMock_Upsert_Call {
+	_c.Call.Return(order1, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_Upsert_Call) RunAndReturn(run func(ctx context.Context, model order.Order, opts database.UpsertOptions, trx *database.Tx) (order.Order, error)) *OrderRepositoryMock_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertMany provides a mock function for the type OrderRepositoryMock
+func (_mock *OrderRepositoryMock) UpsertMany(ctx context.Context, models []order.Order, opts database.UpsertOptions, trx *database.Tx) ([]order.Order, error) {
+	ret := _mock.Called(ctx, models, opts, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertMany")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.Order, database.UpsertOptions, *database.Tx) ([]order.Order, error)); ok {
+		return returnFunc(ctx, models, opts, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.Order, database.UpsertOptions, *database.Tx) []order.Order); ok {
+		r0 = returnFunc(ctx, models, opts, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []order.Order, database.UpsertOptions, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, models, opts, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepositoryMock_UpsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertMany'
+type OrderRepositoryMock_UpsertMany_Call struct {
+	*mock.Call
+}
+
+// UpsertMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - models []order.Order
+//   - opts database.UpsertOptions
+//   - trx *database.Tx
+func (_e *OrderRepositoryMock_Expecter) UpsertMany(ctx interface{}, models interface{}, opts interface{}, trx interface{}) *OrderRepositoryMock_UpsertMany_Call {
+	return &OrderRepositoryMock_UpsertMany_Call{Call: _e.mock.On("UpsertMany", ctx, models, opts, trx)}
+}
+
+func (_c *OrderRepositoryMock_UpsertMany_Call) Run(run func(ctx context.Context, models []order.Order, opts database.UpsertOptions, trx *database.Tx)) *OrderRepositoryMock_UpsertMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []order.Order
+		if args[1] != nil {
+			arg1 = args[1].([]order.Order)
+		}
+		var arg2 database.UpsertOptions
+		if args[2] != nil {
+			arg2 = args[2].(database.UpsertOptions)
+		}
+		var arg3 *database.Tx
+		if args[3] != nil {
+			arg3 = args[3].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepositoryMock_UpsertMany_Call) Return(orders []order.Order, err error) *OrderRepositoryMock_UpsertMany_Call {
+	_c.Call.Return(orders, err)
+	return _c
+}
+
+func (_c *OrderRepositoryMock_UpsertMany_Call) RunAndReturn(run func(ctx context.Context, models []order.Order, opts database.UpsertOptions, trx *database.Tx) ([]order.Order, error)) *OrderRepositoryMock_UpsertMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
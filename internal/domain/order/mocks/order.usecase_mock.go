@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/goodone-dev/go-boilerplate/internal/domain/order"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -105,3 +106,71 @@ func (_c *OrderUsecaseMock_Create_Call) RunAndReturn(run func(ctx context.Contex
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetById provides a mock function for the type OrderUsecaseMock
+func (_mock *OrderUsecaseMock) GetById(ctx context.Context, ID uuid.UUID) (*order.GetOrderResponse, error) {
+	ret := _mock.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 *order.GetOrderResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*order.GetOrderResponse, error)); ok {
+		return returnFunc(ctx, ID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *order.GetOrderResponse); ok {
+		r0 = returnFunc(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.GetOrderResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderUsecaseMock_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type OrderUsecaseMock_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *OrderUsecaseMock_Expecter) GetById(ctx interface{}, ID interface{}) *OrderUsecaseMock_GetById_Call {
+	return &OrderUsecaseMock_GetById_Call{Call: _e.mock.On("GetById", ctx, ID)}
+}
+
+func (_c *OrderUsecaseMock_GetById_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *OrderUsecaseMock_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderUsecaseMock_GetById_Call) Return(getOrderResponse *order.GetOrderResponse, err error) *OrderUsecaseMock_GetById_Call {
+	_c.Call.Return(getOrderResponse, err)
+	return _c
+}
+
+func (_c *OrderUsecaseMock_GetById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID) (*order.GetOrderResponse, error)) *OrderUsecaseMock_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
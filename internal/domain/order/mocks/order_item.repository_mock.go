@@ -6,12 +6,13 @@ package order
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/goodone-dev/go-boilerplate/internal/domain/order"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 )
 
 // NewOrderItemRepositoryMock creates a new instance of OrderItemRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -41,24 +42,87 @@ func (_m *OrderItemRepositoryMock) EXPECT() *OrderItemRepositoryMock_Expecter {
 	return &OrderItemRepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// Aggregate provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) Aggregate(ctx context.Context, query database.AggregateQuery, dest any) error {
+	ret := _mock.Called(ctx, query, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, database.AggregateQuery, any) error); ok {
+		r0 = returnFunc(ctx, query, dest)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// OrderItemRepositoryMock_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type OrderItemRepositoryMock_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query database.AggregateQuery
+//   - dest any
+func (_e *OrderItemRepositoryMock_Expecter) Aggregate(ctx interface{}, query interface{}, dest interface{}) *OrderItemRepositoryMock_Aggregate_Call {
+	return &OrderItemRepositoryMock_Aggregate_Call{Call: _e.mock.On("Aggregate", ctx, query, dest)}
+}
+
+func (_c *OrderItemRepositoryMock_Aggregate_Call) Run(run func(ctx context.Context, query database.AggregateQuery, dest any)) *OrderItemRepositoryMock_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 database.AggregateQuery
+		if args[1] != nil {
+			arg1 = args[1].(database.AggregateQuery)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Aggregate_Call) Return(err error) *OrderItemRepositoryMock_Aggregate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Aggregate_Call) RunAndReturn(run func(ctx context.Context, query database.AggregateQuery, dest any) error) *OrderItemRepositoryMock_Aggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Begin provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) Begin(ctx context.Context) (*gorm.DB, error) {
+func (_mock *OrderItemRepositoryMock) Begin(ctx context.Context) (*database.Tx, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Begin")
 	}
 
-	var r0 *gorm.DB
+	var r0 *database.Tx
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*gorm.DB, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*database.Tx, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *database.Tx); ok {
 		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(*database.Tx)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -93,31 +157,101 @@ func (_c *OrderItemRepositoryMock_Begin_Call) Run(run func(ctx context.Context))
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Begin_Call) Return(dB *gorm.DB, err error) *OrderItemRepositoryMock_Begin_Call {
-	_c.Call.Return(dB, err)
+func (_c *OrderItemRepositoryMock_Begin_Call) Return(tx *database.Tx, err error) *OrderItemRepositoryMock_Begin_Call {
+	_c.Call.Return(tx, err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Begin_Call) RunAndReturn(run func(ctx context.Context) (*database.Tx, error)) *OrderItemRepositoryMock_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkWrite provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) BulkWrite(ctx context.Context, ops []database.WriteOperation[order.OrderItem], trx *database.Tx) (database.BulkWriteResult, error) {
+	ret := _mock.Called(ctx, ops, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkWrite")
+	}
+
+	var r0 database.BulkWriteResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []database.WriteOperation[order.OrderItem], *database.Tx) (database.BulkWriteResult, error)); ok {
+		return returnFunc(ctx, ops, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []database.WriteOperation[order.OrderItem], *database.Tx) database.BulkWriteResult); ok {
+		r0 = returnFunc(ctx, ops, trx)
+	} else {
+		r0 = ret.Get(0).(database.BulkWriteResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []database.WriteOperation[order.OrderItem], *database.Tx) error); ok {
+		r1 = returnFunc(ctx, ops, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_BulkWrite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkWrite'
+type OrderItemRepositoryMock_BulkWrite_Call struct {
+	*mock.Call
+}
+
+// BulkWrite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ops []database.WriteOperation[order.OrderItem]
+//   - trx *database.Tx
+func (_e *OrderItemRepositoryMock_Expecter) BulkWrite(ctx interface{}, ops interface{}, trx interface{}) *OrderItemRepositoryMock_BulkWrite_Call {
+	return &OrderItemRepositoryMock_BulkWrite_Call{Call: _e.mock.On("BulkWrite", ctx, ops, trx)}
+}
+
+func (_c *OrderItemRepositoryMock_BulkWrite_Call) Run(run func(ctx context.Context, ops []database.WriteOperation[order.OrderItem], trx *database.Tx)) *OrderItemRepositoryMock_BulkWrite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []database.WriteOperation[order.OrderItem]
+		if args[1] != nil {
+			arg1 = args[1].([]database.WriteOperation[order.OrderItem])
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_BulkWrite_Call) Return(bulkWriteResult database.BulkWriteResult, err error) *OrderItemRepositoryMock_BulkWrite_Call {
+	_c.Call.Return(bulkWriteResult, err)
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Begin_Call) RunAndReturn(run func(ctx context.Context) (*gorm.DB, error)) *OrderItemRepositoryMock_Begin_Call {
+func (_c *OrderItemRepositoryMock_BulkWrite_Call) RunAndReturn(run func(ctx context.Context, ops []database.WriteOperation[order.OrderItem], trx *database.Tx) (database.BulkWriteResult, error)) *OrderItemRepositoryMock_BulkWrite_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Commit provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) Commit(trx *gorm.DB) *gorm.DB {
+func (_mock *OrderItemRepositoryMock) Commit(trx *database.Tx) error {
 	ret := _mock.Called(trx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Commit")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) *gorm.DB); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.Tx) error); ok {
 		r0 = returnFunc(trx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
@@ -128,36 +262,102 @@ type OrderItemRepositoryMock_Commit_Call struct {
 }
 
 // Commit is a helper method to define mock.On call
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) Commit(trx interface{}) *OrderItemRepositoryMock_Commit_Call {
 	return &OrderItemRepositoryMock_Commit_Call{Call: _e.mock.On("Commit", trx)}
 }
 
-func (_c *OrderItemRepositoryMock_Commit_Call) Run(run func(trx *gorm.DB)) *OrderItemRepositoryMock_Commit_Call {
+func (_c *OrderItemRepositoryMock_Commit_Call) Run(run func(trx *database.Tx)) *OrderItemRepositoryMock_Commit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *database.Tx
+		if args[0] != nil {
+			arg0 = args[0].(*database.Tx)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Commit_Call) Return(err error) *OrderItemRepositoryMock_Commit_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Commit_Call) RunAndReturn(run func(trx *database.Tx) error) *OrderItemRepositoryMock_Commit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) Count(ctx context.Context, filter map[string]any) (int64, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) (int64, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) int64); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type OrderItemRepositoryMock_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *OrderItemRepositoryMock_Expecter) Count(ctx interface{}, filter interface{}) *OrderItemRepositoryMock_Count_Call {
+	return &OrderItemRepositoryMock_Count_Call{Call: _e.mock.On("Count", ctx, filter)}
+}
+
+func (_c *OrderItemRepositoryMock_Count_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderItemRepositoryMock_Count_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Commit_Call) Return(dB *gorm.DB) *OrderItemRepositoryMock_Commit_Call {
-	_c.Call.Return(dB)
+func (_c *OrderItemRepositoryMock_Count_Call) Return(n int64, err error) *OrderItemRepositoryMock_Count_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Commit_Call) RunAndReturn(run func(trx *gorm.DB) *gorm.DB) *OrderItemRepositoryMock_Commit_Call {
+func (_c *OrderItemRepositoryMock_Count_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) (int64, error)) *OrderItemRepositoryMock_Count_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteById provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID, trx *gorm.DB) error {
+func (_mock *OrderItemRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
 	ret := _mock.Called(ctx, ID, trx)
 
 	if len(ret) == 0 {
@@ -165,7 +365,7 @@ func (_mock *OrderItemRepositoryMock) DeleteById(ctx context.Context, ID uuid.UU
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, ID, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -181,12 +381,12 @@ type OrderItemRepositoryMock_DeleteById_Call struct {
 // DeleteById is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ID uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) DeleteById(ctx interface{}, ID interface{}, trx interface{}) *OrderItemRepositoryMock_DeleteById_Call {
 	return &OrderItemRepositoryMock_DeleteById_Call{Call: _e.mock.On("DeleteById", ctx, ID, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB)) *OrderItemRepositoryMock_DeleteById_Call {
+func (_c *OrderItemRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *OrderItemRepositoryMock_DeleteById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -196,9 +396,9 @@ func (_c *OrderItemRepositoryMock_DeleteById_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -214,13 +414,13 @@ func (_c *OrderItemRepositoryMock_DeleteById_Call) Return(err error) *OrderItemR
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_DeleteById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB) error) *OrderItemRepositoryMock_DeleteById_Call {
+func (_c *OrderItemRepositoryMock_DeleteById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *OrderItemRepositoryMock_DeleteById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteByIds provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB) error {
+func (_mock *OrderItemRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UUID, trx *database.Tx) error {
 	ret := _mock.Called(ctx, IDs, trx)
 
 	if len(ret) == 0 {
@@ -228,7 +428,7 @@ func (_mock *OrderItemRepositoryMock) DeleteByIds(ctx context.Context, IDs []uui
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, IDs, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -244,12 +444,12 @@ type OrderItemRepositoryMock_DeleteByIds_Call struct {
 // DeleteByIds is a helper method to define mock.On call
 //   - ctx context.Context
 //   - IDs []uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) DeleteByIds(ctx interface{}, IDs interface{}, trx interface{}) *OrderItemRepositoryMock_DeleteByIds_Call {
 	return &OrderItemRepositoryMock_DeleteByIds_Call{Call: _e.mock.On("DeleteByIds", ctx, IDs, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB)) *OrderItemRepositoryMock_DeleteByIds_Call {
+func (_c *OrderItemRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, trx *database.Tx)) *OrderItemRepositoryMock_DeleteByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -259,9 +459,9 @@ func (_c *OrderItemRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].([]uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -277,13 +477,13 @@ func (_c *OrderItemRepositoryMock_DeleteByIds_Call) Return(err error) *OrderItem
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_DeleteByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB) error) *OrderItemRepositoryMock_DeleteByIds_Call {
+func (_c *OrderItemRepositoryMock_DeleteByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, trx *database.Tx) error) *OrderItemRepositoryMock_DeleteByIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteMany provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) DeleteMany(ctx context.Context, filter map[string]any, trx *gorm.DB) error {
+func (_mock *OrderItemRepositoryMock) DeleteMany(ctx context.Context, filter map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, filter, trx)
 
 	if len(ret) == 0 {
@@ -291,7 +491,7 @@ func (_mock *OrderItemRepositoryMock) DeleteMany(ctx context.Context, filter map
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, filter, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -307,12 +507,12 @@ type OrderItemRepositoryMock_DeleteMany_Call struct {
 // DeleteMany is a helper method to define mock.On call
 //   - ctx context.Context
 //   - filter map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) DeleteMany(ctx interface{}, filter interface{}, trx interface{}) *OrderItemRepositoryMock_DeleteMany_Call {
 	return &OrderItemRepositoryMock_DeleteMany_Call{Call: _e.mock.On("DeleteMany", ctx, filter, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context, filter map[string]any, trx *gorm.DB)) *OrderItemRepositoryMock_DeleteMany_Call {
+func (_c *OrderItemRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context, filter map[string]any, trx *database.Tx)) *OrderItemRepositoryMock_DeleteMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -322,9 +522,9 @@ func (_c *OrderItemRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(map[string]any)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -340,7 +540,73 @@ func (_c *OrderItemRepositoryMock_DeleteMany_Call) Return(err error) *OrderItemR
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, trx *gorm.DB) error) *OrderItemRepositoryMock_DeleteMany_Call {
+func (_c *OrderItemRepositoryMock_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, trx *database.Tx) error) *OrderItemRepositoryMock_DeleteMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) Exists(ctx context.Context, filter map[string]any) (bool, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) (bool, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) bool); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type OrderItemRepositoryMock_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *OrderItemRepositoryMock_Expecter) Exists(ctx interface{}, filter interface{}) *OrderItemRepositoryMock_Exists_Call {
+	return &OrderItemRepositoryMock_Exists_Call{Call: _e.mock.On("Exists", ctx, filter)}
+}
+
+func (_c *OrderItemRepositoryMock_Exists_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderItemRepositoryMock_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Exists_Call) Return(b bool, err error) *OrderItemRepositoryMock_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Exists_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) (bool, error)) *OrderItemRepositoryMock_Exists_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -566,7 +832,7 @@ func (_c *OrderItemRepositoryMock_FindById_Call) RunAndReturn(run func(ctx conte
 }
 
 // FindByIdAndLock provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.UUID, trx *gorm.DB) (*order.OrderItem, error) {
+func (_mock *OrderItemRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.UUID, trx *database.Tx) (*order.OrderItem, error) {
 	ret := _mock.Called(ctx, ID, trx)
 
 	if len(ret) == 0 {
@@ -575,17 +841,17 @@ func (_mock *OrderItemRepositoryMock) FindByIdAndLock(ctx context.Context, ID uu
 
 	var r0 *order.OrderItem
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) (*order.OrderItem, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) (*order.OrderItem, error)); ok {
 		return returnFunc(ctx, ID, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) *order.OrderItem); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) *order.OrderItem); ok {
 		r0 = returnFunc(ctx, ID, trx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*order.OrderItem)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, ID, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -601,12 +867,12 @@ type OrderItemRepositoryMock_FindByIdAndLock_Call struct {
 // FindByIdAndLock is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ID uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) FindByIdAndLock(ctx interface{}, ID interface{}, trx interface{}) *OrderItemRepositoryMock_FindByIdAndLock_Call {
 	return &OrderItemRepositoryMock_FindByIdAndLock_Call{Call: _e.mock.On("FindByIdAndLock", ctx, ID, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB)) *OrderItemRepositoryMock_FindByIdAndLock_Call {
+func (_c *OrderItemRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *OrderItemRepositoryMock_FindByIdAndLock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -616,9 +882,9 @@ func (_c *OrderItemRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -634,7 +900,7 @@ func (_c *OrderItemRepositoryMock_FindByIdAndLock_Call) Return(orderItem *order.
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_FindByIdAndLock_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB) (*order.OrderItem, error)) *OrderItemRepositoryMock_FindByIdAndLock_Call {
+func (_c *OrderItemRepositoryMock_FindByIdAndLock_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) (*order.OrderItem, error)) *OrderItemRepositoryMock_FindByIdAndLock_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -791,132 +1057,468 @@ func (_c *OrderItemRepositoryMock_FindByOffset_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
-// Insert provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) Insert(ctx context.Context, model order.OrderItem, trx *gorm.DB) (order.OrderItem, error) {
-	ret := _mock.Called(ctx, model, trx)
+// FindOnlyTrashed provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) FindOnlyTrashed(ctx context.Context, filter map[string]any) ([]order.OrderItem, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Insert")
+		panic("no return value specified for FindOnlyTrashed")
 	}
 
-	var r0 order.OrderItem
+	var r0 []order.OrderItem
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, order.OrderItem, *gorm.DB) (order.OrderItem, error)); ok {
-		return returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]order.OrderItem, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, order.OrderItem, *gorm.DB) order.OrderItem); ok {
-		r0 = returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []order.OrderItem); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
-		r0 = ret.Get(0).(order.OrderItem)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.OrderItem)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, order.OrderItem, *gorm.DB) error); ok {
-		r1 = returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// OrderItemRepositoryMock_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
-type OrderItemRepositoryMock_Insert_Call struct {
+// OrderItemRepositoryMock_FindOnlyTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOnlyTrashed'
+type OrderItemRepositoryMock_FindOnlyTrashed_Call struct {
 	*mock.Call
 }
 
-// Insert is a helper method to define mock.On call
+// FindOnlyTrashed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - model order.OrderItem
-//   - trx *gorm.DB
-func (_e *OrderItemRepositoryMock_Expecter) Insert(ctx interface{}, model interface{}, trx interface{}) *OrderItemRepositoryMock_Insert_Call {
-	return &OrderItemRepositoryMock_Insert_Call{Call: _e.mock.On("Insert", ctx, model, trx)}
+//   - filter map[string]any
+func (_e *OrderItemRepositoryMock_Expecter) FindOnlyTrashed(ctx interface{}, filter interface{}) *OrderItemRepositoryMock_FindOnlyTrashed_Call {
+	return &OrderItemRepositoryMock_FindOnlyTrashed_Call{Call: _e.mock.On("FindOnlyTrashed", ctx, filter)}
 }
 
-func (_c *OrderItemRepositoryMock_Insert_Call) Run(run func(ctx context.Context, model order.OrderItem, trx *gorm.DB)) *OrderItemRepositoryMock_Insert_Call {
+func (_c *OrderItemRepositoryMock_FindOnlyTrashed_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderItemRepositoryMock_FindOnlyTrashed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 order.OrderItem
+		var arg1 map[string]any
 		if args[1] != nil {
-			arg1 = args[1].(order.OrderItem)
-		}
-		var arg2 *gorm.DB
-		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg1 = args[1].(map[string]any)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Insert_Call) Return(orderItem order.OrderItem, err error) *OrderItemRepositoryMock_Insert_Call {
-	_c.Call.Return(orderItem, err)
+func (_c *OrderItemRepositoryMock_FindOnlyTrashed_Call) Return(orderItems []order.OrderItem, err error) *OrderItemRepositoryMock_FindOnlyTrashed_Call {
+	_c.Call.Return(orderItems, err)
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Insert_Call) RunAndReturn(run func(ctx context.Context, model order.OrderItem, trx *gorm.DB) (order.OrderItem, error)) *OrderItemRepositoryMock_Insert_Call {
+func (_c *OrderItemRepositoryMock_FindOnlyTrashed_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]order.OrderItem, error)) *OrderItemRepositoryMock_FindOnlyTrashed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertMany provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) InsertMany(ctx context.Context, models []order.OrderItem, trx *gorm.DB) ([]order.OrderItem, error) {
-	ret := _mock.Called(ctx, models, trx)
+// FindProjected provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) FindProjected(ctx context.Context, filter map[string]any, fields []string, dest any) error {
+	ret := _mock.Called(ctx, filter, fields, dest)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertMany")
+		panic("no return value specified for FindProjected")
 	}
 
-	var r0 []order.OrderItem
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.OrderItem, *gorm.DB) ([]order.OrderItem, error)); ok {
-		return returnFunc(ctx, models, trx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.OrderItem, *gorm.DB) []order.OrderItem); ok {
-		r0 = returnFunc(ctx, models, trx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]order.OrderItem)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []order.OrderItem, *gorm.DB) error); ok {
-		r1 = returnFunc(ctx, models, trx)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, []string, any) error); ok {
+		r0 = returnFunc(ctx, filter, fields, dest)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// OrderItemRepositoryMock_InsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMany'
-type OrderItemRepositoryMock_InsertMany_Call struct {
+// OrderItemRepositoryMock_FindProjected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProjected'
+type OrderItemRepositoryMock_FindProjected_Call struct {
 	*mock.Call
 }
 
-// InsertMany is a helper method to define mock.On call
+// FindProjected is a helper method to define mock.On call
 //   - ctx context.Context
-//   - models []order.OrderItem
-//   - trx *gorm.DB
-func (_e *OrderItemRepositoryMock_Expecter) InsertMany(ctx interface{}, models interface{}, trx interface{}) *OrderItemRepositoryMock_InsertMany_Call {
-	return &OrderItemRepositoryMock_InsertMany_Call{Call: _e.mock.On("InsertMany", ctx, models, trx)}
+//   - filter map[string]any
+//   - fields []string
+//   - dest any
+func (_e *OrderItemRepositoryMock_Expecter) FindProjected(ctx interface{}, filter interface{}, fields interface{}, dest interface{}) *OrderItemRepositoryMock_FindProjected_Call {
+	return &OrderItemRepositoryMock_FindProjected_Call{Call: _e.mock.On("FindProjected", ctx, filter, fields, dest)}
 }
 
-func (_c *OrderItemRepositoryMock_InsertMany_Call) Run(run func(ctx context.Context, models []order.OrderItem, trx *gorm.DB)) *OrderItemRepositoryMock_InsertMany_Call {
+func (_c *OrderItemRepositoryMock_FindProjected_Call) Run(run func(ctx context.Context, filter map[string]any, fields []string, dest any)) *OrderItemRepositoryMock_FindProjected_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []order.OrderItem
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 any
+		if args[3] != nil {
+			arg3 = args[3].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_FindProjected_Call) Return(err error) *OrderItemRepositoryMock_FindProjected_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_FindProjected_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, fields []string, dest any) error) *OrderItemRepositoryMock_FindProjected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWithTrashed provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) FindWithTrashed(ctx context.Context, filter map[string]any) ([]order.OrderItem, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWithTrashed")
+	}
+
+	var r0 []order.OrderItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]order.OrderItem, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []order.OrderItem); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.OrderItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_FindWithTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWithTrashed'
+type OrderItemRepositoryMock_FindWithTrashed_Call struct {
+	*mock.Call
+}
+
+// FindWithTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *OrderItemRepositoryMock_Expecter) FindWithTrashed(ctx interface{}, filter interface{}) *OrderItemRepositoryMock_FindWithTrashed_Call {
+	return &OrderItemRepositoryMock_FindWithTrashed_Call{Call: _e.mock.On("FindWithTrashed", ctx, filter)}
+}
+
+func (_c *OrderItemRepositoryMock_FindWithTrashed_Call) Run(run func(ctx context.Context, filter map[string]any)) *OrderItemRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_FindWithTrashed_Call) Return(orderItems []order.OrderItem, err error) *OrderItemRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Return(orderItems, err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_FindWithTrashed_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]order.OrderItem, error)) *OrderItemRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForceDelete provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) ForceDelete(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
+	ret := _mock.Called(ctx, ID, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
+		r0 = returnFunc(ctx, ID, trx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// OrderItemRepositoryMock_ForceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceDelete'
+type OrderItemRepositoryMock_ForceDelete_Call struct {
+	*mock.Call
+}
+
+// ForceDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - trx *database.Tx
+func (_e *OrderItemRepositoryMock_Expecter) ForceDelete(ctx interface{}, ID interface{}, trx interface{}) *OrderItemRepositoryMock_ForceDelete_Call {
+	return &OrderItemRepositoryMock_ForceDelete_Call{Call: _e.mock.On("ForceDelete", ctx, ID, trx)}
+}
+
+func (_c *OrderItemRepositoryMock_ForceDelete_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *OrderItemRepositoryMock_ForceDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_ForceDelete_Call) Return(err error) *OrderItemRepositoryMock_ForceDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_ForceDelete_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *OrderItemRepositoryMock_ForceDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) History(ctx context.Context, ID uuid.UUID) ([]database.AuditLog, error) {
+	ret := _mock.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []database.AuditLog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]database.AuditLog, error)); ok {
+		return returnFunc(ctx, ID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []database.AuditLog); ok {
+		r0 = returnFunc(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.AuditLog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type OrderItemRepositoryMock_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *OrderItemRepositoryMock_Expecter) History(ctx interface{}, ID interface{}) *OrderItemRepositoryMock_History_Call {
+	return &OrderItemRepositoryMock_History_Call{Call: _e.mock.On("History", ctx, ID)}
+}
+
+func (_c *OrderItemRepositoryMock_History_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *OrderItemRepositoryMock_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_History_Call) Return(auditLogs []database.AuditLog, err error) *OrderItemRepositoryMock_History_Call {
+	_c.Call.Return(auditLogs, err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_History_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID) ([]database.AuditLog, error)) *OrderItemRepositoryMock_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) Insert(ctx context.Context, model order.OrderItem, trx *database.Tx) (order.OrderItem, error) {
+	ret := _mock.Called(ctx, model, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 order.OrderItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.OrderItem, *database.Tx) (order.OrderItem, error)); ok {
+		return returnFunc(ctx, model, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.OrderItem, *database.Tx) order.OrderItem); ok {
+		r0 = returnFunc(ctx, model, trx)
+	} else {
+		r0 = ret.Get(0).(order.OrderItem)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, order.OrderItem, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, model, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type OrderItemRepositoryMock_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - model order.OrderItem
+//   - trx *database.Tx
+func (_e *OrderItemRepositoryMock_Expecter) Insert(ctx interface{}, model interface{}, trx interface{}) *OrderItemRepositoryMock_Insert_Call {
+	return &OrderItemRepositoryMock_Insert_Call{Call: _e.mock.On("Insert", ctx, model, trx)}
+}
+
+func (_c *OrderItemRepositoryMock_Insert_Call) Run(run func(ctx context.Context, model order.OrderItem, trx *database.Tx)) *OrderItemRepositoryMock_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 order.OrderItem
+		if args[1] != nil {
+			arg1 = args[1].(order.OrderItem)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Insert_Call) Return(orderItem order.OrderItem, err error) *OrderItemRepositoryMock_Insert_Call {
+	_c.Call.Return(orderItem, err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Insert_Call) RunAndReturn(run func(ctx context.Context, model order.OrderItem, trx *database.Tx) (order.OrderItem, error)) *OrderItemRepositoryMock_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertMany provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) InsertMany(ctx context.Context, models []order.OrderItem, trx *database.Tx) ([]order.OrderItem, error) {
+	ret := _mock.Called(ctx, models, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertMany")
+	}
+
+	var r0 []order.OrderItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.OrderItem, *database.Tx) ([]order.OrderItem, error)); ok {
+		return returnFunc(ctx, models, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.OrderItem, *database.Tx) []order.OrderItem); ok {
+		r0 = returnFunc(ctx, models, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.OrderItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []order.OrderItem, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, models, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_InsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMany'
+type OrderItemRepositoryMock_InsertMany_Call struct {
+	*mock.Call
+}
+
+// InsertMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - models []order.OrderItem
+//   - trx *database.Tx
+func (_e *OrderItemRepositoryMock_Expecter) InsertMany(ctx interface{}, models interface{}, trx interface{}) *OrderItemRepositoryMock_InsertMany_Call {
+	return &OrderItemRepositoryMock_InsertMany_Call{Call: _e.mock.On("InsertMany", ctx, models, trx)}
+}
+
+func (_c *OrderItemRepositoryMock_InsertMany_Call) Run(run func(ctx context.Context, models []order.OrderItem, trx *database.Tx)) *OrderItemRepositoryMock_InsertMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []order.OrderItem
 		if args[1] != nil {
 			arg1 = args[1].([]order.OrderItem)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -932,72 +1534,215 @@ func (_c *OrderItemRepositoryMock_InsertMany_Call) Return(orderItems []order.Ord
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_InsertMany_Call) RunAndReturn(run func(ctx context.Context, models []order.OrderItem, trx *gorm.DB) ([]order.OrderItem, error)) *OrderItemRepositoryMock_InsertMany_Call {
+func (_c *OrderItemRepositoryMock_InsertMany_Call) RunAndReturn(run func(ctx context.Context, models []order.OrderItem, trx *database.Tx) ([]order.OrderItem, error)) *OrderItemRepositoryMock_InsertMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MasterDB provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) MasterDB() *gorm.DB {
-	ret := _mock.Called()
+// Preload provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) Preload(relations ...string) database.BaseRepository[uuid.UUID, order.OrderItem] {
+	var tmpRet mock.Arguments
+	if len(relations) > 0 {
+		tmpRet = _mock.Called(relations)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for MasterDB")
+		panic("no return value specified for Preload")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = returnFunc()
+	var r0 database.BaseRepository[uuid.UUID, order.OrderItem]
+	if returnFunc, ok := ret.Get(0).(func(...string) database.BaseRepository[uuid.UUID, order.OrderItem]); ok {
+		r0 = returnFunc(relations...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(database.BaseRepository[uuid.UUID, order.OrderItem])
+		}
+	}
+	return r0
+}
+
+// OrderItemRepositoryMock_Preload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Preload'
+type OrderItemRepositoryMock_Preload_Call struct {
+	*mock.Call
+}
+
+// Preload is a helper method to define mock.On call
+//   - relations ...string
+func (_e *OrderItemRepositoryMock_Expecter) Preload(relations ...interface{}) *OrderItemRepositoryMock_Preload_Call {
+	return &OrderItemRepositoryMock_Preload_Call{Call: _e.mock.On("Preload",
+		append([]interface{}{}, relations...)...)}
+}
+
+func (_c *OrderItemRepositoryMock_Preload_Call) Run(run func(relations ...string)) *OrderItemRepositoryMock_Preload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		var variadicArgs []string
+		if len(args) > 0 {
+			variadicArgs = args[0].([]string)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Preload_Call) Return(baseRepository database.BaseRepository[uuid.UUID, order.OrderItem]) *OrderItemRepositoryMock_Preload_Call {
+	_c.Call.Return(baseRepository)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Preload_Call) RunAndReturn(run func(relations ...string) database.BaseRepository[uuid.UUID, order.OrderItem]) *OrderItemRepositoryMock_Preload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrashed provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrashed")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_PurgeTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrashed'
+type OrderItemRepositoryMock_PurgeTrashed_Call struct {
+	*mock.Call
+}
+
+// PurgeTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *OrderItemRepositoryMock_Expecter) PurgeTrashed(ctx interface{}, before interface{}) *OrderItemRepositoryMock_PurgeTrashed_Call {
+	return &OrderItemRepositoryMock_PurgeTrashed_Call{Call: _e.mock.On("PurgeTrashed", ctx, before)}
+}
+
+func (_c *OrderItemRepositoryMock_PurgeTrashed_Call) Run(run func(ctx context.Context, before time.Time)) *OrderItemRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
 		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_PurgeTrashed_Call) Return(n int64, err error) *OrderItemRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_PurgeTrashed_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *OrderItemRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) Restore(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
+	ret := _mock.Called(ctx, ID, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
+		r0 = returnFunc(ctx, ID, trx)
+	} else {
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// OrderItemRepositoryMock_MasterDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MasterDB'
-type OrderItemRepositoryMock_MasterDB_Call struct {
+// OrderItemRepositoryMock_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type OrderItemRepositoryMock_Restore_Call struct {
 	*mock.Call
 }
 
-// MasterDB is a helper method to define mock.On call
-func (_e *OrderItemRepositoryMock_Expecter) MasterDB() *OrderItemRepositoryMock_MasterDB_Call {
-	return &OrderItemRepositoryMock_MasterDB_Call{Call: _e.mock.On("MasterDB")}
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - trx *database.Tx
+func (_e *OrderItemRepositoryMock_Expecter) Restore(ctx interface{}, ID interface{}, trx interface{}) *OrderItemRepositoryMock_Restore_Call {
+	return &OrderItemRepositoryMock_Restore_Call{Call: _e.mock.On("Restore", ctx, ID, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_MasterDB_Call) Run(run func()) *OrderItemRepositoryMock_MasterDB_Call {
+func (_c *OrderItemRepositoryMock_Restore_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *OrderItemRepositoryMock_Restore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_MasterDB_Call) Return(dB *gorm.DB) *OrderItemRepositoryMock_MasterDB_Call {
-	_c.Call.Return(dB)
+func (_c *OrderItemRepositoryMock_Restore_Call) Return(err error) *OrderItemRepositoryMock_Restore_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_MasterDB_Call) RunAndReturn(run func() *gorm.DB) *OrderItemRepositoryMock_MasterDB_Call {
+func (_c *OrderItemRepositoryMock_Restore_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *OrderItemRepositoryMock_Restore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Rollback provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) Rollback(trx *gorm.DB) *gorm.DB {
+func (_mock *OrderItemRepositoryMock) Rollback(trx *database.Tx) error {
 	ret := _mock.Called(trx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Rollback")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) *gorm.DB); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.Tx) error); ok {
 		r0 = returnFunc(trx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
@@ -1008,16 +1753,16 @@ type OrderItemRepositoryMock_Rollback_Call struct {
 }
 
 // Rollback is a helper method to define mock.On call
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) Rollback(trx interface{}) *OrderItemRepositoryMock_Rollback_Call {
 	return &OrderItemRepositoryMock_Rollback_Call{Call: _e.mock.On("Rollback", trx)}
 }
 
-func (_c *OrderItemRepositoryMock_Rollback_Call) Run(run func(trx *gorm.DB)) *OrderItemRepositoryMock_Rollback_Call {
+func (_c *OrderItemRepositoryMock_Rollback_Call) Run(run func(trx *database.Tx)) *OrderItemRepositoryMock_Rollback_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 *database.Tx
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1026,64 +1771,83 @@ func (_c *OrderItemRepositoryMock_Rollback_Call) Run(run func(trx *gorm.DB)) *Or
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Rollback_Call) Return(dB *gorm.DB) *OrderItemRepositoryMock_Rollback_Call {
-	_c.Call.Return(dB)
+func (_c *OrderItemRepositoryMock_Rollback_Call) Return(err error) *OrderItemRepositoryMock_Rollback_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Rollback_Call) RunAndReturn(run func(trx *gorm.DB) *gorm.DB) *OrderItemRepositoryMock_Rollback_Call {
+func (_c *OrderItemRepositoryMock_Rollback_Call) RunAndReturn(run func(trx *database.Tx) error) *OrderItemRepositoryMock_Rollback_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SlaveDB provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) SlaveDB() *gorm.DB {
-	ret := _mock.Called()
+// Stream provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) Stream(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[order.OrderItem, error] {
+	ret := _mock.Called(ctx, filter, batchSize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SlaveDB")
+		panic("no return value specified for Stream")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = returnFunc()
+	var r0 iter.Seq2[order.OrderItem, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, int) iter.Seq2[order.OrderItem, error]); ok {
+		r0 = returnFunc(ctx, filter, batchSize)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(iter.Seq2[order.OrderItem, error])
 		}
 	}
 	return r0
 }
 
-// OrderItemRepositoryMock_SlaveDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SlaveDB'
-type OrderItemRepositoryMock_SlaveDB_Call struct {
+// OrderItemRepositoryMock_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type OrderItemRepositoryMock_Stream_Call struct {
 	*mock.Call
 }
 
-// SlaveDB is a helper method to define mock.On call
-func (_e *OrderItemRepositoryMock_Expecter) SlaveDB() *OrderItemRepositoryMock_SlaveDB_Call {
-	return &OrderItemRepositoryMock_SlaveDB_Call{Call: _e.mock.On("SlaveDB")}
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+//   - batchSize int
+func (_e *OrderItemRepositoryMock_Expecter) Stream(ctx interface{}, filter interface{}, batchSize interface{}) *OrderItemRepositoryMock_Stream_Call {
+	return &OrderItemRepositoryMock_Stream_Call{Call: _e.mock.On("Stream", ctx, filter, batchSize)}
 }
 
-func (_c *OrderItemRepositoryMock_SlaveDB_Call) Run(run func()) *OrderItemRepositoryMock_SlaveDB_Call {
+func (_c *OrderItemRepositoryMock_Stream_Call) Run(run func(ctx context.Context, filter map[string]any, batchSize int)) *OrderItemRepositoryMock_Stream_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_SlaveDB_Call) Return(dB *gorm.DB) *OrderItemRepositoryMock_SlaveDB_Call {
-	_c.Call.Return(dB)
+func (_c *OrderItemRepositoryMock_Stream_Call) Return(seq2 iter.Seq2[order.OrderItem, error]) *OrderItemRepositoryMock_Stream_Call {
+	_c.Call.Return(seq2)
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_SlaveDB_Call) RunAndReturn(run func() *gorm.DB) *OrderItemRepositoryMock_SlaveDB_Call {
+func (_c *OrderItemRepositoryMock_Stream_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[order.OrderItem, error]) *OrderItemRepositoryMock_Stream_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) Update(ctx context.Context, model order.OrderItem, trx *gorm.DB) error {
+func (_mock *OrderItemRepositoryMock) Update(ctx context.Context, model order.OrderItem, trx *database.Tx) error {
 	ret := _mock.Called(ctx, model, trx)
 
 	if len(ret) == 0 {
@@ -1091,7 +1855,7 @@ func (_mock *OrderItemRepositoryMock) Update(ctx context.Context, model order.Or
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, order.OrderItem, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.OrderItem, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, model, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1107,12 +1871,12 @@ type OrderItemRepositoryMock_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
 //   - model order.OrderItem
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) Update(ctx interface{}, model interface{}, trx interface{}) *OrderItemRepositoryMock_Update_Call {
 	return &OrderItemRepositoryMock_Update_Call{Call: _e.mock.On("Update", ctx, model, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_Update_Call) Run(run func(ctx context.Context, model order.OrderItem, trx *gorm.DB)) *OrderItemRepositoryMock_Update_Call {
+func (_c *OrderItemRepositoryMock_Update_Call) Run(run func(ctx context.Context, model order.OrderItem, trx *database.Tx)) *OrderItemRepositoryMock_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1122,9 +1886,9 @@ func (_c *OrderItemRepositoryMock_Update_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(order.OrderItem)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1140,13 +1904,13 @@ func (_c *OrderItemRepositoryMock_Update_Call) Return(err error) *OrderItemRepos
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, model order.OrderItem, trx *gorm.DB) error) *OrderItemRepositoryMock_Update_Call {
+func (_c *OrderItemRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, model order.OrderItem, trx *database.Tx) error) *OrderItemRepositoryMock_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateById provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB) (order.OrderItem, error) {
+func (_mock *OrderItemRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx) (order.OrderItem, error) {
 	ret := _mock.Called(ctx, ID, payload, trx)
 
 	if len(ret) == 0 {
@@ -1155,15 +1919,15 @@ func (_mock *OrderItemRepositoryMock) UpdateById(ctx context.Context, ID uuid.UU
 
 	var r0 order.OrderItem
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) (order.OrderItem, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) (order.OrderItem, error)); ok {
 		return returnFunc(ctx, ID, payload, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) order.OrderItem); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) order.OrderItem); ok {
 		r0 = returnFunc(ctx, ID, payload, trx)
 	} else {
 		r0 = ret.Get(0).(order.OrderItem)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, ID, payload, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -1180,12 +1944,12 @@ type OrderItemRepositoryMock_UpdateById_Call struct {
 //   - ctx context.Context
 //   - ID uuid.UUID
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) UpdateById(ctx interface{}, ID interface{}, payload interface{}, trx interface{}) *OrderItemRepositoryMock_UpdateById_Call {
 	return &OrderItemRepositoryMock_UpdateById_Call{Call: _e.mock.On("UpdateById", ctx, ID, payload, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB)) *OrderItemRepositoryMock_UpdateById_Call {
+func (_c *OrderItemRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx)) *OrderItemRepositoryMock_UpdateById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1199,9 +1963,9 @@ func (_c *OrderItemRepositoryMock_UpdateById_Call) Run(run func(ctx context.Cont
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1218,13 +1982,13 @@ func (_c *OrderItemRepositoryMock_UpdateById_Call) Return(orderItem order.OrderI
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_UpdateById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB) (order.OrderItem, error)) *OrderItemRepositoryMock_UpdateById_Call {
+func (_c *OrderItemRepositoryMock_UpdateById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx) (order.OrderItem, error)) *OrderItemRepositoryMock_UpdateById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateByIds provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB) error {
+func (_mock *OrderItemRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, IDs, payload, trx)
 
 	if len(ret) == 0 {
@@ -1232,7 +1996,7 @@ func (_mock *OrderItemRepositoryMock) UpdateByIds(ctx context.Context, IDs []uui
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, IDs, payload, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1249,12 +2013,12 @@ type OrderItemRepositoryMock_UpdateByIds_Call struct {
 //   - ctx context.Context
 //   - IDs []uuid.UUID
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) UpdateByIds(ctx interface{}, IDs interface{}, payload interface{}, trx interface{}) *OrderItemRepositoryMock_UpdateByIds_Call {
 	return &OrderItemRepositoryMock_UpdateByIds_Call{Call: _e.mock.On("UpdateByIds", ctx, IDs, payload, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB)) *OrderItemRepositoryMock_UpdateByIds_Call {
+func (_c *OrderItemRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx)) *OrderItemRepositoryMock_UpdateByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1268,9 +2032,9 @@ func (_c *OrderItemRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Con
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1287,13 +2051,13 @@ func (_c *OrderItemRepositoryMock_UpdateByIds_Call) Return(err error) *OrderItem
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_UpdateByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB) error) *OrderItemRepositoryMock_UpdateByIds_Call {
+func (_c *OrderItemRepositoryMock_UpdateByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx) error) *OrderItemRepositoryMock_UpdateByIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateMany provides a mock function for the type OrderItemRepositoryMock
-func (_mock *OrderItemRepositoryMock) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB) error {
+func (_mock *OrderItemRepositoryMock) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, filter, payload, trx)
 
 	if len(ret) == 0 {
@@ -1301,7 +2065,7 @@ func (_mock *OrderItemRepositoryMock) UpdateMany(ctx context.Context, filter map
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, filter, payload, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1318,12 +2082,12 @@ type OrderItemRepositoryMock_UpdateMany_Call struct {
 //   - ctx context.Context
 //   - filter map[string]any
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *OrderItemRepositoryMock_Expecter) UpdateMany(ctx interface{}, filter interface{}, payload interface{}, trx interface{}) *OrderItemRepositoryMock_UpdateMany_Call {
 	return &OrderItemRepositoryMock_UpdateMany_Call{Call: _e.mock.On("UpdateMany", ctx, filter, payload, trx)}
 }
 
-func (_c *OrderItemRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB)) *OrderItemRepositoryMock_UpdateMany_Call {
+func (_c *OrderItemRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx)) *OrderItemRepositoryMock_UpdateMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1337,9 +2101,9 @@ func (_c *OrderItemRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Cont
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1356,7 +2120,165 @@ func (_c *OrderItemRepositoryMock_UpdateMany_Call) Return(err error) *OrderItemR
 	return _c
 }
 
-func (_c *OrderItemRepositoryMock_UpdateMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB) error) *OrderItemRepositoryMock_UpdateMany_Call {
+func (_c *OrderItemRepositoryMock_UpdateMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) error) *OrderItemRepositoryMock_UpdateMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) Upsert(ctx context.Context, model order.OrderItem, opts database.UpsertOptions, trx *database.Tx) (order.OrderItem, error) {
+	ret := _mock.Called(ctx, model, opts, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 order.OrderItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.OrderItem, database.UpsertOptions, *database.Tx) (order.OrderItem, error)); ok {
+		return returnFunc(ctx, model, opts, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, order.OrderItem, database.UpsertOptions, *database.Tx) order.OrderItem); ok {
+		r0 = returnFunc(ctx, model, opts, trx)
+	} else {
+		r0 = ret.Get(0).(order.OrderItem)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, order.OrderItem, database.UpsertOptions, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, model, opts, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type OrderItemRepositoryMock_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - model order.OrderItem
+//   - opts database.UpsertOptions
+//   - trx *database.Tx
+func (_e *OrderItemRepositoryMock_Expecter) Upsert(ctx interface{}, model interface{}, opts interface{}, trx interface{}) *OrderItemRepositoryMock_Upsert_Call {
+	return &OrderItemRepositoryMock_Upsert_Call{Call: _e.mock.On("Upsert", ctx, model, opts, trx)}
+}
+
+func (_c *OrderItemRepositoryMock_Upsert_Call) Run(run func(ctx context.Context, model order.OrderItem, opts database.UpsertOptions, trx *database.Tx)) *OrderItemRepositoryMock_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 order.OrderItem
+		if args[1] != nil {
+			arg1 = args[1].(order.OrderItem)
+		}
+		var arg2 database.UpsertOptions
+		if args[2] != nil {
+			arg2 = args[2].(database.UpsertOptions)
+		}
+		var arg3 *database.Tx
+		if args[3] != nil {
+			arg3 = args[3].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Upsert_Call) Return(orderItem order.OrderItem, err error) *OrderItemRepositoryMock_Upsert_Call {
+	_c.Call.Return(orderItem, err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_Upsert_Call) RunAndReturn(run func(ctx context.Context, model order.OrderItem, opts database.UpsertOptions, trx *database.Tx) (order.OrderItem, error)) *OrderItemRepositoryMock_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertMany provides a mock function for the type OrderItemRepositoryMock
+func (_mock *OrderItemRepositoryMock) UpsertMany(ctx context.Context, models []order.OrderItem, opts database.UpsertOptions, trx *database.Tx) ([]order.OrderItem, error) {
+	ret := _mock.Called(ctx, models, opts, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertMany")
+	}
+
+	var r0 []order.OrderItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.OrderItem, database.UpsertOptions, *database.Tx) ([]order.OrderItem, error)); ok {
+		return returnFunc(ctx, models, opts, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []order.OrderItem, database.UpsertOptions, *database.Tx) []order.OrderItem); ok {
+		r0 = returnFunc(ctx, models, opts, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.OrderItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []order.OrderItem, database.UpsertOptions, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, models, opts, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderItemRepositoryMock_UpsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertMany'
+type OrderItemRepositoryMock_UpsertMany_Call struct {
+	*mock.Call
+}
+
+// UpsertMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - models []order.OrderItem
+//   - opts database.UpsertOptions
+//   - trx *database.Tx
+func (_e *OrderItemRepositoryMock_Expecter) UpsertMany(ctx interface{}, models interface{}, opts interface{}, trx interface{}) *OrderItemRepositoryMock_UpsertMany_Call {
+	return &OrderItemRepositoryMock_UpsertMany_Call{Call: _e.mock.On("UpsertMany", ctx, models, opts, trx)}
+}
+
+func (_c *OrderItemRepositoryMock_UpsertMany_Call) Run(run func(ctx context.Context, models []order.OrderItem, opts database.UpsertOptions, trx *database.Tx)) *OrderItemRepositoryMock_UpsertMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []order.OrderItem
+		if args[1] != nil {
+			arg1 = args[1].([]order.OrderItem)
+		}
+		var arg2 database.UpsertOptions
+		if args[2] != nil {
+			arg2 = args[2].(database.UpsertOptions)
+		}
+		var arg3 *database.Tx
+		if args[3] != nil {
+			arg3 = args[3].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_UpsertMany_Call) Return(orderItems []order.OrderItem, err error) *OrderItemRepositoryMock_UpsertMany_Call {
+	_c.Call.Return(orderItems, err)
+	return _c
+}
+
+func (_c *OrderItemRepositoryMock_UpsertMany_Call) RunAndReturn(run func(ctx context.Context, models []order.OrderItem, opts database.UpsertOptions, trx *database.Tx) ([]order.OrderItem, error)) *OrderItemRepositoryMock_UpsertMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,6 +1,10 @@
 package order
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type CreateOrderRequest struct {
 	CustomerID uuid.UUID          `json:"customer_id" validate:"required"`
@@ -18,3 +22,27 @@ type CreateOrderResponse struct {
 	TotalAmount float64   `json:"total_amount"`
 	Status      string    `json:"status"`
 }
+
+type GetOrderResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	CustomerID  uuid.UUID           `json:"customer_id"`
+	TotalAmount float64             `json:"total_amount"`
+	Status      string              `json:"status"`
+	OrderItems  []OrderItemResponse `json:"order_items"`
+	CreatedAt   *time.Time          `json:"created_at"`
+}
+
+type OrderItemResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Quantity    int       `json:"quantity"`
+	Price       float64   `json:"price"`
+	Total       float64   `json:"total"`
+}
+
+type CustomerRevenue struct {
+	CustomerID uuid.UUID `json:"customer_id" bson:"customer_id"`
+	OrderCount int64     `json:"order_count" bson:"order_count"`
+	Revenue    float64   `json:"revenue" bson:"revenue"`
+}
@@ -5,11 +5,16 @@ import (
 	"github.com/google/uuid"
 )
 
+//go:generate go run github.com/goodone-dev/go-boilerplate/cmd/repogen -type=Order
+
+//repogen:finder Status CustomerID
 type Order struct {
 	database.BaseEntity[uuid.UUID] `bson:",inline"`
-	CustomerID                     uuid.UUID `json:"customer_id" bson:"customer_id"`
-	TotalAmount                    float64   `json:"total_amount" bson:"total_amount"`
-	Status                         string    `json:"status" bson:"status"`
+	database.TenantModel           `bson:",inline"`
+	CustomerID                     uuid.UUID   `json:"customer_id" bson:"customer_id"`
+	TotalAmount                    float64     `json:"total_amount" bson:"total_amount"`
+	Status                         string      `json:"status" bson:"status"`
+	Items                          []OrderItem `json:"items,omitempty" gorm:"-" bson:"-" relation:"has_many:order_id"`
 }
 
 func (Order) TableName() string {
@@ -19,3 +24,21 @@ func (Order) TableName() string {
 func (Order) RepositoryName() string {
 	return "OrderRepository"
 }
+
+func (Order) Indexes() []database.Index {
+	return []database.Index{
+		{Keys: []string{"tenant_id", "status", "customer_id"}, Name: "idx_orders_status_customer_id"},
+	}
+}
+
+func (Order) JSONSchema() map[string]any {
+	return map[string]any{
+		"bsonType": "object",
+		"required": []string{"customer_id", "total_amount", "status"},
+		"properties": map[string]any{
+			"customer_id":  map[string]any{"bsonType": "binData"},
+			"total_amount": map[string]any{"bsonType": []string{"double", "int", "long", "decimal"}, "minimum": 0},
+			"status":       map[string]any{"bsonType": "string"},
+		},
+	}
+}
@@ -0,0 +1,14 @@
+// Code generated by repogen. DO NOT EDIT.
+
+package order
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// OrderFinder holds the finders declared on Order.
+type OrderFinder interface {
+	FindByStatusAndCustomerID(ctx context.Context, status string, customerID uuid.UUID) ([]Order, error)
+}
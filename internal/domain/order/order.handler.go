@@ -4,4 +4,5 @@ import "github.com/gin-gonic/gin"
 
 type OrderHandler interface {
 	Create(c *gin.Context)
+	GetById(c *gin.Context)
 }
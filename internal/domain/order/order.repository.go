@@ -1,11 +1,14 @@
 package order
 
 import (
+	"context"
+
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type OrderRepository interface {
-	database.BaseRepository[gorm.DB, uuid.UUID, Order]
+	database.BaseRepository[uuid.UUID, Order]
+	OrderFinder
+	RevenuePerCustomer(ctx context.Context, filter map[string]any) ([]CustomerRevenue, error)
 }
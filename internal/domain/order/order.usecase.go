@@ -2,8 +2,11 @@ package order
 
 import (
 	"context"
+
+	"github.com/google/uuid"
 )
 
 type OrderUsecase interface {
 	Create(ctx context.Context, req CreateOrderRequest) (*CreateOrderResponse, error)
+	GetById(ctx context.Context, ID uuid.UUID) (*GetOrderResponse, error)
 }
@@ -1,18 +1,21 @@
 package order
 
 import (
+	"github.com/goodone-dev/go-boilerplate/internal/domain/product"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
 )
 
 type OrderItem struct {
 	database.BaseEntity[uuid.UUID] `bson:",inline"`
-	OrderID                        uuid.UUID `json:"order_id" bson:"order_id"`
-	ProductID                      uuid.UUID `json:"product_id" bson:"product_id"`
-	ProductName                    string    `json:"product_name" gorm:"-" bson:"product_name"`
-	Quantity                       int       `json:"quantity" bson:"quantity"`
-	Price                          float64   `json:"price" bson:"price"`
-	Total                          float64   `json:"total" gorm:"-" bson:"total"`
+	database.TenantModel           `bson:",inline"`
+	OrderID                        uuid.UUID        `json:"order_id" bson:"order_id"`
+	ProductID                      uuid.UUID        `json:"product_id" bson:"product_id"`
+	ProductName                    string           `json:"product_name" gorm:"-" bson:"product_name"`
+	Quantity                       int              `json:"quantity" bson:"quantity"`
+	Price                          float64          `json:"price" bson:"price"`
+	Total                          float64          `json:"total" gorm:"-" bson:"total"`
+	Product                        *product.Product `json:"product,omitempty" gorm:"-" bson:"-" relation:"belongs_to:product_id"`
 }
 
 func (OrderItem) TableName() string {
@@ -6,12 +6,13 @@ package product
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/goodone-dev/go-boilerplate/internal/domain/product"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 )
 
 // NewProductRepositoryMock creates a new instance of ProductRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -41,24 +42,87 @@ func (_m *ProductRepositoryMock) EXPECT() *ProductRepositoryMock_Expecter {
 	return &ProductRepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// Aggregate provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) Aggregate(ctx context.Context, query database.AggregateQuery, dest any) error {
+	ret := _mock.Called(ctx, query, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, database.AggregateQuery, any) error); ok {
+		r0 = returnFunc(ctx, query, dest)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepositoryMock_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type ProductRepositoryMock_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query database.AggregateQuery
+//   - dest any
+func (_e *ProductRepositoryMock_Expecter) Aggregate(ctx interface{}, query interface{}, dest interface{}) *ProductRepositoryMock_Aggregate_Call {
+	return &ProductRepositoryMock_Aggregate_Call{Call: _e.mock.On("Aggregate", ctx, query, dest)}
+}
+
+func (_c *ProductRepositoryMock_Aggregate_Call) Run(run func(ctx context.Context, query database.AggregateQuery, dest any)) *ProductRepositoryMock_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 database.AggregateQuery
+		if args[1] != nil {
+			arg1 = args[1].(database.AggregateQuery)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Aggregate_Call) Return(err error) *ProductRepositoryMock_Aggregate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Aggregate_Call) RunAndReturn(run func(ctx context.Context, query database.AggregateQuery, dest any) error) *ProductRepositoryMock_Aggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Begin provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) Begin(ctx context.Context) (*gorm.DB, error) {
+func (_mock *ProductRepositoryMock) Begin(ctx context.Context) (*database.Tx, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Begin")
 	}
 
-	var r0 *gorm.DB
+	var r0 *database.Tx
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*gorm.DB, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*database.Tx, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *database.Tx); ok {
 		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(*database.Tx)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -93,31 +157,101 @@ func (_c *ProductRepositoryMock_Begin_Call) Run(run func(ctx context.Context)) *
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Begin_Call) Return(dB *gorm.DB, err error) *ProductRepositoryMock_Begin_Call {
-	_c.Call.Return(dB, err)
+func (_c *ProductRepositoryMock_Begin_Call) Return(tx *database.Tx, err error) *ProductRepositoryMock_Begin_Call {
+	_c.Call.Return(tx, err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Begin_Call) RunAndReturn(run func(ctx context.Context) (*database.Tx, error)) *ProductRepositoryMock_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkWrite provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) BulkWrite(ctx context.Context, ops []database.WriteOperation[product.Product], trx *database.Tx) (database.BulkWriteResult, error) {
+	ret := _mock.Called(ctx, ops, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkWrite")
+	}
+
+	var r0 database.BulkWriteResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []database.WriteOperation[product.Product], *database.Tx) (database.BulkWriteResult, error)); ok {
+		return returnFunc(ctx, ops, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []database.WriteOperation[product.Product], *database.Tx) database.BulkWriteResult); ok {
+		r0 = returnFunc(ctx, ops, trx)
+	} else {
+		r0 = ret.Get(0).(database.BulkWriteResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []database.WriteOperation[product.Product], *database.Tx) error); ok {
+		r1 = returnFunc(ctx, ops, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_BulkWrite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkWrite'
+type ProductRepositoryMock_BulkWrite_Call struct {
+	*mock.Call
+}
+
+// BulkWrite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ops []database.WriteOperation[product.Product]
+//   - trx *database.Tx
+func (_e *ProductRepositoryMock_Expecter) BulkWrite(ctx interface{}, ops interface{}, trx interface{}) *ProductRepositoryMock_BulkWrite_Call {
+	return &ProductRepositoryMock_BulkWrite_Call{Call: _e.mock.On("BulkWrite", ctx, ops, trx)}
+}
+
+func (_c *ProductRepositoryMock_BulkWrite_Call) Run(run func(ctx context.Context, ops []database.WriteOperation[product.Product], trx *database.Tx)) *ProductRepositoryMock_BulkWrite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []database.WriteOperation[product.Product]
+		if args[1] != nil {
+			arg1 = args[1].([]database.WriteOperation[product.Product])
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_BulkWrite_Call) Return(bulkWriteResult database.BulkWriteResult, err error) *ProductRepositoryMock_BulkWrite_Call {
+	_c.Call.Return(bulkWriteResult, err)
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Begin_Call) RunAndReturn(run func(ctx context.Context) (*gorm.DB, error)) *ProductRepositoryMock_Begin_Call {
+func (_c *ProductRepositoryMock_BulkWrite_Call) RunAndReturn(run func(ctx context.Context, ops []database.WriteOperation[product.Product], trx *database.Tx) (database.BulkWriteResult, error)) *ProductRepositoryMock_BulkWrite_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Commit provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) Commit(trx *gorm.DB) *gorm.DB {
+func (_mock *ProductRepositoryMock) Commit(trx *database.Tx) error {
 	ret := _mock.Called(trx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Commit")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) *gorm.DB); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.Tx) error); ok {
 		r0 = returnFunc(trx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
@@ -128,36 +262,102 @@ type ProductRepositoryMock_Commit_Call struct {
 }
 
 // Commit is a helper method to define mock.On call
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) Commit(trx interface{}) *ProductRepositoryMock_Commit_Call {
 	return &ProductRepositoryMock_Commit_Call{Call: _e.mock.On("Commit", trx)}
 }
 
-func (_c *ProductRepositoryMock_Commit_Call) Run(run func(trx *gorm.DB)) *ProductRepositoryMock_Commit_Call {
+func (_c *ProductRepositoryMock_Commit_Call) Run(run func(trx *database.Tx)) *ProductRepositoryMock_Commit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *database.Tx
+		if args[0] != nil {
+			arg0 = args[0].(*database.Tx)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Commit_Call) Return(err error) *ProductRepositoryMock_Commit_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Commit_Call) RunAndReturn(run func(trx *database.Tx) error) *ProductRepositoryMock_Commit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) Count(ctx context.Context, filter map[string]any) (int64, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) (int64, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) int64); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type ProductRepositoryMock_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *ProductRepositoryMock_Expecter) Count(ctx interface{}, filter interface{}) *ProductRepositoryMock_Count_Call {
+	return &ProductRepositoryMock_Count_Call{Call: _e.mock.On("Count", ctx, filter)}
+}
+
+func (_c *ProductRepositoryMock_Count_Call) Run(run func(ctx context.Context, filter map[string]any)) *ProductRepositoryMock_Count_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Commit_Call) Return(dB *gorm.DB) *ProductRepositoryMock_Commit_Call {
-	_c.Call.Return(dB)
+func (_c *ProductRepositoryMock_Count_Call) Return(n int64, err error) *ProductRepositoryMock_Count_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Commit_Call) RunAndReturn(run func(trx *gorm.DB) *gorm.DB) *ProductRepositoryMock_Commit_Call {
+func (_c *ProductRepositoryMock_Count_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) (int64, error)) *ProductRepositoryMock_Count_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteById provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID, trx *gorm.DB) error {
+func (_mock *ProductRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
 	ret := _mock.Called(ctx, ID, trx)
 
 	if len(ret) == 0 {
@@ -165,7 +365,7 @@ func (_mock *ProductRepositoryMock) DeleteById(ctx context.Context, ID uuid.UUID
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, ID, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -181,12 +381,12 @@ type ProductRepositoryMock_DeleteById_Call struct {
 // DeleteById is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ID uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) DeleteById(ctx interface{}, ID interface{}, trx interface{}) *ProductRepositoryMock_DeleteById_Call {
 	return &ProductRepositoryMock_DeleteById_Call{Call: _e.mock.On("DeleteById", ctx, ID, trx)}
 }
 
-func (_c *ProductRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB)) *ProductRepositoryMock_DeleteById_Call {
+func (_c *ProductRepositoryMock_DeleteById_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *ProductRepositoryMock_DeleteById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -196,9 +396,9 @@ func (_c *ProductRepositoryMock_DeleteById_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -214,13 +414,13 @@ func (_c *ProductRepositoryMock_DeleteById_Call) Return(err error) *ProductRepos
 	return _c
 }
 
-func (_c *ProductRepositoryMock_DeleteById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB) error) *ProductRepositoryMock_DeleteById_Call {
+func (_c *ProductRepositoryMock_DeleteById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *ProductRepositoryMock_DeleteById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteByIds provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB) error {
+func (_mock *ProductRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.UUID, trx *database.Tx) error {
 	ret := _mock.Called(ctx, IDs, trx)
 
 	if len(ret) == 0 {
@@ -228,7 +428,7 @@ func (_mock *ProductRepositoryMock) DeleteByIds(ctx context.Context, IDs []uuid.
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, IDs, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -244,12 +444,12 @@ type ProductRepositoryMock_DeleteByIds_Call struct {
 // DeleteByIds is a helper method to define mock.On call
 //   - ctx context.Context
 //   - IDs []uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) DeleteByIds(ctx interface{}, IDs interface{}, trx interface{}) *ProductRepositoryMock_DeleteByIds_Call {
 	return &ProductRepositoryMock_DeleteByIds_Call{Call: _e.mock.On("DeleteByIds", ctx, IDs, trx)}
 }
 
-func (_c *ProductRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB)) *ProductRepositoryMock_DeleteByIds_Call {
+func (_c *ProductRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, trx *database.Tx)) *ProductRepositoryMock_DeleteByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -259,9 +459,9 @@ func (_c *ProductRepositoryMock_DeleteByIds_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].([]uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -277,13 +477,13 @@ func (_c *ProductRepositoryMock_DeleteByIds_Call) Return(err error) *ProductRepo
 	return _c
 }
 
-func (_c *ProductRepositoryMock_DeleteByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, trx *gorm.DB) error) *ProductRepositoryMock_DeleteByIds_Call {
+func (_c *ProductRepositoryMock_DeleteByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, trx *database.Tx) error) *ProductRepositoryMock_DeleteByIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteMany provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) DeleteMany(ctx context.Context, filter map[string]any, trx *gorm.DB) error {
+func (_mock *ProductRepositoryMock) DeleteMany(ctx context.Context, filter map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, filter, trx)
 
 	if len(ret) == 0 {
@@ -291,7 +491,7 @@ func (_mock *ProductRepositoryMock) DeleteMany(ctx context.Context, filter map[s
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, filter, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -307,12 +507,12 @@ type ProductRepositoryMock_DeleteMany_Call struct {
 // DeleteMany is a helper method to define mock.On call
 //   - ctx context.Context
 //   - filter map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) DeleteMany(ctx interface{}, filter interface{}, trx interface{}) *ProductRepositoryMock_DeleteMany_Call {
 	return &ProductRepositoryMock_DeleteMany_Call{Call: _e.mock.On("DeleteMany", ctx, filter, trx)}
 }
 
-func (_c *ProductRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context, filter map[string]any, trx *gorm.DB)) *ProductRepositoryMock_DeleteMany_Call {
+func (_c *ProductRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Context, filter map[string]any, trx *database.Tx)) *ProductRepositoryMock_DeleteMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -322,9 +522,9 @@ func (_c *ProductRepositoryMock_DeleteMany_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(map[string]any)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -340,7 +540,73 @@ func (_c *ProductRepositoryMock_DeleteMany_Call) Return(err error) *ProductRepos
 	return _c
 }
 
-func (_c *ProductRepositoryMock_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, trx *gorm.DB) error) *ProductRepositoryMock_DeleteMany_Call {
+func (_c *ProductRepositoryMock_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, trx *database.Tx) error) *ProductRepositoryMock_DeleteMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) Exists(ctx context.Context, filter map[string]any) (bool, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) (bool, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) bool); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type ProductRepositoryMock_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *ProductRepositoryMock_Expecter) Exists(ctx interface{}, filter interface{}) *ProductRepositoryMock_Exists_Call {
+	return &ProductRepositoryMock_Exists_Call{Call: _e.mock.On("Exists", ctx, filter)}
+}
+
+func (_c *ProductRepositoryMock_Exists_Call) Run(run func(ctx context.Context, filter map[string]any)) *ProductRepositoryMock_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Exists_Call) Return(b bool, err error) *ProductRepositoryMock_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Exists_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) (bool, error)) *ProductRepositoryMock_Exists_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -566,7 +832,7 @@ func (_c *ProductRepositoryMock_FindById_Call) RunAndReturn(run func(ctx context
 }
 
 // FindByIdAndLock provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.UUID, trx *gorm.DB) (*product.Product, error) {
+func (_mock *ProductRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid.UUID, trx *database.Tx) (*product.Product, error) {
 	ret := _mock.Called(ctx, ID, trx)
 
 	if len(ret) == 0 {
@@ -575,17 +841,17 @@ func (_mock *ProductRepositoryMock) FindByIdAndLock(ctx context.Context, ID uuid
 
 	var r0 *product.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) (*product.Product, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) (*product.Product, error)); ok {
 		return returnFunc(ctx, ID, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) *product.Product); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) *product.Product); ok {
 		r0 = returnFunc(ctx, ID, trx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*product.Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, ID, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -601,12 +867,12 @@ type ProductRepositoryMock_FindByIdAndLock_Call struct {
 // FindByIdAndLock is a helper method to define mock.On call
 //   - ctx context.Context
 //   - ID uuid.UUID
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) FindByIdAndLock(ctx interface{}, ID interface{}, trx interface{}) *ProductRepositoryMock_FindByIdAndLock_Call {
 	return &ProductRepositoryMock_FindByIdAndLock_Call{Call: _e.mock.On("FindByIdAndLock", ctx, ID, trx)}
 }
 
-func (_c *ProductRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB)) *ProductRepositoryMock_FindByIdAndLock_Call {
+func (_c *ProductRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *ProductRepositoryMock_FindByIdAndLock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -616,9 +882,9 @@ func (_c *ProductRepositoryMock_FindByIdAndLock_Call) Run(run func(ctx context.C
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -634,7 +900,7 @@ func (_c *ProductRepositoryMock_FindByIdAndLock_Call) Return(product1 *product.P
 	return _c
 }
 
-func (_c *ProductRepositoryMock_FindByIdAndLock_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *gorm.DB) (*product.Product, error)) *ProductRepositoryMock_FindByIdAndLock_Call {
+func (_c *ProductRepositoryMock_FindByIdAndLock_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) (*product.Product, error)) *ProductRepositoryMock_FindByIdAndLock_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -791,132 +1057,468 @@ func (_c *ProductRepositoryMock_FindByOffset_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
-// Insert provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) Insert(ctx context.Context, model product.Product, trx *gorm.DB) (product.Product, error) {
-	ret := _mock.Called(ctx, model, trx)
+// FindOnlyTrashed provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) FindOnlyTrashed(ctx context.Context, filter map[string]any) ([]product.Product, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Insert")
+		panic("no return value specified for FindOnlyTrashed")
 	}
 
-	var r0 product.Product
+	var r0 []product.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product, *gorm.DB) (product.Product, error)); ok {
-		return returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]product.Product, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product, *gorm.DB) product.Product); ok {
-		r0 = returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []product.Product); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
-		r0 = ret.Get(0).(product.Product)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.Product)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, product.Product, *gorm.DB) error); ok {
-		r1 = returnFunc(ctx, model, trx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// ProductRepositoryMock_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
-type ProductRepositoryMock_Insert_Call struct {
+// ProductRepositoryMock_FindOnlyTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOnlyTrashed'
+type ProductRepositoryMock_FindOnlyTrashed_Call struct {
 	*mock.Call
 }
 
-// Insert is a helper method to define mock.On call
+// FindOnlyTrashed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - model product.Product
-//   - trx *gorm.DB
-func (_e *ProductRepositoryMock_Expecter) Insert(ctx interface{}, model interface{}, trx interface{}) *ProductRepositoryMock_Insert_Call {
-	return &ProductRepositoryMock_Insert_Call{Call: _e.mock.On("Insert", ctx, model, trx)}
+//   - filter map[string]any
+func (_e *ProductRepositoryMock_Expecter) FindOnlyTrashed(ctx interface{}, filter interface{}) *ProductRepositoryMock_FindOnlyTrashed_Call {
+	return &ProductRepositoryMock_FindOnlyTrashed_Call{Call: _e.mock.On("FindOnlyTrashed", ctx, filter)}
 }
 
-func (_c *ProductRepositoryMock_Insert_Call) Run(run func(ctx context.Context, model product.Product, trx *gorm.DB)) *ProductRepositoryMock_Insert_Call {
+func (_c *ProductRepositoryMock_FindOnlyTrashed_Call) Run(run func(ctx context.Context, filter map[string]any)) *ProductRepositoryMock_FindOnlyTrashed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 product.Product
+		var arg1 map[string]any
 		if args[1] != nil {
-			arg1 = args[1].(product.Product)
-		}
-		var arg2 *gorm.DB
-		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg1 = args[1].(map[string]any)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Insert_Call) Return(product1 product.Product, err error) *ProductRepositoryMock_Insert_Call {
-	_c.Call.Return(product1, err)
+func (_c *ProductRepositoryMock_FindOnlyTrashed_Call) Return(products []product.Product, err error) *ProductRepositoryMock_FindOnlyTrashed_Call {
+	_c.Call.Return(products, err)
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Insert_Call) RunAndReturn(run func(ctx context.Context, model product.Product, trx *gorm.DB) (product.Product, error)) *ProductRepositoryMock_Insert_Call {
+func (_c *ProductRepositoryMock_FindOnlyTrashed_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]product.Product, error)) *ProductRepositoryMock_FindOnlyTrashed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertMany provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) InsertMany(ctx context.Context, models []product.Product, trx *gorm.DB) ([]product.Product, error) {
-	ret := _mock.Called(ctx, models, trx)
+// FindProjected provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) FindProjected(ctx context.Context, filter map[string]any, fields []string, dest any) error {
+	ret := _mock.Called(ctx, filter, fields, dest)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertMany")
+		panic("no return value specified for FindProjected")
 	}
 
-	var r0 []product.Product
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []product.Product, *gorm.DB) ([]product.Product, error)); ok {
-		return returnFunc(ctx, models, trx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []product.Product, *gorm.DB) []product.Product); ok {
-		r0 = returnFunc(ctx, models, trx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]product.Product)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []product.Product, *gorm.DB) error); ok {
-		r1 = returnFunc(ctx, models, trx)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, []string, any) error); ok {
+		r0 = returnFunc(ctx, filter, fields, dest)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// ProductRepositoryMock_InsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMany'
-type ProductRepositoryMock_InsertMany_Call struct {
+// ProductRepositoryMock_FindProjected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProjected'
+type ProductRepositoryMock_FindProjected_Call struct {
 	*mock.Call
 }
 
-// InsertMany is a helper method to define mock.On call
+// FindProjected is a helper method to define mock.On call
 //   - ctx context.Context
-//   - models []product.Product
-//   - trx *gorm.DB
-func (_e *ProductRepositoryMock_Expecter) InsertMany(ctx interface{}, models interface{}, trx interface{}) *ProductRepositoryMock_InsertMany_Call {
-	return &ProductRepositoryMock_InsertMany_Call{Call: _e.mock.On("InsertMany", ctx, models, trx)}
+//   - filter map[string]any
+//   - fields []string
+//   - dest any
+func (_e *ProductRepositoryMock_Expecter) FindProjected(ctx interface{}, filter interface{}, fields interface{}, dest interface{}) *ProductRepositoryMock_FindProjected_Call {
+	return &ProductRepositoryMock_FindProjected_Call{Call: _e.mock.On("FindProjected", ctx, filter, fields, dest)}
 }
 
-func (_c *ProductRepositoryMock_InsertMany_Call) Run(run func(ctx context.Context, models []product.Product, trx *gorm.DB)) *ProductRepositoryMock_InsertMany_Call {
+func (_c *ProductRepositoryMock_FindProjected_Call) Run(run func(ctx context.Context, filter map[string]any, fields []string, dest any)) *ProductRepositoryMock_FindProjected_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []product.Product
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 any
+		if args[3] != nil {
+			arg3 = args[3].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_FindProjected_Call) Return(err error) *ProductRepositoryMock_FindProjected_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_FindProjected_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, fields []string, dest any) error) *ProductRepositoryMock_FindProjected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWithTrashed provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) FindWithTrashed(ctx context.Context, filter map[string]any) ([]product.Product, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWithTrashed")
+	}
+
+	var r0 []product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) ([]product.Product, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) []product.Product); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, map[string]any) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_FindWithTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWithTrashed'
+type ProductRepositoryMock_FindWithTrashed_Call struct {
+	*mock.Call
+}
+
+// FindWithTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+func (_e *ProductRepositoryMock_Expecter) FindWithTrashed(ctx interface{}, filter interface{}) *ProductRepositoryMock_FindWithTrashed_Call {
+	return &ProductRepositoryMock_FindWithTrashed_Call{Call: _e.mock.On("FindWithTrashed", ctx, filter)}
+}
+
+func (_c *ProductRepositoryMock_FindWithTrashed_Call) Run(run func(ctx context.Context, filter map[string]any)) *ProductRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_FindWithTrashed_Call) Return(products []product.Product, err error) *ProductRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_FindWithTrashed_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any) ([]product.Product, error)) *ProductRepositoryMock_FindWithTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForceDelete provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) ForceDelete(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
+	ret := _mock.Called(ctx, ID, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
+		r0 = returnFunc(ctx, ID, trx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepositoryMock_ForceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceDelete'
+type ProductRepositoryMock_ForceDelete_Call struct {
+	*mock.Call
+}
+
+// ForceDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - trx *database.Tx
+func (_e *ProductRepositoryMock_Expecter) ForceDelete(ctx interface{}, ID interface{}, trx interface{}) *ProductRepositoryMock_ForceDelete_Call {
+	return &ProductRepositoryMock_ForceDelete_Call{Call: _e.mock.On("ForceDelete", ctx, ID, trx)}
+}
+
+func (_c *ProductRepositoryMock_ForceDelete_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *ProductRepositoryMock_ForceDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_ForceDelete_Call) Return(err error) *ProductRepositoryMock_ForceDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_ForceDelete_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *ProductRepositoryMock_ForceDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) History(ctx context.Context, ID uuid.UUID) ([]database.AuditLog, error) {
+	ret := _mock.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []database.AuditLog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]database.AuditLog, error)); ok {
+		return returnFunc(ctx, ID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []database.AuditLog); ok {
+		r0 = returnFunc(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.AuditLog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type ProductRepositoryMock_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *ProductRepositoryMock_Expecter) History(ctx interface{}, ID interface{}) *ProductRepositoryMock_History_Call {
+	return &ProductRepositoryMock_History_Call{Call: _e.mock.On("History", ctx, ID)}
+}
+
+func (_c *ProductRepositoryMock_History_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *ProductRepositoryMock_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_History_Call) Return(auditLogs []database.AuditLog, err error) *ProductRepositoryMock_History_Call {
+	_c.Call.Return(auditLogs, err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_History_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID) ([]database.AuditLog, error)) *ProductRepositoryMock_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) Insert(ctx context.Context, model product.Product, trx *database.Tx) (product.Product, error) {
+	ret := _mock.Called(ctx, model, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product, *database.Tx) (product.Product, error)); ok {
+		return returnFunc(ctx, model, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product, *database.Tx) product.Product); ok {
+		r0 = returnFunc(ctx, model, trx)
+	} else {
+		r0 = ret.Get(0).(product.Product)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.Product, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, model, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type ProductRepositoryMock_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - model product.Product
+//   - trx *database.Tx
+func (_e *ProductRepositoryMock_Expecter) Insert(ctx interface{}, model interface{}, trx interface{}) *ProductRepositoryMock_Insert_Call {
+	return &ProductRepositoryMock_Insert_Call{Call: _e.mock.On("Insert", ctx, model, trx)}
+}
+
+func (_c *ProductRepositoryMock_Insert_Call) Run(run func(ctx context.Context, model product.Product, trx *database.Tx)) *ProductRepositoryMock_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.Product
+		if args[1] != nil {
+			arg1 = args[1].(product.Product)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Insert_Call) Return(product1 product.Product, err error) *ProductRepositoryMock_Insert_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Insert_Call) RunAndReturn(run func(ctx context.Context, model product.Product, trx *database.Tx) (product.Product, error)) *ProductRepositoryMock_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertMany provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) InsertMany(ctx context.Context, models []product.Product, trx *database.Tx) ([]product.Product, error) {
+	ret := _mock.Called(ctx, models, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertMany")
+	}
+
+	var r0 []product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []product.Product, *database.Tx) ([]product.Product, error)); ok {
+		return returnFunc(ctx, models, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []product.Product, *database.Tx) []product.Product); ok {
+		r0 = returnFunc(ctx, models, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []product.Product, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, models, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_InsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMany'
+type ProductRepositoryMock_InsertMany_Call struct {
+	*mock.Call
+}
+
+// InsertMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - models []product.Product
+//   - trx *database.Tx
+func (_e *ProductRepositoryMock_Expecter) InsertMany(ctx interface{}, models interface{}, trx interface{}) *ProductRepositoryMock_InsertMany_Call {
+	return &ProductRepositoryMock_InsertMany_Call{Call: _e.mock.On("InsertMany", ctx, models, trx)}
+}
+
+func (_c *ProductRepositoryMock_InsertMany_Call) Run(run func(ctx context.Context, models []product.Product, trx *database.Tx)) *ProductRepositoryMock_InsertMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []product.Product
 		if args[1] != nil {
 			arg1 = args[1].([]product.Product)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -932,72 +1534,215 @@ func (_c *ProductRepositoryMock_InsertMany_Call) Return(products []product.Produ
 	return _c
 }
 
-func (_c *ProductRepositoryMock_InsertMany_Call) RunAndReturn(run func(ctx context.Context, models []product.Product, trx *gorm.DB) ([]product.Product, error)) *ProductRepositoryMock_InsertMany_Call {
+func (_c *ProductRepositoryMock_InsertMany_Call) RunAndReturn(run func(ctx context.Context, models []product.Product, trx *database.Tx) ([]product.Product, error)) *ProductRepositoryMock_InsertMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MasterDB provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) MasterDB() *gorm.DB {
-	ret := _mock.Called()
+// Preload provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) Preload(relations ...string) database.BaseRepository[uuid.UUID, product.Product] {
+	var tmpRet mock.Arguments
+	if len(relations) > 0 {
+		tmpRet = _mock.Called(relations)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for MasterDB")
+		panic("no return value specified for Preload")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = returnFunc()
+	var r0 database.BaseRepository[uuid.UUID, product.Product]
+	if returnFunc, ok := ret.Get(0).(func(...string) database.BaseRepository[uuid.UUID, product.Product]); ok {
+		r0 = returnFunc(relations...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(database.BaseRepository[uuid.UUID, product.Product])
+		}
+	}
+	return r0
+}
+
+// ProductRepositoryMock_Preload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Preload'
+type ProductRepositoryMock_Preload_Call struct {
+	*mock.Call
+}
+
+// Preload is a helper method to define mock.On call
+//   - relations ...string
+func (_e *ProductRepositoryMock_Expecter) Preload(relations ...interface{}) *ProductRepositoryMock_Preload_Call {
+	return &ProductRepositoryMock_Preload_Call{Call: _e.mock.On("Preload",
+		append([]interface{}{}, relations...)...)}
+}
+
+func (_c *ProductRepositoryMock_Preload_Call) Run(run func(relations ...string)) *ProductRepositoryMock_Preload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		var variadicArgs []string
+		if len(args) > 0 {
+			variadicArgs = args[0].([]string)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Preload_Call) Return(baseRepository database.BaseRepository[uuid.UUID, product.Product]) *ProductRepositoryMock_Preload_Call {
+	_c.Call.Return(baseRepository)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Preload_Call) RunAndReturn(run func(relations ...string) database.BaseRepository[uuid.UUID, product.Product]) *ProductRepositoryMock_Preload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrashed provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrashed")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_PurgeTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrashed'
+type ProductRepositoryMock_PurgeTrashed_Call struct {
+	*mock.Call
+}
+
+// PurgeTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ProductRepositoryMock_Expecter) PurgeTrashed(ctx interface{}, before interface{}) *ProductRepositoryMock_PurgeTrashed_Call {
+	return &ProductRepositoryMock_PurgeTrashed_Call{Call: _e.mock.On("PurgeTrashed", ctx, before)}
+}
+
+func (_c *ProductRepositoryMock_PurgeTrashed_Call) Run(run func(ctx context.Context, before time.Time)) *ProductRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
 		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_PurgeTrashed_Call) Return(n int64, err error) *ProductRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_PurgeTrashed_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *ProductRepositoryMock_PurgeTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) Restore(ctx context.Context, ID uuid.UUID, trx *database.Tx) error {
+	ret := _mock.Called(ctx, ID, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *database.Tx) error); ok {
+		r0 = returnFunc(ctx, ID, trx)
+	} else {
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// ProductRepositoryMock_MasterDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MasterDB'
-type ProductRepositoryMock_MasterDB_Call struct {
+// ProductRepositoryMock_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type ProductRepositoryMock_Restore_Call struct {
 	*mock.Call
 }
 
-// MasterDB is a helper method to define mock.On call
-func (_e *ProductRepositoryMock_Expecter) MasterDB() *ProductRepositoryMock_MasterDB_Call {
-	return &ProductRepositoryMock_MasterDB_Call{Call: _e.mock.On("MasterDB")}
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - trx *database.Tx
+func (_e *ProductRepositoryMock_Expecter) Restore(ctx interface{}, ID interface{}, trx interface{}) *ProductRepositoryMock_Restore_Call {
+	return &ProductRepositoryMock_Restore_Call{Call: _e.mock.On("Restore", ctx, ID, trx)}
 }
 
-func (_c *ProductRepositoryMock_MasterDB_Call) Run(run func()) *ProductRepositoryMock_MasterDB_Call {
+func (_c *ProductRepositoryMock_Restore_Call) Run(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx)) *ProductRepositoryMock_Restore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *database.Tx
+		if args[2] != nil {
+			arg2 = args[2].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *ProductRepositoryMock_MasterDB_Call) Return(dB *gorm.DB) *ProductRepositoryMock_MasterDB_Call {
-	_c.Call.Return(dB)
+func (_c *ProductRepositoryMock_Restore_Call) Return(err error) *ProductRepositoryMock_Restore_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ProductRepositoryMock_MasterDB_Call) RunAndReturn(run func() *gorm.DB) *ProductRepositoryMock_MasterDB_Call {
+func (_c *ProductRepositoryMock_Restore_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, trx *database.Tx) error) *ProductRepositoryMock_Restore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Rollback provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) Rollback(trx *gorm.DB) *gorm.DB {
+func (_mock *ProductRepositoryMock) Rollback(trx *database.Tx) error {
 	ret := _mock.Called(trx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Rollback")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) *gorm.DB); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*database.Tx) error); ok {
 		r0 = returnFunc(trx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
@@ -1008,16 +1753,16 @@ type ProductRepositoryMock_Rollback_Call struct {
 }
 
 // Rollback is a helper method to define mock.On call
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) Rollback(trx interface{}) *ProductRepositoryMock_Rollback_Call {
 	return &ProductRepositoryMock_Rollback_Call{Call: _e.mock.On("Rollback", trx)}
 }
 
-func (_c *ProductRepositoryMock_Rollback_Call) Run(run func(trx *gorm.DB)) *ProductRepositoryMock_Rollback_Call {
+func (_c *ProductRepositoryMock_Rollback_Call) Run(run func(trx *database.Tx)) *ProductRepositoryMock_Rollback_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 *database.Tx
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1026,64 +1771,83 @@ func (_c *ProductRepositoryMock_Rollback_Call) Run(run func(trx *gorm.DB)) *Prod
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Rollback_Call) Return(dB *gorm.DB) *ProductRepositoryMock_Rollback_Call {
-	_c.Call.Return(dB)
+func (_c *ProductRepositoryMock_Rollback_Call) Return(err error) *ProductRepositoryMock_Rollback_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Rollback_Call) RunAndReturn(run func(trx *gorm.DB) *gorm.DB) *ProductRepositoryMock_Rollback_Call {
+func (_c *ProductRepositoryMock_Rollback_Call) RunAndReturn(run func(trx *database.Tx) error) *ProductRepositoryMock_Rollback_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SlaveDB provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) SlaveDB() *gorm.DB {
-	ret := _mock.Called()
+// Stream provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) Stream(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[product.Product, error] {
+	ret := _mock.Called(ctx, filter, batchSize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SlaveDB")
+		panic("no return value specified for Stream")
 	}
 
-	var r0 *gorm.DB
-	if returnFunc, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = returnFunc()
+	var r0 iter.Seq2[product.Product, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, int) iter.Seq2[product.Product, error]); ok {
+		r0 = returnFunc(ctx, filter, batchSize)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*gorm.DB)
+			r0 = ret.Get(0).(iter.Seq2[product.Product, error])
 		}
 	}
 	return r0
 }
 
-// ProductRepositoryMock_SlaveDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SlaveDB'
-type ProductRepositoryMock_SlaveDB_Call struct {
+// ProductRepositoryMock_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type ProductRepositoryMock_Stream_Call struct {
 	*mock.Call
 }
 
-// SlaveDB is a helper method to define mock.On call
-func (_e *ProductRepositoryMock_Expecter) SlaveDB() *ProductRepositoryMock_SlaveDB_Call {
-	return &ProductRepositoryMock_SlaveDB_Call{Call: _e.mock.On("SlaveDB")}
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter map[string]any
+//   - batchSize int
+func (_e *ProductRepositoryMock_Expecter) Stream(ctx interface{}, filter interface{}, batchSize interface{}) *ProductRepositoryMock_Stream_Call {
+	return &ProductRepositoryMock_Stream_Call{Call: _e.mock.On("Stream", ctx, filter, batchSize)}
 }
 
-func (_c *ProductRepositoryMock_SlaveDB_Call) Run(run func()) *ProductRepositoryMock_SlaveDB_Call {
+func (_c *ProductRepositoryMock_Stream_Call) Run(run func(ctx context.Context, filter map[string]any, batchSize int)) *ProductRepositoryMock_Stream_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *ProductRepositoryMock_SlaveDB_Call) Return(dB *gorm.DB) *ProductRepositoryMock_SlaveDB_Call {
-	_c.Call.Return(dB)
+func (_c *ProductRepositoryMock_Stream_Call) Return(seq2 iter.Seq2[product.Product, error]) *ProductRepositoryMock_Stream_Call {
+	_c.Call.Return(seq2)
 	return _c
 }
 
-func (_c *ProductRepositoryMock_SlaveDB_Call) RunAndReturn(run func() *gorm.DB) *ProductRepositoryMock_SlaveDB_Call {
+func (_c *ProductRepositoryMock_Stream_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[product.Product, error]) *ProductRepositoryMock_Stream_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) Update(ctx context.Context, model product.Product, trx *gorm.DB) error {
+func (_mock *ProductRepositoryMock) Update(ctx context.Context, model product.Product, trx *database.Tx) error {
 	ret := _mock.Called(ctx, model, trx)
 
 	if len(ret) == 0 {
@@ -1091,7 +1855,7 @@ func (_mock *ProductRepositoryMock) Update(ctx context.Context, model product.Pr
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, model, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1107,12 +1871,12 @@ type ProductRepositoryMock_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
 //   - model product.Product
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) Update(ctx interface{}, model interface{}, trx interface{}) *ProductRepositoryMock_Update_Call {
 	return &ProductRepositoryMock_Update_Call{Call: _e.mock.On("Update", ctx, model, trx)}
 }
 
-func (_c *ProductRepositoryMock_Update_Call) Run(run func(ctx context.Context, model product.Product, trx *gorm.DB)) *ProductRepositoryMock_Update_Call {
+func (_c *ProductRepositoryMock_Update_Call) Run(run func(ctx context.Context, model product.Product, trx *database.Tx)) *ProductRepositoryMock_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1122,9 +1886,9 @@ func (_c *ProductRepositoryMock_Update_Call) Run(run func(ctx context.Context, m
 		if args[1] != nil {
 			arg1 = args[1].(product.Product)
 		}
-		var arg2 *gorm.DB
+		var arg2 *database.Tx
 		if args[2] != nil {
-			arg2 = args[2].(*gorm.DB)
+			arg2 = args[2].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1140,13 +1904,13 @@ func (_c *ProductRepositoryMock_Update_Call) Return(err error) *ProductRepositor
 	return _c
 }
 
-func (_c *ProductRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, model product.Product, trx *gorm.DB) error) *ProductRepositoryMock_Update_Call {
+func (_c *ProductRepositoryMock_Update_Call) RunAndReturn(run func(ctx context.Context, model product.Product, trx *database.Tx) error) *ProductRepositoryMock_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateById provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB) (product.Product, error) {
+func (_mock *ProductRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx) (product.Product, error) {
 	ret := _mock.Called(ctx, ID, payload, trx)
 
 	if len(ret) == 0 {
@@ -1155,15 +1919,15 @@ func (_mock *ProductRepositoryMock) UpdateById(ctx context.Context, ID uuid.UUID
 
 	var r0 product.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) (product.Product, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) (product.Product, error)); ok {
 		return returnFunc(ctx, ID, payload, trx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) product.Product); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) product.Product); ok {
 		r0 = returnFunc(ctx, ID, payload, trx)
 	} else {
 		r0 = ret.Get(0).(product.Product)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]any, *database.Tx) error); ok {
 		r1 = returnFunc(ctx, ID, payload, trx)
 	} else {
 		r1 = ret.Error(1)
@@ -1180,12 +1944,12 @@ type ProductRepositoryMock_UpdateById_Call struct {
 //   - ctx context.Context
 //   - ID uuid.UUID
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) UpdateById(ctx interface{}, ID interface{}, payload interface{}, trx interface{}) *ProductRepositoryMock_UpdateById_Call {
 	return &ProductRepositoryMock_UpdateById_Call{Call: _e.mock.On("UpdateById", ctx, ID, payload, trx)}
 }
 
-func (_c *ProductRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB)) *ProductRepositoryMock_UpdateById_Call {
+func (_c *ProductRepositoryMock_UpdateById_Call) Run(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx)) *ProductRepositoryMock_UpdateById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1199,9 +1963,9 @@ func (_c *ProductRepositoryMock_UpdateById_Call) Run(run func(ctx context.Contex
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1218,13 +1982,13 @@ func (_c *ProductRepositoryMock_UpdateById_Call) Return(product1 product.Product
 	return _c
 }
 
-func (_c *ProductRepositoryMock_UpdateById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *gorm.DB) (product.Product, error)) *ProductRepositoryMock_UpdateById_Call {
+func (_c *ProductRepositoryMock_UpdateById_Call) RunAndReturn(run func(ctx context.Context, ID uuid.UUID, payload map[string]any, trx *database.Tx) (product.Product, error)) *ProductRepositoryMock_UpdateById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateByIds provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB) error {
+func (_mock *ProductRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, IDs, payload, trx)
 
 	if len(ret) == 0 {
@@ -1232,7 +1996,7 @@ func (_mock *ProductRepositoryMock) UpdateByIds(ctx context.Context, IDs []uuid.
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, IDs, payload, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1249,12 +2013,12 @@ type ProductRepositoryMock_UpdateByIds_Call struct {
 //   - ctx context.Context
 //   - IDs []uuid.UUID
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) UpdateByIds(ctx interface{}, IDs interface{}, payload interface{}, trx interface{}) *ProductRepositoryMock_UpdateByIds_Call {
 	return &ProductRepositoryMock_UpdateByIds_Call{Call: _e.mock.On("UpdateByIds", ctx, IDs, payload, trx)}
 }
 
-func (_c *ProductRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB)) *ProductRepositoryMock_UpdateByIds_Call {
+func (_c *ProductRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx)) *ProductRepositoryMock_UpdateByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1268,9 +2032,9 @@ func (_c *ProductRepositoryMock_UpdateByIds_Call) Run(run func(ctx context.Conte
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1287,13 +2051,13 @@ func (_c *ProductRepositoryMock_UpdateByIds_Call) Return(err error) *ProductRepo
 	return _c
 }
 
-func (_c *ProductRepositoryMock_UpdateByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *gorm.DB) error) *ProductRepositoryMock_UpdateByIds_Call {
+func (_c *ProductRepositoryMock_UpdateByIds_Call) RunAndReturn(run func(ctx context.Context, IDs []uuid.UUID, payload map[string]any, trx *database.Tx) error) *ProductRepositoryMock_UpdateByIds_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateMany provides a mock function for the type ProductRepositoryMock
-func (_mock *ProductRepositoryMock) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB) error {
+func (_mock *ProductRepositoryMock) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) error {
 	ret := _mock.Called(ctx, filter, payload, trx)
 
 	if len(ret) == 0 {
@@ -1301,7 +2065,7 @@ func (_mock *ProductRepositoryMock) UpdateMany(ctx context.Context, filter map[s
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, map[string]any, *gorm.DB) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any, map[string]any, *database.Tx) error); ok {
 		r0 = returnFunc(ctx, filter, payload, trx)
 	} else {
 		r0 = ret.Error(0)
@@ -1318,12 +2082,12 @@ type ProductRepositoryMock_UpdateMany_Call struct {
 //   - ctx context.Context
 //   - filter map[string]any
 //   - payload map[string]any
-//   - trx *gorm.DB
+//   - trx *database.Tx
 func (_e *ProductRepositoryMock_Expecter) UpdateMany(ctx interface{}, filter interface{}, payload interface{}, trx interface{}) *ProductRepositoryMock_UpdateMany_Call {
 	return &ProductRepositoryMock_UpdateMany_Call{Call: _e.mock.On("UpdateMany", ctx, filter, payload, trx)}
 }
 
-func (_c *ProductRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB)) *ProductRepositoryMock_UpdateMany_Call {
+func (_c *ProductRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx)) *ProductRepositoryMock_UpdateMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1337,9 +2101,9 @@ func (_c *ProductRepositoryMock_UpdateMany_Call) Run(run func(ctx context.Contex
 		if args[2] != nil {
 			arg2 = args[2].(map[string]any)
 		}
-		var arg3 *gorm.DB
+		var arg3 *database.Tx
 		if args[3] != nil {
-			arg3 = args[3].(*gorm.DB)
+			arg3 = args[3].(*database.Tx)
 		}
 		run(
 			arg0,
@@ -1356,7 +2120,165 @@ func (_c *ProductRepositoryMock_UpdateMany_Call) Return(err error) *ProductRepos
 	return _c
 }
 
-func (_c *ProductRepositoryMock_UpdateMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *gorm.DB) error) *ProductRepositoryMock_UpdateMany_Call {
+func (_c *ProductRepositoryMock_UpdateMany_Call) RunAndReturn(run func(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) error) *ProductRepositoryMock_UpdateMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) Upsert(ctx context.Context, model product.Product, opts database.UpsertOptions, trx *database.Tx) (product.Product, error) {
+	ret := _mock.Called(ctx, model, opts, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product, database.UpsertOptions, *database.Tx) (product.Product, error)); ok {
+		return returnFunc(ctx, model, opts, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product, database.UpsertOptions, *database.Tx) product.Product); ok {
+		r0 = returnFunc(ctx, model, opts, trx)
+	} else {
+		r0 = ret.Get(0).(product.Product)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.Product, database.UpsertOptions, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, model, opts, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type ProductRepositoryMock_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - model product.Product
+//   - opts database.UpsertOptions
+//   - trx *database.Tx
+func (_e *ProductRepositoryMock_Expecter) Upsert(ctx interface{}, model interface{}, opts interface{}, trx interface{}) *ProductRepositoryMock_Upsert_Call {
+	return &ProductRepositoryMock_Upsert_Call{Call: _e.mock.On("Upsert", ctx, model, opts, trx)}
+}
+
+func (_c *ProductRepositoryMock_Upsert_Call) Run(run func(ctx context.Context, model product.Product, opts database.UpsertOptions, trx *database.Tx)) *ProductRepositoryMock_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.Product
+		if args[1] != nil {
+			arg1 = args[1].(product.Product)
+		}
+		var arg2 database.UpsertOptions
+		if args[2] != nil {
+			arg2 = args[2].(database.UpsertOptions)
+		}
+		var arg3 *database.Tx
+		if args[3] != nil {
+			arg3 = args[3].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Upsert_Call) Return(product1 product.Product, err error) *ProductRepositoryMock_Upsert_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_Upsert_Call) RunAndReturn(run func(ctx context.Context, model product.Product, opts database.UpsertOptions, trx *database.Tx) (product.Product, error)) *ProductRepositoryMock_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertMany provides a mock function for the type ProductRepositoryMock
+func (_mock *ProductRepositoryMock) UpsertMany(ctx context.Context, models []product.Product, opts database.UpsertOptions, trx *database.Tx) ([]product.Product, error) {
+	ret := _mock.Called(ctx, models, opts, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertMany")
+	}
+
+	var r0 []product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []product.Product, database.UpsertOptions, *database.Tx) ([]product.Product, error)); ok {
+		return returnFunc(ctx, models, opts, trx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []product.Product, database.UpsertOptions, *database.Tx) []product.Product); ok {
+		r0 = returnFunc(ctx, models, opts, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []product.Product, database.UpsertOptions, *database.Tx) error); ok {
+		r1 = returnFunc(ctx, models, opts, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepositoryMock_UpsertMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertMany'
+type ProductRepositoryMock_UpsertMany_Call struct {
+	*mock.Call
+}
+
+// UpsertMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - models []product.Product
+//   - opts database.UpsertOptions
+//   - trx *database.Tx
+func (_e *ProductRepositoryMock_Expecter) UpsertMany(ctx interface{}, models interface{}, opts interface{}, trx interface{}) *ProductRepositoryMock_UpsertMany_Call {
+	return &ProductRepositoryMock_UpsertMany_Call{Call: _e.mock.On("UpsertMany", ctx, models, opts, trx)}
+}
+
+func (_c *ProductRepositoryMock_UpsertMany_Call) Run(run func(ctx context.Context, models []product.Product, opts database.UpsertOptions, trx *database.Tx)) *ProductRepositoryMock_UpsertMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []product.Product
+		if args[1] != nil {
+			arg1 = args[1].([]product.Product)
+		}
+		var arg2 database.UpsertOptions
+		if args[2] != nil {
+			arg2 = args[2].(database.UpsertOptions)
+		}
+		var arg3 *database.Tx
+		if args[3] != nil {
+			arg3 = args[3].(*database.Tx)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepositoryMock_UpsertMany_Call) Return(products []product.Product, err error) *ProductRepositoryMock_UpsertMany_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *ProductRepositoryMock_UpsertMany_Call) RunAndReturn(run func(ctx context.Context, models []product.Product, opts database.UpsertOptions, trx *database.Tx) ([]product.Product, error)) *ProductRepositoryMock_UpsertMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
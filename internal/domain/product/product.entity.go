@@ -7,6 +7,7 @@ import (
 
 type Product struct {
 	database.BaseEntity[uuid.UUID] `bson:",inline"`
+	database.TenantModel           `bson:",inline"`
 	Name                           string  `json:"name" bson:"name"`
 	Description                    string  `json:"description" bson:"description"`
 	Price                          float64 `json:"price" bson:"price"`
@@ -3,9 +3,8 @@ package product
 import (
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type ProductRepository interface {
-	database.BaseRepository[gorm.DB, uuid.UUID, Product]
+	database.BaseRepository[uuid.UUID, Product]
 }
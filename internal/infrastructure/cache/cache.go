@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"iter"
 	"time"
 )
 
@@ -16,5 +17,54 @@ type Cache interface {
 	IncrBy(ctx context.Context, key string, value int64) (int64, error)
 	DecrBy(ctx context.Context, key string, value int64) (int64, error)
 	Expire(ctx context.Context, key string, ttl time.Duration) error
+
+	// MGet returns the values of keys in order, nil for the missing ones.
+	MGet(ctx context.Context, keys ...string) ([]*CacheValue, error)
+	// MSet sets every value atomically, each expiring after ttl. A Redis
+	// Cluster sets them one by one instead.
+	MSet(ctx context.Context, values map[string]any, ttl time.Duration) error
+	// SetNX sets the value only if key does not exist, reporting whether it
+	// was set.
+	SetNX(ctx context.Context, key string, val any, ttl time.Duration) (bool, error)
+	GetDel(ctx context.Context, key string) (*CacheValue, error)
+	// Scan yields the keys matching a glob pattern, e.g. "session:*",
+	// without blocking the cache; keys changed meanwhile may be missed or
+	// yielded twice.
+	Scan(ctx context.Context, pattern string) iter.Seq2[string, error]
+
+	HashCache
+	SetCache
+	SortedSetCache
+
+	// Pipeline sends the commands queued by fn in a single round trip.
+	Pipeline(ctx context.Context, fn func(p Pipeliner) error) error
+	// TxPipeline runs the commands queued by fn atomically, in a MULTI
+	// transaction. On a Redis Cluster their keys must share a hash slot,
+	// e.g. through a hash tag as in "cart:{42}:items" and "cart:{42}:total".
+	TxPipeline(ctx context.Context, fn func(p Pipeliner) error) error
+
+	RateLimiter
+	Locker
 	Shutdown(ctx context.Context) error
 }
+
+// Stats counts how the entries of an in-process cache were served.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int64
+}
+
+// StatsReporter is implemented by caches keeping Stats.
+type StatsReporter interface {
+	Stats() Stats
+}
+
+// Invalidator broadcasts the keys changed by one instance to the others,
+// so they drop their in-process copies.
+type Invalidator interface {
+	PublishInvalidation(ctx context.Context, keys ...string) error
+	SubscribeInvalidations(ctx context.Context, handler func(keys []string)) error
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec serializes the values stored by Set and read by Get. Its ID is
+// written with every value, so values keep decoding after the codec of
+// their key changed.
+type Codec interface {
+	// ID identifies the codec within the marker byte, from 1 to 15.
+	ID() byte
+	Marshal(val any) ([]byte, error)
+	Unmarshal(data []byte, val any) error
+}
+
+var (
+	JSON    Codec = jsonCodec{}
+	Msgpack Codec = msgpackCodec{}
+	Gob     Codec = gobCodec{}
+)
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[byte]Codec{}
+)
+
+func init() {
+	for _, codec := range []Codec{JSON, Msgpack, Gob} {
+		RegisterCodec(codec)
+	}
+}
+
+// RegisterCodec makes the values encoded by codec decodable. It panics if
+// the ID is out of range or taken by another codec.
+func RegisterCodec(codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+
+	id := codec.ID()
+	if id == 0 || id > 0x0f {
+		panic(fmt.Sprintf("cache: codec ID %d out of range", id))
+	}
+	if registered, ok := codecs[id]; ok && registered != codec {
+		panic(fmt.Sprintf("cache: codec ID %d registered twice", id))
+	}
+
+	codecs[id] = codec
+}
+
+func codecOf(id byte) (Codec, error) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	codec, ok := codecs[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown cache codec %d", id)
+	}
+
+	return codec, nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ID() byte {
+	return 1
+}
+
+func (jsonCodec) Marshal(val any) ([]byte, error) {
+	return json.Marshal(val)
+}
+
+func (jsonCodec) Unmarshal(data []byte, val any) error {
+	return json.Unmarshal(data, val)
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) ID() byte {
+	return 2
+}
+
+func (msgpackCodec) Marshal(val any) ([]byte, error) {
+	return msgpack.Marshal(val)
+}
+
+func (msgpackCodec) Unmarshal(data []byte, val any) error {
+	return msgpack.Unmarshal(data, val)
+}
+
+type gobCodec struct{}
+
+func (gobCodec) ID() byte {
+	return 3
+}
+
+func (gobCodec) Marshal(val any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(val); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, val any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(val)
+}
@@ -0,0 +1,48 @@
+package cache
+
+import "context"
+
+// HashCache stores maps of fields under a key.
+type HashCache interface {
+	HGet(ctx context.Context, key string, field string) (*CacheValue, error)
+	HGetAll(ctx context.Context, key string) (map[string]CacheValue, error)
+	HSet(ctx context.Context, key string, values map[string]any) error
+	HDel(ctx context.Context, key string, fields ...string) error
+	HIncrBy(ctx context.Context, key string, field string, value int64) (int64, error)
+}
+
+// SetCache stores sets of unique members under a key, e.g. the active
+// sessions of a user or the keys tagged for invalidation.
+type SetCache interface {
+	SAdd(ctx context.Context, key string, members ...string) error
+	SRem(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	SIsMember(ctx context.Context, key string, member string) (bool, error)
+	SCard(ctx context.Context, key string) (int64, error)
+}
+
+// ZMember is a member of a sorted set with its score.
+type ZMember struct {
+	Member string
+	Score  float64
+}
+
+// SortedSetCache stores members ordered by score under a key, e.g. a
+// leaderboard. Members of equal score are ordered lexicographically.
+type SortedSetCache interface {
+	ZAdd(ctx context.Context, key string, members ...ZMember) error
+	ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error)
+	ZRem(ctx context.Context, key string, members ...string) error
+	// ZScore returns the score of member, nil when it is not in the set.
+	ZScore(ctx context.Context, key string, member string) (*float64, error)
+	// ZRange returns the members ranked start to stop by ascending score,
+	// both inclusive; negative ranks count from the end.
+	ZRange(ctx context.Context, key string, start int64, stop int64) ([]ZMember, error)
+	// ZRevRange returns the members ranked start to stop by descending
+	// score.
+	ZRevRange(ctx context.Context, key string, start int64, stop int64) ([]ZMember, error)
+	// ZRangeByScore returns the members scored between min and max, both
+	// inclusive, by ascending score.
+	ZRangeByScore(ctx context.Context, key string, min float64, max float64) ([]ZMember, error)
+	ZCard(ctx context.Context, key string) (int64, error)
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is how values larger than config.Cache.CompressionThreshold
+// bytes are compressed.
+type Compression string
+
+const (
+	NoCompression Compression = "none"
+	// Zstd compresses best, for large documents.
+	Zstd Compression = "zstd"
+	// Snappy compresses less but faster.
+	Snappy Compression = "snappy"
+)
+
+// id identifies the compression within the marker byte, from 0 to 3.
+func (c Compression) id() (byte, error) {
+	switch c {
+	case NoCompression, "":
+		return 0, nil
+	case Zstd:
+		return 1, nil
+	case Snappy:
+		return 2, nil
+	}
+
+	return 0, fmt.Errorf("unknown cache compression %q", c)
+}
+
+// The zstd encoder and decoder are safe for concurrent use with EncodeAll
+// and DecodeAll.
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil)
+	})
+)
+
+func compress(id byte, data []byte) ([]byte, error) {
+	switch id {
+	case 1:
+		enc, err := zstdEncoder()
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeAll(data, nil), nil
+	case 2:
+		return snappy.Encode(nil, data), nil
+	}
+
+	return data, nil
+}
+
+func decompress(id byte, data []byte) ([]byte, error) {
+	switch id {
+	case 0:
+		return data, nil
+	case 1:
+		dec, err := zstdDecoder()
+		if err != nil {
+			return nil, err
+		}
+		return dec.DecodeAll(data, nil)
+	case 2:
+		return snappy.Decode(nil, data)
+	}
+
+	return nil, fmt.Errorf("unknown cache compression %d", id)
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+)
+
+// The values encoded here start with a marker byte: its high bit is set,
+// the next 3 bits identify the compression and the low 4 bits the codec. A
+// byte with the high bit set cannot start a JSON document, so values
+// stored as plain JSON by Set(ctx, key, string(data), ttl) still decode.
+const (
+	markerBit        = 0x80
+	compressionShift = 4
+	compressionMask  = 0x07
+	codecMask        = 0x0f
+)
+
+// Encode marshals val with codec, compressed as configured by
+// config.Cache.Compression when larger than
+// config.Cache.CompressionThreshold bytes and compression pays off.
+func Encode(codec Codec, val any) ([]byte, error) {
+	data, err := codec.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	compression, err := Compression(config.Cache.Compression).id()
+	if err != nil {
+		return nil, err
+	}
+
+	if compression > 0 && len(data) > config.Cache.CompressionThreshold {
+		compressed, err := compress(compression, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(compressed) < len(data) {
+			data = compressed
+		} else {
+			compression = 0
+		}
+	} else {
+		compression = 0
+	}
+
+	return append([]byte{markerBit | compression<<compressionShift | codec.ID()}, data...), nil
+}
+
+// Decode unmarshals data encoded by Encode into val, whatever its codec
+// and compression, or data without a marker as JSON.
+func Decode(data []byte, val any) error {
+	if len(data) == 0 || data[0]&markerBit == 0 {
+		return json.Unmarshal(data, val)
+	}
+
+	marker := data[0]
+
+	codec, err := codecOf(marker & codecMask)
+	if err != nil {
+		return err
+	}
+
+	data, err = decompress(marker>>compressionShift&compressionMask, data[1:])
+	if err != nil {
+		return err
+	}
+
+	return codec.Unmarshal(data, val)
+}
+
+// Get returns the value of key decoded into a T, nil when it is missing.
+func Get[T any](ctx context.Context, c Cache, key string) (*T, error) {
+	val, err := c.Get(ctx, key)
+	if err != nil || val == nil {
+		return nil, err
+	}
+
+	var res T
+	if err := Decode(val.ToBytes(), &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Set stores val under key for ttl, encoded with codec.
+func Set[T any](ctx context.Context, c Cache, codec Codec, key string, val T, ttl time.Duration) error {
+	data, err := Encode(codec, val)
+	if err != nil {
+		return err
+	}
+
+	return c.Set(ctx, key, data, ttl)
+}
+
+// GetJSON returns the value of key decoded into a T, nil when it is
+// missing. It reads the values of every codec, as Get does.
+func GetJSON[T any](ctx context.Context, c Cache, key string) (*T, error) {
+	return Get[T](ctx, c, key)
+}
+
+// SetJSON stores val under key for ttl as JSON.
+func SetJSON[T any](ctx context.Context, c Cache, key string, val T, ttl time.Duration) error {
+	return Set(ctx, c, JSON, key, val, ttl)
+}
@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"crypto/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+type profile struct {
+	Name  string
+	Tags  []string
+	Score float64
+}
+
+// withCompression compresses the values beyond threshold bytes for the
+// duration of the test.
+func withCompression(t *testing.T, compression Compression, threshold int) {
+	t.Helper()
+
+	previous := config.Cache
+	t.Cleanup(func() {
+		config.Cache = previous
+	})
+
+	config.Cache.Compression = string(compression)
+	config.Cache.CompressionThreshold = threshold
+}
+
+// marker splits the marker byte of data.
+func marker(data []byte) (compression byte, codec byte) {
+	return data[0] >> compressionShift & compressionMask, data[0] & codecMask
+}
+
+func TestEncode_Decode(t *testing.T) {
+	small := profile{Name: "Acme", Tags: []string{"b2b"}, Score: 4.5}
+	large := profile{Name: "Acme", Tags: strings.Fields(strings.Repeat("retail wholesale ", 100)), Score: 4.5}
+
+	cases := []struct {
+		name        string
+		compression Compression
+		val         profile
+		wantID      byte
+	}{
+		{name: "uncompressed", compression: NoCompression, val: large},
+		{name: "below threshold", compression: Zstd, val: small},
+		{name: "zstd", compression: Zstd, val: large, wantID: 1},
+		{name: "snappy", compression: Snappy, val: large, wantID: 2},
+	}
+
+	named := map[string]Codec{"json": JSON, "msgpack": Msgpack, "gob": Gob}
+
+	for name, codec := range named {
+		for _, tc := range cases {
+			t.Run(name+"/"+tc.name, func(t *testing.T) {
+				withCompression(t, tc.compression, 256)
+
+				data, err := Encode(codec, tc.val)
+				require.NoError(t, err)
+
+				require.NotZero(t, data[0]&markerBit)
+				compression, id := marker(data)
+				assert.Equal(t, codec.ID(), id)
+				assert.Equal(t, tc.wantID, compression)
+
+				var res profile
+				require.NoError(t, Decode(data, &res))
+				assert.Equal(t, tc.val, res)
+			})
+		}
+	}
+}
+
+func TestEncode_Incompressible(t *testing.T) {
+	withCompression(t, Zstd, 16)
+
+	noise := make([]byte, 1024)
+	_, err := rand.Read(noise)
+	require.NoError(t, err)
+
+	data, err := Encode(Msgpack, noise)
+	require.NoError(t, err)
+
+	// Stored as is, since compression would not make it smaller.
+	compression, _ := marker(data)
+	assert.Zero(t, compression)
+
+	var res []byte
+	require.NoError(t, Decode(data, &res))
+	assert.Equal(t, noise, res)
+}
+
+func TestEncode_UnknownCompression(t *testing.T) {
+	withCompression(t, "lz4", 0)
+
+	_, err := Encode(JSON, profile{Name: "Acme"})
+
+	assert.EqualError(t, err, `unknown cache compression "lz4"`)
+}
+
+func TestDecode_PlainJSON(t *testing.T) {
+	// Values stored as JSON before the marker byte still decode.
+	var res profile
+	require.NoError(t, Decode([]byte(`{"Name":"Acme","Score":4.5}`), &res))
+
+	assert.Equal(t, profile{Name: "Acme", Score: 4.5}, res)
+}
+
+func TestDecode_UnknownMarker(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{name: "codec", data: []byte{markerBit | 0x0e, '{', '}'}, wantErr: "unknown cache codec 14"},
+		{name: "compression", data: []byte{markerBit | 3<<compressionShift | 1, '{', '}'}, wantErr: "unknown cache compression 3"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var res profile
+			assert.EqualError(t, Decode(tc.data, &res), tc.wantErr)
+		})
+	}
+}
+
+type reversedCodec struct {
+	id byte
+}
+
+func (c reversedCodec) ID() byte {
+	return c.id
+}
+
+func (reversedCodec) Marshal(val any) ([]byte, error) {
+	return []byte(reverse(val.(string))), nil
+}
+
+func (reversedCodec) Unmarshal(data []byte, val any) error {
+	*val.(*string) = reverse(string(data))
+	return nil
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+
+	return string(runes)
+}
+
+func TestRegisterCodec(t *testing.T) {
+	codec := reversedCodec{id: 0x0f}
+	RegisterCodec(codec)
+	t.Cleanup(func() {
+		codecsMu.Lock()
+		delete(codecs, codec.id)
+		codecsMu.Unlock()
+	})
+
+	// Registering it again is harmless.
+	assert.NotPanics(t, func() { RegisterCodec(codec) })
+
+	data, err := Encode(codec, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "olleh", string(data[1:]))
+
+	var res string
+	require.NoError(t, Decode(data, &res))
+	assert.Equal(t, "hello", res)
+}
+
+func TestRegisterCodec_Invalid(t *testing.T) {
+	cases := []struct {
+		name  string
+		codec Codec
+	}{
+		{name: "zero", codec: reversedCodec{id: 0}},
+		{name: "out of range", codec: reversedCodec{id: 0x10}},
+		{name: "taken", codec: reversedCodec{id: JSON.ID()}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Panics(t, func() { RegisterCodec(tc.codec) })
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Algorithm is how a rate limit counts requests.
+type Algorithm string
+
+const (
+	// SlidingLog records every request of the window; exact, but memory
+	// grows with the limit.
+	SlidingLog Algorithm = "sliding_log"
+	// SlidingWindow weighs the count of the previous fixed window by how
+	// much of it still overlaps the sliding one.
+	SlidingWindow Algorithm = "sliding_window"
+	// TokenBucket refills Burst tokens at Rate per Period, one spent per
+	// request.
+	TokenBucket Algorithm = "token_bucket"
+	// GCRA spaces requests Period/Rate apart, letting Burst of them through
+	// back to back; it stores a single timestamp per key.
+	GCRA Algorithm = "gcra"
+	// FixedWindow counts requests until the key expires a Period after the
+	// first one; with a key per calendar day or month it makes a quota.
+	// Refused requests are not counted.
+	FixedWindow Algorithm = "fixed_window"
+)
+
+// Limit allows Rate requests per Period.
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	// Burst is the capacity of the token bucket and GCRA, Rate when zero.
+	Burst     int
+	Algorithm Algorithm
+}
+
+// Capacity returns how many requests may go through back to back.
+func (l Limit) Capacity() int {
+	if l.Burst > 0 && (l.Algorithm == TokenBucket || l.Algorithm == GCRA) {
+		return l.Burst
+	}
+
+	return l.Rate
+}
+
+// LimitResult is the outcome of a rate limited request.
+type LimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long to wait before the next request is allowed,
+	// zero when allowed.
+	RetryAfter time.Duration
+	// ResetAfter is how long until the limit is fully available again.
+	ResetAfter time.Duration
+}
+
+// RateLimiter counts requests against a limit atomically, so concurrent
+// requests cannot race past it.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (*LimitResult, error)
+	// Refund gives back a request allowed under limit, e.g. once another
+	// limit refused it.
+	Refund(ctx context.Context, key string, limit Limit) error
+}
@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Locker holds keys on behalf of the owner of a token, atomically, so
+// instances can exclude each other. Use it through the lock package.
+type Locker interface {
+	// Acquire sets key to token for ttl if no one holds it, returning the
+	// fencing token of the hold, which grows with every hold of key, or
+	// zero when key is held.
+	Acquire(ctx context.Context, key string, token string, ttl time.Duration) (int64, error)
+	// Extend resets the expiry of key to ttl if token still holds it,
+	// reporting whether it does.
+	Extend(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
+	// Release deletes key if token still holds it, reporting whether it
+	// did.
+	Release(ctx context.Context, key string, token string) (bool, error)
+}
+
+// Local is implemented by caches kept within the process, whose locks only
+// exclude the holders of the same instance.
+type Local interface {
+	Local() bool
+}
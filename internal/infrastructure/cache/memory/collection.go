@@ -0,0 +1,396 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+)
+
+type (
+	hash      map[string]string
+	set       map[string]struct{}
+	sortedSet map[string]float64
+)
+
+func (c *memoryCache) HGet(ctx context.Context, key string, field string) (*cache.CacheValue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, _, err := lookupAs[hash](c, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	str, ok := h[field]
+	if !ok {
+		return nil, nil
+	}
+
+	val := cache.CacheValue(str)
+	return &val, nil
+}
+
+func (c *memoryCache) HGetAll(ctx context.Context, key string) (map[string]cache.CacheValue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, _, err := lookupAs[hash](c, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]cache.CacheValue, len(h))
+	for field, val := range h {
+		res[field] = cache.CacheValue(val)
+	}
+
+	return res, nil
+}
+
+func (c *memoryCache) HSet(ctx context.Context, key string, values map[string]any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hSet(key, values)
+}
+
+func (c *memoryCache) HDel(ctx context.Context, key string, fields ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, e, err := lookupAs[hash](c, key, nil)
+	if err != nil || e == nil {
+		return err
+	}
+
+	for _, field := range fields {
+		delete(h, field)
+	}
+	c.changed(e, len(h))
+
+	return nil
+}
+
+func (c *memoryCache) HIncrBy(ctx context.Context, key string, field string, value int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hIncrBy(key, field, value)
+}
+
+func (c *memoryCache) SAdd(ctx context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sAdd(key, members...)
+}
+
+func (c *memoryCache) SRem(ctx context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sRem(key, members...)
+}
+
+func (c *memoryCache) SMembers(ctx context.Context, key string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, _, err := lookupAs[set](c, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Collect(maps.Keys(s)), nil
+}
+
+func (c *memoryCache) SIsMember(ctx context.Context, key string, member string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, _, err := lookupAs[set](c, key, nil)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := s[member]
+	return ok, nil
+}
+
+func (c *memoryCache) SCard(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, _, err := lookupAs[set](c, key, nil)
+	return int64(len(s)), err
+}
+
+func (c *memoryCache) ZAdd(ctx context.Context, key string, members ...cache.ZMember) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.zAdd(key, members...)
+}
+
+func (c *memoryCache) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.zIncrBy(key, increment, member)
+}
+
+func (c *memoryCache) ZRem(ctx context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.zRem(key, members...)
+}
+
+func (c *memoryCache) ZScore(ctx context.Context, key string, member string) (*float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	z, _, err := lookupAs[sortedSet](c, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	score, ok := z[member]
+	if !ok {
+		return nil, nil
+	}
+
+	return &score, nil
+}
+
+func (c *memoryCache) ZRange(ctx context.Context, key string, start int64, stop int64) ([]cache.ZMember, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	z, _, err := lookupAs[sortedSet](c, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return between(z.sorted(), start, stop), nil
+}
+
+func (c *memoryCache) ZRevRange(ctx context.Context, key string, start int64, stop int64) ([]cache.ZMember, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	z, _, err := lookupAs[sortedSet](c, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	members := z.sorted()
+	slices.Reverse(members)
+
+	return between(members, start, stop), nil
+}
+
+func (c *memoryCache) ZRangeByScore(ctx context.Context, key string, min float64, max float64) ([]cache.ZMember, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	z, _, err := lookupAs[sortedSet](c, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(z.sorted(), func(m cache.ZMember) bool {
+		return m.Score < min || m.Score > max
+	}), nil
+}
+
+func (c *memoryCache) ZCard(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	z, _, err := lookupAs[sortedSet](c, key, nil)
+	return int64(len(z)), err
+}
+
+func (c *memoryCache) hSet(key string, values map[string]any) error {
+	strs := make(hash, len(values))
+	for field, val := range values {
+		str, err := format(val)
+		if err != nil {
+			return err
+		}
+		strs[field] = str
+	}
+
+	h, e, err := lookupAs(c, key, func() hash { return hash{} })
+	if err != nil {
+		return err
+	}
+
+	maps.Copy(h, strs)
+	c.changed(e, len(h))
+
+	return nil
+}
+
+func (c *memoryCache) hIncrBy(key string, field string, value int64) (int64, error) {
+	h, e, err := lookupAs(c, key, func() hash { return hash{} })
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	if str, ok := h[field]; ok {
+		if n, err = strconv.ParseInt(str, 10, 64); err != nil {
+			return 0, errNotInteger
+		}
+	}
+
+	n += value
+	h[field] = strconv.FormatInt(n, 10)
+	c.changed(e, len(h))
+
+	return n, nil
+}
+
+func (c *memoryCache) sAdd(key string, members ...string) error {
+	s, e, err := lookupAs(c, key, func() set { return set{} })
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		s[member] = struct{}{}
+	}
+	c.changed(e, len(s))
+
+	return nil
+}
+
+func (c *memoryCache) sRem(key string, members ...string) error {
+	s, e, err := lookupAs[set](c, key, nil)
+	if err != nil || e == nil {
+		return err
+	}
+
+	for _, member := range members {
+		delete(s, member)
+	}
+	c.changed(e, len(s))
+
+	return nil
+}
+
+func (c *memoryCache) zAdd(key string, members ...cache.ZMember) error {
+	z, e, err := lookupAs(c, key, func() sortedSet { return sortedSet{} })
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		z[member.Member] = member.Score
+	}
+	c.changed(e, len(z))
+
+	return nil
+}
+
+func (c *memoryCache) zIncrBy(key string, increment float64, member string) (float64, error) {
+	z, e, err := lookupAs(c, key, func() sortedSet { return sortedSet{} })
+	if err != nil {
+		return 0, err
+	}
+
+	z[member] += increment
+	c.changed(e, len(z))
+
+	return z[member], nil
+}
+
+func (c *memoryCache) zRem(key string, members ...string) error {
+	z, e, err := lookupAs[sortedSet](c, key, nil)
+	if err != nil || e == nil {
+		return err
+	}
+
+	for _, member := range members {
+		delete(z, member)
+	}
+	c.changed(e, len(z))
+
+	return nil
+}
+
+// lookupAs returns the collection of key and its entry, which create
+// makes when the key does not exist; a nil create returns the zero
+// collection and a nil entry instead.
+func lookupAs[T hash | set | sortedSet](c *memoryCache, key string, create func() T) (T, *entry, error) {
+	e := c.lookup(key, time.Now())
+	if e == nil {
+		if create == nil {
+			return nil, nil, nil
+		}
+
+		data := create()
+		return data, c.store(key, data, time.Time{}), nil
+	}
+
+	data, ok := e.data.(T)
+	if !ok {
+		return nil, nil, errWrongType
+	}
+
+	return data, e, nil
+}
+
+// changed accounts for a collection now holding n elements, removing it
+// once empty as Redis does.
+func (c *memoryCache) changed(e *entry, n int) {
+	if n == 0 {
+		c.del(e.key)
+		return
+	}
+
+	c.resize(e)
+}
+
+// sorted returns the members by score, then lexicographically.
+func (z sortedSet) sorted() []cache.ZMember {
+	members := make([]cache.ZMember, 0, len(z))
+	for member, score := range z {
+		members = append(members, cache.ZMember{Member: member, Score: score})
+	}
+
+	slices.SortFunc(members, func(a, b cache.ZMember) int {
+		if n := cmp.Compare(a.Score, b.Score); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Member, b.Member)
+	})
+
+	return members
+}
+
+// between returns members[start:stop+1], counting negative indexes from
+// the end, as ZRANGE does.
+func between(members []cache.ZMember, start int64, stop int64) []cache.ZMember {
+	n := int64(len(members))
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if stop < 0 {
+		stop = n + stop
+	}
+	stop = min(stop, n-1)
+
+	if start > stop {
+		return []cache.ZMember{}
+	}
+
+	return members[start : stop+1]
+}
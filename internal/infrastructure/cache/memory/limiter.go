@@ -0,0 +1,217 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+)
+
+// The limiters count as the Redis scripts do, against the clock of the
+// process, which is enough for a single instance.
+
+type slidingLog struct {
+	requests []time.Time
+}
+
+type slidingWindow struct {
+	index    int64
+	current  int
+	previous int
+}
+
+type tokenBucket struct {
+	tokens float64
+	ts     time.Time
+}
+
+type gcra struct {
+	tat time.Time
+}
+
+type fixedWindow struct {
+	count int
+}
+
+func (c *memoryCache) Allow(ctx context.Context, key string, limit cache.Limit) (*cache.LimitResult, error) {
+	if limit.Rate <= 0 || limit.Period <= 0 {
+		return nil, fmt.Errorf("invalid rate limit of %d per %v", limit.Rate, limit.Period)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	var state any
+	var expiresAt time.Time
+	if e := c.lookup(key, now); e != nil {
+		state, expiresAt = e.data, e.expiresAt
+	}
+
+	capacity := limit.Capacity()
+	res := &cache.LimitResult{Limit: capacity}
+
+	switch limit.Algorithm {
+	case cache.SlidingLog:
+		s, _ := state.(*slidingLog)
+		if s == nil {
+			s = &slidingLog{}
+		}
+
+		from := now.Add(-limit.Period)
+		for len(s.requests) > 0 && !s.requests[0].After(from) {
+			s.requests = s.requests[1:]
+		}
+
+		if len(s.requests) < limit.Rate {
+			s.requests = append(s.requests, now)
+			res.Allowed = true
+			res.Remaining = limit.Rate - len(s.requests)
+		}
+		res.ResetAfter = s.requests[0].Add(limit.Period).Sub(now)
+		if !res.Allowed {
+			res.RetryAfter = res.ResetAfter
+		}
+
+		state, expiresAt = s, now.Add(limit.Period)
+
+	case cache.SlidingWindow:
+		s, _ := state.(*slidingWindow)
+		index := now.UnixMicro() / limit.Period.Microseconds()
+		if s == nil || index > s.index+1 {
+			s = &slidingWindow{index: index}
+		} else if index == s.index+1 {
+			s = &slidingWindow{index: index, previous: s.current}
+		}
+
+		elapsed := time.Duration(now.UnixMicro()-index*limit.Period.Microseconds()) * time.Microsecond
+		weight := float64(limit.Period-elapsed) / float64(limit.Period)
+		estimate := float64(s.previous)*weight + float64(s.current)
+
+		res.ResetAfter = limit.Period - elapsed
+		if s.previous > 0 {
+			res.ResetAfter += limit.Period
+		}
+
+		if estimate+1 > float64(limit.Rate) {
+			res.RetryAfter = limit.Period - elapsed
+			if s.previous > 0 && s.current+1 <= limit.Rate {
+				res.RetryAfter = time.Duration(math.Ceil(float64(limit.Period)*(1-float64(limit.Rate-s.current-1)/float64(s.previous)))) - elapsed
+			}
+		} else {
+			s.current++
+			res.Allowed = true
+			res.Remaining = int(math.Floor(float64(limit.Rate) - estimate - 1))
+		}
+
+		state, expiresAt = s, now.Add(2*limit.Period)
+
+	case cache.TokenBucket:
+		s, _ := state.(*tokenBucket)
+		if s == nil {
+			s = &tokenBucket{tokens: float64(capacity), ts: now}
+		}
+
+		rate := float64(limit.Rate) / float64(limit.Period)
+		s.tokens = math.Min(float64(capacity), s.tokens+float64(max(now.Sub(s.ts), 0))*rate)
+		s.ts = now
+
+		if s.tokens >= 1 {
+			s.tokens--
+			res.Allowed = true
+		} else {
+			res.RetryAfter = time.Duration(math.Ceil((1 - s.tokens) / rate))
+		}
+		res.Remaining = int(math.Floor(s.tokens))
+		res.ResetAfter = time.Duration(math.Ceil((float64(capacity) - s.tokens) / rate))
+
+		state, expiresAt = s, now.Add(time.Duration(float64(capacity)/rate)+time.Second)
+
+	case cache.GCRA:
+		s, _ := state.(*gcra)
+		if s == nil || s.tat.Before(now) {
+			s = &gcra{tat: now}
+		}
+
+		emission := limit.Period / time.Duration(limit.Rate)
+		newTat := s.tat.Add(emission)
+		allowAt := newTat.Add(-emission * time.Duration(capacity))
+
+		if now.Before(allowAt) {
+			res.RetryAfter = allowAt.Sub(now)
+			res.ResetAfter = s.tat.Sub(now)
+		} else {
+			s.tat = newTat
+			res.Allowed = true
+			res.Remaining = int(now.Sub(allowAt) / emission)
+			res.ResetAfter = newTat.Sub(now)
+		}
+
+		state, expiresAt = s, s.tat.Add(time.Millisecond)
+
+	case cache.FixedWindow:
+		s, _ := state.(*fixedWindow)
+		if s == nil {
+			s = &fixedWindow{}
+			expiresAt = now.Add(limit.Period)
+		}
+
+		res.ResetAfter = expiresAt.Sub(now)
+		if s.count >= limit.Rate {
+			res.RetryAfter = res.ResetAfter
+		} else {
+			s.count++
+			res.Allowed = true
+			res.Remaining = limit.Rate - s.count
+		}
+
+		state = s
+
+	default:
+		return nil, fmt.Errorf("unknown rate limit algorithm %q", limit.Algorithm)
+	}
+
+	c.store(key, state, expiresAt)
+
+	return res, nil
+}
+
+func (c *memoryCache) Refund(ctx context.Context, key string, limit cache.Limit) error {
+	if limit.Rate <= 0 || limit.Period <= 0 {
+		return fmt.Errorf("invalid rate limit of %d per %v", limit.Rate, limit.Period)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	e := c.lookup(key, now)
+	if e == nil {
+		return nil
+	}
+
+	switch s := e.data.(type) {
+	case *slidingLog:
+		if len(s.requests) > 0 {
+			s.requests = s.requests[:len(s.requests)-1]
+		}
+	case *slidingWindow:
+		if s.current > 0 {
+			s.current--
+		}
+	case *tokenBucket:
+		s.tokens = math.Min(float64(limit.Capacity()), s.tokens+1)
+	case *gcra:
+		s.tat = s.tat.Add(-limit.Period / time.Duration(limit.Rate))
+	case *fixedWindow:
+		if s.count > 0 {
+			s.count--
+		}
+	}
+
+	return nil
+}
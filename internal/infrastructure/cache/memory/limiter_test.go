@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+var algorithms = []cache.Algorithm{cache.SlidingLog, cache.SlidingWindow, cache.TokenBucket, cache.GCRA, cache.FixedWindow}
+
+func TestMemoryCache_Refund(t *testing.T) {
+	ctx := context.Background()
+
+	for _, algorithm := range algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			c := NewClient(ctx)
+			limit := cache.Limit{Rate: 2, Period: time.Minute, Algorithm: algorithm}
+
+			for range 2 {
+				res, err := c.Allow(ctx, "key", limit)
+				assert.NoError(t, err)
+				assert.True(t, res.Allowed)
+			}
+
+			res, err := c.Allow(ctx, "key", limit)
+			assert.NoError(t, err)
+			assert.False(t, res.Allowed)
+
+			assert.NoError(t, c.Refund(ctx, "key", limit))
+
+			res, err = c.Allow(ctx, "key", limit)
+			assert.NoError(t, err)
+			assert.True(t, res.Allowed)
+
+			res, err = c.Allow(ctx, "key", limit)
+			assert.NoError(t, err)
+			assert.False(t, res.Allowed)
+		})
+	}
+}
+
+func TestMemoryCache_Refund_Missing(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient(ctx)
+	limit := cache.Limit{Rate: 1, Period: time.Minute, Algorithm: cache.FixedWindow}
+
+	assert.NoError(t, c.Refund(ctx, "key", limit))
+
+	res, err := c.Allow(ctx, "key", limit)
+	assert.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+}
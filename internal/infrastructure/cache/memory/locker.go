@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"time"
+)
+
+// Locks are held as strings, as in Redis, with the fencing token counted
+// under fenceKey.
+
+func (c *memoryCache) Acquire(ctx context.Context, key string, token string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lookup(key, time.Now()) != nil {
+		return 0, nil
+	}
+
+	c.store(key, token, expiry(ttl))
+
+	return c.incrBy(fenceKey(key), 1)
+}
+
+func (c *memoryCache) Extend(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.lookup(key, time.Now())
+	if e == nil || e.data != token {
+		return false, nil
+	}
+
+	e.expiresAt = expiry(ttl)
+
+	return true, nil
+}
+
+func (c *memoryCache) Release(ctx context.Context, key string, token string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.lookup(key, time.Now())
+	if e == nil || e.data != token {
+		return false, nil
+	}
+
+	c.del(key)
+
+	return true, nil
+}
+
+// Local reports that the locks are held within the process only, so they
+// do not exclude the other instances.
+func (c *memoryCache) Local() bool {
+	return true
+}
+
+func fenceKey(key string) string {
+	return key + ":fence"
+}
@@ -0,0 +1,514 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"iter"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+)
+
+var (
+	errNotInteger = errors.New("value is not an integer or out of range")
+	errWrongType  = errors.New("WRONGTYPE operation against a key holding the wrong kind of value")
+)
+
+// sweepInterval is how often expired entries are removed, besides when
+// they are read.
+const sweepInterval = time.Minute
+
+// entry holds a string, a hash (map[string]string), a set
+// (map[string]struct{}), a sorted set (map[string]float64) or the state of
+// a rate limiter.
+type entry struct {
+	key       string
+	data      any
+	expiresAt time.Time
+	size      int64
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// memoryCache is a cache.Cache within the process, which evicts the least
+// recently used entries beyond config.Cache.L1MaxEntries entries or
+// config.Cache.L1MaxBytes bytes, zero for no limit. It serves as the L1 of
+// the tiered cache, or alone with the memory driver, and behaves as
+// Redis does for the commands it supports, so tests can run against it.
+type memoryCache struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+
+	stop chan struct{}
+	once sync.Once
+}
+
+func NewClient(ctx context.Context) cache.Cache {
+	c := &memoryCache{
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+		maxEntries: config.Cache.L1MaxEntries,
+		maxBytes:   config.Cache.L1MaxBytes,
+		stop:       make(chan struct{}),
+	}
+
+	go c.sweep(ctx)
+
+	return c
+}
+
+func (c *memoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) (*cache.CacheValue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.get(key)
+}
+
+func (c *memoryCache) Set(ctx context.Context, key string, val any, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.set(key, val, ttl)
+}
+
+// TTL returns -2 when the key does not exist and -1 when it does not
+// expire, as Redis does.
+func (c *memoryCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	e := c.lookup(key, now)
+	if e == nil {
+		return -2, nil
+	}
+	if e.expiresAt.IsZero() {
+		return -1, nil
+	}
+
+	return e.expiresAt.Sub(now), nil
+}
+
+func (c *memoryCache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.del(keys...)
+
+	return nil
+}
+
+func (c *memoryCache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.IncrBy(ctx, key, 1)
+}
+
+func (c *memoryCache) Decr(ctx context.Context, key string) (int64, error) {
+	return c.IncrBy(ctx, key, -1)
+}
+
+func (c *memoryCache) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.incrBy(key, value)
+}
+
+func (c *memoryCache) DecrBy(ctx context.Context, key string, value int64) (int64, error) {
+	return c.IncrBy(ctx, key, -value)
+}
+
+// Expire sets the expiry of a key that has none, as Redis EXPIRE NX does.
+func (c *memoryCache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(key, ttl)
+
+	return nil
+}
+
+func (c *memoryCache) MGet(ctx context.Context, keys ...string) ([]*cache.CacheValue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make([]*cache.CacheValue, len(keys))
+	for i, key := range keys {
+		// MGET answers nil for keys of another type rather than failing.
+		res[i], _ = c.get(key)
+	}
+
+	return res, nil
+}
+
+func (c *memoryCache) MSet(ctx context.Context, values map[string]any, ttl time.Duration) error {
+	strs := make(map[string]string, len(values))
+	for key, val := range values {
+		str, err := format(val)
+		if err != nil {
+			return err
+		}
+		strs[key] = str
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, str := range strs {
+		c.store(key, str, expiry(ttl))
+	}
+
+	return nil
+}
+
+func (c *memoryCache) SetNX(ctx context.Context, key string, val any, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.setNX(key, val, ttl)
+}
+
+func (c *memoryCache) GetDel(ctx context.Context, key string) (*cache.CacheValue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	val, err := c.get(key)
+	if err != nil || val == nil {
+		return val, err
+	}
+
+	c.del(key)
+
+	return val, nil
+}
+
+func (c *memoryCache) Scan(ctx context.Context, pattern string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		match, err := glob(pattern)
+		if err != nil {
+			yield("", err)
+			return
+		}
+
+		c.mu.Lock()
+		now := time.Now()
+		var keys []string
+		for key, el := range c.entries {
+			if !el.Value.(*entry).expired(now) && match.MatchString(key) {
+				keys = append(keys, key)
+			}
+		}
+		c.mu.Unlock()
+
+		for _, key := range keys {
+			if !yield(key, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (c *memoryCache) Stats() cache.Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return cache.Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   c.lru.Len(),
+		Bytes:     c.bytes,
+	}
+}
+
+func (c *memoryCache) Shutdown(ctx context.Context) error {
+	c.once.Do(func() {
+		close(c.stop)
+	})
+
+	return nil
+}
+
+// The commands below expect c.mu to be held, so pipelines can run several
+// of them atomically.
+
+func (c *memoryCache) get(key string) (*cache.CacheValue, error) {
+	e := c.lookup(key, time.Now())
+	if e == nil {
+		c.misses++
+		return nil, nil
+	}
+
+	str, ok := e.data.(string)
+	if !ok {
+		c.misses++
+		return nil, errWrongType
+	}
+
+	c.hits++
+
+	val := cache.CacheValue(str)
+	return &val, nil
+}
+
+func (c *memoryCache) set(key string, val any, ttl time.Duration) error {
+	str, err := format(val)
+	if err != nil {
+		return err
+	}
+
+	c.store(key, str, expiry(ttl))
+
+	return nil
+}
+
+func (c *memoryCache) setNX(key string, val any, ttl time.Duration) (bool, error) {
+	if c.lookup(key, time.Now()) != nil {
+		return false, nil
+	}
+
+	if err := c.set(key, val, ttl); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *memoryCache) del(keys ...string) {
+	for _, key := range keys {
+		if el, ok := c.entries[key]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+func (c *memoryCache) incrBy(key string, value int64) (int64, error) {
+	var n int64
+	var expiresAt time.Time
+
+	if e := c.lookup(key, time.Now()); e != nil {
+		str, ok := e.data.(string)
+		if !ok {
+			return 0, errWrongType
+		}
+
+		var err error
+		n, err = strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return 0, errNotInteger
+		}
+		expiresAt = e.expiresAt
+	}
+
+	n += value
+	c.store(key, strconv.FormatInt(n, 10), expiresAt)
+
+	return n, nil
+}
+
+func (c *memoryCache) expire(key string, ttl time.Duration) {
+	if e := c.lookup(key, time.Now()); e != nil && e.expiresAt.IsZero() {
+		e.expiresAt = time.Now().Add(ttl)
+	}
+}
+
+// lookup returns the live entry of key, marking it as recently used.
+func (c *memoryCache) lookup(key string, now time.Time) *entry {
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	e := el.Value.(*entry)
+	if e.expired(now) {
+		c.remove(el)
+		return nil
+	}
+
+	c.lru.MoveToFront(el)
+
+	return e
+}
+
+// store replaces the entry of key.
+func (c *memoryCache) store(key string, data any, expiresAt time.Time) *entry {
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+
+	e := &entry{
+		key:       key,
+		data:      data,
+		expiresAt: expiresAt,
+	}
+
+	c.entries[key] = c.lru.PushFront(e)
+	c.resize(e)
+
+	return e
+}
+
+// resize accounts for the size of e after its data changed, evicting the
+// least recently used entries beyond the limits.
+func (c *memoryCache) resize(e *entry) {
+	size := int64(len(e.key) + 64)
+	switch data := e.data.(type) {
+	case string:
+		size += int64(len(data))
+	case map[string]string:
+		for field, val := range data {
+			size += int64(len(field) + len(val) + 16)
+		}
+	case map[string]struct{}:
+		for member := range data {
+			size += int64(len(member) + 16)
+		}
+	case map[string]float64:
+		for member := range data {
+			size += int64(len(member) + 24)
+		}
+	case *slidingLog:
+		size += int64(len(data.requests) * 24)
+	}
+
+	c.bytes += size - e.size
+	e.size = size
+
+	for c.lru.Len() > 1 && ((c.maxEntries > 0 && c.lru.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes)) {
+		c.remove(c.lru.Back())
+		c.evictions++
+	}
+}
+
+func (c *memoryCache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*entry)
+	delete(c.entries, e.key)
+	c.bytes -= e.size
+}
+
+func (c *memoryCache) sweep(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			c.mu.Lock()
+			for el := c.lru.Back(); el != nil; {
+				prev := el.Prev()
+				if el.Value.(*entry).expired(now) {
+					c.remove(el)
+				}
+				el = prev
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(ttl)
+}
+
+// format writes val the way Redis stores it.
+func format(val any) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return strconv.FormatInt(v.Nanoseconds(), 10), nil
+	case encoding.BinaryMarshaler:
+		b, err := v.MarshalBinary()
+		return string(b), err
+	}
+
+	return "", fmt.Errorf("can't marshal %T (implement encoding.BinaryMarshaler)", val)
+}
+
+// glob compiles a Redis glob pattern: * and ? match any characters, [...]
+// a class of them and \ escapes the next character.
+func glob(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			b.WriteString("(?s:.*)")
+		case '?':
+			b.WriteString("(?s:.)")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "^") {
+				class = "^" + regexp.QuoteMeta(class[1:])
+			} else {
+				class = regexp.QuoteMeta(class)
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\-`, "-") + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ctx = context.Background()
+
+// newTestClient returns a cache shut down with the test.
+func newTestClient(t *testing.T) cache.Cache {
+	t.Helper()
+
+	c := NewClient(ctx)
+	t.Cleanup(func() {
+		c.Shutdown(ctx)
+	})
+
+	return c
+}
+
+func TestMemoryCache_MSet(t *testing.T) {
+	c := newTestClient(t)
+
+	require.NoError(t, c.MSet(ctx, map[string]any{"a": 1, "b": "two"}, time.Minute))
+
+	res, err := c.MGet(ctx, "a", "missing", "b")
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.Equal(t, 1, res[0].ToInt())
+	assert.Nil(t, res[1])
+	assert.Equal(t, "two", res[2].ToString())
+
+	ttl, err := c.TTL(ctx, "b")
+	require.NoError(t, err)
+	assert.Positive(t, ttl)
+}
+
+func TestMemoryCache_SetNX(t *testing.T) {
+	c := newTestClient(t)
+
+	set, err := c.SetNX(ctx, "greeting", "hello", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	set, err = c.SetNX(ctx, "greeting", "bonjour", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	res, err := c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.ToString())
+}
+
+func TestMemoryCache_GetDel(t *testing.T) {
+	c := newTestClient(t)
+
+	require.NoError(t, c.Set(ctx, "greeting", "hello", time.Minute))
+
+	res, err := c.GetDel(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.ToString())
+
+	res, err = c.GetDel(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestMemoryCache_Scan(t *testing.T) {
+	c := newTestClient(t)
+
+	for _, key := range []string{"session:1", "session:2", "user:1"} {
+		require.NoError(t, c.Set(ctx, key, "x", time.Minute))
+	}
+
+	var keys []string
+	for key, err := range c.Scan(ctx, "session:*") {
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	assert.ElementsMatch(t, []string{"session:1", "session:2"}, keys)
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	c := newTestClient(t)
+
+	require.NoError(t, c.Set(ctx, "greeting", "hello", 20*time.Millisecond))
+	require.NoError(t, c.HSet(ctx, "account", map[string]any{"name": "Acme"}))
+	require.NoError(t, c.Expire(ctx, "account", 20*time.Millisecond))
+
+	time.Sleep(30 * time.Millisecond)
+
+	res, err := c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	all, err := c.HGetAll(ctx, "account")
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	for key, err := range c.Scan(ctx, "*") {
+		require.NoError(t, err)
+		assert.Fail(t, "expired key scanned", key)
+	}
+}
+
+func TestMemoryCache_Evicts(t *testing.T) {
+	previous := config.Cache.L1MaxEntries
+	t.Cleanup(func() {
+		config.Cache.L1MaxEntries = previous
+	})
+	config.Cache.L1MaxEntries = 2
+
+	c := newTestClient(t)
+
+	require.NoError(t, c.Set(ctx, "a", 1, 0))
+	require.NoError(t, c.Set(ctx, "b", 2, 0))
+	_, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, c.Set(ctx, "c", 3, 0))
+
+	// b was the least recently used.
+	res, err := c.MGet(ctx, "a", "b", "c")
+	require.NoError(t, err)
+	assert.Equal(t, 1, res[0].ToInt())
+	assert.Nil(t, res[1])
+	assert.Equal(t, 3, res[2].ToInt())
+	assert.Equal(t, uint64(1), c.(cache.StatsReporter).Stats().Evictions)
+}
+
+func TestMemoryCache_Set_Codecs(t *testing.T) {
+	previous := config.Cache
+	t.Cleanup(func() {
+		config.Cache = previous
+	})
+	config.Cache.Compression = string(cache.Zstd)
+	config.Cache.CompressionThreshold = 64
+
+	type profile struct {
+		Name string
+		Bio  string
+	}
+	val := profile{Name: "Acme", Bio: strings.Repeat("wholesale ", 50)}
+
+	c := newTestClient(t)
+
+	for _, codec := range []cache.Codec{cache.JSON, cache.Msgpack, cache.Gob} {
+		require.NoError(t, cache.Set(ctx, c, codec, "profile", val, time.Minute))
+
+		// The marker byte and compressed payload are kept byte for byte.
+		res, err := cache.Get[profile](ctx, c, "profile")
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, val, *res)
+	}
+
+	require.NoError(t, c.Set(ctx, "legacy", `{"Name":"Acme"}`, time.Minute))
+
+	res, err := cache.GetJSON[profile](ctx, c, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, &profile{Name: "Acme"}, res)
+
+	res, err = cache.GetJSON[profile](ctx, c, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
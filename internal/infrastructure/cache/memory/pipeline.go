@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+)
+
+// pipeliner queues commands to run at once under the lock of the cache, so
+// that pipelines are always atomic here.
+type pipeliner struct {
+	c   *memoryCache
+	ops []func() error
+}
+
+func (c *memoryCache) Pipeline(ctx context.Context, fn func(p cache.Pipeliner) error) error {
+	return c.exec(fn)
+}
+
+func (c *memoryCache) TxPipeline(ctx context.Context, fn func(p cache.Pipeliner) error) error {
+	return c.exec(fn)
+}
+
+// exec runs every command queued by fn, as MULTI does after one failed,
+// returning the first error.
+func (c *memoryCache) exec(fn func(p cache.Pipeliner) error) (err error) {
+	p := &pipeliner{c: c}
+	if err := fn(p); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, op := range p.ops {
+		if opErr := op(); opErr != nil && err == nil {
+			err = opErr
+		}
+	}
+
+	return err
+}
+
+func (p *pipeliner) Get(key string) *cache.Result[*cache.CacheValue] {
+	return queue(p, func() (*cache.CacheValue, error) {
+		return p.c.get(key)
+	})
+}
+
+func (p *pipeliner) Set(key string, val any, ttl time.Duration) {
+	p.ops = append(p.ops, func() error {
+		return p.c.set(key, val, ttl)
+	})
+}
+
+func (p *pipeliner) SetNX(key string, val any, ttl time.Duration) *cache.Result[bool] {
+	return queue(p, func() (bool, error) {
+		return p.c.setNX(key, val, ttl)
+	})
+}
+
+func (p *pipeliner) Del(keys ...string) {
+	p.ops = append(p.ops, func() error {
+		p.c.del(keys...)
+		return nil
+	})
+}
+
+func (p *pipeliner) Expire(key string, ttl time.Duration) {
+	p.ops = append(p.ops, func() error {
+		p.c.expire(key, ttl)
+		return nil
+	})
+}
+
+func (p *pipeliner) IncrBy(key string, value int64) *cache.Result[int64] {
+	return queue(p, func() (int64, error) {
+		return p.c.incrBy(key, value)
+	})
+}
+
+func (p *pipeliner) HSet(key string, values map[string]any) {
+	p.ops = append(p.ops, func() error {
+		return p.c.hSet(key, values)
+	})
+}
+
+func (p *pipeliner) HIncrBy(key string, field string, value int64) *cache.Result[int64] {
+	return queue(p, func() (int64, error) {
+		return p.c.hIncrBy(key, field, value)
+	})
+}
+
+func (p *pipeliner) SAdd(key string, members ...string) {
+	p.ops = append(p.ops, func() error {
+		return p.c.sAdd(key, members...)
+	})
+}
+
+func (p *pipeliner) SRem(key string, members ...string) {
+	p.ops = append(p.ops, func() error {
+		return p.c.sRem(key, members...)
+	})
+}
+
+func (p *pipeliner) ZAdd(key string, members ...cache.ZMember) {
+	p.ops = append(p.ops, func() error {
+		return p.c.zAdd(key, members...)
+	})
+}
+
+func (p *pipeliner) ZIncrBy(key string, increment float64, member string) *cache.Result[float64] {
+	return queue(p, func() (float64, error) {
+		return p.c.zIncrBy(key, increment, member)
+	})
+}
+
+func (p *pipeliner) ZRem(key string, members ...string) {
+	p.ops = append(p.ops, func() error {
+		return p.c.zRem(key, members...)
+	})
+}
+
+// queue returns the result of op, set once the pipeline ran.
+func queue[T any](p *pipeliner, op func() (T, error)) *cache.Result[T] {
+	res := &cache.Result[T]{}
+
+	p.ops = append(p.ops, func() error {
+		val, err := op()
+		res.Resolve(val, err)
+		return err
+	})
+
+	return res
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache_Pipeline(t *testing.T) {
+	c := newTestClient(t)
+
+	require.NoError(t, c.Set(ctx, "greeting", "hello", time.Minute))
+
+	var (
+		greeting *cache.Result[*cache.CacheValue]
+		missing  *cache.Result[*cache.CacheValue]
+		visits   *cache.Result[int64]
+		score    *cache.Result[float64]
+	)
+	err := c.Pipeline(ctx, func(p cache.Pipeliner) error {
+		greeting = p.Get("greeting")
+		missing = p.Get("missing")
+		visits = p.IncrBy("visits", 2)
+		p.HSet("account", map[string]any{"name": "Acme"})
+		p.SAdd("sessions", "a")
+		p.ZAdd("leaderboard", cache.ZMember{Member: "alice", Score: 10})
+		score = p.ZIncrBy("leaderboard", 5, "alice")
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello", greeting.Val().ToString())
+	assert.NoError(t, missing.Err())
+	assert.Nil(t, missing.Val())
+	assert.Equal(t, int64(2), visits.Val())
+	assert.Equal(t, float64(15), score.Val())
+
+	name, err := c.HGet(ctx, "account", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", name.ToString())
+
+	found, err := c.SIsMember(ctx, "sessions", "a")
+	require.NoError(t, err)
+	assert.True(t, found)
+}
+
+func TestMemoryCache_TxPipeline_Aborted(t *testing.T) {
+	c := newTestClient(t)
+	errAborted := errors.New("aborted")
+
+	err := c.TxPipeline(ctx, func(p cache.Pipeliner) error {
+		p.Set("greeting", "hello", time.Minute)
+		return errAborted
+	})
+	assert.ErrorIs(t, err, errAborted)
+
+	res, err := c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestMemoryCache_TxPipeline_WriteError(t *testing.T) {
+	c := newTestClient(t)
+
+	require.NoError(t, c.Set(ctx, "greeting", "hello", time.Minute))
+
+	// As with MULTI, a failed command does not stop the others.
+	var visits *cache.Result[int64]
+	err := c.TxPipeline(ctx, func(p cache.Pipeliner) error {
+		p.SAdd("greeting", "a")
+		visits = p.IncrBy("visits", 1)
+		return nil
+	})
+	assert.Error(t, err)
+
+	assert.Equal(t, int64(1), visits.Val())
+}
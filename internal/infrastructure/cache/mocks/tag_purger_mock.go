@@ -0,0 +1,104 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package cache
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewTagPurgerMock creates a new instance of TagPurgerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTagPurgerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TagPurgerMock {
+	mock := &TagPurgerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// TagPurgerMock is an autogenerated mock type for the TagPurger type
+type TagPurgerMock struct {
+	mock.Mock
+}
+
+type TagPurgerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TagPurgerMock) EXPECT() *TagPurgerMock_Expecter {
+	return &TagPurgerMock_Expecter{mock: &_m.Mock}
+}
+
+// PurgeTags provides a mock function for the type TagPurgerMock
+func (_mock *TagPurgerMock) PurgeTags(ctx context.Context, tags ...string) error {
+	var tmpRet mock.Arguments
+	if len(tags) > 0 {
+		tmpRet = _mock.Called(ctx, tags)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTags")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = returnFunc(ctx, tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TagPurgerMock_PurgeTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTags'
+type TagPurgerMock_PurgeTags_Call struct {
+	*mock.Call
+}
+
+// PurgeTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tags ...string
+func (_e *TagPurgerMock_Expecter) PurgeTags(ctx interface{}, tags ...interface{}) *TagPurgerMock_PurgeTags_Call {
+	return &TagPurgerMock_PurgeTags_Call{Call: _e.mock.On("PurgeTags",
+		append([]interface{}{ctx}, tags...)...)}
+}
+
+func (_c *TagPurgerMock_PurgeTags_Call) Run(run func(ctx context.Context, tags ...string)) *TagPurgerMock_PurgeTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *TagPurgerMock_PurgeTags_Call) Return(err error) *TagPurgerMock_PurgeTags_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TagPurgerMock_PurgeTags_Call) RunAndReturn(run func(ctx context.Context, tags ...string) error) *TagPurgerMock_PurgeTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
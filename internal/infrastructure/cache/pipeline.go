@@ -0,0 +1,48 @@
+package cache
+
+import "time"
+
+// Pipeliner queues commands, sent together once the function given to
+// Pipeline or TxPipeline returns. Writes report their errors through the
+// pipeline; reads return a Result, set once the commands ran.
+type Pipeliner interface {
+	Get(key string) *Result[*CacheValue]
+	Set(key string, val any, ttl time.Duration)
+	SetNX(key string, val any, ttl time.Duration) *Result[bool]
+	Del(keys ...string)
+	Expire(key string, ttl time.Duration)
+	IncrBy(key string, value int64) *Result[int64]
+
+	HSet(key string, values map[string]any)
+	HIncrBy(key string, field string, value int64) *Result[int64]
+
+	SAdd(key string, members ...string)
+	SRem(key string, members ...string)
+
+	ZAdd(key string, members ...ZMember)
+	ZIncrBy(key string, increment float64, member string) *Result[float64]
+	ZRem(key string, members ...string)
+}
+
+// Result is the outcome of a command queued in a pipeline.
+type Result[T any] struct {
+	val T
+	err error
+}
+
+// Resolve sets the outcome of the command, once the pipeline ran it.
+func (r *Result[T]) Resolve(val T, err error) {
+	r.val, r.err = val, err
+}
+
+func (r *Result[T]) Val() T {
+	return r.val
+}
+
+func (r *Result[T]) Err() error {
+	return r.err
+}
+
+func (r *Result[T]) Result() (T, error) {
+	return r.val, r.err
+}
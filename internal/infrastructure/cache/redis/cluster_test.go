@@ -0,0 +1,178 @@
+package redis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClusterClient returns a client of an in-memory Redis server serving
+// every hash slot as a single node cluster, closed with the test.
+func newClusterClient(t *testing.T) (*redisClient, *miniredis.Miniredis) {
+	t.Helper()
+
+	previous := config.Redis
+	t.Cleanup(func() {
+		config.Redis = previous
+	})
+
+	m := miniredis.RunT(t)
+	config.Redis = config.RedisConfig{Mode: string(Cluster), Addrs: []string{m.Addr()}}
+
+	client := &redisClient{
+		client: createClient(ctx),
+		id:     uuid.NewString(),
+	}
+	t.Cleanup(func() {
+		client.client.Close()
+	})
+
+	return client, m
+}
+
+// hashTag returns the part of key Redis Cluster hashes to pick its slot:
+// the content of the first braces, unless empty, or the whole key.
+func hashTag(key string) string {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			return key[start+1 : start+1+end]
+		}
+	}
+
+	return key
+}
+
+func TestConfigured(t *testing.T) {
+	cases := []struct {
+		name  string
+		redis config.RedisConfig
+		want  bool
+	}{
+		{name: "standalone", redis: config.RedisConfig{Mode: string(Standalone), Host: "localhost"}, want: true},
+		{name: "standalone without host", redis: config.RedisConfig{Mode: string(Standalone), Addrs: []string{"localhost:6379"}}},
+		{name: "sentinel", redis: config.RedisConfig{Mode: string(Sentinel), Addrs: []string{"localhost:26379"}}, want: true},
+		{name: "cluster", redis: config.RedisConfig{Mode: string(Cluster), Addrs: []string{"localhost:7000"}}, want: true},
+		{name: "cluster without seeds", redis: config.RedisConfig{Mode: string(Cluster), Host: "localhost"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			previous := config.Redis
+			t.Cleanup(func() {
+				config.Redis = previous
+			})
+			config.Redis = tc.redis
+
+			assert.Equal(t, tc.want, Configured())
+		})
+	}
+}
+
+func TestFenceKey(t *testing.T) {
+	cases := []struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{key: "lock:{report}", want: "lock:{report}:fence"},
+		{key: "lock:{a}{b}", want: "lock:{a}{b}:fence"},
+		{key: "report", want: "{report}:fence"},
+		{key: "lock:{report", want: "{lock:{report}:fence"},
+		{key: "lock:{}report", wantErr: true},
+		{key: "lock:report}", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.key, func(t *testing.T) {
+			res, err := fenceKey(tc.key)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, res)
+			// The scripts touch both keys, which must share a hash slot.
+			assert.Equal(t, hashTag(tc.key), hashTag(res))
+		})
+	}
+}
+
+func TestRedisClient_Cluster(t *testing.T) {
+	c, m := newClusterClient(t)
+
+	_, ok := c.client.(*redis.ClusterClient)
+	require.True(t, ok)
+
+	require.NoError(t, c.MSet(ctx, map[string]any{"a": 1, "b": 2, "c": 3}, time.Minute))
+
+	res, err := c.MGet(ctx, "a", "b", "missing", "c")
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+	assert.Equal(t, 1, res[0].ToInt())
+	assert.Equal(t, 2, res[1].ToInt())
+	assert.Nil(t, res[2])
+	assert.Equal(t, 3, res[3].ToInt())
+
+	var keys []string
+	for key, err := range c.Scan(ctx, "*") {
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, keys)
+
+	require.NoError(t, c.Del(ctx, "a", "b"))
+	assert.False(t, m.Exists("a"))
+	assert.False(t, m.Exists("b"))
+	assert.True(t, m.Exists("c"))
+}
+
+func TestRedisClient_Cluster_Pipeline(t *testing.T) {
+	c, m := newClusterClient(t)
+
+	var total *cache.Result[int64]
+	err := c.TxPipeline(ctx, func(p cache.Pipeliner) error {
+		p.HSet("cart:{42}:items", map[string]any{"apple": 2})
+		total = p.IncrBy("cart:{42}:total", 2)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total.Val())
+
+	err = c.Pipeline(ctx, func(p cache.Pipeliner) error {
+		p.Del("cart:{42}:items", "cart:{42}:total")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, m.Keys())
+}
+
+func TestRedisClient_Cluster_Scripts(t *testing.T) {
+	c, _ := newClusterClient(t)
+
+	fence, err := c.Acquire(ctx, "lock:{report}", "token", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), fence)
+
+	extended, err := c.Extend(ctx, "lock:{report}", "token", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, extended)
+
+	released, err := c.Release(ctx, "lock:{report}", "token")
+	require.NoError(t, err)
+	assert.True(t, released)
+
+	for _, algorithm := range algorithms {
+		res, err := c.Allow(ctx, "limit:"+string(algorithm), cache.Limit{Rate: 1, Period: time.Minute, Algorithm: algorithm})
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	}
+}
@@ -0,0 +1,358 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	"github.com/redis/go-redis/v9"
+)
+
+func (c *redisClient) HGet(ctx context.Context, key string, field string) (res *cache.CacheValue, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":   key,
+		"field": field,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	str, err := c.client.HGet(ctx, key, field).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	val := cache.CacheValue(str)
+	return &val, nil
+}
+
+func (c *redisClient) HGetAll(ctx context.Context, key string) (res map[string]cache.CacheValue, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	vals, err := c.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res = make(map[string]cache.CacheValue, len(vals))
+	for field, val := range vals {
+		res[field] = cache.CacheValue(val)
+	}
+
+	return res, nil
+}
+
+func (c *redisClient) HSet(ctx context.Context, key string, values map[string]any) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":    key,
+		"values": values,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	return c.client.HSet(ctx, key, values).Err()
+}
+
+func (c *redisClient) HDel(ctx context.Context, key string, fields ...string) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":    key,
+		"fields": fields,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	return c.client.HDel(ctx, key, fields...).Err()
+}
+
+func (c *redisClient) HIncrBy(ctx context.Context, key string, field string, value int64) (res int64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":   key,
+		"field": field,
+		"value": value,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	return c.client.HIncrBy(ctx, key, field, value).Result()
+}
+
+func (c *redisClient) SAdd(ctx context.Context, key string, members ...string) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":     key,
+		"members": members,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	return c.client.SAdd(ctx, key, toArgs(members)...).Err()
+}
+
+func (c *redisClient) SRem(ctx context.Context, key string, members ...string) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":     key,
+		"members": members,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	return c.client.SRem(ctx, key, toArgs(members)...).Err()
+}
+
+func (c *redisClient) SMembers(ctx context.Context, key string) (res []string, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	return c.client.SMembers(ctx, key).Result()
+}
+
+func (c *redisClient) SIsMember(ctx context.Context, key string, member string) (res bool, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":    key,
+		"member": member,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	return c.client.SIsMember(ctx, key, member).Result()
+}
+
+func (c *redisClient) SCard(ctx context.Context, key string) (res int64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	return c.client.SCard(ctx, key).Result()
+}
+
+func (c *redisClient) ZAdd(ctx context.Context, key string, members ...cache.ZMember) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":     key,
+		"members": members,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	return c.client.ZAdd(ctx, key, toZ(members)...).Err()
+}
+
+func (c *redisClient) ZIncrBy(ctx context.Context, key string, increment float64, member string) (res float64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":       key,
+		"increment": increment,
+		"member":    member,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	return c.client.ZIncrBy(ctx, key, increment, member).Result()
+}
+
+func (c *redisClient) ZRem(ctx context.Context, key string, members ...string) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":     key,
+		"members": members,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	return c.client.ZRem(ctx, key, toArgs(members)...).Err()
+}
+
+func (c *redisClient) ZScore(ctx context.Context, key string, member string) (res *float64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":    key,
+		"member": member,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	score, err := c.client.ZScore(ctx, key, member).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &score, nil
+}
+
+func (c *redisClient) ZRange(ctx context.Context, key string, start int64, stop int64) (res []cache.ZMember, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":   key,
+		"start": start,
+		"stop":  stop,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	zs, err := c.client.ZRangeWithScores(ctx, key, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return fromZ(zs), nil
+}
+
+func (c *redisClient) ZRevRange(ctx context.Context, key string, start int64, stop int64) (res []cache.ZMember, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":   key,
+		"start": start,
+		"stop":  stop,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	zs, err := c.client.ZRevRangeWithScores(ctx, key, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return fromZ(zs), nil
+}
+
+func (c *redisClient) ZRangeByScore(ctx context.Context, key string, min float64, max float64) (res []cache.ZMember, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+		"min": min,
+		"max": max,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	zs, err := c.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: strconv.FormatFloat(min, 'g', -1, 64),
+		Max: strconv.FormatFloat(max, 'g', -1, 64),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return fromZ(zs), nil
+}
+
+func (c *redisClient) ZCard(ctx context.Context, key string) (res int64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	return c.client.ZCard(ctx, key).Result()
+}
+
+func toArgs(members []string) []any {
+	args := make([]any, len(members))
+	for i, member := range members {
+		args[i] = member
+	}
+
+	return args
+}
+
+func toZ(members []cache.ZMember) []redis.Z {
+	zs := make([]redis.Z, len(members))
+	for i, member := range members {
+		zs[i] = redis.Z{Score: member.Score, Member: member.Member}
+	}
+
+	return zs
+}
+
+func fromZ(zs []redis.Z) []cache.ZMember {
+	members := make([]cache.ZMember, len(zs))
+	for i, z := range zs {
+		member, _ := z.Member.(string)
+		members[i] = cache.ZMember{Member: member, Score: z.Score}
+	}
+
+	return members
+}
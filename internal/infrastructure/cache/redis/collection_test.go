@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisClient_Hash(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	require.NoError(t, c.HSet(ctx, "account", map[string]any{"name": "Acme", "visits": 1}))
+
+	res, err := c.HGet(ctx, "account", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", res.ToString())
+
+	res, err = c.HGet(ctx, "account", "email")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	visits, err := c.HIncrBy(ctx, "account", "visits", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), visits)
+
+	all, err := c.HGetAll(ctx, "account")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cache.CacheValue{"name": "Acme", "visits": "3"}, all)
+
+	require.NoError(t, c.HDel(ctx, "account", "name"))
+
+	all, err = c.HGetAll(ctx, "account")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cache.CacheValue{"visits": "3"}, all)
+
+	all, err = c.HGetAll(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestRedisClient_Set(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	require.NoError(t, c.SAdd(ctx, "sessions", "a", "b", "b"))
+
+	count, err := c.SCard(ctx, "sessions")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	found, err := c.SIsMember(ctx, "sessions", "a")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = c.SIsMember(ctx, "sessions", "c")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	members, err := c.SMembers(ctx, "sessions")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b"}, members)
+
+	require.NoError(t, c.SRem(ctx, "sessions", "a", "c"))
+
+	members, err = c.SMembers(ctx, "sessions")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, members)
+}
+
+func TestRedisClient_SortedSet(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	require.NoError(t, c.ZAdd(ctx, "leaderboard",
+		cache.ZMember{Member: "alice", Score: 10},
+		cache.ZMember{Member: "carol", Score: 20},
+		cache.ZMember{Member: "bob", Score: 20},
+		cache.ZMember{Member: "dave", Score: 5},
+	))
+
+	score, err := c.ZIncrBy(ctx, "leaderboard", 15, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, float64(25), score)
+
+	res, err := c.ZScore(ctx, "leaderboard", "alice")
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, float64(25), *res)
+
+	res, err = c.ZScore(ctx, "leaderboard", "erin")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	// Members of equal score are ordered lexicographically.
+	members, err := c.ZRange(ctx, "leaderboard", 0, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []cache.ZMember{
+		{Member: "dave", Score: 5},
+		{Member: "bob", Score: 20},
+		{Member: "carol", Score: 20},
+		{Member: "alice", Score: 25},
+	}, members)
+
+	members, err = c.ZRevRange(ctx, "leaderboard", 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []cache.ZMember{{Member: "alice", Score: 25}, {Member: "carol", Score: 20}}, members)
+
+	members, err = c.ZRangeByScore(ctx, "leaderboard", 10, 20)
+	require.NoError(t, err)
+	assert.Equal(t, []cache.ZMember{{Member: "bob", Score: 20}, {Member: "carol", Score: 20}}, members)
+
+	require.NoError(t, c.ZRem(ctx, "leaderboard", "dave"))
+
+	count, err := c.ZCard(ctx, "leaderboard")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestRedisClient_WrongType(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	require.NoError(t, c.Set(ctx, "greeting", "hello", time.Minute))
+
+	assert.Error(t, c.HSet(ctx, "greeting", map[string]any{"name": "Acme"}))
+	assert.Error(t, c.SAdd(ctx, "greeting", "a"))
+	assert.Error(t, c.ZAdd(ctx, "greeting", cache.ZMember{Member: "a", Score: 1}))
+
+	_, err := c.SMembers(ctx, "greeting")
+	assert.Error(t, err)
+}
@@ -0,0 +1,276 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	"github.com/redis/go-redis/v9"
+)
+
+// The scripts read the clock of the Redis server, so that every instance
+// of the application counts against the same time. They take the limit,
+// the period in microseconds and the capacity, and return whether the
+// request is allowed, the remaining requests, then the retry and reset
+// delays in microseconds.
+
+var slidingLogScript = redis.NewScript(`
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+
+local reset = 0
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+if count < limit then
+	redis.call('ZADD', key, now, now .. '-' .. count)
+	redis.call('PEXPIRE', key, math.ceil(window / 1000))
+	if count == 0 then
+		reset = window
+	end
+	return {1, limit - count - 1, 0, reset}
+end
+
+return {0, 0, reset, reset}
+`)
+
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+local index = math.floor(now / window)
+local data = redis.call('HMGET', key, 'w', 'c', 'p')
+local current = tonumber(data[2]) or 0
+local previous = tonumber(data[3]) or 0
+local last = tonumber(data[1]) or index
+
+if index == last + 1 then
+	previous = current
+	current = 0
+elseif index ~= last then
+	previous = 0
+	current = 0
+end
+
+local elapsed = now - index * window
+local estimate = previous * (window - elapsed) / window + current
+local reset = window - elapsed
+if previous > 0 then
+	reset = reset + window
+end
+
+if estimate + 1 > limit then
+	local retry = window - elapsed
+	if previous > 0 and current + 1 <= limit then
+		retry = math.ceil(window * (1 - (limit - current - 1) / previous)) - elapsed
+	end
+	return {0, 0, retry, reset}
+end
+
+current = current + 1
+redis.call('HSET', key, 'w', index, 'c', current, 'p', previous)
+redis.call('PEXPIRE', key, math.ceil(2 * window / 1000))
+
+return {1, math.floor(limit - estimate - 1), 0, reset}
+`)
+
+var tokenBucketScript = redis.NewScript(`
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local capacity = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+local rate = limit / period
+local data = redis.call('HMGET', key, 'tokens', 'ts')
+local tokens = tonumber(data[1]) or capacity
+local ts = tonumber(data[2]) or now
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', key, 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', key, math.ceil(capacity / rate / 1000) + 1000)
+
+return {allowed, math.floor(tokens), retry, math.ceil((capacity - tokens) / rate)}
+`)
+
+var gcraScript = redis.NewScript(`
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local capacity = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+local emission = period / limit
+local tolerance = emission * capacity
+local tat = math.max(tonumber(redis.call('GET', key)) or now, now)
+local newTat = tat + emission
+local allowAt = newTat - tolerance
+
+if now < allowAt then
+	return {0, 0, math.ceil(allowAt - now), math.ceil(tat - now)}
+end
+
+redis.call('SET', key, math.floor(newTat), 'PX', math.ceil((newTat - now) / 1000) + 1)
+
+return {1, math.floor((now - allowAt) / emission), 0, math.ceil(newTat - now)}
+`)
+
+var fixedWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local count = tonumber(redis.call('GET', key)) or 0
+if count >= limit then
+	local reset = redis.call('PTTL', key) * 1000
+	return {0, 0, reset, reset}
+end
+
+count = redis.call('INCR', key)
+if count == 1 then
+	redis.call('PEXPIRE', key, math.ceil(window / 1000))
+end
+
+local reset = redis.call('PTTL', key) * 1000
+return {1, limit - count, 0, reset}
+`)
+
+// The refund scripts take the same arguments and undo what the scripts
+// above counted for a request, leaving the expiry of the key as it is.
+
+var slidingLogRefundScript = redis.NewScript(`
+redis.call('ZPOPMAX', KEYS[1])
+return 0
+`)
+
+var slidingWindowRefundScript = redis.NewScript(`
+local current = tonumber(redis.call('HGET', KEYS[1], 'c')) or 0
+if current > 0 then
+	redis.call('HSET', KEYS[1], 'c', current - 1)
+end
+return 0
+`)
+
+var tokenBucketRefundScript = redis.NewScript(`
+local tokens = tonumber(redis.call('HGET', KEYS[1], 'tokens'))
+if tokens then
+	redis.call('HSET', KEYS[1], 'tokens', tostring(math.min(tonumber(ARGV[3]), tokens + 1)))
+end
+return 0
+`)
+
+var gcraRefundScript = redis.NewScript(`
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if tat then
+	redis.call('SET', KEYS[1], math.floor(tat - tonumber(ARGV[2]) / tonumber(ARGV[1])), 'KEEPTTL')
+end
+return 0
+`)
+
+var fixedWindowRefundScript = redis.NewScript(`
+local count = tonumber(redis.call('GET', KEYS[1])) or 0
+if count > 0 then
+	redis.call('DECR', KEYS[1])
+end
+return 0
+`)
+
+var refundScripts = map[cache.Algorithm]*redis.Script{
+	cache.SlidingLog:    slidingLogRefundScript,
+	cache.SlidingWindow: slidingWindowRefundScript,
+	cache.TokenBucket:   tokenBucketRefundScript,
+	cache.GCRA:          gcraRefundScript,
+	cache.FixedWindow:   fixedWindowRefundScript,
+}
+
+var limiterScripts = map[cache.Algorithm]*redis.Script{
+	cache.SlidingLog:    slidingLogScript,
+	cache.SlidingWindow: slidingWindowScript,
+	cache.TokenBucket:   tokenBucketScript,
+	cache.GCRA:          gcraScript,
+	cache.FixedWindow:   fixedWindowScript,
+}
+
+func (c *redisClient) Allow(ctx context.Context, key string, limit cache.Limit) (res *cache.LimitResult, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":   key,
+		"limit": limit,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	script, ok := limiterScripts[limit.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unknown rate limit algorithm %q", limit.Algorithm)
+	}
+	if limit.Rate <= 0 || limit.Period <= 0 {
+		return nil, fmt.Errorf("invalid rate limit of %d per %v", limit.Rate, limit.Period)
+	}
+
+	capacity := limit.Capacity()
+
+	vals, err := script.Run(ctx, c.client, []string{key}, limit.Rate, limit.Period.Microseconds(), capacity).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cache.LimitResult{
+		Allowed:    vals[0] == 1,
+		Limit:      capacity,
+		Remaining:  int(max(vals[1], 0)),
+		RetryAfter: time.Duration(max(vals[2], 0)) * time.Microsecond,
+		ResetAfter: time.Duration(max(vals[3], 0)) * time.Microsecond,
+	}, nil
+}
+
+func (c *redisClient) Refund(ctx context.Context, key string, limit cache.Limit) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key":   key,
+		"limit": limit,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	script, ok := refundScripts[limit.Algorithm]
+	if !ok {
+		return fmt.Errorf("unknown rate limit algorithm %q", limit.Algorithm)
+	}
+	if limit.Rate <= 0 || limit.Period <= 0 {
+		return fmt.Errorf("invalid rate limit of %d per %v", limit.Rate, limit.Period)
+	}
+
+	return script.Run(ctx, c.client, []string{key}, limit.Rate, limit.Period.Microseconds(), limit.Capacity()).Err()
+}
@@ -0,0 +1,224 @@
+package redis
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var algorithms = []cache.Algorithm{cache.SlidingLog, cache.SlidingWindow, cache.TokenBucket, cache.GCRA, cache.FixedWindow}
+
+func TestRedisClient_Allow(t *testing.T) {
+	for _, algorithm := range algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			client, _ := newTestClient(t)
+			limit := cache.Limit{Rate: 3, Period: time.Minute, Algorithm: algorithm}
+
+			for i := range 3 {
+				res, err := client.Allow(ctx, "key", limit)
+				require.NoError(t, err)
+				assert.True(t, res.Allowed)
+				assert.Equal(t, 3, res.Limit)
+				assert.Equal(t, 2-i, res.Remaining)
+				assert.Zero(t, res.RetryAfter)
+				assert.Positive(t, res.ResetAfter)
+			}
+
+			res, err := client.Allow(ctx, "key", limit)
+			require.NoError(t, err)
+			assert.False(t, res.Allowed)
+			assert.Zero(t, res.Remaining)
+			assert.Positive(t, res.RetryAfter)
+			assert.LessOrEqual(t, res.RetryAfter, limit.Period)
+
+			// Other keys are limited apart.
+			res, err = client.Allow(ctx, "other", limit)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+		})
+	}
+}
+
+func TestRedisClient_Allow_AfterPeriod(t *testing.T) {
+	for _, algorithm := range algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			client, m := newTestClient(t)
+			limit := cache.Limit{Rate: 2, Period: time.Minute, Algorithm: algorithm}
+
+			now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+			m.SetTime(now)
+
+			for range 2 {
+				res, err := client.Allow(ctx, "key", limit)
+				require.NoError(t, err)
+				assert.True(t, res.Allowed)
+			}
+
+			res, err := client.Allow(ctx, "key", limit)
+			require.NoError(t, err)
+			require.False(t, res.Allowed)
+
+			// The scripts read the clock of the server, and the keys
+			// expire with it.
+			m.SetTime(now.Add(2 * limit.Period))
+			m.FastForward(2 * limit.Period)
+
+			res, err = client.Allow(ctx, "key", limit)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 1, res.Remaining)
+		})
+	}
+}
+
+func TestRedisClient_Allow_Burst(t *testing.T) {
+	cases := []struct {
+		algorithm cache.Algorithm
+		allowed   int
+	}{
+		{algorithm: cache.TokenBucket, allowed: 5},
+		{algorithm: cache.GCRA, allowed: 5},
+		{algorithm: cache.SlidingLog, allowed: 2},
+		{algorithm: cache.FixedWindow, allowed: 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.algorithm), func(t *testing.T) {
+			client, _ := newTestClient(t)
+			limit := cache.Limit{Rate: 2, Period: time.Minute, Burst: 5, Algorithm: tc.algorithm}
+
+			var allowed int
+			for range 10 {
+				res, err := client.Allow(ctx, "key", limit)
+				require.NoError(t, err)
+				assert.Equal(t, limit.Capacity(), res.Limit)
+				if res.Allowed {
+					allowed++
+				}
+			}
+
+			assert.Equal(t, tc.allowed, allowed)
+		})
+	}
+}
+
+func TestRedisClient_Allow_Concurrent(t *testing.T) {
+	for _, algorithm := range algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			client, _ := newTestClient(t)
+			limit := cache.Limit{Rate: 5, Period: time.Minute, Algorithm: algorithm}
+
+			var (
+				wg      sync.WaitGroup
+				allowed atomic.Int32
+			)
+			for range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					res, err := client.Allow(ctx, "key", limit)
+					if assert.NoError(t, err) && res.Allowed {
+						allowed.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(t, int32(5), allowed.Load())
+		})
+	}
+}
+
+func TestRedisClient_Allow_Invalid(t *testing.T) {
+	cases := []struct {
+		name    string
+		limit   cache.Limit
+		wantErr string
+	}{
+		{
+			name:    "unknown algorithm",
+			limit:   cache.Limit{Rate: 1, Period: time.Minute, Algorithm: "leaky"},
+			wantErr: `unknown rate limit algorithm "leaky"`,
+		},
+		{
+			name:    "zero rate",
+			limit:   cache.Limit{Period: time.Minute, Algorithm: cache.GCRA},
+			wantErr: "invalid rate limit of 0 per 1m0s",
+		},
+		{
+			name:    "zero period",
+			limit:   cache.Limit{Rate: 1, Algorithm: cache.TokenBucket},
+			wantErr: "invalid rate limit of 1 per 0s",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+
+			res, err := client.Allow(ctx, "key", tc.limit)
+
+			assert.EqualError(t, err, tc.wantErr)
+			assert.Nil(t, res)
+		})
+	}
+}
+
+func TestRedisClient_Refund(t *testing.T) {
+	for _, algorithm := range algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			client, _ := newTestClient(t)
+			limit := cache.Limit{Rate: 2, Period: time.Minute, Algorithm: algorithm}
+
+			for range 2 {
+				res, err := client.Allow(ctx, "key", limit)
+				assert.NoError(t, err)
+				assert.True(t, res.Allowed)
+			}
+
+			res, err := client.Allow(ctx, "key", limit)
+			assert.NoError(t, err)
+			assert.False(t, res.Allowed)
+
+			assert.NoError(t, client.Refund(ctx, "key", limit))
+
+			res, err = client.Allow(ctx, "key", limit)
+			assert.NoError(t, err)
+			assert.True(t, res.Allowed)
+
+			res, err = client.Allow(ctx, "key", limit)
+			assert.NoError(t, err)
+			assert.False(t, res.Allowed)
+		})
+	}
+}
+
+func TestRedisClient_Refund_Missing(t *testing.T) {
+	for _, algorithm := range algorithms {
+		t.Run(string(algorithm), func(t *testing.T) {
+			client, _ := newTestClient(t)
+			limit := cache.Limit{Rate: 1, Period: time.Minute, Algorithm: algorithm}
+
+			assert.NoError(t, client.Refund(ctx, "key", limit))
+
+			res, err := client.Allow(ctx, "key", limit)
+			assert.NoError(t, err)
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 0, res.Remaining)
+		})
+	}
+}
+
+func TestRedisClient_Refund_Unknown(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	err := client.Refund(ctx, "key", cache.Limit{Rate: 1, Period: time.Minute, Algorithm: "leaky"})
+
+	assert.Error(t, err)
+}
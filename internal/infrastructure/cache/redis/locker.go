@@ -0,0 +1,107 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	"github.com/redis/go-redis/v9"
+)
+
+// The fencing token of a key is counted under fenceKey, which never
+// expires so the tokens keep growing across holds.
+
+var acquireScript = redis.NewScript(`
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return redis.call('INCR', KEYS[2])
+end
+return 0
+`)
+
+var extendScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+func (c *redisClient) Acquire(ctx context.Context, key string, token string, ttl time.Duration) (res int64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+		"ttl": ttl,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	fence, err := fenceKey(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return acquireScript.Run(ctx, c.client, []string{key, fence}, token, ttl.Milliseconds()).Int64()
+}
+
+func (c *redisClient) Extend(ctx context.Context, key string, token string, ttl time.Duration) (res bool, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+		"ttl": ttl,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	n, err := extendScript.Run(ctx, c.client, []string{key}, token, ttl.Milliseconds()).Int64()
+	return n == 1, err
+}
+
+func (c *redisClient) Release(ctx context.Context, key string, token string) (res bool, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	n, err := releaseScript.Run(ctx, c.client, []string{key}, token).Int64()
+	return n == 1, err
+}
+
+// fenceKey returns the key counting the fencing tokens of key, in the same
+// hash slot so the scripts also run on a cluster: it shares the hash tag of
+// key, or is tagged with the whole key when key has none. A key without a
+// hash tag but with a closing brace cannot be tagged whole, and is refused.
+func fenceKey(key string) (string, error) {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			return key + ":fence", nil
+		}
+	}
+
+	if strings.IndexByte(key, '}') >= 0 {
+		return "", fmt.Errorf("lock key %q has no hash tag to share with its fencing token", key)
+	}
+
+	return "{" + key + "}:fence", nil
+}
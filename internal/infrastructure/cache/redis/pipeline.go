@@ -0,0 +1,160 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	"github.com/redis/go-redis/v9"
+)
+
+// pipeliner queues commands on a go-redis pipeline, resolving the results
+// of the reads once it ran.
+type pipeliner struct {
+	ctx      context.Context
+	pipe     redis.Pipeliner
+	resolves []func()
+}
+
+func (c *redisClient) Pipeline(ctx context.Context, fn func(p cache.Pipeliner) error) (err error) {
+	ctx, span := tracer.Start(ctx)
+
+	var count int
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"count": count,
+		}).End(err)
+	}()
+
+	count, err = c.exec(ctx, c.client.Pipeline(), fn)
+
+	return err
+}
+
+func (c *redisClient) TxPipeline(ctx context.Context, fn func(p cache.Pipeliner) error) (err error) {
+	ctx, span := tracer.Start(ctx)
+
+	var count int
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"count": count,
+		}).End(err)
+	}()
+
+	count, err = c.exec(ctx, c.client.TxPipeline(), fn)
+
+	return err
+}
+
+// exec queues the commands of fn on pipe and runs them, returning how many
+// ran and the first error of a write.
+func (c *redisClient) exec(ctx context.Context, pipe redis.Pipeliner, fn func(p cache.Pipeliner) error) (int, error) {
+	p := &pipeliner{ctx: ctx, pipe: pipe}
+
+	if err := fn(p); err != nil {
+		pipe.Discard()
+		return 0, err
+	}
+
+	cmds, err := pipe.Exec(ctx)
+
+	for _, resolve := range p.resolves {
+		resolve()
+	}
+
+	// Exec returns the first error of any command, which is the missing
+	// value of a read for redis.Nil; reads report theirs through Result.
+	if err != nil {
+		for _, cmd := range cmds {
+			if cmdErr := cmd.Err(); cmdErr != nil && !errors.Is(cmdErr, redis.Nil) {
+				return len(cmds), cmdErr
+			}
+		}
+		err = nil
+	}
+
+	return len(cmds), err
+}
+
+func (p *pipeliner) Get(key string) *cache.Result[*cache.CacheValue] {
+	cmd := p.pipe.Get(p.ctx, key)
+	res := &cache.Result[*cache.CacheValue]{}
+
+	p.resolves = append(p.resolves, func() {
+		str, err := cmd.Result()
+		if err == redis.Nil {
+			res.Resolve(nil, nil)
+			return
+		}
+
+		val := cache.CacheValue(str)
+		res.Resolve(&val, err)
+	})
+
+	return res
+}
+
+func (p *pipeliner) Set(key string, val any, ttl time.Duration) {
+	p.pipe.Set(p.ctx, key, val, ttl)
+}
+
+func (p *pipeliner) SetNX(key string, val any, ttl time.Duration) *cache.Result[bool] {
+	return resolve(p, p.pipe.SetNX(p.ctx, key, val, ttl))
+}
+
+// Del deletes the keys one by one, since a cluster fails to delete keys of
+// several hash slots at once.
+func (p *pipeliner) Del(keys ...string) {
+	for _, key := range keys {
+		p.pipe.Del(p.ctx, key)
+	}
+}
+
+func (p *pipeliner) Expire(key string, ttl time.Duration) {
+	p.pipe.ExpireNX(p.ctx, key, ttl)
+}
+
+func (p *pipeliner) IncrBy(key string, value int64) *cache.Result[int64] {
+	return resolve(p, p.pipe.IncrBy(p.ctx, key, value))
+}
+
+func (p *pipeliner) HSet(key string, values map[string]any) {
+	p.pipe.HSet(p.ctx, key, values)
+}
+
+func (p *pipeliner) HIncrBy(key string, field string, value int64) *cache.Result[int64] {
+	return resolve(p, p.pipe.HIncrBy(p.ctx, key, field, value))
+}
+
+func (p *pipeliner) SAdd(key string, members ...string) {
+	p.pipe.SAdd(p.ctx, key, toArgs(members)...)
+}
+
+func (p *pipeliner) SRem(key string, members ...string) {
+	p.pipe.SRem(p.ctx, key, toArgs(members)...)
+}
+
+func (p *pipeliner) ZAdd(key string, members ...cache.ZMember) {
+	p.pipe.ZAdd(p.ctx, key, toZ(members)...)
+}
+
+func (p *pipeliner) ZIncrBy(key string, increment float64, member string) *cache.Result[float64] {
+	return resolve(p, p.pipe.ZIncrBy(p.ctx, key, increment, member))
+}
+
+func (p *pipeliner) ZRem(key string, members ...string) {
+	p.pipe.ZRem(p.ctx, key, toArgs(members)...)
+}
+
+// resolve returns the result of cmd, set once the pipeline ran.
+func resolve[T any](p *pipeliner, cmd interface{ Result() (T, error) }) *cache.Result[T] {
+	res := &cache.Result[T]{}
+
+	p.resolves = append(p.resolves, func() {
+		res.Resolve(cmd.Result())
+	})
+
+	return res
+}
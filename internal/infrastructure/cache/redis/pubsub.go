@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+)
+
+type invalidation struct {
+	Source string   `json:"source"`
+	Keys   []string `json:"keys"`
+}
+
+// PublishInvalidation broadcasts keys on config.Cache.InvalidationChannel.
+func (c *redisClient) PublishInvalidation(ctx context.Context, keys ...string) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"keys": keys,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	msg, err := json.Marshal(invalidation{Source: c.id, Keys: keys})
+	if err != nil {
+		return err
+	}
+
+	return c.client.Publish(ctx, config.Cache.InvalidationChannel, msg).Err()
+}
+
+// SubscribeInvalidations calls handler with the keys invalidated by the
+// other clients, usually other instances, until ctx is done. The
+// subscription reconnects on its own after Redis restarts.
+func (c *redisClient) SubscribeInvalidations(ctx context.Context, handler func(keys []string)) error {
+	sub := c.client.Subscribe(ctx, config.Cache.InvalidationChannel)
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return err
+	}
+
+	go func() {
+		defer sub.Close()
+
+		ch := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+
+				var inv invalidation
+				if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+					logger.Errorf(ctx, err, "❌ Failed to decode cache invalidation").Write()
+					continue
+				}
+
+				if inv.Source != c.id {
+					handler(inv.Keys)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
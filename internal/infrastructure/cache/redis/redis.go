@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"iter"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/goodone-dev/go-boilerplate/internal/config"
@@ -12,20 +14,65 @@ import (
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
 	"github.com/goodone-dev/go-boilerplate/internal/utils/retry"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
+// scanCount is how many keys SCAN looks at per round trip.
+const scanCount = 100
+
+// Mode is how the application reaches Redis.
+type Mode string
+
+const (
+	// Standalone connects to REDIS_HOST:REDIS_PORT.
+	Standalone Mode = "standalone"
+	// Sentinel connects to the master REDIS_MASTER_NAME, found through the
+	// sentinels of REDIS_ADDRS, following it across failovers.
+	Sentinel Mode = "sentinel"
+	// Cluster connects to the cluster of the seed nodes of REDIS_ADDRS,
+	// routing every key to the node serving its hash slot.
+	Cluster Mode = "cluster"
+)
+
 type redisClient struct {
-	client *redis.Client
+	client redis.UniversalClient
+	// id tells the invalidations published by this client apart from
+	// those of the others.
+	id string
 }
 
-func createClient(ctx context.Context) (client *redis.Client) {
+// Configured reports whether Redis is configured for the selected mode.
+func Configured() bool {
+	if Mode(config.Redis.Mode) == Standalone {
+		return config.Redis.Host != ""
+	}
+
+	return len(config.Redis.Addrs) > 0
+}
+
+func createClient(ctx context.Context) (client redis.UniversalClient) {
 	redis.SetLogger(&noLogger{})
 
-	options := &redis.Options{
-		Addr: fmt.Sprintf("%v:%v", config.Redis.Host, config.Redis.Port),
-		DB:   config.Redis.DB,
+	options := &redis.UniversalOptions{
+		Password: config.Redis.Password,
+	}
+
+	switch Mode(config.Redis.Mode) {
+	case Standalone:
+		options.Addrs = []string{fmt.Sprintf("%v:%v", config.Redis.Host, config.Redis.Port)}
+		options.DB = config.Redis.DB
+	case Sentinel:
+		options.Addrs = config.Redis.Addrs
+		options.MasterName = config.Redis.MasterName
+		options.SentinelPassword = config.Redis.SentinelPassword
+		options.DB = config.Redis.DB
+	case Cluster:
+		options.Addrs = config.Redis.Addrs
+		options.IsClusterMode = true
+	default:
+		logger.Fatalf(ctx, nil, "❌ Unsupported Redis mode %q", config.Redis.Mode).Write()
 	}
 
 	if config.Redis.TLS {
@@ -34,33 +81,32 @@ func createClient(ctx context.Context) (client *redis.Client) {
 		}
 	}
 
-	if config.Redis.Password != "" {
-		options.Password = config.Redis.Password
-	}
-
-	client = redis.NewClient(options)
-
-	_, err := retry.RetryWithBackoff(ctx, "Redis connection test", func() (any, error) {
-		return nil, client.Ping(ctx).Err()
-	})
-	if err != nil {
-		logger.Fatal(ctx, err, "❌ Redis failed to establish connection").Write()
-	}
+	client = redis.NewUniversalClient(options)
 
 	traceOpts := redisotel.WithCommandFilter(func(cmd redis.Cmder) bool {
 		return strings.EqualFold(cmd.Name(), "ping") // Skip tracing PING commands
 	})
 
+	// Instrumented before the first command, since a cluster instruments
+	// the nodes it discovers afterwards only.
 	if err := redisotel.InstrumentTracing(client, traceOpts); err != nil {
 		logger.Fatal(ctx, err, "❌ Redis failed to instrument connection").Write()
 	}
 
+	_, err := retry.RetryWithBackoff(ctx, "Redis connection test", func() (any, error) {
+		return nil, client.Ping(ctx).Err()
+	})
+	if err != nil {
+		logger.Fatal(ctx, err, "❌ Redis failed to establish connection").Write()
+	}
+
 	return client
 }
 
 func NewClient(ctx context.Context) cache.Cache {
 	client := &redisClient{
 		client: createClient(ctx),
+		id:     uuid.NewString(),
 	}
 
 	go client.Monitor(ctx)
@@ -135,6 +181,18 @@ func (c *redisClient) Del(ctx context.Context, keys ...string) (err error) {
 		span.End(err)
 	}()
 
+	if _, ok := c.client.(*redis.ClusterClient); ok && len(keys) > 1 {
+		// DEL fails with CROSSSLOT once the keys span several hash slots,
+		// so a cluster deletes them one by one.
+		_, err = c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				pipe.Del(ctx, key)
+			}
+			return nil
+		})
+		return err
+	}
+
 	return c.client.Del(ctx, keys...).Err()
 }
 
@@ -214,6 +272,203 @@ func (c *redisClient) Expire(ctx context.Context, key string, ttl time.Duration)
 	return c.client.ExpireNX(ctx, key, ttl).Err()
 }
 
+func (c *redisClient) MGet(ctx context.Context, keys ...string) (res []*cache.CacheValue, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"keys": keys,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	vals, err := c.mget(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	res = make([]*cache.CacheValue, len(vals))
+	for i, v := range vals {
+		if str, ok := v.(string); ok {
+			val := cache.CacheValue(str)
+			res[i] = &val
+		}
+	}
+
+	return res, nil
+}
+
+func (c *redisClient) MSet(ctx context.Context, values map[string]any, ttl time.Duration) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"values": values,
+		"ttl":    ttl,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	pipelined := c.client.TxPipelined
+	if _, ok := c.client.(*redis.ClusterClient); ok {
+		// MSET and MULTI fail with CROSSSLOT once the keys span several
+		// hash slots, so a cluster sets them one by one.
+		pipelined = c.client.Pipelined
+	} else if ttl <= 0 {
+		return c.client.MSet(ctx, values).Err()
+	}
+
+	_, err = pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, val := range values {
+			pipe.Set(ctx, key, val, ttl)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (c *redisClient) SetNX(ctx context.Context, key string, val any, ttl time.Duration) (res bool, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+		"val": val,
+		"ttl": ttl,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	return c.client.SetNX(ctx, key, val, ttl).Result()
+}
+
+func (c *redisClient) GetDel(ctx context.Context, key string) (res *cache.CacheValue, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	str, err := c.client.GetDel(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	val := cache.CacheValue(str)
+	return &val, nil
+}
+
+func (c *redisClient) Scan(ctx context.Context, pattern string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		ctx, span := tracer.Start(ctx)
+		span.SetFunctionInput(tracer.Metadata{
+			"pattern": pattern,
+		})
+
+		var count int
+		var err error
+
+		defer func() {
+			span.SetFunctionOutput(tracer.Metadata{
+				"count": count,
+			}).End(err)
+		}()
+
+		var nodes []redis.Cmdable
+		nodes, err = c.masters(ctx)
+		if err != nil {
+			yield("", err)
+			return
+		}
+
+		for _, node := range nodes {
+			var cursor uint64
+			for {
+				var keys []string
+				keys, cursor, err = node.Scan(ctx, cursor, pattern, scanCount).Result()
+				if err != nil {
+					yield("", err)
+					return
+				}
+
+				for _, key := range keys {
+					count++
+					if !yield(key, nil) {
+						return
+					}
+				}
+
+				if cursor == 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+// mget reads keys with MGET, or with a GET per key on a cluster, where
+// MGET fails once the keys span several hash slots; the pipeline sends the
+// GETs to the node of each key.
+func (c *redisClient) mget(ctx context.Context, keys []string) ([]any, error) {
+	if _, ok := c.client.(*redis.ClusterClient); !ok {
+		return c.client.MGet(ctx, keys...).Result()
+	}
+
+	cmds, _ := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Get(ctx, key)
+		}
+		return nil
+	})
+
+	vals := make([]any, len(cmds))
+	for i, cmd := range cmds {
+		str, err := cmd.(*redis.StringCmd).Result()
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		vals[i] = str
+	}
+
+	return vals, nil
+}
+
+// masters returns the nodes holding the keys: every master of a cluster,
+// or the only one otherwise.
+func (c *redisClient) masters(ctx context.Context) ([]redis.Cmdable, error) {
+	cluster, ok := c.client.(*redis.ClusterClient)
+	if !ok {
+		return []redis.Cmdable{c.client}, nil
+	}
+
+	var mu sync.Mutex
+	var nodes []redis.Cmdable
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		nodes = append(nodes, client)
+		return nil
+	})
+
+	return nodes, err
+}
+
 func (c *redisClient) Shutdown(ctx context.Context) (err error) {
 	return c.client.Close()
 }
@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+// newTestClient returns a client of an in-memory Redis server, closed with
+// the test.
+func newTestClient(t *testing.T) (*redisClient, *miniredis.Miniredis) {
+	t.Helper()
+
+	m := miniredis.RunT(t)
+	client := &redisClient{
+		client: redis.NewClient(&redis.Options{Addr: m.Addr()}),
+		id:     uuid.NewString(),
+	}
+	t.Cleanup(func() {
+		client.client.Close()
+	})
+
+	return client, m
+}
+
+var ctx = context.Background()
+
+func TestRedisClient_MSet(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	require.NoError(t, c.MSet(ctx, map[string]any{"a": 1, "b": "two"}, time.Minute))
+
+	res, err := c.MGet(ctx, "a", "missing", "b")
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.Equal(t, 1, res[0].ToInt())
+	assert.Nil(t, res[1])
+	assert.Equal(t, "two", res[2].ToString())
+
+	ttl, err := c.TTL(ctx, "b")
+	require.NoError(t, err)
+	assert.Positive(t, ttl)
+}
+
+func TestRedisClient_SetNX(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	set, err := c.SetNX(ctx, "greeting", "hello", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	set, err = c.SetNX(ctx, "greeting", "bonjour", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	res, err := c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.ToString())
+}
+
+func TestRedisClient_GetDel(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	require.NoError(t, c.Set(ctx, "greeting", "hello", time.Minute))
+
+	res, err := c.GetDel(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.ToString())
+
+	res, err = c.GetDel(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestRedisClient_Scan(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	for _, key := range []string{"session:1", "session:2", "user:1"} {
+		require.NoError(t, c.Set(ctx, key, "x", time.Minute))
+	}
+
+	var keys []string
+	for key, err := range c.Scan(ctx, "session:*") {
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	assert.ElementsMatch(t, []string{"session:1", "session:2"}, keys)
+}
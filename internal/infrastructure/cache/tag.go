@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+)
+
+// TagPurger drops the entries tagged with any of the given tags, e.g. the
+// cached responses showing a resource, after a usecase changed it.
+type TagPurger interface {
+	PurgeTags(ctx context.Context, tags ...string) error
+}
+
+// tagPurger purges tags by bumping a version counter per tag rather than
+// deleting entries: keys derived from TagVersion move on, so entries
+// stored before the purge, even by requests still running, are never read
+// again and expire on their own.
+type tagPurger struct {
+	cache Cache
+}
+
+func NewTagPurger(cache Cache) TagPurger {
+	return &tagPurger{
+		cache: cache,
+	}
+}
+
+func (p *tagPurger) PurgeTags(ctx context.Context, tags ...string) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"tags": tags,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return p.cache.Pipeline(ctx, func(pipe Pipeliner) error {
+		for _, tag := range tags {
+			pipe.IncrBy(tagKey(tag), 1)
+		}
+		return nil
+	})
+}
+
+// TagVersion returns the current version of tags, which changes whenever
+// any of them is purged. Entries keyed with it are dropped by PurgeTags.
+func TagVersion(ctx context.Context, c Cache, tags ...string) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = tagKey(tag)
+	}
+
+	vals, err := c.MGet(ctx, keys...)
+	if err != nil {
+		return "", err
+	}
+
+	versions := make([]string, len(vals))
+	for i, val := range vals {
+		versions[i] = strconv.FormatInt(val.ToInt64(), 10)
+	}
+
+	return strings.Join(versions, "."), nil
+}
+
+func tagKey(tag string) string {
+	return "tag:" + tag
+}
@@ -0,0 +1,82 @@
+package tiered
+
+import (
+	"context"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+)
+
+// Hashes, sets and sorted sets are not kept in L1, so their commands go to
+// Redis as they are.
+
+func (c *tieredCache) HGet(ctx context.Context, key string, field string) (*cache.CacheValue, error) {
+	return c.l2.HGet(ctx, key, field)
+}
+
+func (c *tieredCache) HGetAll(ctx context.Context, key string) (map[string]cache.CacheValue, error) {
+	return c.l2.HGetAll(ctx, key)
+}
+
+func (c *tieredCache) HSet(ctx context.Context, key string, values map[string]any) error {
+	return c.l2.HSet(ctx, key, values)
+}
+
+func (c *tieredCache) HDel(ctx context.Context, key string, fields ...string) error {
+	return c.l2.HDel(ctx, key, fields...)
+}
+
+func (c *tieredCache) HIncrBy(ctx context.Context, key string, field string, value int64) (int64, error) {
+	return c.l2.HIncrBy(ctx, key, field, value)
+}
+
+func (c *tieredCache) SAdd(ctx context.Context, key string, members ...string) error {
+	return c.l2.SAdd(ctx, key, members...)
+}
+
+func (c *tieredCache) SRem(ctx context.Context, key string, members ...string) error {
+	return c.l2.SRem(ctx, key, members...)
+}
+
+func (c *tieredCache) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.l2.SMembers(ctx, key)
+}
+
+func (c *tieredCache) SIsMember(ctx context.Context, key string, member string) (bool, error) {
+	return c.l2.SIsMember(ctx, key, member)
+}
+
+func (c *tieredCache) SCard(ctx context.Context, key string) (int64, error) {
+	return c.l2.SCard(ctx, key)
+}
+
+func (c *tieredCache) ZAdd(ctx context.Context, key string, members ...cache.ZMember) error {
+	return c.l2.ZAdd(ctx, key, members...)
+}
+
+func (c *tieredCache) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	return c.l2.ZIncrBy(ctx, key, increment, member)
+}
+
+func (c *tieredCache) ZRem(ctx context.Context, key string, members ...string) error {
+	return c.l2.ZRem(ctx, key, members...)
+}
+
+func (c *tieredCache) ZScore(ctx context.Context, key string, member string) (*float64, error) {
+	return c.l2.ZScore(ctx, key, member)
+}
+
+func (c *tieredCache) ZRange(ctx context.Context, key string, start int64, stop int64) ([]cache.ZMember, error) {
+	return c.l2.ZRange(ctx, key, start, stop)
+}
+
+func (c *tieredCache) ZRevRange(ctx context.Context, key string, start int64, stop int64) ([]cache.ZMember, error) {
+	return c.l2.ZRevRange(ctx, key, start, stop)
+}
+
+func (c *tieredCache) ZRangeByScore(ctx context.Context, key string, min float64, max float64) ([]cache.ZMember, error) {
+	return c.l2.ZRangeByScore(ctx, key, min, max)
+}
+
+func (c *tieredCache) ZCard(ctx context.Context, key string) (int64, error) {
+	return c.l2.ZCard(ctx, key)
+}
@@ -0,0 +1,64 @@
+package tiered
+
+import (
+	"context"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+)
+
+// pipeliner queues commands on the pipeline of Redis, recording the keys
+// written so they are dropped from L1 once it ran.
+type pipeliner struct {
+	cache.Pipeliner
+	keys []string
+}
+
+func (c *tieredCache) Pipeline(ctx context.Context, fn func(p cache.Pipeliner) error) error {
+	return c.exec(ctx, c.l2.Pipeline, fn)
+}
+
+func (c *tieredCache) TxPipeline(ctx context.Context, fn func(p cache.Pipeliner) error) error {
+	return c.exec(ctx, c.l2.TxPipeline, fn)
+}
+
+func (c *tieredCache) exec(ctx context.Context, pipeline func(context.Context, func(cache.Pipeliner) error) error, fn func(p cache.Pipeliner) error) error {
+	var keys []string
+
+	err := pipeline(ctx, func(pipe cache.Pipeliner) error {
+		p := &pipeliner{Pipeliner: pipe}
+		if err := fn(p); err != nil {
+			return err
+		}
+
+		keys = p.keys
+		return nil
+	})
+
+	// A failed pipeline may still have run some of the writes.
+	if len(keys) > 0 {
+		c.invalidate(ctx, keys...)
+	}
+
+	return err
+}
+
+func (p *pipeliner) Set(key string, val any, ttl time.Duration) {
+	p.keys = append(p.keys, key)
+	p.Pipeliner.Set(key, val, ttl)
+}
+
+func (p *pipeliner) SetNX(key string, val any, ttl time.Duration) *cache.Result[bool] {
+	p.keys = append(p.keys, key)
+	return p.Pipeliner.SetNX(key, val, ttl)
+}
+
+func (p *pipeliner) Del(keys ...string) {
+	p.keys = append(p.keys, keys...)
+	p.Pipeliner.Del(keys...)
+}
+
+func (p *pipeliner) IncrBy(key string, value int64) *cache.Result[int64] {
+	p.keys = append(p.keys, key)
+	return p.Pipeliner.IncrBy(key, value)
+}
@@ -0,0 +1,376 @@
+package tiered
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/memory"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/redis"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+)
+
+// tieredCache reads through an in-process L1 in front of Redis. Writes go
+// to Redis, then drop the key from the L1 of every instance through
+// cache.Invalidator; an instance that missed an invalidation, e.g. while
+// Redis restarted, serves the stale entry for at most
+// config.Cache.L1TTL. Counters, collections, rate limits and locks are
+// always served by Redis.
+type tieredCache struct {
+	l1 cache.Cache
+	l2 cache.Cache
+}
+
+// Driver names the backend of the cache.
+type Driver string
+
+const (
+	Redis  Driver = "redis"
+	Memory Driver = "memory"
+)
+
+// NewClient returns the cache of the application selected by CACHE_DRIVER:
+// Redis behind an L1, or Redis alone when CACHE_L1_MAX_ENTRIES is zero. The
+// memory driver caches within the process only, e.g. for local development
+// of a single instance, as locks, rate limits and invalidations are then
+// not shared; so does the Redis driver while Redis is not configured.
+func NewClient(ctx context.Context) cache.Cache {
+	c, err := newClient(ctx)
+	if err != nil {
+		logger.Fatal(ctx, err, "❌ Failed to create the cache client").Write()
+	}
+
+	return c
+}
+
+func newClient(ctx context.Context) (cache.Cache, error) {
+	switch Driver(config.Cache.Driver) {
+	case Memory:
+		logger.Info(ctx, "🗄️ Caching within the process only").Write()
+		return memory.NewClient(ctx), nil
+	case Redis:
+	default:
+		return nil, fmt.Errorf("unsupported cache driver %q", config.Cache.Driver)
+	}
+
+	if !redis.Configured() {
+		logger.Warn(ctx, "⚠️ Redis is not configured, caching within the process only").Write()
+		return memory.NewClient(ctx), nil
+	}
+
+	l2 := redis.NewClient(ctx)
+	if config.Cache.L1MaxEntries <= 0 {
+		return l2, nil
+	}
+
+	c := &tieredCache{
+		l1: memory.NewClient(ctx),
+		l2: l2,
+	}
+
+	if invalidator, ok := l2.(cache.Invalidator); ok {
+		err := invalidator.SubscribeInvalidations(ctx, func(keys []string) {
+			_ = c.l1.Del(ctx, keys...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("subscribe to cache invalidations: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *tieredCache) Ping(ctx context.Context) error {
+	return c.l2.Ping(ctx)
+}
+
+func (c *tieredCache) Get(ctx context.Context, key string) (res *cache.CacheValue, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+	})
+
+	var tier string
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+			"tier":   tier,
+		}).End(err)
+	}()
+
+	res, err = c.l1.Get(ctx, key)
+	if err == nil && res != nil {
+		tier = "l1"
+		return res, nil
+	}
+
+	res, err = c.l2.Get(ctx, key)
+	if err != nil || res == nil {
+		return res, err
+	}
+
+	tier = "l2"
+	c.fill(ctx, key, res.ToString())
+
+	return res, nil
+}
+
+func (c *tieredCache) Set(ctx context.Context, key string, val any, ttl time.Duration) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"key": key,
+		"ttl": ttl,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	if err = c.l2.Set(ctx, key, val, ttl); err != nil {
+		return err
+	}
+
+	// The value is read back from Redis on the next Get rather than kept
+	// here, so that L1 holds it exactly as Redis encoded it.
+	c.invalidate(ctx, key)
+
+	return nil
+}
+
+func (c *tieredCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return c.l2.TTL(ctx, key)
+}
+
+func (c *tieredCache) Del(ctx context.Context, keys ...string) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"keys": keys,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	if err = c.l2.Del(ctx, keys...); err != nil {
+		return err
+	}
+
+	c.invalidate(ctx, keys...)
+
+	return nil
+}
+
+func (c *tieredCache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.counted(ctx, key, func() (int64, error) {
+		return c.l2.Incr(ctx, key)
+	})
+}
+
+func (c *tieredCache) Decr(ctx context.Context, key string) (int64, error) {
+	return c.counted(ctx, key, func() (int64, error) {
+		return c.l2.Decr(ctx, key)
+	})
+}
+
+func (c *tieredCache) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
+	return c.counted(ctx, key, func() (int64, error) {
+		return c.l2.IncrBy(ctx, key, value)
+	})
+}
+
+func (c *tieredCache) DecrBy(ctx context.Context, key string, value int64) (int64, error) {
+	return c.counted(ctx, key, func() (int64, error) {
+		return c.l2.DecrBy(ctx, key, value)
+	})
+}
+
+func (c *tieredCache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.l2.Expire(ctx, key, ttl)
+}
+
+func (c *tieredCache) MGet(ctx context.Context, keys ...string) (res []*cache.CacheValue, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"keys": keys,
+	})
+
+	var hits int
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+			"l1":     hits,
+		}).End(err)
+	}()
+
+	res, err = c.l1.MGet(ctx, keys...)
+	if err != nil {
+		res = make([]*cache.CacheValue, len(keys))
+	}
+
+	var missing []string
+	var indexes []int
+	for i, val := range res {
+		if val == nil {
+			missing = append(missing, keys[i])
+			indexes = append(indexes, i)
+		}
+	}
+
+	hits = len(keys) - len(missing)
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	vals, err := c.l2.MGet(ctx, missing...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, val := range vals {
+		if val != nil {
+			res[indexes[i]] = val
+			c.fill(ctx, missing[i], val.ToString())
+		}
+	}
+
+	return res, nil
+}
+
+func (c *tieredCache) MSet(ctx context.Context, values map[string]any, ttl time.Duration) (err error) {
+	ctx, span := tracer.Start(ctx)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	span.SetFunctionInput(tracer.Metadata{
+		"keys": keys,
+		"ttl":  ttl,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	if err = c.l2.MSet(ctx, values, ttl); err != nil {
+		return err
+	}
+
+	c.invalidate(ctx, keys...)
+
+	return nil
+}
+
+func (c *tieredCache) SetNX(ctx context.Context, key string, val any, ttl time.Duration) (bool, error) {
+	ok, err := c.l2.SetNX(ctx, key, val, ttl)
+	if err == nil && ok {
+		c.invalidate(ctx, key)
+	}
+
+	return ok, err
+}
+
+func (c *tieredCache) GetDel(ctx context.Context, key string) (*cache.CacheValue, error) {
+	res, err := c.l2.GetDel(ctx, key)
+	if err == nil && res != nil {
+		c.invalidate(ctx, key)
+	}
+
+	return res, err
+}
+
+func (c *tieredCache) Scan(ctx context.Context, pattern string) iter.Seq2[string, error] {
+	return c.l2.Scan(ctx, pattern)
+}
+
+func (c *tieredCache) Allow(ctx context.Context, key string, limit cache.Limit) (*cache.LimitResult, error) {
+	return c.l2.Allow(ctx, key, limit)
+}
+
+func (c *tieredCache) Refund(ctx context.Context, key string, limit cache.Limit) error {
+	return c.l2.Refund(ctx, key, limit)
+}
+
+func (c *tieredCache) Acquire(ctx context.Context, key string, token string, ttl time.Duration) (int64, error) {
+	return c.l2.Acquire(ctx, key, token, ttl)
+}
+
+func (c *tieredCache) Extend(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	return c.l2.Extend(ctx, key, token, ttl)
+}
+
+func (c *tieredCache) Release(ctx context.Context, key string, token string) (bool, error) {
+	return c.l2.Release(ctx, key, token)
+}
+
+// Stats returns the stats of the L1.
+func (c *tieredCache) Stats() cache.Stats {
+	if reporter, ok := c.l1.(cache.StatsReporter); ok {
+		return reporter.Stats()
+	}
+
+	return cache.Stats{}
+}
+
+func (c *tieredCache) Shutdown(ctx context.Context) error {
+	stats := c.Stats()
+	logger.Infof(ctx, "📊 Cache L1 served %d hits and %d misses, evicting %d entries", stats.Hits, stats.Misses, stats.Evictions).Write()
+
+	if err := c.l1.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	return c.l2.Shutdown(ctx)
+}
+
+// fill keeps val in L1 for at most config.Cache.L1TTL, and no longer than
+// it has left in Redis, so L1 never serves an entry Redis expired.
+func (c *tieredCache) fill(ctx context.Context, key string, val string) {
+	ttl := config.Cache.L1TTL
+
+	remaining, err := c.l2.TTL(ctx, key)
+	if err != nil {
+		logger.Warnf(ctx, "⚠️ Failed to read the TTL of %s, not keeping it in the L1 cache: %v", key, err).Write()
+		return
+	}
+
+	// -1 is an entry without expiry, -2 one gone since it was read.
+	if remaining == -2 || remaining == 0 {
+		return
+	}
+	if remaining > 0 && remaining < ttl {
+		ttl = remaining
+	}
+
+	if err := c.l1.Set(ctx, key, val, ttl); err != nil {
+		logger.Warnf(ctx, "⚠️ Failed to keep %s in the L1 cache: %v", key, err).Write()
+	}
+}
+
+// invalidate drops keys from the L1 of every instance.
+func (c *tieredCache) invalidate(ctx context.Context, keys ...string) {
+	_ = c.l1.Del(ctx, keys...)
+
+	invalidator, ok := c.l2.(cache.Invalidator)
+	if !ok {
+		return
+	}
+
+	if err := invalidator.PublishInvalidation(ctx, keys...); err != nil {
+		logger.Errorf(ctx, err, "❌ Failed to publish the invalidation of the cache").Write()
+	}
+}
+
+func (c *tieredCache) counted(ctx context.Context, key string, count func() (int64, error)) (int64, error) {
+	res, err := count()
+	if err == nil {
+		c.invalidate(ctx, key)
+	}
+
+	return res, err
+}
@@ -0,0 +1,208 @@
+package tiered
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+// withCacheConfig restores the cache and Redis configuration after the
+// test, and selects the Redis driver with a long-lived L1.
+func withCacheConfig(t *testing.T) {
+	t.Helper()
+
+	previousCache, previousRedis := config.Cache, config.Redis
+	t.Cleanup(func() {
+		config.Cache, config.Redis = previousCache, previousRedis
+	})
+
+	config.Cache.Driver = string(Redis)
+	config.Cache.L1MaxEntries = 100
+	config.Cache.L1TTL = time.Minute
+	config.Cache.InvalidationChannel = "cache:invalidation"
+	config.Redis = config.RedisConfig{Mode: "standalone"}
+}
+
+// withRedis points the configuration at an in-memory Redis server.
+func withRedis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+
+	m := miniredis.RunT(t)
+	port, err := strconv.Atoi(m.Port())
+	require.NoError(t, err)
+
+	config.Redis.Host = m.Host()
+	config.Redis.Port = port
+
+	return m
+}
+
+// newTestClient returns a client shut down with the test.
+func newTestClient(t *testing.T) cache.Cache {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c, err := newClient(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		cancel()
+		c.Shutdown(context.Background())
+	})
+
+	return c
+}
+
+func TestNewClient(t *testing.T) {
+	cases := []struct {
+		name    string
+		driver  Driver
+		redis   bool
+		l1      int
+		tiered  bool
+		wantErr string
+	}{
+		{name: "memory", driver: Memory},
+		{name: "redis behind l1", driver: Redis, redis: true, l1: 100, tiered: true},
+		{name: "redis alone", driver: Redis, redis: true},
+		{name: "redis not configured", driver: Redis, l1: 100},
+		{name: "unsupported driver", driver: "memcached", wantErr: `unsupported cache driver "memcached"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withCacheConfig(t)
+			config.Cache.Driver = string(tc.driver)
+			config.Cache.L1MaxEntries = tc.l1
+			if tc.redis {
+				withRedis(t)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			c, err := newClient(ctx)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer c.Shutdown(context.Background())
+
+			_, tiered := c.(*tieredCache)
+			assert.Equal(t, tc.tiered, tiered)
+			assert.NoError(t, c.Ping(ctx))
+		})
+	}
+}
+
+func TestTieredCache_Get_FillsL1(t *testing.T) {
+	withCacheConfig(t)
+	m := withRedis(t)
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "greeting", "hello", time.Minute))
+
+	res, err := c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.ToString())
+
+	// Served by the L1 once read, even if Redis lost it.
+	m.Del("greeting")
+
+	res, err = c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, "hello", res.ToString())
+	assert.Equal(t, uint64(1), c.(cache.StatsReporter).Stats().Hits)
+}
+
+func TestTieredCache_Get_FillsL1UpToRedisTTL(t *testing.T) {
+	withCacheConfig(t)
+	withRedis(t)
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "short", "hello", 5*time.Second))
+	require.NoError(t, c.Set(ctx, "forever", "hello", 0))
+
+	_, err := c.Get(ctx, "short")
+	require.NoError(t, err)
+	_, err = c.Get(ctx, "forever")
+	require.NoError(t, err)
+
+	l1 := c.(*tieredCache).l1
+	short, err := l1.TTL(ctx, "short")
+	require.NoError(t, err)
+	forever, err := l1.TTL(ctx, "forever")
+	require.NoError(t, err)
+
+	assert.Greater(t, short, time.Duration(0))
+	assert.LessOrEqual(t, short, 5*time.Second)
+	assert.Greater(t, forever, 5*time.Second)
+	assert.LessOrEqual(t, forever, time.Minute)
+}
+
+func TestTieredCache_Set_InvalidatesL1(t *testing.T) {
+	withCacheConfig(t)
+	withRedis(t)
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Set(ctx, "greeting", "hello", time.Minute))
+	_, err := c.Get(ctx, "greeting")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set(ctx, "greeting", "bonjour", time.Minute))
+
+	res, err := c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "bonjour", res.ToString())
+
+	require.NoError(t, c.Del(ctx, "greeting"))
+
+	res, err = c.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestTieredCache_Invalidation(t *testing.T) {
+	withCacheConfig(t)
+	withRedis(t)
+	writer := newTestClient(t)
+	reader := newTestClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, writer.Set(ctx, "greeting", "hello", time.Minute))
+
+	res, err := reader.Get(ctx, "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.ToString())
+
+	// The write of the other instance drops the entry from the L1 of the
+	// reader through pub/sub.
+	require.NoError(t, writer.Set(ctx, "greeting", "bonjour", time.Minute))
+
+	assert.Eventually(t, func() bool {
+		res, err := reader.Get(ctx, "greeting")
+		return err == nil && res != nil && res.ToString() == "bonjour"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -1,7 +1,6 @@
 package cache
 
 import (
-	"encoding/json"
 	"strconv"
 )
 
@@ -81,11 +80,12 @@ func (v *CacheValue) IsEmpty() bool {
 	return v == nil || string(*v) == ""
 }
 
+// Decode unmarshals the value into obj, a pointer, as the package Decode
+// does.
 func (v *CacheValue) Decode(obj any) error {
 	if v == nil {
 		return nil
 	}
 
-	err := json.Unmarshal([]byte(string(*v)), &obj)
-	return err
+	return Decode(v.ToBytes(), obj)
 }
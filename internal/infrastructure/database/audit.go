@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/utils/masker"
+	"github.com/google/uuid"
+)
+
+// AuditOperation is the kind of change recorded in the audit trail.
+type AuditOperation string
+
+const (
+	AuditInsert  AuditOperation = "insert"
+	AuditUpdate  AuditOperation = "update"
+	AuditDelete  AuditOperation = "delete"
+	AuditRestore AuditOperation = "restore"
+	AuditUpsert  AuditOperation = "upsert"
+)
+
+const (
+	// SystemActor is recorded for changes made outside of a request, such
+	// as background jobs.
+	SystemActor = "system"
+	// AnonymousActor is recorded for requests that carry no identity.
+	AnonymousActor = "anonymous"
+)
+
+type actorKey struct{}
+
+// WithActor returns a context carrying who is making the changes.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx, SystemActor otherwise.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+
+	return SystemActor
+}
+
+// AuditEnabled reports whether base repositories record an audit log of the
+// changes made by every write, bulk writes and purges included.
+func AuditEnabled() bool {
+	return config.Audit.Enabled
+}
+
+// FieldChange holds the masked value of a field before and after a change.
+type FieldChange struct {
+	Before any `json:"before,omitempty" bson:"before,omitempty"`
+	After  any `json:"after,omitempty" bson:"after,omitempty"`
+}
+
+// AuditLog records a change made to a single record.
+type AuditLog struct {
+	TenantModel `bson:",inline"`
+	ID          uuid.UUID              `json:"id" bson:"_id"`
+	Actor       string                 `json:"actor" bson:"actor"`
+	Entity      string                 `json:"entity" bson:"entity"`
+	EntityID    string                 `json:"entity_id" bson:"entity_id"`
+	Operation   AuditOperation         `json:"operation" bson:"operation"`
+	Changes     map[string]FieldChange `json:"changes" gorm:"serializer:json" bson:"changes"`
+	CreatedAt   time.Time              `json:"created_at" bson:"created_at"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (AuditLog) RepositoryName() string {
+	return "AuditLogRepository"
+}
+
+func (AuditLog) Indexes() []Index {
+	return []Index{
+		{Name: "idx_audit_logs_entity", Keys: []string{"entity", "entity_id", "created_at"}},
+		{Keys: []string{"actor"}},
+		{Keys: []string{"tenant_id"}},
+	}
+}
+
+// NewAuditLogs pairs the records before and after a write by primary key
+// and returns an audit log for every record that changed. Inserts have no
+// records before and hard deletes none after.
+func NewAuditLogs[I any, E Entity](ctx context.Context, op AuditOperation, before []E, after []E) ([]AuditLog, error) {
+	var entity E
+
+	previous := make(map[string]E, len(before))
+	for _, model := range before {
+		previous[auditKey[I](model)] = model
+	}
+
+	logs := make([]AuditLog, 0, max(len(before), len(after)))
+	add := func(id string, before, after any) error {
+		changes, err := Diff(before, after)
+		if err != nil || len(changes) == 0 {
+			return err
+		}
+
+		log := AuditLog{
+			Actor:     ActorFromContext(ctx),
+			Entity:    entity.TableName(),
+			EntityID:  id,
+			Operation: op,
+			Changes:   changes,
+			CreatedAt: Now(),
+		}
+
+		log.ID, err = uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		StampTenant(ctx, &log)
+		logs = append(logs, log)
+
+		return nil
+	}
+
+	for _, model := range after {
+		id := auditKey[I](model)
+
+		var err error
+		if old, ok := previous[id]; ok {
+			err = add(id, old, model)
+			delete(previous, id)
+		} else {
+			err = add(id, nil, model)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, model := range before {
+		id := auditKey[I](model)
+		if old, ok := previous[id]; ok {
+			if err := add(id, old, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+func auditKey[I any](model any) string {
+	if pk, ok := model.(interface{ PrimaryKey() I }); ok {
+		return fmt.Sprint(pk.PrimaryKey())
+	}
+
+	return ""
+}
+
+// Diff returns the fields whose JSON value differs between before and
+// after, either of which may be nil. Values are masked with the masker so
+// sensitive fields never reach the audit trail in clear.
+func Diff(before any, after any) (map[string]FieldChange, error) {
+	old, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changedBefore := map[string]any{}
+	changedAfter := map[string]any{}
+	for name, value := range cur {
+		prev, ok := old[name]
+		if !ok && value == nil {
+			continue
+		}
+
+		if !ok || !reflect.DeepEqual(prev, value) {
+			changedAfter[name] = value
+			if ok {
+				changedBefore[name] = prev
+			}
+		}
+	}
+	for name, value := range old {
+		if _, ok := cur[name]; !ok && value != nil {
+			changedBefore[name] = value
+		}
+	}
+
+	maskedBefore, _ := masker.Mask(changedBefore).(map[string]any)
+	maskedAfter, _ := masker.Mask(changedAfter).(map[string]any)
+
+	changes := make(map[string]FieldChange, len(changedAfter)+len(changedBefore))
+	for name := range changedBefore {
+		changes[name] = FieldChange{Before: maskedBefore[name]}
+	}
+	for name := range changedAfter {
+		change := changes[name]
+		change.After = maskedAfter[name]
+		changes[name] = change
+	}
+
+	return changes, nil
+}
+
+func fields(model any) (map[string]any, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type member struct {
+	BaseEntity[int64]
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+func (member) TableName() string {
+	return "members"
+}
+
+func (member) RepositoryName() string {
+	return "MemberRepository"
+}
+
+func newMember(id int64, name string) member {
+	m := member{Name: name, Password: "hunter2"}
+	m.ID = id
+
+	return m
+}
+
+func TestDiff(t *testing.T) {
+	acme := newMember(1, "Acme")
+	renamed := newMember(1, "Globex")
+	rekeyed := newMember(1, "Acme")
+	rekeyed.Password = "correct horse"
+
+	cases := []struct {
+		name    string
+		before  any
+		after   any
+		changes map[string]FieldChange
+	}{
+		{
+			name:    "unchanged",
+			before:  acme,
+			after:   acme,
+			changes: map[string]FieldChange{},
+		},
+		{
+			name:    "changed field",
+			before:  acme,
+			after:   renamed,
+			changes: map[string]FieldChange{"name": {Before: "Acme", After: "Globex"}},
+		},
+		{
+			name:   "insert",
+			before: nil,
+			after:  acme,
+			changes: map[string]FieldChange{
+				"id":       {After: float64(1)},
+				"name":     {After: "Acme"},
+				"password": {After: "************"},
+			},
+		},
+		{
+			name:   "delete",
+			before: acme,
+			after:  nil,
+			changes: map[string]FieldChange{
+				"id":       {Before: float64(1)},
+				"name":     {Before: "Acme"},
+				"password": {Before: "************"},
+			},
+		},
+		{
+			name:    "masked field",
+			before:  acme,
+			after:   rekeyed,
+			changes: map[string]FieldChange{"password": {Before: "************", After: "************"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, err := Diff(tc.before, tc.after)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.changes, changes)
+		})
+	}
+}
+
+func TestNewAuditLogs(t *testing.T) {
+	ctx := WithActor(context.Background(), "alice")
+
+	logs, err := NewAuditLogs[int64](ctx, AuditUpsert,
+		[]member{newMember(1, "Acme"), newMember(2, "Globex"), newMember(3, "Initech")},
+		[]member{newMember(1, "Acme"), newMember(2, "Umbrella"), newMember(4, "Hooli")},
+	)
+	require.NoError(t, err)
+
+	byEntity := map[string]AuditLog{}
+	for _, log := range logs {
+		assert.Equal(t, "alice", log.Actor)
+		assert.Equal(t, "members", log.Entity)
+		assert.Equal(t, AuditUpsert, log.Operation)
+		assert.NotZero(t, log.ID)
+		byEntity[log.EntityID] = log
+	}
+
+	require.Len(t, byEntity, 3)
+	assert.Equal(t, map[string]FieldChange{"name": {Before: "Globex", After: "Umbrella"}}, byEntity["2"].Changes)
+	assert.Equal(t, "Initech", byEntity["3"].Changes["name"].Before)
+	assert.Nil(t, byEntity["3"].Changes["name"].After)
+	assert.Equal(t, "Hooli", byEntity["4"].Changes["name"].After)
+	assert.Nil(t, byEntity["4"].Changes["name"].Before)
+}
+
+func TestNewAuditLogs_Tenant(t *testing.T) {
+	withTenantMode(t, TenantColumn)
+
+	logs, err := NewAuditLogs[int64](WithTenant(context.Background(), "acme"), AuditInsert, nil, []member{newMember(1, "Acme")})
+	require.NoError(t, err)
+
+	require.Len(t, logs, 1)
+	assert.Equal(t, "acme", logs[0].TenantID)
+	assert.Equal(t, SystemActor, logs[0].Actor)
+}
@@ -0,0 +1,46 @@
+package database
+
+// UpsertOptions configures how Upsert and UpsertMany resolve conflicts.
+type UpsertOptions struct {
+	// Conflict lists the columns of the unique key that detects an existing
+	// record. Defaults to the primary key.
+	Conflict []string
+	// Update lists the columns overwritten when the record already exists.
+	// Defaults to every column except the primary key and created_at.
+	Update []string
+}
+
+type WriteKind string
+
+const (
+	WriteInsert WriteKind = "insert"
+	WriteUpdate WriteKind = "update"
+	WriteDelete WriteKind = "delete"
+)
+
+// WriteOperation is a single insert, update or delete executed by BulkWrite.
+type WriteOperation[E Entity] struct {
+	Kind    WriteKind
+	Model   E
+	Filter  map[string]any
+	Payload map[string]any
+}
+
+func InsertOperation[E Entity](model E) WriteOperation[E] {
+	return WriteOperation[E]{Kind: WriteInsert, Model: model}
+}
+
+func UpdateOperation[E Entity](filter map[string]any, payload map[string]any) WriteOperation[E] {
+	return WriteOperation[E]{Kind: WriteUpdate, Filter: filter, Payload: payload}
+}
+
+// DeleteOperation soft deletes the records matching filter, like DeleteMany.
+func DeleteOperation[E Entity](filter map[string]any) WriteOperation[E] {
+	return WriteOperation[E]{Kind: WriteDelete, Filter: filter}
+}
+
+type BulkWriteResult struct {
+	Inserted int64 `json:"inserted"`
+	Updated  int64 `json:"updated"`
+	Deleted  int64 `json:"deleted"`
+}
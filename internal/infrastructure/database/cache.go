@@ -0,0 +1,495 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheVersioner is implemented by entities whose cached form changed, e.g.
+// after a field was added or renamed. Bumping the version moves the entity
+// to new cache keys, leaving the old entries to expire.
+type CacheVersioner interface {
+	CacheVersion() int
+}
+
+// notFound is cached for the IDs that have no record.
+const notFound = "null"
+
+type cachedRepo[I any, E Entity] struct {
+	BaseRepository[I, E]
+	cache     cache.Cache
+	group     singleflight.Group
+	preloaded bool
+}
+
+// NewCachedRepository returns repo with FindById and FindByIds read through
+// the cache for config.Database.CacheTTL, and the IDs without a record
+// remembered for config.Database.CacheNegativeTTL. Concurrent misses of the
+// same records make a single query. Writes through the repository
+// invalidate the records they change once committed; writes by filter
+// invalidate every cached record of the entity. Reads that preload
+// relations, must see the primary or run in a transaction bypass the cache,
+// and a failing cache falls back to the database.
+func NewCachedRepository[I any, E Entity](repo BaseRepository[I, E], cache cache.Cache) BaseRepository[I, E] {
+	if config.Database.CacheTTL <= 0 {
+		return repo
+	}
+
+	return &cachedRepo[I, E]{
+		BaseRepository: repo,
+		cache:          cache,
+	}
+}
+
+func (r *cachedRepo[I, E]) FindById(ctx context.Context, ID I) (res *E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"id": ID,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	if r.bypassCache(ctx) {
+		return r.BaseRepository.FindById(ctx, ID)
+	}
+
+	key := r.key(ctx, r.namespace(ctx), ID)
+
+	if res, ok := r.get(ctx, key); ok {
+		return res, nil
+	}
+
+	val, err := r.share(ctx, key, func(ctx context.Context) (any, error) {
+		res, err := r.BaseRepository.FindById(ctx, ID)
+		if err != nil {
+			return nil, err
+		}
+
+		r.set(ctx, key, res)
+
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clone(val.(*E)), nil
+}
+
+// FindByIds returns the records in the order of IDs, reading only those
+// missing from the cache from the database.
+func (r *cachedRepo[I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"ids": IDs,
+	})
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	if r.bypassCache(ctx) {
+		return r.BaseRepository.FindByIds(ctx, IDs)
+	}
+
+	namespace := r.namespace(ctx)
+
+	keys := make([]string, 0, len(IDs))
+	uniqueIDs := make([]I, 0, len(IDs))
+	for _, ID := range IDs {
+		key := r.key(ctx, namespace, ID)
+		if slices.Contains(keys, key) {
+			continue
+		}
+		keys = append(keys, key)
+		uniqueIDs = append(uniqueIDs, ID)
+	}
+
+	vals, err := r.cache.MGet(ctx, keys...)
+	if err != nil {
+		logger.Warnf(ctx, "⚠️ Failed to read %d records of %s from the cache: %v", len(keys), namespace, err).Write()
+		vals = make([]*cache.CacheValue, len(keys))
+	}
+
+	found := make(map[string]*E, len(keys))
+	var missing []I
+	var missingKeys []string
+	for i, key := range keys {
+		if model, ok := r.decode(ctx, key, vals[i]); ok {
+			found[key] = model
+			continue
+		}
+
+		missing = append(missing, uniqueIDs[i])
+		missingKeys = append(missingKeys, key)
+	}
+
+	if len(missing) > 0 {
+		val, err := r.share(ctx, strings.Join(missingKeys, ","), func(ctx context.Context) (any, error) {
+			models, err := r.BaseRepository.FindByIds(ctx, missing)
+			if err != nil {
+				return nil, err
+			}
+
+			loaded := make(map[string]*E, len(missing))
+			for i := range models {
+				loaded[r.key(ctx, namespace, primaryKeyOf[I](models[i]))] = &models[i]
+			}
+
+			for _, key := range missingKeys {
+				r.set(ctx, key, loaded[key])
+			}
+
+			return loaded, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for key, model := range val.(map[string]*E) {
+			found[key] = clone(model)
+		}
+	}
+
+	res = make([]E, 0, len(keys))
+	for _, key := range keys {
+		if model := found[key]; model != nil {
+			res = append(res, *model)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *cachedRepo[I, E]) Insert(ctx context.Context, model E, trx *Tx) (E, error) {
+	res, err := r.BaseRepository.Insert(ctx, model, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, primaryKeyOf[I](res))
+	}
+
+	return res, err
+}
+
+func (r *cachedRepo[I, E]) InsertMany(ctx context.Context, models []E, trx *Tx) ([]E, error) {
+	res, err := r.BaseRepository.InsertMany(ctx, models, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, primaryKeysOf[I](res)...)
+	}
+
+	return res, err
+}
+
+func (r *cachedRepo[I, E]) Upsert(ctx context.Context, model E, opts UpsertOptions, trx *Tx) (E, error) {
+	res, err := r.BaseRepository.Upsert(ctx, model, opts, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, primaryKeyOf[I](res))
+	}
+
+	return res, err
+}
+
+func (r *cachedRepo[I, E]) UpsertMany(ctx context.Context, models []E, opts UpsertOptions, trx *Tx) ([]E, error) {
+	res, err := r.BaseRepository.UpsertMany(ctx, models, opts, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, primaryKeysOf[I](res)...)
+	}
+
+	return res, err
+}
+
+func (r *cachedRepo[I, E]) BulkWrite(ctx context.Context, ops []WriteOperation[E], trx *Tx) (BulkWriteResult, error) {
+	res, err := r.BaseRepository.BulkWrite(ctx, ops, trx)
+	if err == nil {
+		r.invalidateAll(ctx, trx)
+	}
+
+	return res, err
+}
+
+func (r *cachedRepo[I, E]) Update(ctx context.Context, model E, trx *Tx) error {
+	err := r.BaseRepository.Update(ctx, model, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, primaryKeyOf[I](model))
+	}
+
+	return err
+}
+
+func (r *cachedRepo[I, E]) UpdateById(ctx context.Context, ID I, payload map[string]any, trx *Tx) (E, error) {
+	res, err := r.BaseRepository.UpdateById(ctx, ID, payload, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, ID)
+	}
+
+	return res, err
+}
+
+func (r *cachedRepo[I, E]) UpdateByIds(ctx context.Context, IDs []I, payload map[string]any, trx *Tx) error {
+	err := r.BaseRepository.UpdateByIds(ctx, IDs, payload, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, IDs...)
+	}
+
+	return err
+}
+
+func (r *cachedRepo[I, E]) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *Tx) error {
+	err := r.BaseRepository.UpdateMany(ctx, filter, payload, trx)
+	if err == nil {
+		r.invalidateAll(ctx, trx)
+	}
+
+	return err
+}
+
+func (r *cachedRepo[I, E]) DeleteById(ctx context.Context, ID I, trx *Tx) error {
+	err := r.BaseRepository.DeleteById(ctx, ID, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, ID)
+	}
+
+	return err
+}
+
+func (r *cachedRepo[I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *Tx) error {
+	err := r.BaseRepository.DeleteByIds(ctx, IDs, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, IDs...)
+	}
+
+	return err
+}
+
+func (r *cachedRepo[I, E]) DeleteMany(ctx context.Context, filter map[string]any, trx *Tx) error {
+	err := r.BaseRepository.DeleteMany(ctx, filter, trx)
+	if err == nil {
+		r.invalidateAll(ctx, trx)
+	}
+
+	return err
+}
+
+func (r *cachedRepo[I, E]) Restore(ctx context.Context, ID I, trx *Tx) error {
+	err := r.BaseRepository.Restore(ctx, ID, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, ID)
+	}
+
+	return err
+}
+
+func (r *cachedRepo[I, E]) ForceDelete(ctx context.Context, ID I, trx *Tx) error {
+	err := r.BaseRepository.ForceDelete(ctx, ID, trx)
+	if err == nil {
+		r.invalidate(ctx, trx, ID)
+	}
+
+	return err
+}
+
+// Preload returns the repository with its reads loading the given
+// relations from the database, since the cache holds records without their
+// relations. Its writes still invalidate the cache.
+func (r *cachedRepo[I, E]) Preload(relations ...string) BaseRepository[I, E] {
+	return &cachedRepo[I, E]{
+		BaseRepository: r.BaseRepository.Preload(relations...),
+		cache:          r.cache,
+		preloaded:      true,
+	}
+}
+
+// bypassCache reports whether reads made with ctx must not be served from
+// the cache, which holds records without their relations, may lag behind
+// the primary and never holds the uncommitted writes of a transaction.
+func (r *cachedRepo[I, E]) bypassCache(ctx context.Context) bool {
+	return r.preloaded || ReadFromPrimary(ctx) || InTransaction(ctx)
+}
+
+// share makes concurrent misses of the same key run load once. load is
+// detached from the cancellation of the caller that started it, so that it
+// does not fail the others, while every caller stops waiting once its own
+// ctx is done.
+func (r *cachedRepo[I, E]) share(ctx context.Context, key string, load func(ctx context.Context) (any, error)) (any, error) {
+	ch := r.group.DoChan(key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		if config.ContextTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, config.ContextTimeout)
+			defer cancel()
+		}
+
+		return load(ctx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		return res.Val, res.Err
+	}
+}
+
+// clone returns a copy of model, so that callers sharing a load do not
+// share the record.
+func clone[E any](model *E) *E {
+	if model == nil {
+		return nil
+	}
+
+	res := *model
+	return &res
+}
+
+// namespace returns the prefix of the cache keys of the entity: its table,
+// cache version and generation, which invalidateAll moves forward.
+func (r *cachedRepo[I, E]) namespace(ctx context.Context) string {
+	prefix := r.prefix()
+
+	val, err := r.cache.Get(ctx, prefix+":generation")
+	if err != nil {
+		logger.Warnf(ctx, "⚠️ Failed to read the cache generation of %s: %v", prefix, err).Write()
+	}
+
+	return fmt.Sprintf("%s:g%d", prefix, val.ToInt64())
+}
+
+func (r *cachedRepo[I, E]) prefix() string {
+	var entity E
+
+	version := 1
+	if v, ok := any(entity).(CacheVersioner); ok {
+		version = v.CacheVersion()
+	}
+
+	return fmt.Sprintf("repository:%s:v%d", entity.TableName(), version)
+}
+
+// key returns the cache key of a record, scoped by tenant since the same ID
+// must not be read across tenants.
+func (r *cachedRepo[I, E]) key(ctx context.Context, namespace string, ID I) string {
+	tenant, _ := TenantFromContext(ctx)
+
+	return fmt.Sprintf("%s:%s:%v", namespace, tenant, ID)
+}
+
+// get returns the cached record under key, nil when it is cached as
+// missing, and whether the key was cached at all.
+func (r *cachedRepo[I, E]) get(ctx context.Context, key string) (*E, bool) {
+	val, err := r.cache.Get(ctx, key)
+	if err != nil {
+		logger.Warnf(ctx, "⚠️ Failed to read %s from the cache: %v", key, err).Write()
+		return nil, false
+	}
+
+	return r.decode(ctx, key, val)
+}
+
+// decode returns the record cached as val under key, as get does.
+func (r *cachedRepo[I, E]) decode(ctx context.Context, key string, val *cache.CacheValue) (*E, bool) {
+	if val.IsEmpty() {
+		return nil, false
+	}
+
+	var res *E
+	if err := cache.Decode(val.ToBytes(), &res); err != nil {
+		logger.Warnf(ctx, "⚠️ Failed to decode %s from the cache: %v", key, err).Write()
+		return nil, false
+	}
+
+	return res, true
+}
+
+func (r *cachedRepo[I, E]) set(ctx context.Context, key string, model *E) {
+	val, ttl := notFound, config.Database.CacheNegativeTTL
+	if model != nil {
+		data, err := cache.Encode(cache.JSON, model)
+		if err != nil {
+			logger.Warnf(ctx, "⚠️ Failed to encode %s for the cache: %v", key, err).Write()
+			return
+		}
+
+		val, ttl = string(data), config.Database.CacheTTL
+	}
+
+	if ttl <= 0 {
+		return
+	}
+
+	if err := r.cache.Set(ctx, key, val, ttl); err != nil {
+		logger.Warnf(ctx, "⚠️ Failed to write %s to the cache: %v", key, err).Write()
+	}
+}
+
+// invalidate deletes the cached records once the write is visible: when
+// its transaction commits, or right away outside transactions. Deleting them
+// before would let a concurrent read cache them again as they were.
+func (r *cachedRepo[I, E]) invalidate(ctx context.Context, trx *Tx, IDs ...I) {
+	if len(IDs) == 0 {
+		return
+	}
+
+	namespace := r.namespace(ctx)
+
+	keys := make([]string, 0, len(IDs))
+	for _, ID := range IDs {
+		keys = append(keys, r.key(ctx, namespace, ID))
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	AfterCommit(ctx, trx, func() {
+		r.del(ctx, keys)
+	})
+}
+
+// invalidateAll moves the entity to a new generation of keys, for writes
+// that do not tell which records they changed, once the write is visible as
+// invalidate does. The entries of the previous generation are left to
+// expire.
+func (r *cachedRepo[I, E]) invalidateAll(ctx context.Context, trx *Tx) {
+	key := r.prefix() + ":generation"
+
+	ctx = context.WithoutCancel(ctx)
+	AfterCommit(ctx, trx, func() {
+		if _, err := r.cache.Incr(ctx, key); err != nil {
+			logger.Errorf(ctx, err, "❌ Failed to invalidate the cache of %s", r.prefix()).Write()
+		}
+	})
+}
+
+func (r *cachedRepo[I, E]) del(ctx context.Context, keys []string) {
+	if err := r.cache.Del(ctx, keys...); err != nil {
+		logger.Errorf(ctx, err, "❌ Failed to invalidate %s in the cache", strings.Join(keys, ", ")).Write()
+	}
+}
+
+func primaryKeyOf[I any](model any) I {
+	var ID I
+	if m, ok := model.(interface{ PrimaryKey() I }); ok {
+		ID = m.PrimaryKey()
+	}
+
+	return ID
+}
+
+func primaryKeysOf[I any, E any](models []E) []I {
+	IDs := make([]I, 0, len(models))
+	for _, model := range models {
+		IDs = append(IDs, primaryKeyOf[I](model))
+	}
+
+	return IDs
+}
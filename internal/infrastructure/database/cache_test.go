@@ -0,0 +1,358 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	BaseEntity[int64]
+	Name string `json:"name"`
+}
+
+func (item) TableName() string {
+	return "items"
+}
+
+func (item) RepositoryName() string {
+	return "ItemRepository"
+}
+
+// itemRepo serves items from memory, counting the reads reaching it.
+type itemRepo struct {
+	BaseRepository[int64, item]
+	mu      sync.Mutex
+	items   map[int64]item
+	reads   atomic.Int32
+	release chan struct{}
+}
+
+func newItemRepo(items ...item) *itemRepo {
+	repo := &itemRepo{items: map[int64]item{}}
+	for _, it := range items {
+		repo.items[it.ID] = it
+	}
+
+	return repo
+}
+
+func (r *itemRepo) FindById(ctx context.Context, ID int64) (*item, error) {
+	r.reads.Add(1)
+	if r.release != nil {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	it, ok := r.items[ID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &it, nil
+}
+
+func (r *itemRepo) FindByIds(ctx context.Context, IDs []int64) ([]item, error) {
+	r.reads.Add(1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var res []item
+	for _, ID := range IDs {
+		if it, ok := r.items[ID]; ok {
+			res = append(res, it)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *itemRepo) Preload(relations ...string) BaseRepository[int64, item] {
+	return r
+}
+
+func (r *itemRepo) UpdateById(ctx context.Context, ID int64, payload map[string]any, trx *Tx) (item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	it := r.items[ID]
+	it.Name = payload["name"].(string)
+	r.items[ID] = it
+
+	return it, nil
+}
+
+func (r *itemRepo) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for ID, it := range r.items {
+		it.Name = payload["name"].(string)
+		r.items[ID] = it
+	}
+
+	return nil
+}
+
+// txTransactor runs functions in a pretend transaction.
+type txTransactor struct{}
+
+func (txTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ContextWithTx(ctx, NewTx(nil)))
+}
+
+func newCachedItemRepo(t *testing.T, items ...item) (BaseRepository[int64, item], *itemRepo) {
+	t.Helper()
+
+	ttl, negativeTTL := config.Database.CacheTTL, config.Database.CacheNegativeTTL
+	t.Cleanup(func() {
+		config.Database.CacheTTL, config.Database.CacheNegativeTTL = ttl, negativeTTL
+	})
+
+	config.Database.CacheTTL = time.Minute
+	config.Database.CacheNegativeTTL = time.Minute
+
+	repo := newItemRepo(items...)
+
+	return NewCachedRepository[int64, item](repo, memory.NewClient(t.Context())), repo
+}
+
+func withTransactor(t *testing.T, tr Transactor) {
+	t.Helper()
+
+	previous := transactor
+	t.Cleanup(func() {
+		transactor = previous
+	})
+
+	transactor = tr
+}
+
+func TestCachedRepo_FindById(t *testing.T) {
+	cached, repo := newCachedItemRepo(t, item{BaseEntity: BaseEntity[int64]{ID: 1}, Name: "apple"})
+	ctx := context.Background()
+
+	first, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+	second, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "apple", second.Name)
+	assert.Equal(t, first, second)
+	assert.EqualValues(t, 1, repo.reads.Load())
+}
+
+func TestCachedRepo_FindById_NotFound(t *testing.T) {
+	cached, repo := newCachedItemRepo(t)
+	ctx := context.Background()
+
+	first, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+	second, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Nil(t, first)
+	assert.Nil(t, second)
+	assert.EqualValues(t, 1, repo.reads.Load())
+}
+
+func TestCachedRepo_FindByIds(t *testing.T) {
+	cached, repo := newCachedItemRepo(t,
+		item{BaseEntity: BaseEntity[int64]{ID: 1}, Name: "apple"},
+		item{BaseEntity: BaseEntity[int64]{ID: 2}, Name: "pear"},
+	)
+	ctx := context.Background()
+
+	_, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	res, err := cached.FindByIds(ctx, []int64{2, 1, 3, 2})
+	require.NoError(t, err)
+	again, err := cached.FindByIds(ctx, []int64{1, 2, 3})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"pear", "apple"}, []string{res[0].Name, res[1].Name})
+	assert.Len(t, again, 2)
+	assert.EqualValues(t, 2, repo.reads.Load())
+}
+
+func TestCachedRepo_Preload(t *testing.T) {
+	cached, repo := newCachedItemRepo(t, item{BaseEntity: BaseEntity[int64]{ID: 1}, Name: "apple"})
+	ctx := context.Background()
+
+	_, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	// Records are cached without their relations, so preloading reads skip
+	// the cache, while their writes still invalidate it.
+	preloaded := cached.Preload("Tags")
+	_, err = preloaded.FindById(ctx, 1)
+	require.NoError(t, err)
+	_, err = preloaded.UpdateById(ctx, 1, map[string]any{"name": "pear"}, nil)
+	require.NoError(t, err)
+
+	updated, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pear", updated.Name)
+	assert.EqualValues(t, 3, repo.reads.Load())
+}
+
+func TestCachedRepo_Invalidate(t *testing.T) {
+	cached, repo := newCachedItemRepo(t, item{BaseEntity: BaseEntity[int64]{ID: 1}, Name: "apple"})
+	ctx := context.Background()
+
+	_, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+	_, err = cached.UpdateById(ctx, 1, map[string]any{"name": "pear"}, nil)
+	require.NoError(t, err)
+	updated, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, cached.UpdateMany(ctx, map[string]any{}, map[string]any{"name": "plum"}, nil))
+	renamed, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pear", updated.Name)
+	assert.Equal(t, "plum", renamed.Name)
+	assert.EqualValues(t, 3, repo.reads.Load())
+}
+
+func TestCachedRepo_Transaction(t *testing.T) {
+	withTransactor(t, txTransactor{})
+	cached, repo := newCachedItemRepo(t, item{BaseEntity: BaseEntity[int64]{ID: 1}, Name: "apple"})
+	ctx := context.Background()
+
+	_, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	var during *item
+	err = WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := cached.UpdateById(ctx, 1, map[string]any{"name": "pear"}, nil); err != nil {
+			return err
+		}
+
+		// The write is not committed yet, so others still read the
+		// cached record, while the transaction reads its own write.
+		outside, err := cached.FindById(context.Background(), 1)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, "apple", outside.Name)
+
+		during, err = cached.FindById(ctx, 1)
+		return err
+	})
+	require.NoError(t, err)
+
+	after, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pear", during.Name)
+	assert.Equal(t, "pear", after.Name)
+	assert.EqualValues(t, 3, repo.reads.Load())
+}
+
+func TestCachedRepo_Transaction_Rollback(t *testing.T) {
+	withTransactor(t, txTransactor{})
+	cached, repo := newCachedItemRepo(t, item{BaseEntity: BaseEntity[int64]{ID: 1}, Name: "apple"})
+	ctx := context.Background()
+
+	_, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	err = WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := cached.UpdateById(ctx, 1, map[string]any{"name": "pear"}, nil); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	require.EqualError(t, err, "rollback")
+
+	_, err = cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, repo.reads.Load())
+}
+
+func TestCachedRepo_ExplicitTransaction(t *testing.T) {
+	cached, repo := newCachedItemRepo(t, item{BaseEntity: BaseEntity[int64]{ID: 1}, Name: "apple"})
+	ctx := context.Background()
+	trx := NewTx(nil)
+
+	_, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+	_, err = cached.UpdateById(ctx, 1, map[string]any{"name": "pear"}, trx)
+	require.NoError(t, err)
+
+	before, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	Committed(trx)
+
+	after, err := cached.FindById(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "apple", before.Name)
+	assert.Equal(t, "pear", after.Name)
+	assert.EqualValues(t, 2, repo.reads.Load())
+}
+
+func TestCachedRepo_FindById_Singleflight(t *testing.T) {
+	cached, repo := newCachedItemRepo(t, item{BaseEntity: BaseEntity[int64]{ID: 1}, Name: "apple"})
+	repo.release = make(chan struct{})
+
+	// The first caller gives up before the read completes, which must not
+	// fail the others sharing it.
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := cached.FindById(first, 1)
+		firstErr <- err
+	}()
+
+	assert.Eventually(t, func() bool { return repo.reads.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	var wg sync.WaitGroup
+	results := make([]*item, 3)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := cached.FindById(context.Background(), 1)
+			assert.NoError(t, err)
+			results[i] = res
+		}()
+	}
+
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(repo.release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, repo.reads.Load())
+	for _, res := range results {
+		assert.Equal(t, "apple", res.Name)
+	}
+
+	// Every caller gets a record of its own.
+	results[0].Name = "pear"
+	assert.Equal(t, "apple", results[1].Name)
+	assert.NotSame(t, results[1], results[2])
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells repositories the time to record in timestamps.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to a Clock, e.g. to freeze time in tests:
+//
+//	database.SetClock(database.ClockFunc(func() time.Time { return frozen }))
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var (
+	clockMu sync.RWMutex
+	clock   Clock = systemClock{}
+)
+
+// SetClock replaces the clock used for timestamps; nil restores the system
+// clock.
+func SetClock(c Clock) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	if c == nil {
+		c = systemClock{}
+	}
+
+	clock = c
+}
+
+// Now returns the current time according to the clock.
+func Now() time.Time {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+
+	return clock.Now()
+}
+
+// timestamped is implemented by BaseEntity and promoted to every entity
+// embedding it.
+type timestamped interface {
+	stampCreated(now time.Time)
+	stampUpdated(now time.Time)
+}
+
+// StampCreated sets the creation and update times of a model about to be
+// inserted, keeping a creation time already set.
+func StampCreated(model any) {
+	if m, ok := model.(timestamped); ok {
+		m.stampCreated(Now())
+	}
+}
+
+// StampUpdated bumps the update time of a model about to be saved.
+func StampUpdated(model any) {
+	if m, ok := model.(timestamped); ok {
+		m.stampUpdated(Now())
+	}
+}
+
+// Touch returns a copy of an update payload that also bumps updated_at.
+func Touch(payload map[string]any) map[string]any {
+	touched := make(map[string]any, len(payload)+1)
+	for k, v := range payload {
+		touched[k] = v
+	}
+
+	if _, ok := touched["updated_at"]; !ok {
+		touched["updated_at"] = Now()
+	}
+
+	return touched
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+)
+
+type primaryKey struct{}
+type consistencyKey struct{}
+
+type consistency struct {
+	mu        sync.RWMutex
+	lastWrite time.Time
+}
+
+// WithPrimary returns a context whose reads are always served by the primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// WithConsistency starts a read-your-writes scope, usually one per request.
+// Reads made within the scope stick to the primary for
+// config.Database.StickyPrimaryDuration after the last write.
+func WithConsistency(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(consistencyKey{}).(*consistency); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, consistencyKey{}, &consistency{})
+}
+
+// MarkWrite records a write in the read-your-writes scope of ctx, if any.
+func MarkWrite(ctx context.Context) {
+	c, ok := ctx.Value(consistencyKey{}).(*consistency)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	c.lastWrite = time.Now()
+	c.mu.Unlock()
+}
+
+// ReadFromPrimary reports whether reads made with ctx must go to the primary.
+func ReadFromPrimary(ctx context.Context) bool {
+	if primary, _ := ctx.Value(primaryKey{}).(bool); primary {
+		return true
+	}
+
+	c, ok := ctx.Value(consistencyKey{}).(*consistency)
+	if !ok {
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return !c.lastWrite.IsZero() && time.Since(c.lastWrite) < config.Database.StickyPrimaryDuration
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// withStickyPrimary keeps reads on the primary this long after a write for
+// the duration of the test.
+func withStickyPrimary(t *testing.T, d time.Duration) {
+	t.Helper()
+
+	previous := config.Database.StickyPrimaryDuration
+	t.Cleanup(func() {
+		config.Database.StickyPrimaryDuration = previous
+	})
+
+	config.Database.StickyPrimaryDuration = d
+}
+
+func TestReadFromPrimary(t *testing.T) {
+	withStickyPrimary(t, time.Minute)
+
+	written := WithConsistency(context.Background())
+	MarkWrite(written)
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{name: "plain", ctx: context.Background()},
+		{name: "primary", ctx: WithPrimary(context.Background()), want: true},
+		{name: "scope without writes", ctx: WithConsistency(context.Background())},
+		{name: "after a write", ctx: written, want: true},
+		{name: "nested scope", ctx: WithConsistency(written), want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ReadFromPrimary(tc.ctx))
+		})
+	}
+}
+
+func TestReadFromPrimary_Expires(t *testing.T) {
+	withStickyPrimary(t, 20*time.Millisecond)
+
+	ctx := WithConsistency(context.Background())
+	MarkWrite(ctx)
+	assert.True(t, ReadFromPrimary(ctx))
+
+	// Replicas have caught up by then.
+	assert.Eventually(t, func() bool {
+		return !ReadFromPrimary(ctx)
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestMarkWrite_SharedScope(t *testing.T) {
+	withStickyPrimary(t, time.Minute)
+
+	ctx := WithConsistency(context.Background())
+	type key struct{}
+	child := WithConsistency(context.WithValue(ctx, key{}, "handler"))
+
+	// Writes made deeper in the request count for the whole request.
+	MarkWrite(child)
+
+	assert.True(t, ReadFromPrimary(ctx))
+}
+
+func TestMarkWrite_NoScope(t *testing.T) {
+	withStickyPrimary(t, time.Minute)
+
+	ctx := context.Background()
+	MarkWrite(ctx)
+
+	assert.False(t, ReadFromPrimary(ctx))
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,7 +20,33 @@ type BaseEntity[I any] struct {
 	DeletedAt *time.Time `json:"deleted_at" bson:"deleted_at"`
 }
 
+// ErrNoPrimaryKey is returned when generic code needs to page through
+// records by key but the entity does not embed BaseEntity.
+var ErrNoPrimaryKey = errors.New("entity has no primary key to page by")
+
+// PrimaryKey returns the entity ID, letting generic code page through
+// records by key.
+func (b BaseEntity[I]) PrimaryKey() I {
+	return b.ID
+}
+
+func (b *BaseEntity[I]) stampCreated(now time.Time) {
+	if b.CreatedAt == nil {
+		b.CreatedAt = &now
+	}
+
+	b.UpdatedAt = &now
+}
+
+func (b *BaseEntity[I]) stampUpdated(now time.Time) {
+	b.UpdatedAt = &now
+}
+
 func (b *BaseEntity[I]) BeforeCreate(tx *gorm.DB) (err error) {
+	return b.stampID()
+}
+
+func (b *BaseEntity[I]) stampID() error {
 	id, ok := any(b.ID).(uuid.UUID)
 	if !ok {
 		return nil
@@ -27,7 +54,7 @@ func (b *BaseEntity[I]) BeforeCreate(tx *gorm.DB) (err error) {
 		return nil
 	}
 
-	id, err = uuid.NewV7()
+	id, err := uuid.NewV7()
 	if err != nil {
 		return err
 	}
@@ -36,3 +63,19 @@ func (b *BaseEntity[I]) BeforeCreate(tx *gorm.DB) (err error) {
 
 	return nil
 }
+
+// identified is implemented by BaseEntity and promoted to every entity
+// embedding it.
+type identified interface {
+	stampID() error
+}
+
+// StampID gives a model about to be written a UUIDv7 when its ID is an
+// unset uuid.UUID, as BeforeCreate does on gorm, for drivers without hooks.
+func StampID(model any) error {
+	if m, ok := model.(identified); ok {
+		return m.stampID()
+	}
+
+	return nil
+}
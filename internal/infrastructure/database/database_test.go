@@ -0,0 +1,48 @@
+package database
+
+import (
+	"os"
+	"testing"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+// withTenantMode switches the tenant mode for the duration of the test.
+func withTenantMode(t *testing.T, mode TenantMode) {
+	t.Helper()
+
+	previous, prefix := config.Tenant.Mode, config.Tenant.SchemaPrefix
+	t.Cleanup(func() {
+		config.Tenant.Mode, config.Tenant.SchemaPrefix = previous, prefix
+	})
+
+	config.Tenant.Mode = string(mode)
+	config.Tenant.SchemaPrefix = "tenant_"
+}
+
+func TestStampID(t *testing.T) {
+	unset := &BaseEntity[uuid.UUID]{}
+	set := &BaseEntity[uuid.UUID]{ID: uuid.New()}
+	id := set.ID
+	other := &BaseEntity[int64]{}
+
+	assert.NoError(t, StampID(unset))
+	assert.NoError(t, StampID(set))
+	assert.NoError(t, StampID(other))
+
+	assert.NotEqual(t, uuid.Nil, unset.ID)
+	assert.Equal(t, byte(7), unset.ID[6]>>4)
+	assert.Equal(t, id, set.ID)
+	assert.Zero(t, other.ID)
+}
@@ -0,0 +1,81 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/mongodb"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/mysql"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/postgres"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+)
+
+// Driver names the database backing the repositories.
+type Driver string
+
+const (
+	Postgres Driver = "postgres"
+	MySQL    Driver = "mysql"
+	Mongo    Driver = "mongo"
+)
+
+// Connection is an open connection to the selected database. It reports
+// its health and shuts down with the application.
+type Connection interface {
+	Ping(ctx context.Context) error
+	Shutdown(ctx context.Context) error
+}
+
+// Current returns the configured driver.
+func Current() Driver {
+	return Driver(config.Database.Driver)
+}
+
+// Open connects to the database selected by DB_DRIVER.
+func Open(ctx context.Context) Connection {
+	switch Current() {
+	case Postgres:
+		return postgres.Open(ctx)
+	case MySQL:
+		return mysql.Open(ctx)
+	case Mongo:
+		return mongodb.Open(ctx)
+	}
+
+	logger.Fatalf(ctx, nil, "❌ Unsupported database driver %q", config.Database.Driver).Write()
+
+	return nil
+}
+
+// NewMigrator returns a migrator of the embedded migrations of the database
+// selected by DB_DRIVER.
+func NewMigrator(ctx context.Context) (*database.Migrator, error) {
+	switch Current() {
+	case Postgres:
+		return postgres.NewMigrator(ctx)
+	case MySQL:
+		return mysql.NewMigrator(ctx)
+	case Mongo:
+		return mongodb.NewMigrator(ctx)
+	}
+
+	return nil, fmt.Errorf("unsupported database driver %q", config.Database.Driver)
+}
+
+// NewBaseRepository returns the base repository of E on conn, whichever
+// database it is connected to, so the same domain repositories run on every
+// backend.
+func NewBaseRepository[I any, E database.Entity](conn Connection) (database.BaseRepository[I, E], error) {
+	switch c := conn.(type) {
+	case *postgres.Connection:
+		return postgres.NewBaseRepository[I, E](c), nil
+	case *mysql.Connection:
+		return mysql.NewBaseRepository[I, E](c), nil
+	case *mongodb.Connection:
+		return mongodb.NewBaseRepository[I, E](c), nil
+	}
+
+	return nil, fmt.Errorf("unsupported database connection %T", conn)
+}
@@ -0,0 +1,103 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/mongodb"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/mysql"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database/postgres"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"gorm.io/gorm"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+type widget struct {
+	database.BaseEntity[uuid.UUID]
+}
+
+func (widget) TableName() string {
+	return "widgets"
+}
+
+func (widget) RepositoryName() string {
+	return "WidgetRepository"
+}
+
+// closed is a connection of no supported driver.
+type closed struct{}
+
+func (closed) Ping(context.Context) error {
+	return nil
+}
+
+func (closed) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestNewBaseRepository(t *testing.T) {
+	gormDB := &gorm.DB{}
+	mongoDB := &mongo.Database{}
+
+	cases := []struct {
+		name string
+		conn Connection
+	}{
+		{
+			name: "postgres",
+			conn: &postgres.Connection{Master: gormDB, Slaves: database.NewReplicaPool(gormDB, nil, database.RoundRobin, nil)},
+		},
+		{
+			name: "mysql",
+			conn: &mysql.Connection{Master: gormDB, Slaves: database.NewReplicaPool(gormDB, nil, database.RoundRobin, nil)},
+		},
+		{
+			name: "mongo",
+			conn: &mongodb.Connection{Master: mongoDB, Slaves: database.NewReplicaPool(mongoDB, nil, database.RoundRobin, nil)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, err := NewBaseRepository[uuid.UUID, widget](tc.conn)
+
+			require.NoError(t, err)
+			assert.NotNil(t, repo)
+		})
+	}
+}
+
+func TestNewBaseRepository_Unsupported(t *testing.T) {
+	repo, err := NewBaseRepository[uuid.UUID, widget](closed{})
+
+	assert.EqualError(t, err, "unsupported database connection driver.closed")
+	assert.Nil(t, repo)
+}
+
+func TestNewMigrator_Unsupported(t *testing.T) {
+	previous := config.Database.Driver
+	t.Cleanup(func() {
+		config.Database.Driver = previous
+	})
+
+	config.Database.Driver = "sqlite"
+
+	assert.Equal(t, Driver("sqlite"), Current())
+
+	_, err := NewMigrator(context.Background())
+	assert.EqualError(t, err, `unsupported database driver "sqlite"`)
+}
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/goodone-dev/go-boilerplate/migrations"
+)
+
+// Migrator runs the migrations embedded in the binary for one driver. The
+// database drivers take a lock for the whole run, so replicas starting
+// together apply each migration once and wait for each other.
+type Migrator struct {
+	*migrate.Migrate
+	// Schema applies the indexes and validators declared by the registered
+	// entities after the migrations, when the database keeps them apart.
+	Schema SchemaApplier
+	source source.Driver
+	ctx    context.Context
+}
+
+// SchemaApplier applies the schemas declared by the registered entities and
+// reports how the database drifted from them.
+type SchemaApplier interface {
+	ApplySchema(ctx context.Context) error
+	SchemaDrift(ctx context.Context) ([]SchemaDrift, error)
+}
+
+// MigrationStatus is a migration of the source and whether it was applied.
+type MigrationStatus struct {
+	Version uint
+	Name    string
+	Applied bool
+	Dirty   bool
+}
+
+// NewMigrator returns a migrator of the migrations in dir, applied through
+// db. Closing the migrator closes db.
+func NewMigrator(ctx context.Context, dir string, name string, db migratedb.Driver) (*Migrator, error) {
+	src, err := iofs.New(migrations.FS, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, name, db)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Log = migrationLogger{ctx: ctx}
+	if config.Database.MigrationLockTimeout > 0 {
+		m.LockTimeout = config.Database.MigrationLockTimeout
+	}
+
+	return &Migrator{Migrate: m, source: src, ctx: ctx}, nil
+}
+
+// Up applies every pending migration, then the declared schemas. Being up
+// to date is not an error.
+func (m *Migrator) Up() error {
+	if err := m.Migrate.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	if m.Schema == nil {
+		return nil
+	}
+
+	return m.Schema.ApplySchema(m.ctx)
+}
+
+// Steps applies the next n migrations, or rolls back the last -n, followed
+// by the declared schemas when migrating up.
+func (m *Migrator) Steps(n int) error {
+	if err := m.Migrate.Steps(n); err != nil {
+		return err
+	}
+
+	if n < 0 || m.Schema == nil {
+		return nil
+	}
+
+	return m.Schema.ApplySchema(m.ctx)
+}
+
+// Goto migrates up or down to version, followed by the declared schemas
+// when migrating up. Being at version already is not an error.
+func (m *Migrator) Goto(version uint) error {
+	current, _, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		current, err = 0, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := m.Migrate.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	if version < current || m.Schema == nil {
+		return nil
+	}
+
+	return m.Schema.ApplySchema(m.ctx)
+}
+
+// Drift reports how the database drifted from the declared schemas.
+func (m *Migrator) Drift() ([]SchemaDrift, error) {
+	if m.Schema == nil {
+		return nil, nil
+	}
+
+	return m.Schema.SchemaDrift(m.ctx)
+}
+
+// Status lists the migrations of the source in order, marking the ones
+// applied up to the current version.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	current, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		current, dirty, err = 0, false, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := m.source.First()
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	for {
+		status := MigrationStatus{
+			Version: version,
+			Name:    m.name(version),
+			Applied: current > 0 && version <= current,
+		}
+		status.Dirty = dirty && version == current
+		statuses = append(statuses, status)
+
+		version, err = m.source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, os.ErrNotExist) {
+			return statuses, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// name returns the identifier of a migration, e.g. create_orders_table.
+func (m *Migrator) name(version uint) string {
+	r, identifier, err := m.source.ReadUp(version)
+	if err != nil {
+		return ""
+	}
+	r.Close()
+
+	return identifier
+}
+
+// migrationLogger reports each migration applied through the logger.
+type migrationLogger struct {
+	ctx context.Context
+}
+
+func (l migrationLogger) Printf(format string, v ...any) {
+	logger.Infof(l.ctx, "🗃️ "+strings.TrimSpace(format), v...).Write()
+}
+
+func (l migrationLogger) Verbose() bool {
+	return false
+}
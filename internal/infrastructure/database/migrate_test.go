@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/database/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	firstMigration  = 20250927191041
+	secondMigration = 20250927191111
+	thirdMigration  = 20250927191127
+)
+
+// schemaSpy counts the times the declared schemas were applied.
+type schemaSpy struct {
+	applied int
+}
+
+func (s *schemaSpy) ApplySchema(ctx context.Context) error {
+	s.applied++
+	return nil
+}
+
+func (s *schemaSpy) SchemaDrift(ctx context.Context) ([]SchemaDrift, error) {
+	return nil, nil
+}
+
+// newStubMigrator returns a migrator of the PostgreSQL migrations run
+// against a stub database.
+func newStubMigrator(t *testing.T) (*Migrator, *schemaSpy) {
+	t.Helper()
+
+	db, err := stub.WithInstance(nil, &stub.Config{})
+	require.NoError(t, err)
+
+	m, err := NewMigrator(context.Background(), "postgres", "stub", db)
+	require.NoError(t, err)
+
+	spy := &schemaSpy{}
+	m.Schema = spy
+
+	return m, spy
+}
+
+func TestMigrator_Steps(t *testing.T) {
+	m, spy := newStubMigrator(t)
+
+	require.NoError(t, m.Steps(2))
+
+	version, _, err := m.Version()
+	require.NoError(t, err)
+	assert.Equal(t, uint(secondMigration), version)
+	assert.Equal(t, 1, spy.applied)
+
+	require.NoError(t, m.Steps(-1))
+
+	version, _, err = m.Version()
+	require.NoError(t, err)
+	assert.Equal(t, uint(firstMigration), version)
+	assert.Equal(t, 1, spy.applied)
+}
+
+func TestMigrator_Goto(t *testing.T) {
+	m, spy := newStubMigrator(t)
+
+	require.NoError(t, m.Goto(thirdMigration))
+	assert.Equal(t, 1, spy.applied)
+
+	// Staying at the same version still applies the schemas, as Up does.
+	require.NoError(t, m.Goto(thirdMigration))
+	assert.Equal(t, 2, spy.applied)
+
+	require.NoError(t, m.Goto(firstMigration))
+	assert.Equal(t, 2, spy.applied)
+
+	version, _, err := m.Version()
+	require.NoError(t, err)
+	assert.Equal(t, uint(firstMigration), version)
+}
+
+func TestMigrator_Up(t *testing.T) {
+	m, spy := newStubMigrator(t)
+
+	require.NoError(t, m.Up())
+	require.NoError(t, m.Up())
+
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, status.Name)
+	}
+	assert.Equal(t, 2, spy.applied)
+}
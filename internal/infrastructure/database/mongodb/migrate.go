@@ -0,0 +1,242 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	migrationsCollection = "schema_migrations"
+	migrationLockID      = "migrate"
+	// migrationLockTTL bounds how long the lock of an instance that died
+	// while migrating blocks the others. The holder extends it every third
+	// of it for as long as it migrates.
+	migrationLockTTL = time.Minute
+)
+
+// NewMigrator returns a migrator of the embedded MongoDB migrations on a
+// client of its own, since closing the migrator disconnects it. Migrations
+// are JSON arrays of database commands, e.g. createIndexes or collMod, and
+// are followed by the indexes and validators declared by the registered
+// entities.
+func NewMigrator(ctx context.Context) (*database.Migrator, error) {
+	opts := setConfig().Master
+	opts.SetDirect(true)
+
+	client, err := mongo.Connect(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	migrateDriver := &migrationDriver{db: client.Database(config.Mongo.Database), owner: uuid.NewString()}
+
+	m, err := database.NewMigrator(ctx, "mongodb", "mongodb", migrateDriver)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Schema = migrateDriver
+
+	return m, nil
+}
+
+// migrationDriver runs migrations through mongo-driver v2, which the
+// golang-migrate MongoDB driver does not support. The version is kept in a
+// single document and the lock is a document with a unique id, so
+// concurrent replicas migrate one at a time.
+type migrationDriver struct {
+	db       *mongo.Database
+	isLocked atomic.Bool
+	// owner tells the lock of this instance apart from one taken over by
+	// another after it expired.
+	owner     string
+	heartbeat context.CancelFunc
+}
+
+type migrationVersion struct {
+	Version int  `bson:"version"`
+	Dirty   bool `bson:"dirty"`
+}
+
+func (d *migrationDriver) Open(url string) (migratedb.Driver, error) {
+	return nil, errors.New("mongodb migrations open through NewMigrator")
+}
+
+func (d *migrationDriver) Close() error {
+	return d.db.Client().Disconnect(context.Background())
+}
+
+// Lock inserts the lock document, polling while another instance holds it
+// and taking it over once it expired. The lock is extended until Unlock.
+func (d *migrationDriver) Lock() error {
+	if !d.isLocked.CompareAndSwap(false, true) {
+		return migratedb.ErrLocked
+	}
+
+	ctx := context.Background()
+	locks := d.locks()
+	deadline := time.Now().Add(config.Database.MigrationLockTimeout)
+
+	for {
+		now := time.Now()
+
+		_, err := locks.DeleteOne(ctx, bson.M{"_id": migrationLockID, "expires_at": bson.M{"$lt": now}})
+		if err != nil {
+			d.isLocked.Store(false)
+			return err
+		}
+
+		_, err = locks.InsertOne(ctx, bson.M{"_id": migrationLockID, "owner": d.owner, "locked_at": now, "expires_at": now.Add(migrationLockTTL)})
+		if err == nil {
+			ctx, cancel := context.WithCancel(ctx)
+			d.heartbeat = cancel
+			go d.extend(ctx)
+
+			return nil
+		}
+
+		if !mongo.IsDuplicateKeyError(err) || now.After(deadline) {
+			d.isLocked.Store(false)
+			if mongo.IsDuplicateKeyError(err) {
+				return migratedb.ErrLocked
+			}
+			return err
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// extend pushes the expiry of the lock back until ctx is canceled, so a
+// migration outlasting the TTL keeps it.
+func (d *migrationDriver) extend(ctx context.Context) {
+	ticker := time.NewTicker(migrationLockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := d.locks().UpdateOne(ctx, d.ownLock(), bson.M{"$set": bson.M{"expires_at": time.Now().Add(migrationLockTTL)}})
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Warnf(ctx, "⚠️ MongoDB failed to extend the migration lock: %v", err).Write()
+				}
+				continue
+			}
+
+			if result.MatchedCount == 0 {
+				logger.Warn(ctx, "⚠️ MongoDB migration lock was taken over by another instance").Write()
+				return
+			}
+		}
+	}
+}
+
+// Unlock stops extending the lock and deletes it, unless another instance
+// took it over after it expired.
+func (d *migrationDriver) Unlock() error {
+	if !d.isLocked.Load() {
+		return migratedb.ErrNotLocked
+	}
+
+	if d.heartbeat != nil {
+		d.heartbeat()
+		d.heartbeat = nil
+	}
+
+	_, err := d.locks().DeleteOne(context.Background(), d.ownLock())
+	if err != nil {
+		return err
+	}
+
+	d.isLocked.Store(false)
+
+	return nil
+}
+
+func (d *migrationDriver) locks() *mongo.Collection {
+	return d.db.Collection(migrationsCollection + "_lock")
+}
+
+// ownLock matches the lock document while this instance holds it.
+func (d *migrationDriver) ownLock() bson.M {
+	return bson.M{"_id": migrationLockID, "owner": d.owner}
+}
+
+// Run runs each command of the migration in order.
+func (d *migrationDriver) Run(migration io.Reader) error {
+	data, err := io.ReadAll(migration)
+	if err != nil {
+		return err
+	}
+
+	var commands []bson.D
+	if err := bson.UnmarshalExtJSON(data, true, &commands); err != nil {
+		return fmt.Errorf("migration is not a JSON array of commands: %w", err)
+	}
+
+	for _, command := range commands {
+		if err := d.db.RunCommand(context.Background(), command).Err(); err != nil {
+			return &migratedb.Error{OrigErr: err, Err: "migration command failed", Query: data}
+		}
+	}
+
+	return nil
+}
+
+func (d *migrationDriver) SetVersion(version int, dirty bool) error {
+	_, err := d.db.Collection(migrationsCollection).ReplaceOne(context.Background(),
+		bson.M{"_id": migrationsCollection},
+		migrationVersion{Version: version, Dirty: dirty},
+		options.Replace().SetUpsert(true),
+	)
+
+	return err
+}
+
+func (d *migrationDriver) Version() (int, bool, error) {
+	var v migrationVersion
+
+	err := d.db.Collection(migrationsCollection).FindOne(context.Background(), bson.M{"_id": migrationsCollection}).Decode(&v)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return migratedb.NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return v.Version, v.Dirty, nil
+}
+
+// Drop drops every collection of the database.
+func (d *migrationDriver) Drop() error {
+	ctx := context.Background()
+
+	names, err := d.db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := d.db.Collection(name).Drop(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestMigrationDriver_OwnLock(t *testing.T) {
+	first := &migrationDriver{owner: "a"}
+	second := &migrationDriver{owner: "b"}
+
+	assert.Equal(t, bson.M{"_id": migrationLockID, "owner": "a"}, first.ownLock())
+	assert.NotEqual(t, first.ownLock(), second.ownLock())
+}
@@ -2,14 +2,18 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"sync/atomic"
 	"time"
 
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 	"github.com/goodone-dev/go-boilerplate/internal/utils/retry"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
@@ -17,7 +21,7 @@ import (
 
 type mongoConfig struct {
 	Master *options.ClientOptions
-	Slave  *options.ClientOptions
+	Slaves map[string]*options.ClientOptions
 }
 
 func setConfig() mongoConfig {
@@ -35,44 +39,86 @@ func setConfig() mongoConfig {
 		}
 	}
 
-	uriSlave := url.URL{
-		Scheme: "mongodb",
-		Host:   fmt.Sprintf("%s:%d", config.Mongo.Host, config.Mongo.Port),
-		User:   url.UserPassword(config.Mongo.Username, config.Mongo.Password),
-	}
-
-	if len(config.Mongo.SlaveHost) > 0 {
-		uriSlave = url.URL{
+	slaveConfigs := map[string]*options.ClientOptions{}
+	for _, addr := range database.ReplicaAddrs(config.Mongo.SlaveHost, config.Mongo.SlaveHosts, config.Mongo.SlavePort) {
+		uriSlave := url.URL{
 			Scheme: "mongodb",
-			Host:   fmt.Sprintf("%s:%d", config.Mongo.SlaveHost, config.Mongo.SlavePort),
+			Host:   addr.String(),
 			User:   url.UserPassword(config.Mongo.SlaveUsername, config.Mongo.SlavePassword),
 		}
+
+		slaveConfigs[addr.String()] = options.Client().ApplyURI(uriSlave.String())
 	}
 
 	return mongoConfig{
 		Master: options.Client().ApplyURI(uriMaster.String()),
-		Slave:  options.Client().ApplyURI(uriSlave.String()),
+		Slaves: slaveConfigs,
 	}
 }
 
+// Connection is the MongoDB connection returned by Open.
+type Connection = mongoConnection
+
 type mongoConnection struct {
 	Master *mongo.Database
-	Slave  *mongo.Database
+	Slaves *database.ReplicaPool[*mongo.Database]
 }
 
 func Open(ctx context.Context) *mongoConnection {
 	mongoConfig := setConfig()
 
+	master := open(ctx, mongoConfig.Master, readpref.Primary())
+	if database.AuditEnabled() {
+		checkTransactions(ctx, master)
+	}
+	if config.Mongo.AutoMigrate {
+		migrateUp(ctx)
+	}
+
+	replicas := make([]*database.Replica[*mongo.Database], 0, len(mongoConfig.Slaves))
+	checkedOut := make(map[*mongo.Database]*atomic.Int64, len(mongoConfig.Slaves))
+	for name, slaveConfig := range mongoConfig.Slaves {
+		inUse := &atomic.Int64{}
+		slaveConfig.SetPoolMonitor(poolMonitor(inUse))
+
+		db := open(ctx, slaveConfig, readpref.Secondary())
+		checkedOut[db] = inUse
+		replicas = append(replicas, database.NewReplica(name, db))
+	}
+
+	load := func(db *mongo.Database) int {
+		return int(checkedOut[db].Load())
+	}
+
 	conn := &mongoConnection{
-		Master: open(ctx, mongoConfig.Master, readpref.Primary()),
-		Slave:  open(ctx, mongoConfig.Slave, readpref.Secondary()),
+		Master: master,
+		Slaves: database.NewReplicaPool(master, replicas, database.Balancer(config.Database.ReplicaBalancer), load),
 	}
 
 	go conn.Monitor(ctx)
 
+	if err := database.SetTransactor(conn); err != nil {
+		logger.Fatal(ctx, err, "❌ MongoDB connection cannot serve WithTransaction").Write()
+	}
+
 	return conn
 }
 
+// poolMonitor counts the connections checked out of the pool, used by the
+// least-connections balancer.
+func poolMonitor(inUse *atomic.Int64) *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			switch evt.Type {
+			case event.ConnectionCheckedOut:
+				inUse.Add(1)
+			case event.ConnectionCheckedIn:
+				inUse.Add(-1)
+			}
+		},
+	}
+}
+
 func open(ctx context.Context, opts *options.ClientOptions, rp *readpref.ReadPref) *mongo.Database {
 	// TODO: Enable MongoDB OpenTelemetry monitoring once otelmongo supports mongo-driver v2
 	// Currently blocked by: https://github.com/open-telemetry/opentelemetry-go-contrib/issues/
@@ -105,35 +151,40 @@ func open(ctx context.Context, opts *options.ClientOptions, rp *readpref.ReadPre
 		logger.Fatal(ctx, err, "❌ MongoDB connection test failed").Write()
 	}
 
-	mongoDB := client.Database(config.Mongo.Database)
-	if !config.Mongo.AutoMigrate {
-		return mongoDB
-	}
-
-	// TODO: Enable MongoDB migrations once golang-migrate supports mongo-driver v2
-	// Currently blocked by: https://github.com/golang-migrate/migrate/pull/1265
-	// The migrate mongodb package only supports mongo-driver v1.x
-	// Alternative: Consider using github.com/xakep666/mongo-migrate which supports v2
-	//
-	// Example implementation:
-	// migrateDriver, err := migratemongo.WithInstance(client, &migratemongo.Config{
-	// 	DatabaseName: config.Mongo.Database,
-	// })
-	// if err != nil {
-	// 	logger.Fatal(ctx, err, "❌ Failed to initialize MongoDB migration driver").Write()
-	// }
-	//
-	// m, err := migrate.NewWithDatabaseInstance("file://migrations/mongodb", "mongodb", migrateDriver)
-	// if err != nil {
-	// 	logger.Fatal(ctx, err, "❌ Failed to create migration instance from MongoDB driver").Write()
-	// }
-	//
-	// err = m.Up()
-	// if err != nil && err != migrate.ErrNoChange {
-	// 	logger.Fatal(ctx, err, "❌ MongoDB migration failed").Write()
-	// }
-
-	return mongoDB
+	return client.Database(config.Mongo.Database)
+}
+
+// checkTransactions stops startup when the audit trail is enabled on a
+// standalone server: audited writes run in a transaction, which MongoDB only
+// supports on replica sets and sharded clusters.
+func checkTransactions(ctx context.Context, db *mongo.Database) {
+	var hello bson.M
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		logger.Fatal(ctx, err, "❌ MongoDB failed to read the server topology").Write()
+	}
+
+	if !supportsTransactions(hello) {
+		logger.Fatal(ctx, errors.New("standalone server does not support transactions"), "❌ MongoDB audit trail needs a replica set or sharded cluster").Write()
+	}
+}
+
+// supportsTransactions reports whether the server answering hello is a
+// replica set member or a mongos router.
+func supportsTransactions(hello bson.M) bool {
+	_, replicaSet := hello["setName"]
+	return replicaSet || hello["msg"] == "isdbgrid"
+}
+
+func migrateUp(ctx context.Context) {
+	m, err := NewMigrator(ctx)
+	if err != nil {
+		logger.Fatal(ctx, err, "❌ MongoDB failed to create migration instance").Write()
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil {
+		logger.Fatal(ctx, err, "❌ MongoDB failed migration").Write()
+	}
 }
 
 func (c *mongoConnection) Shutdown(ctx context.Context) error {
@@ -141,8 +192,10 @@ func (c *mongoConnection) Shutdown(ctx context.Context) error {
 		return err
 	}
 
-	if err := close(ctx, c.Slave); err != nil {
-		return err
+	for _, replica := range c.Slaves.Replicas() {
+		if err := close(ctx, replica.DB); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -152,16 +205,10 @@ func close(ctx context.Context, db *mongo.Database) error {
 	return db.Client().Disconnect(ctx)
 }
 
+// Ping checks the primary only; replicas are ejected from the read pool
+// by the monitor instead of failing the whole connection.
 func (c *mongoConnection) Ping(ctx context.Context) error {
-	if err := c.Master.Client().Ping(ctx, readpref.Primary()); err != nil {
-		return err
-	}
-
-	if err := c.Slave.Client().Ping(ctx, readpref.Secondary()); err != nil {
-		return err
-	}
-
-	return nil
+	return c.Master.Client().Ping(ctx, readpref.Primary())
 }
 
 func (c *mongoConnection) Monitor(ctx context.Context) {
@@ -187,6 +234,44 @@ func (c *mongoConnection) Monitor(ctx context.Context) {
 					wasLost = false
 				}
 			}
+
+			for _, replica := range c.Slaves.Replicas() {
+				c.checkReplica(ctx, replica)
+			}
+		}
+	}
+}
+
+func (c *mongoConnection) checkReplica(ctx context.Context, replica *database.Replica[*mongo.Database]) {
+	err := replica.DB.Client().Ping(ctx, readpref.Secondary())
+	if err != nil {
+		if replica.SetHealthy(false) {
+			logger.Errorf(ctx, err, "🛑 MongoDB replica %s ejected from read pool", replica.Name).Write()
 		}
+	} else if replica.SetHealthy(true) {
+		logger.Infof(ctx, "✅ MongoDB replica %s restored to read pool", replica.Name).Write()
+	}
+}
+
+// Transaction runs fn in a session transaction carried by the context.
+// MongoDB has no savepoints, so nested calls join the outer transaction;
+// the driver retries transient transaction and commit errors itself.
+func (c *mongoConnection) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	database.MarkWrite(ctx)
+
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
 	}
+
+	session, err := c.Master.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
+
+	return err
 }
@@ -0,0 +1,37 @@
+package mongodb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+func TestSupportsTransactions(t *testing.T) {
+	cases := []struct {
+		name  string
+		hello bson.M
+		want  bool
+	}{
+		{name: "standalone", hello: bson.M{"isWritablePrimary": true}, want: false},
+		{name: "replica set", hello: bson.M{"isWritablePrimary": true, "setName": "rs0"}, want: true},
+		{name: "sharded cluster", hello: bson.M{"isWritablePrimary": true, "msg": "isdbgrid"}, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, supportsTransactions(tc.hello))
+		})
+	}
+}
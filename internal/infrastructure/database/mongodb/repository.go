@@ -4,9 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"iter"
+	"maps"
 	"math"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/goodone-dev/go-boilerplate/internal/config"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -14,28 +20,150 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-type baseRepo[D any, I any, E database.Entity] struct {
+type baseRepo[I any, E database.Entity] struct {
 	Entity   E
+	dbConn   *mongoConnection
 	dbMaster *mongo.Database
-	dbSlave  *mongo.Database
+	preloads []string
 }
 
-func NewBaseRepository[D any, I any, E database.Entity](dbConn *mongoConnection) database.BaseRepository[D, I, E] {
-	return &baseRepo[D, I, E]{
+func NewBaseRepository[I any, E database.Entity](dbConn *mongoConnection) database.BaseRepository[I, E] {
+	return &baseRepo[I, E]{
+		dbConn:   dbConn,
 		dbMaster: dbConn.Master,
-		dbSlave:  dbConn.Slave,
 	}
 }
 
-func (r *baseRepo[D, I, E]) MasterDB() *D {
-	return any(r.dbMaster).(*D)
+// reader returns the database for reads, falling back to the master when
+// the context carries a transaction, asks for read-your-writes or every
+// slave is down. In schema mode the tenant database is used instead.
+func (r *baseRepo[I, E]) reader(ctx context.Context) *mongo.Database {
+	if mongo.SessionFromContext(ctx) != nil || database.ReadFromPrimary(ctx) {
+		return tenantDatabase(ctx, r.dbMaster)
+	}
+
+	return tenantDatabase(ctx, r.dbConn.Slaves.Pick())
+}
+
+// writer returns the master, or the tenant database on it in schema mode,
+// and records the write so subsequent reads in the same request hit the
+// master. Writes of tenant-scoped entities need a tenant, and transactions
+// join through WithTransaction only since Begin is not supported.
+func (r *baseRepo[I, E]) writer(ctx context.Context, trx *database.Tx) (*mongo.Database, error) {
+	if trx != nil {
+		return nil, database.ErrForeignTx
+	}
+
+	if _, _, err := database.TenantScope(ctx, r.Entity); err != nil {
+		return nil, err
+	}
+
+	database.MarkWrite(ctx)
+
+	return tenantDatabase(ctx, r.dbMaster), nil
 }
 
-func (r *baseRepo[D, I, E]) SlaveDB() *D {
-	return any(r.dbSlave).(*D)
+// tenantDatabase routes db to the database of the tenant carried by the
+// context in schema mode.
+func tenantDatabase(ctx context.Context, db *mongo.Database) *mongo.Database {
+	if name, ok := database.TenantSchemaName(ctx); ok {
+		return db.Client().Database(name)
+	}
+
+	return db
 }
 
-func (r *baseRepo[D, I, E]) FindAll(ctx context.Context, filter map[string]any) (res []E, err error) {
+// scope returns a copy of filter restricted to the tenant carried by the
+// context, leaving the caller's filter untouched. A nil filter matches every
+// document of the tenant, and none at all when the context carries no
+// tenant although it should.
+func scope(ctx context.Context, entity any, filter bson.M) bson.M {
+	tenant, ok, err := database.TenantScope(ctx, entity)
+	if !ok && err == nil {
+		return filter
+	}
+
+	res := make(bson.M, len(filter)+1)
+	maps.Copy(res, filter)
+
+	if err != nil {
+		res["tenant_id"] = bson.M{"$in": bson.A{}}
+	} else {
+		res["tenant_id"] = tenant
+	}
+
+	return res
+}
+
+// withDeletedAt returns a copy of filter also matching deleted_at against
+// deleted, leaving the caller's filter untouched. A nil filter matches every
+// document.
+func withDeletedAt(filter map[string]any, deleted any) bson.M {
+	res := bson.M{}
+	maps.Copy(res, filter)
+	res["deleted_at"] = deleted
+
+	return res
+}
+
+// audit runs write and, when the audit trail is enabled, records an audit
+// log for every document it changed within the same transaction, which
+// needs MongoDB to run as a replica set. filter selects the documents about
+// to change, which are read first; write returns the documents it inserted,
+// any others are read back after.
+func (r *baseRepo[I, E]) audit(ctx context.Context, coll *mongo.Collection, op database.AuditOperation, filter bson.M, write func(ctx context.Context) ([]E, error)) error {
+	if !database.AuditEnabled() {
+		_, err := write(ctx)
+		return err
+	}
+
+	return r.dbConn.Transaction(ctx, func(ctx context.Context) error {
+		var before []E
+		if filter != nil {
+			cursor, err := coll.Find(ctx, filter)
+			if err != nil {
+				return err
+			}
+
+			if err = cursor.All(ctx, &before); err != nil {
+				return err
+			}
+		}
+
+		after, err := write(ctx)
+		if err != nil {
+			return err
+		}
+
+		if after == nil && len(before) > 0 {
+			ids := make([]I, 0, len(before))
+			for _, model := range before {
+				if pk, ok := any(model).(interface{ PrimaryKey() I }); ok {
+					ids = append(ids, pk.PrimaryKey())
+				}
+			}
+
+			cursor, err := coll.Find(ctx, scope(ctx, r.Entity, bson.M{"_id": bson.M{"$in": ids}}))
+			if err != nil {
+				return err
+			}
+
+			if err = cursor.All(ctx, &after); err != nil {
+				return err
+			}
+		}
+
+		logs, err := database.NewAuditLogs[I](ctx, op, before, after)
+		if err != nil || len(logs) == 0 {
+			return err
+		}
+
+		_, err = coll.Database().Collection(database.AuditLog{}.TableName()).InsertMany(ctx, logs)
+		return err
+	})
+}
+
+func (r *baseRepo[I, E]) FindAll(ctx context.Context, filter map[string]any) (res []E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter": filter,
@@ -47,11 +175,11 @@ func (r *baseRepo[D, I, E]) FindAll(ctx context.Context, filter map[string]any)
 		}).End(err)
 	}()
 
-	coll := r.dbSlave.Collection(r.Entity.TableName())
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
 
 	filter["deleted_at"] = nil
 
-	cursor, err := coll.Find(ctx, filter)
+	cursor, err := coll.Find(ctx, scope(ctx, r.Entity, filter))
 	if err != nil {
 		return
 	}
@@ -61,10 +189,15 @@ func (r *baseRepo[D, I, E]) FindAll(ctx context.Context, filter map[string]any)
 		return
 	}
 
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
+	if err != nil {
+		return
+	}
+
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindById(ctx context.Context, ID I) (res *E, err error) {
+func (r *baseRepo[I, E]) FindById(ctx context.Context, ID I) (res *E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"id": ID,
@@ -76,9 +209,14 @@ func (r *baseRepo[D, I, E]) FindById(ctx context.Context, ID I) (res *E, err err
 		}).End(err)
 	}()
 
-	coll := r.dbSlave.Collection(r.Entity.TableName())
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
+
+	err = coll.FindOne(ctx, scope(ctx, r.Entity, bson.M{"_id": ID, "deleted_at": nil})).Decode(&res)
+	if err != nil {
+		return
+	}
 
-	err = coll.FindOne(ctx, bson.M{"_id": ID, "deleted_at": nil}).Decode(&res)
+	err = database.LoadRelations(ctx, r.preloads, res, r.fetchRelation)
 	if err != nil {
 		return
 	}
@@ -86,11 +224,11 @@ func (r *baseRepo[D, I, E]) FindById(ctx context.Context, ID I) (res *E, err err
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindByIdAndLock(ctx context.Context, ID I, trx *D) (res *E, err error) {
+func (r *baseRepo[I, E]) FindByIdAndLock(ctx context.Context, ID I, trx *database.Tx) (res *E, err error) {
 	return nil, errors.New("locking not supported")
 }
 
-func (r *baseRepo[D, I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, err error) {
+func (r *baseRepo[I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"ids": IDs,
@@ -102,9 +240,9 @@ func (r *baseRepo[D, I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, er
 		}).End(err)
 	}()
 
-	coll := r.dbSlave.Collection(r.Entity.TableName())
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
 
-	cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$in": IDs}, "deleted_at": nil})
+	cursor, err := coll.Find(ctx, scope(ctx, r.Entity, bson.M{"_id": bson.M{"$in": IDs}, "deleted_at": nil}))
 	if err != nil {
 		return
 	}
@@ -114,10 +252,15 @@ func (r *baseRepo[D, I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, er
 		return
 	}
 
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
+	if err != nil {
+		return
+	}
+
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]any, sort []string, size int, page int) (res database.Pagination[E], err error) {
+func (r *baseRepo[I, E]) FindByOffset(ctx context.Context, filter map[string]any, sort []string, size int, page int) (res database.Pagination[E], err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter": filter,
@@ -132,10 +275,10 @@ func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]
 		}).End(err)
 	}()
 
-	coll := r.dbSlave.Collection(r.Entity.TableName())
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
 
 	filter["deleted_at"] = nil
-	count, err := coll.CountDocuments(ctx, filter)
+	count, err := coll.CountDocuments(ctx, scope(ctx, r.Entity, filter))
 	if err != nil {
 		return
 	}
@@ -152,7 +295,7 @@ func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]
 		SetLimit(int64(size)).
 		SetSkip(int64((page - 1) * size))
 
-	cursor, err := coll.Find(ctx, filter, opt)
+	cursor, err := coll.Find(ctx, scope(ctx, r.Entity, filter), opt)
 	if err != nil {
 		return
 	}
@@ -162,6 +305,11 @@ func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]
 		return
 	}
 
+	err = database.LoadRelations(ctx, r.preloads, &res.Data, r.fetchRelation)
+	if err != nil {
+		return
+	}
+
 	var pages int
 	if count > 0 {
 		pages = int(math.Ceil(float64(count) / float64(size)))
@@ -175,7 +323,7 @@ func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]any, sort []string, size int, next *I) (res database.Pagination[E], err error) {
+func (r *baseRepo[I, E]) FindByCursor(ctx context.Context, filter map[string]any, sort []string, size int, next *I) (res database.Pagination[E], err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter": filter,
@@ -190,14 +338,14 @@ func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]
 		}).End(err)
 	}()
 
-	coll := r.dbSlave.Collection(r.Entity.TableName())
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
 
 	filter["deleted_at"] = nil
 	if next != nil {
 		filter["id > ?"] = *next
 	}
 
-	count, err := coll.CountDocuments(ctx, filter)
+	count, err := coll.CountDocuments(ctx, scope(ctx, r.Entity, filter))
 	if err != nil {
 		return
 	}
@@ -210,7 +358,7 @@ func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]
 		SetSort(sort).
 		SetLimit(int64(size))
 
-	cursor, err := coll.Find(ctx, filter, opt)
+	cursor, err := coll.Find(ctx, scope(ctx, r.Entity, filter), opt)
 	if err != nil {
 		return
 	}
@@ -220,6 +368,11 @@ func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]
 		return
 	}
 
+	err = database.LoadRelations(ctx, r.preloads, &res.Data, r.fetchRelation)
+	if err != nil {
+		return
+	}
+
 	var pages int
 	if count > 0 {
 		pages = int(math.Ceil(float64(count) / float64(size)))
@@ -232,7 +385,362 @@ func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]
 	return
 }
 
-func (r *baseRepo[D, I, E]) Insert(ctx context.Context, payload E, trx *D) (res E, err error) {
+func (r *baseRepo[I, E]) FindWithTrashed(ctx context.Context, filter map[string]any) (res []E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
+
+	if filter == nil {
+		filter = map[string]any{}
+	}
+
+	cursor, err := coll.Find(ctx, scope(ctx, r.Entity, filter))
+	if err != nil {
+		return
+	}
+
+	err = cursor.All(ctx, &res)
+	if err != nil {
+		return
+	}
+
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) FindOnlyTrashed(ctx context.Context, filter map[string]any) (res []E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
+
+	cursor, err := coll.Find(ctx, scope(ctx, r.Entity, withDeletedAt(filter, bson.M{"$ne": nil})))
+	if err != nil {
+		return
+	}
+
+	err = cursor.All(ctx, &res)
+	if err != nil {
+		return
+	}
+
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// fetchRelation loads the related documents of a preloaded relation with a
+// single batched find.
+func (r *baseRepo[I, E]) fetchRelation(ctx context.Context, related database.Entity, field string, keys []any, dest any) error {
+	if field == "id" {
+		field = "_id"
+	}
+
+	coll := r.reader(ctx).Collection(related.TableName())
+
+	cursor, err := coll.Find(ctx, scope(ctx, related, bson.M{field: bson.M{"$in": keys}, "deleted_at": nil}))
+	if err != nil {
+		return err
+	}
+
+	return cursor.All(ctx, dest)
+}
+
+// Preload returns a copy of the repository whose reads also load the given
+// relations, leaving r untouched.
+func (r *baseRepo[I, E]) Preload(relations ...string) database.BaseRepository[I, E] {
+	preloaded := *r
+	preloaded.preloads = append(slices.Clone(r.preloads), relations...)
+
+	return &preloaded
+}
+
+func (r *baseRepo[I, E]) FindProjected(ctx context.Context, filter map[string]any, fields []string, dest any) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+		"fields": fields,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.End(err)
+	}()
+
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
+
+	projection := bson.M{}
+	for _, field := range fields {
+		projection[field] = 1
+	}
+
+	cursor, err := coll.Find(ctx, scope(ctx, r.Entity, withDeletedAt(filter, nil)), options.Find().SetProjection(projection))
+	if err != nil {
+		return
+	}
+
+	err = cursor.All(ctx, dest)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) Count(ctx context.Context, filter map[string]any) (res int64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
+
+	res, err = coll.CountDocuments(ctx, scope(ctx, r.Entity, withDeletedAt(filter, nil)))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) Exists(ctx context.Context, filter map[string]any) (res bool, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
+
+	count, err := coll.CountDocuments(ctx, scope(ctx, r.Entity, withDeletedAt(filter, nil)), options.Count().SetLimit(1))
+	if err != nil {
+		return
+	}
+
+	return count > 0, nil
+}
+
+var aggregateOperators = map[database.AggregateFunc]string{
+	database.AggregateCount: "$sum",
+	database.AggregateSum:   "$sum",
+	database.AggregateAvg:   "$avg",
+	database.AggregateMin:   "$min",
+	database.AggregateMax:   "$max",
+}
+
+// Aggregate runs the query as a $match, $group, $project and $sort pipeline.
+// Group fields are lifted out of _id so results have the same shape as in
+// SQL databases.
+func (r *baseRepo[I, E]) Aggregate(ctx context.Context, query database.AggregateQuery, dest any) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"query": query,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.End(err)
+	}()
+
+	if err = query.Validate(); err != nil {
+		return
+	}
+
+	coll := r.reader(ctx).Collection(r.Entity.TableName())
+
+	match := scope(ctx, r.Entity, withDeletedAt(query.Filter, nil))
+
+	groupID := bson.M{}
+	project := bson.M{"_id": 0}
+	for _, field := range query.GroupBy {
+		groupID[field] = "$" + field
+		project[field] = "$_id." + field
+	}
+
+	group := bson.M{"_id": groupID}
+	for _, agg := range query.Aggregations {
+		var value any = "$" + agg.Field
+		if agg.Func == database.AggregateCount {
+			value = 1
+		}
+
+		group[agg.As] = bson.M{aggregateOperators[agg.Func]: value}
+		project[agg.As] = 1
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: group}},
+		{{Key: "$project", Value: project}},
+	}
+
+	if len(query.Sort) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sortSpec(query.Sort)}})
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return
+	}
+
+	err = cursor.All(ctx, dest)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// sortSpec converts SQL-like sort clauses such as "revenue DESC" into a
+// MongoDB sort document.
+func sortSpec(sort []string) bson.D {
+	spec := bson.D{}
+	for _, s := range sort {
+		parts := strings.Fields(s)
+		if len(parts) == 0 {
+			continue
+		}
+
+		order := 1
+		if len(parts) > 1 && strings.EqualFold(parts[1], "DESC") {
+			order = -1
+		}
+
+		spec = append(spec, bson.E{Key: parts[0], Value: order})
+	}
+
+	return spec
+}
+
+// Stream iterates over the documents matching filter through a cursor
+// fetching batchSize documents per round trip, keeping the span open until
+// the iteration ends.
+func (r *baseRepo[I, E]) Stream(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		ctx, span := tracer.Start(ctx)
+		span.SetFunctionInput(tracer.Metadata{
+			"filter":     filter,
+			"batch_size": batchSize,
+		}).AddAttribute("table.name", r.Entity.TableName())
+
+		var count int
+		var err error
+
+		defer func() {
+			span.SetFunctionOutput(tracer.Metadata{
+				"count": count,
+			}).End(err)
+		}()
+
+		if batchSize <= 0 {
+			batchSize = config.Database.StreamBatchSize
+		}
+
+		coll := r.reader(ctx).Collection(r.Entity.TableName())
+
+		cursor, err := coll.Find(ctx, scope(ctx, r.Entity, withDeletedAt(filter, nil)), options.Find().SetBatchSize(int32(batchSize)))
+		if err != nil {
+			yield(*new(E), err)
+			return
+		}
+		defer cursor.Close(context.WithoutCancel(ctx))
+
+		for cursor.Next(ctx) {
+			var model E
+			if err = cursor.Decode(&model); err != nil {
+				yield(*new(E), err)
+				return
+			}
+
+			count++
+
+			if !yield(model, nil) {
+				return
+			}
+		}
+
+		if err = cursor.Err(); err != nil {
+			yield(*new(E), err)
+		}
+	}
+}
+
+func (r *baseRepo[I, E]) Insert(ctx context.Context, payload E, trx *database.Tx) (res E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"payload": payload,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	database.StampTenant(ctx, &payload)
+	database.StampCreated(&payload)
+
+	err = database.StampID(&payload)
+	if err != nil {
+		return
+	}
+
+	err = r.audit(ctx, coll, database.AuditInsert, nil, func(ctx context.Context) ([]E, error) {
+		result, err := coll.InsertOne(ctx, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		err = coll.FindOne(ctx, scope(ctx, r.Entity, bson.M{"_id": result.InsertedID})).Decode(&res)
+		return []E{res}, err
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) InsertMany(ctx context.Context, payload []E, trx *database.Tx) (res []E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"payload": payload,
@@ -244,14 +752,86 @@ func (r *baseRepo[D, I, E]) Insert(ctx context.Context, payload E, trx *D) (res
 		}).End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	for i := range payload {
+		database.StampTenant(ctx, &payload[i])
+		database.StampCreated(&payload[i])
+
+		err = database.StampID(&payload[i])
+		if err != nil {
+			return
+		}
+	}
+
+	err = r.audit(ctx, coll, database.AuditInsert, nil, func(ctx context.Context) ([]E, error) {
+		result, err := coll.InsertMany(ctx, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		cursor, err := coll.Find(ctx, scope(ctx, r.Entity, bson.M{"_id": bson.M{"$in": result.InsertedIDs}}))
+		if err != nil {
+			return nil, err
+		}
+
+		err = cursor.All(ctx, &res)
+		return res, err
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) Upsert(ctx context.Context, payload E, opts database.UpsertOptions, trx *database.Tx) (res E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"payload": payload,
+		"options": opts,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	database.StampTenant(ctx, &payload)
+	database.StampCreated(&payload)
+
+	err = database.StampID(&payload)
+	if err != nil {
+		return
+	}
 
-	result, err := coll.InsertOne(ctx, payload)
+	model, filter, err := upsertModel(ctx, payload, opts)
 	if err != nil {
 		return
 	}
 
-	err = coll.FindOne(ctx, bson.M{"_id": result.InsertedID}).Decode(&res)
+	err = r.audit(ctx, coll, database.AuditUpsert, filter, func(ctx context.Context) ([]E, error) {
+		if _, err := coll.BulkWrite(ctx, []mongo.WriteModel{model}); err != nil {
+			return nil, err
+		}
+
+		if err := coll.FindOne(ctx, filter).Decode(&res); err != nil {
+			return nil, err
+		}
+
+		return []E{res}, nil
+	})
 	if err != nil {
 		return
 	}
@@ -259,10 +839,11 @@ func (r *baseRepo[D, I, E]) Insert(ctx context.Context, payload E, trx *D) (res
 	return
 }
 
-func (r *baseRepo[D, I, E]) InsertMany(ctx context.Context, payload []E, trx *D) (res []E, err error) {
+func (r *baseRepo[I, E]) UpsertMany(ctx context.Context, payload []E, opts database.UpsertOptions, trx *database.Tx) (res []E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"payload": payload,
+		"options": opts,
 	}).AddAttribute("table.name", r.Entity.TableName())
 
 	defer func() {
@@ -271,27 +852,268 @@ func (r *baseRepo[D, I, E]) InsertMany(ctx context.Context, payload []E, trx *D)
 		}).End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	if len(payload) == 0 {
+		return
+	}
 
-	result, err := coll.InsertMany(ctx, payload)
+	db, err := r.writer(ctx, trx)
 	if err != nil {
 		return
 	}
+	coll := db.Collection(r.Entity.TableName())
+
+	models := make([]mongo.WriteModel, 0, len(payload))
+	filters := make(bson.A, 0, len(payload))
+	for i := range payload {
+		database.StampTenant(ctx, &payload[i])
+		database.StampCreated(&payload[i])
+
+		if err := database.StampID(&payload[i]); err != nil {
+			return nil, err
+		}
+
+		model, filter, err := upsertModel(ctx, payload[i], opts)
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, model)
+		filters = append(filters, filter)
+	}
 
-	cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$in": result.InsertedIDs}})
+	filter := bson.M{"$or": filters}
+
+	err = r.audit(ctx, coll, database.AuditUpsert, filter, func(ctx context.Context) ([]E, error) {
+		if _, err := coll.BulkWrite(ctx, models); err != nil {
+			return nil, err
+		}
+
+		cursor, err := coll.Find(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := cursor.All(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	})
 	if err != nil {
 		return
 	}
 
-	err = cursor.All(ctx, &res)
+	return
+}
+
+// upsertModel builds the write model upserting payload and the filter
+// matching it, scoped to the tenant of the context. The update columns, and
+// updated_at, are $set; the remaining fields, created_at among them, are
+// only written on insert.
+func upsertModel[E any](ctx context.Context, payload E, opts database.UpsertOptions) (mongo.WriteModel, bson.M, error) {
+	doc, err := document(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The ID is generated before the filter is built, so that documents
+	// without one are inserted rather than upserted on {_id: null}.
+	if id, ok := doc["_id"]; !ok || id == nil {
+		doc["_id"] = bson.NewObjectID()
+	}
+
+	conflict := opts.Conflict
+	if len(conflict) == 0 {
+		conflict = []string{"_id"}
+	}
+
+	filter := bson.M{}
+	for _, field := range conflict {
+		if field == "id" {
+			field = "_id"
+		}
+
+		filter[field] = doc[field]
+	}
+
+	filter = scope(ctx, payload, filter)
+
+	update := opts.Update
+	if len(update) == 0 {
+		for field := range doc {
+			if field != "_id" && field != "created_at" {
+				update = append(update, field)
+			}
+		}
+	} else if _, ok := doc["updated_at"]; ok && !slices.Contains(update, "updated_at") {
+		update = append(slices.Clone(update), "updated_at")
+	}
+
+	set := bson.M{}
+	for _, field := range update {
+		set[field] = doc[field]
+		delete(doc, field)
+	}
+
+	modification := bson.M{"$setOnInsert": doc}
+	if len(set) > 0 {
+		modification["$set"] = set
+	}
+
+	return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(modification).SetUpsert(true), filter, nil
+}
+
+// document converts a model to its BSON document.
+func document(model any) (bson.M, error) {
+	data, err := bson.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// BulkWrite sends every run of consecutive operations of the same kind to
+// MongoDB in a single ordered round trip, audited as a whole. Soft deletes
+// are updates to MongoDB, which reports a single modified count per round
+// trip, so runs keep them apart from updates.
+func (r *baseRepo[I, E]) BulkWrite(ctx context.Context, ops []database.WriteOperation[E], trx *database.Tx) (res database.BulkWriteResult, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"operations": ops,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	if len(ops) == 0 {
+		return
+	}
+
+	db, err := r.writer(ctx, trx)
 	if err != nil {
 		return
 	}
+	coll := db.Collection(r.Entity.TableName())
+
+	runs, err := r.bulkRuns(ctx, ops)
+	if err != nil {
+		return
+	}
+
+	for _, run := range runs {
+		err = r.audit(ctx, coll, run.operation(), run.filter(), func(ctx context.Context) ([]E, error) {
+			result, err := coll.BulkWrite(ctx, run.models)
+			if err != nil {
+				return nil, err
+			}
+
+			run.count(&res, result)
+
+			return run.inserted, nil
+		})
+		if err != nil {
+			return
+		}
+	}
 
 	return
 }
 
-func (r *baseRepo[D, I, E]) Update(ctx context.Context, payload E, trx *D) (err error) {
+// bulkRun is a run of consecutive write operations of the same kind, along
+// with the documents it inserts or the filters of its updates and deletes.
+type bulkRun[E any] struct {
+	kind     database.WriteKind
+	models   []mongo.WriteModel
+	inserted []E
+	filters  bson.A
+}
+
+// operation returns the audit operation recorded for the run.
+func (run bulkRun[E]) operation() database.AuditOperation {
+	switch run.kind {
+	case database.WriteUpdate:
+		return database.AuditUpdate
+	case database.WriteDelete:
+		return database.AuditDelete
+	default:
+		return database.AuditInsert
+	}
+}
+
+// filter selects the documents the run is about to change, nil for
+// inserts.
+func (run bulkRun[E]) filter() bson.M {
+	if len(run.filters) == 0 {
+		return nil
+	}
+
+	return bson.M{"$or": run.filters}
+}
+
+// count adds the documents written by the run to res.
+func (run bulkRun[E]) count(res *database.BulkWriteResult, result *mongo.BulkWriteResult) {
+	switch run.kind {
+	case database.WriteInsert:
+		res.Inserted += result.InsertedCount
+	case database.WriteUpdate:
+		res.Updated += result.ModifiedCount
+	case database.WriteDelete:
+		res.Deleted += result.ModifiedCount
+	}
+}
+
+// bulkRuns builds the write models of ops, grouped into runs of
+// consecutive operations of the same kind.
+func (r *baseRepo[I, E]) bulkRuns(ctx context.Context, ops []database.WriteOperation[E]) ([]bulkRun[E], error) {
+	var runs []bulkRun[E]
+	for _, op := range ops {
+		if len(runs) == 0 || runs[len(runs)-1].kind != op.Kind {
+			runs = append(runs, bulkRun[E]{kind: op.Kind})
+		}
+		run := &runs[len(runs)-1]
+
+		switch op.Kind {
+		case database.WriteInsert:
+			database.StampTenant(ctx, &op.Model)
+			database.StampCreated(&op.Model)
+			if err := database.StampID(&op.Model); err != nil {
+				return nil, err
+			}
+
+			run.models = append(run.models, mongo.NewInsertOneModel().SetDocument(op.Model))
+			run.inserted = append(run.inserted, op.Model)
+		case database.WriteUpdate:
+			filter := scope(ctx, r.Entity, op.Filter)
+
+			run.models = append(run.models, mongo.NewUpdateManyModel().SetFilter(filter).SetUpdate(bson.M{"$set": database.Touch(op.Payload)}))
+			run.filters = append(run.filters, filter)
+		case database.WriteDelete:
+			filter := bson.M{"deleted_at": nil}
+			for k, v := range op.Filter {
+				filter[k] = v
+			}
+			filter = scope(ctx, r.Entity, filter)
+
+			run.models = append(run.models, mongo.NewUpdateManyModel().SetFilter(filter).SetUpdate(bson.M{"$set": database.Touch(bson.M{"deleted_at": database.Now()})}))
+			run.filters = append(run.filters, filter)
+		default:
+			return nil, fmt.Errorf("unknown write operation %q", op.Kind)
+		}
+	}
+
+	return runs, nil
+}
+
+func (r *baseRepo[I, E]) Update(ctx context.Context, payload E, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"payload": payload,
@@ -301,7 +1123,11 @@ func (r *baseRepo[D, I, E]) Update(ctx context.Context, payload E, trx *D) (err
 		span.End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
 
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -314,7 +1140,22 @@ func (r *baseRepo[D, I, E]) Update(ctx context.Context, payload E, trx *D) (err
 		return
 	}
 
-	_, err = coll.UpdateOne(ctx, bson.M{"_id": req.ID}, bson.M{"$set": payload})
+	database.StampUpdated(&payload)
+
+	// created_at is never overwritten, even when the payload leaves it unset
+	doc, err := document(payload)
+	if err != nil {
+		return
+	}
+	delete(doc, "_id")
+	delete(doc, "created_at")
+
+	filter := scope(ctx, r.Entity, bson.M{"_id": req.ID})
+
+	err = r.audit(ctx, coll, database.AuditUpdate, filter, func(ctx context.Context) ([]E, error) {
+		_, err := coll.UpdateOne(ctx, filter, bson.M{"$set": doc})
+		return nil, err
+	})
 	if err != nil {
 		return
 	}
@@ -322,7 +1163,7 @@ func (r *baseRepo[D, I, E]) Update(ctx context.Context, payload E, trx *D) (err
 	return
 }
 
-func (r *baseRepo[D, I, E]) UpdateById(ctx context.Context, ID I, payload map[string]any, trx *D) (res E, err error) {
+func (r *baseRepo[I, E]) UpdateById(ctx context.Context, ID I, payload map[string]any, trx *database.Tx) (res E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"id":      ID,
@@ -335,9 +1176,19 @@ func (r *baseRepo[D, I, E]) UpdateById(ctx context.Context, ID I, payload map[st
 		}).End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	payload = database.Touch(payload)
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter := scope(ctx, r.Entity, bson.M{"_id": ID})
 
-	err = coll.FindOneAndUpdate(ctx, bson.M{"_id": ID}, bson.M{"$set": payload}).Decode(&res)
+	err = r.audit(ctx, coll, database.AuditUpdate, filter, func(ctx context.Context) ([]E, error) {
+		return nil, coll.FindOneAndUpdate(ctx, filter, bson.M{"$set": payload}).Decode(&res)
+	})
 	if err != nil {
 		return
 	}
@@ -345,7 +1196,7 @@ func (r *baseRepo[D, I, E]) UpdateById(ctx context.Context, ID I, payload map[st
 	return
 }
 
-func (r *baseRepo[D, I, E]) UpdateByIds(ctx context.Context, IDs []I, payload map[string]any, trx *D) (err error) {
+func (r *baseRepo[I, E]) UpdateByIds(ctx context.Context, IDs []I, payload map[string]any, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"ids":     IDs,
@@ -356,9 +1207,20 @@ func (r *baseRepo[D, I, E]) UpdateByIds(ctx context.Context, IDs []I, payload ma
 		span.End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	payload = database.Touch(payload)
 
-	_, err = coll.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": IDs}}, bson.M{"$set": payload})
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter := scope(ctx, r.Entity, bson.M{"_id": bson.M{"$in": IDs}})
+
+	err = r.audit(ctx, coll, database.AuditUpdate, filter, func(ctx context.Context) ([]E, error) {
+		_, err := coll.UpdateMany(ctx, filter, bson.M{"$set": payload})
+		return nil, err
+	})
 	if err != nil {
 		return err
 	}
@@ -366,7 +1228,7 @@ func (r *baseRepo[D, I, E]) UpdateByIds(ctx context.Context, IDs []I, payload ma
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *D) (err error) {
+func (r *baseRepo[I, E]) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter":  filter,
@@ -377,9 +1239,20 @@ func (r *baseRepo[D, I, E]) UpdateMany(ctx context.Context, filter map[string]an
 		span.End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	payload = database.Touch(payload)
 
-	_, err = coll.UpdateMany(ctx, filter, bson.M{"$set": payload})
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter = scope(ctx, r.Entity, filter)
+
+	err = r.audit(ctx, coll, database.AuditUpdate, filter, func(ctx context.Context) ([]E, error) {
+		_, err := coll.UpdateMany(ctx, filter, bson.M{"$set": payload})
+		return nil, err
+	})
 	if err != nil {
 		return err
 	}
@@ -387,7 +1260,7 @@ func (r *baseRepo[D, I, E]) UpdateMany(ctx context.Context, filter map[string]an
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) DeleteById(ctx context.Context, ID I, trx *D) (err error) {
+func (r *baseRepo[I, E]) DeleteById(ctx context.Context, ID I, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"id": ID,
@@ -397,9 +1270,18 @@ func (r *baseRepo[D, I, E]) DeleteById(ctx context.Context, ID I, trx *D) (err e
 		span.End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter := scope(ctx, r.Entity, bson.M{"_id": ID, "deleted_at": nil})
 
-	_, err = coll.UpdateOne(ctx, bson.M{"_id": ID}, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	err = r.audit(ctx, coll, database.AuditDelete, filter, func(ctx context.Context) ([]E, error) {
+		_, err := coll.UpdateOne(ctx, filter, bson.M{"$set": database.Touch(bson.M{"deleted_at": database.Now()})})
+		return nil, err
+	})
 	if err != nil {
 		return err
 	}
@@ -407,7 +1289,7 @@ func (r *baseRepo[D, I, E]) DeleteById(ctx context.Context, ID I, trx *D) (err e
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *D) (err error) {
+func (r *baseRepo[I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"ids": IDs,
@@ -417,9 +1299,18 @@ func (r *baseRepo[D, I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *D) (e
 		span.End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter := scope(ctx, r.Entity, bson.M{"_id": bson.M{"$in": IDs}, "deleted_at": nil})
 
-	_, err = coll.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": IDs}}, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	err = r.audit(ctx, coll, database.AuditDelete, filter, func(ctx context.Context) ([]E, error) {
+		_, err := coll.UpdateMany(ctx, filter, bson.M{"$set": database.Touch(bson.M{"deleted_at": database.Now()})})
+		return nil, err
+	})
 	if err != nil {
 		return err
 	}
@@ -427,7 +1318,7 @@ func (r *baseRepo[D, I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *D) (e
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) DeleteMany(ctx context.Context, filter map[string]any, trx *D) (err error) {
+func (r *baseRepo[I, E]) DeleteMany(ctx context.Context, filter map[string]any, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter": filter,
@@ -437,9 +1328,18 @@ func (r *baseRepo[D, I, E]) DeleteMany(ctx context.Context, filter map[string]an
 		span.End(err)
 	}()
 
-	coll := r.dbMaster.Collection(r.Entity.TableName())
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter = scope(ctx, r.Entity, withDeletedAt(filter, nil))
 
-	_, err = coll.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	err = r.audit(ctx, coll, database.AuditDelete, filter, func(ctx context.Context) ([]E, error) {
+		_, err := coll.UpdateMany(ctx, filter, bson.M{"$set": database.Touch(bson.M{"deleted_at": database.Now()})})
+		return nil, err
+	})
 	if err != nil {
 		return err
 	}
@@ -447,14 +1347,141 @@ func (r *baseRepo[D, I, E]) DeleteMany(ctx context.Context, filter map[string]an
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) Begin(ctx context.Context) (*D, error) {
-	return nil, errors.New("transaction not supported")
-}
+func (r *baseRepo[I, E]) Restore(ctx context.Context, ID I, trx *database.Tx) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"id": ID,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.End(err)
+	}()
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter := scope(ctx, r.Entity, bson.M{"_id": ID, "deleted_at": bson.M{"$ne": nil}})
+
+	err = r.audit(ctx, coll, database.AuditRestore, filter, func(ctx context.Context) ([]E, error) {
+		_, err := coll.UpdateOne(ctx, filter, bson.M{"$set": database.Touch(bson.M{"deleted_at": nil})})
+		return nil, err
+	})
+	if err != nil {
+		return err
+	}
 
-func (r *baseRepo[D, I, E]) Rollback(trx *D) *D {
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) Commit(trx *D) *D {
+func (r *baseRepo[I, E]) ForceDelete(ctx context.Context, ID I, trx *database.Tx) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"id": ID,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.End(err)
+	}()
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter := scope(ctx, r.Entity, bson.M{"_id": ID})
+
+	err = r.audit(ctx, coll, database.AuditDelete, filter, func(ctx context.Context) ([]E, error) {
+		_, err := coll.DeleteOne(ctx, filter)
+		return nil, err
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
+
+func (r *baseRepo[I, E]) PurgeTrashed(ctx context.Context, before time.Time) (res int64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"before": before,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	db, err := r.writer(ctx, nil)
+	if err != nil {
+		return
+	}
+	coll := db.Collection(r.Entity.TableName())
+
+	filter := scope(ctx, r.Entity, bson.M{"deleted_at": bson.M{"$ne": nil, "$lt": before}})
+
+	err = r.audit(ctx, coll, database.AuditDelete, filter, func(ctx context.Context) ([]E, error) {
+		result, err := coll.DeleteMany(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		res = result.DeletedCount
+
+		return nil, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+// History lists the audit logs of the document, oldest first.
+func (r *baseRepo[I, E]) History(ctx context.Context, ID I) (res []database.AuditLog, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"id": ID,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	var audit database.AuditLog
+
+	coll := r.reader(ctx).Collection(audit.TableName())
+
+	filter := scope(ctx, audit, bson.M{"entity": r.Entity.TableName(), "entity_id": fmt.Sprint(ID)})
+
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return
+	}
+
+	err = cursor.All(ctx, &res)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) Begin(ctx context.Context) (*database.Tx, error) {
+	return nil, errors.New("transaction not supported")
+}
+
+func (r *baseRepo[I, E]) Rollback(trx *database.Tx) error {
+	return errors.New("transaction not supported")
+}
+
+func (r *baseRepo[I, E]) Commit(trx *database.Tx) error {
+	return errors.New("transaction not supported")
+}
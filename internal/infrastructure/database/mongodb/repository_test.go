@@ -0,0 +1,229 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type account struct {
+	database.BaseEntity[uuid.UUID] `bson:",inline"`
+	database.TenantModel           `bson:",inline"`
+	Name                           string `json:"name" bson:"name"`
+	Email                          string `json:"email" bson:"email"`
+}
+
+func (account) TableName() string {
+	return "accounts"
+}
+
+func (account) RepositoryName() string {
+	return "AccountRepository"
+}
+
+// keyed has an ObjectID left out of its document until it is set.
+type keyed struct {
+	ID   bson.ObjectID `bson:"_id,omitempty"`
+	Name string        `bson:"name"`
+}
+
+func TestUpsertModel_ID(t *testing.T) {
+	ctx := context.Background()
+
+	unset := account{Name: "Acme"}
+	require.NoError(t, database.StampID(&unset))
+	_, filter, err := upsertModel(ctx, unset, database.UpsertOptions{})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, uuid.Nil, unset.ID)
+	assert.Len(t, filter, 1)
+	assert.NotNil(t, filter["_id"])
+
+	_, first, err := upsertModel(ctx, keyed{Name: "Acme"}, database.UpsertOptions{})
+	require.NoError(t, err)
+	_, second, err := upsertModel(ctx, keyed{Name: "Acme"}, database.UpsertOptions{})
+	require.NoError(t, err)
+
+	assert.IsType(t, bson.ObjectID{}, first["_id"])
+	assert.NotEqual(t, first["_id"], second["_id"])
+}
+
+func TestUpsertModel_KeepsID(t *testing.T) {
+	id := uuid.New()
+	payload := account{Name: "Acme"}
+	payload.ID = id
+	require.NoError(t, database.StampID(&payload))
+
+	_, filter, err := upsertModel(context.Background(), payload, database.UpsertOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, id, payload.ID)
+	assert.Equal(t, bson.M{"_id": bson.Binary{Data: id[:]}}, filter)
+}
+
+func TestBaseRepo_BulkRuns(t *testing.T) {
+	repo := &baseRepo[uuid.UUID, account]{}
+
+	runs, err := repo.bulkRuns(context.Background(), []database.WriteOperation[account]{
+		database.InsertOperation(account{Name: "Acme"}),
+		database.InsertOperation(account{Name: "Globex"}),
+		database.UpdateOperation[account](map[string]any{"name": "Acme"}, map[string]any{"email": "acme@example.com"}),
+		database.DeleteOperation[account](map[string]any{"name": "Globex"}),
+		database.DeleteOperation[account](map[string]any{"name": "Initech"}),
+		database.UpdateOperation[account](map[string]any{"name": "Umbrella"}, map[string]any{"email": "umbrella@example.com"}),
+	})
+	require.NoError(t, err)
+
+	kinds := make([]database.WriteKind, 0, len(runs))
+	sizes := make([]int, 0, len(runs))
+	for _, run := range runs {
+		kinds = append(kinds, run.kind)
+		sizes = append(sizes, len(run.models))
+	}
+
+	assert.Equal(t, []database.WriteKind{database.WriteInsert, database.WriteUpdate, database.WriteDelete, database.WriteUpdate}, kinds)
+	assert.Equal(t, []int{2, 1, 2, 1}, sizes)
+
+	inserted := runs[0].models[0].(*mongo.InsertOneModel).Document.(account)
+	assert.NotEqual(t, uuid.Nil, inserted.ID)
+	assert.Equal(t, inserted, runs[0].inserted[0])
+}
+
+func TestBaseRepo_BulkRuns_Audit(t *testing.T) {
+	repo := &baseRepo[uuid.UUID, account]{}
+
+	runs, err := repo.bulkRuns(context.Background(), []database.WriteOperation[account]{
+		database.InsertOperation(account{Name: "Acme"}),
+		database.UpdateOperation[account](map[string]any{"name": "Acme"}, map[string]any{"email": "acme@example.com"}),
+		database.DeleteOperation[account](map[string]any{"name": "Globex"}),
+		database.DeleteOperation[account](map[string]any{"name": "Initech"}),
+	})
+	require.NoError(t, err)
+
+	operations := make([]database.AuditOperation, 0, len(runs))
+	for _, run := range runs {
+		operations = append(operations, run.operation())
+	}
+
+	assert.Equal(t, []database.AuditOperation{database.AuditInsert, database.AuditUpdate, database.AuditDelete}, operations)
+	assert.Len(t, runs[0].inserted, 1)
+	assert.Nil(t, runs[0].filter())
+	assert.Equal(t, bson.M{"$or": bson.A{bson.M{"name": "Acme"}}}, runs[1].filter())
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"name": "Globex", "deleted_at": nil},
+		bson.M{"name": "Initech", "deleted_at": nil},
+	}}, runs[2].filter())
+}
+
+func TestBaseRepo_BulkRuns_UnknownKind(t *testing.T) {
+	repo := &baseRepo[uuid.UUID, account]{}
+
+	_, err := repo.bulkRuns(context.Background(), []database.WriteOperation[account]{{Kind: "merge"}})
+
+	assert.EqualError(t, err, `unknown write operation "merge"`)
+}
+
+func TestBulkRun_Count(t *testing.T) {
+	var res database.BulkWriteResult
+
+	bulkRun[account]{kind: database.WriteInsert}.count(&res, &mongo.BulkWriteResult{InsertedCount: 2})
+	bulkRun[account]{kind: database.WriteUpdate}.count(&res, &mongo.BulkWriteResult{MatchedCount: 4, ModifiedCount: 3})
+	bulkRun[account]{kind: database.WriteDelete}.count(&res, &mongo.BulkWriteResult{MatchedCount: 1, ModifiedCount: 1})
+	bulkRun[account]{kind: database.WriteUpdate}.count(&res, &mongo.BulkWriteResult{ModifiedCount: 1})
+
+	assert.Equal(t, database.BulkWriteResult{Inserted: 2, Updated: 4, Deleted: 1}, res)
+}
+
+func TestUpsertModel_Timestamps(t *testing.T) {
+	frozen := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	database.SetClock(database.ClockFunc(func() time.Time { return frozen }))
+	t.Cleanup(func() {
+		database.SetClock(nil)
+	})
+
+	payload := account{Name: "Acme", Email: "acme@example.com"}
+	payload.ID = uuid.New()
+	database.StampCreated(&payload)
+
+	cases := []struct {
+		name        string
+		opts        database.UpsertOptions
+		set         []string
+		setOnInsert []string
+	}{
+		{
+			name:        "every field",
+			set:         []string{"name", "email", "updated_at", "deleted_at"},
+			setOnInsert: []string{"_id", "created_at"},
+		},
+		{
+			name:        "update columns",
+			opts:        database.UpsertOptions{Conflict: []string{"email"}, Update: []string{"name"}},
+			set:         []string{"name", "updated_at"},
+			setOnInsert: []string{"_id", "email", "created_at", "deleted_at"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			model, _, err := upsertModel(context.Background(), payload, tc.opts)
+			require.NoError(t, err)
+
+			update, ok := model.(*mongo.UpdateOneModel)
+			require.True(t, ok)
+			assert.True(t, *update.Upsert)
+
+			modification := update.Update.(bson.M)
+			set := modification["$set"].(bson.M)
+			setOnInsert := modification["$setOnInsert"].(bson.M)
+
+			assert.ElementsMatch(t, tc.set, keys(set))
+			assert.ElementsMatch(t, tc.setOnInsert, keys(setOnInsert))
+			assert.Equal(t, bson.NewDateTimeFromTime(frozen), set["updated_at"])
+			assert.Equal(t, bson.NewDateTimeFromTime(frozen), setOnInsert["created_at"])
+		})
+	}
+}
+
+func keys(m bson.M) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+
+	return res
+}
+
+func TestWithDeletedAt(t *testing.T) {
+	filter := map[string]any{"name": "Acme"}
+
+	assert.Equal(t, bson.M{"name": "Acme", "deleted_at": nil}, withDeletedAt(filter, nil))
+	assert.Equal(t, bson.M{"deleted_at": bson.M{"$ne": nil}}, withDeletedAt(nil, bson.M{"$ne": nil}))
+	assert.Equal(t, map[string]any{"name": "Acme"}, filter)
+}
+
+func TestScope(t *testing.T) {
+	mode := config.Tenant.Mode
+	t.Cleanup(func() {
+		config.Tenant.Mode = mode
+	})
+
+	config.Tenant.Mode = string(database.TenantColumn)
+
+	filter := bson.M{"name": "Acme"}
+	acme := database.WithTenant(context.Background(), "acme")
+
+	assert.Equal(t, bson.M{"name": "Acme", "tenant_id": "acme"}, scope(acme, account{}, filter))
+	assert.Equal(t, bson.M{"tenant_id": "acme"}, scope(acme, account{}, nil))
+	assert.Equal(t, bson.M{"name": "Acme", "tenant_id": bson.M{"$in": bson.A{}}}, scope(context.Background(), account{}, filter))
+	assert.Equal(t, bson.M{"name": "Acme"}, scope(database.WithAllTenants(context.Background()), account{}, filter))
+	assert.Equal(t, bson.M{"name": "Acme"}, filter)
+}
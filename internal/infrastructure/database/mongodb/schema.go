@@ -0,0 +1,346 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// indexSpec is an index as listed by the server.
+type indexSpec struct {
+	Name                    string `bson:"name"`
+	Key                     bson.D `bson:"key"`
+	Unique                  bool   `bson:"unique"`
+	ExpireAfterSeconds      *int64 `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.D `bson:"partialFilterExpression"`
+}
+
+// ApplySchema creates the collections, validators and indexes declared by
+// the registered entities that are missing, under the migration lock so
+// replicas apply them once. Changed and undeclared indexes are left alone
+// and reported, since rebuilding one can lock a large collection.
+func (d *migrationDriver) ApplySchema(ctx context.Context) error {
+	if err := d.Lock(); err != nil {
+		return err
+	}
+	defer d.Unlock()
+
+	for _, entity := range database.Schemas() {
+		if err := d.applyValidator(ctx, entity); err != nil {
+			return fmt.Errorf("validator of %s: %w", entity.TableName(), err)
+		}
+
+		if err := d.applyIndexes(ctx, entity); err != nil {
+			return fmt.Errorf("indexes of %s: %w", entity.TableName(), err)
+		}
+	}
+
+	drifts, err := d.SchemaDrift(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, drift := range drifts {
+		logger.Warnf(ctx, "⚠️ MongoDB schema drift: %s", drift).Write()
+	}
+
+	return nil
+}
+
+// SchemaDrift compares the indexes and validators declared by the
+// registered entities with the ones of their collections.
+func (d *migrationDriver) SchemaDrift(ctx context.Context) ([]database.SchemaDrift, error) {
+	var drifts []database.SchemaDrift
+
+	for _, entity := range database.Schemas() {
+		name := entity.TableName()
+
+		var current bson.M
+		if _, ok := entity.(database.SchemaValidator); ok {
+			var err error
+			current, err = d.validator(ctx, name)
+			if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, err
+			}
+		}
+
+		var existing map[string]indexSpec
+		if _, ok := entity.(database.Indexer); ok {
+			var err error
+			existing, err = d.indexes(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		drifts = append(drifts, entityDrift(entity, current, existing)...)
+	}
+
+	return drifts, nil
+}
+
+// entityDrift compares the validator and indexes declared by an entity with
+// the validator and indexes of its collection, undeclared indexes by name.
+func entityDrift(entity database.Entity, current bson.M, existing map[string]indexSpec) []database.SchemaDrift {
+	var drifts []database.SchemaDrift
+
+	name := entity.TableName()
+
+	if v, ok := entity.(database.SchemaValidator); ok {
+		switch {
+		case current == nil:
+			drifts = append(drifts, database.SchemaDrift{Collection: name, Kind: "validator", Problem: "missing"})
+		case !sameDocument(current, v.JSONSchema()):
+			drifts = append(drifts, database.SchemaDrift{Collection: name, Kind: "validator", Problem: "changed"})
+		}
+	}
+
+	indexer, ok := entity.(database.Indexer)
+	if !ok {
+		return drifts
+	}
+
+	declared := map[string]bool{"_id_": true}
+	for _, index := range indexer.Indexes() {
+		indexName := index.IndexName(name)
+		declared[indexName] = true
+
+		spec, ok := existing[indexName]
+		switch {
+		case !ok:
+			drifts = append(drifts, database.SchemaDrift{Collection: name, Kind: "index", Name: indexName, Problem: "missing"})
+		case !sameIndex(spec, index):
+			drifts = append(drifts, database.SchemaDrift{Collection: name, Kind: "index", Name: indexName, Problem: "changed"})
+		}
+	}
+
+	for _, indexName := range slices.Sorted(maps.Keys(existing)) {
+		if !declared[indexName] {
+			drifts = append(drifts, database.SchemaDrift{Collection: name, Kind: "index", Name: indexName, Problem: "undeclared"})
+		}
+	}
+
+	return drifts
+}
+
+// applyValidator creates the collection with its validator, or updates the
+// validator of an existing collection when it changed.
+func (d *migrationDriver) applyValidator(ctx context.Context, entity database.Entity) error {
+	v, ok := entity.(database.SchemaValidator)
+	if !ok {
+		return nil
+	}
+
+	name := entity.TableName()
+	schema := v.JSONSchema()
+
+	current, err := d.validator(ctx, name)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return d.db.CreateCollection(ctx, name, options.CreateCollection().SetValidator(bson.M{"$jsonSchema": schema}))
+	}
+	if err != nil || sameDocument(current, schema) {
+		return err
+	}
+
+	logger.Infof(ctx, "🗃️ MongoDB updating validator of %s", name).Write()
+
+	return d.db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: name},
+		{Key: "validator", Value: bson.M{"$jsonSchema": schema}},
+	}).Err()
+}
+
+// applyIndexes creates the declared indexes missing from the collection.
+func (d *migrationDriver) applyIndexes(ctx context.Context, entity database.Entity) error {
+	indexer, ok := entity.(database.Indexer)
+	if !ok {
+		return nil
+	}
+
+	name := entity.TableName()
+
+	existing, err := d.indexes(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	var models []mongo.IndexModel
+	for _, index := range indexer.Indexes() {
+		if _, ok := existing[index.IndexName(name)]; ok {
+			continue
+		}
+
+		logger.Infof(ctx, "🗃️ MongoDB creating index %s on %s", index.IndexName(name), name).Write()
+		models = append(models, indexModel(name, index))
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	_, err = d.db.Collection(name).Indexes().CreateMany(ctx, models)
+
+	return err
+}
+
+// validator returns the $jsonSchema of a collection, nil when it has none,
+// or mongo.ErrNoDocuments when the collection does not exist.
+func (d *migrationDriver) validator(ctx context.Context, name string) (bson.M, error) {
+	specs, err := d.db.ListCollectionSpecifications(ctx, bson.M{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	if len(specs) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	var opts struct {
+		Validator struct {
+			JSONSchema bson.M `bson:"$jsonSchema"`
+		} `bson:"validator"`
+	}
+	if err := bson.Unmarshal(specs[0].Options, &opts); err != nil {
+		return nil, err
+	}
+
+	return opts.Validator.JSONSchema, nil
+}
+
+// indexes returns the indexes of a collection by name, none when the
+// collection does not exist.
+func (d *migrationDriver) indexes(ctx context.Context, name string) (map[string]indexSpec, error) {
+	cursor, err := d.db.Collection(name).Indexes().List(ctx)
+	if err != nil {
+		// NamespaceNotFound
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.Code == 26 {
+			return map[string]indexSpec{}, nil
+		}
+		return nil, err
+	}
+
+	var specs []indexSpec
+	if err := cursor.All(ctx, &specs); err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]indexSpec, len(specs))
+	for _, spec := range specs {
+		indexes[spec.Name] = spec
+	}
+
+	return indexes, nil
+}
+
+func indexModel(collection string, index database.Index) mongo.IndexModel {
+	keys := make(bson.D, len(index.Keys))
+	for i, key := range index.Keys {
+		if field, desc := strings.CutPrefix(key, "-"); desc {
+			keys[i] = bson.E{Key: field, Value: -1}
+		} else {
+			keys[i] = bson.E{Key: key, Value: 1}
+		}
+	}
+
+	opts := options.Index().SetName(index.IndexName(collection))
+	if index.Unique {
+		opts.SetUnique(true)
+	}
+	if index.TTL > 0 {
+		opts.SetExpireAfterSeconds(int32(index.TTL.Seconds()))
+	}
+	if index.Partial != nil {
+		opts.SetPartialFilterExpression(index.Partial)
+	}
+
+	return mongo.IndexModel{Keys: keys, Options: opts}
+}
+
+// sameIndex reports whether an index of the collection matches the one
+// declared.
+func sameIndex(spec indexSpec, index database.Index) bool {
+	if len(spec.Key) != len(index.Keys) || spec.Unique != index.Unique {
+		return false
+	}
+
+	for i, key := range index.Keys {
+		field, desc := strings.CutPrefix(key, "-")
+		if spec.Key[i].Key != field || direction(spec.Key[i].Value) != desc {
+			return false
+		}
+	}
+
+	var ttl int64
+	if spec.ExpireAfterSeconds != nil {
+		ttl = *spec.ExpireAfterSeconds
+	}
+	if ttl != int64(index.TTL.Seconds()) {
+		return false
+	}
+
+	if len(spec.PartialFilterExpression) == 0 || index.Partial == nil {
+		return len(spec.PartialFilterExpression) == 0 && index.Partial == nil
+	}
+
+	return sameDocument(spec.PartialFilterExpression, index.Partial)
+}
+
+// direction reports whether an index key is descending.
+func direction(value any) bool {
+	switch v := value.(type) {
+	case int32:
+		return v < 0
+	case int64:
+		return v < 0
+	case float64:
+		return v < 0
+	}
+
+	return false
+}
+
+// sameDocument compares a document read from the server with a declared
+// one through their JSON forms, so that numeric types and key order do not
+// count as differences.
+func sameDocument(current any, declared any) bool {
+	a, err := normalize(current, true)
+	if err != nil {
+		return false
+	}
+
+	b, err := normalize(declared, false)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func normalize(doc any, fromServer bool) (any, error) {
+	var data []byte
+	var err error
+	if fromServer {
+		data, err = bson.MarshalExtJSON(doc, false, false)
+	} else {
+		data, err = json.Marshal(doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out any
+	err = json.Unmarshal(data, &out)
+
+	return out, err
+}
@@ -0,0 +1,225 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type product struct {
+	account `bson:",inline"`
+}
+
+func (product) TableName() string {
+	return "products"
+}
+
+func (product) Indexes() []database.Index {
+	return []database.Index{
+		{Keys: []string{"tenant_id", "sku"}, Unique: true, Partial: map[string]any{"deleted_at": nil}},
+		{Keys: []string{"-created_at"}, Name: "idx_products_recent"},
+		{Keys: []string{"expires_at"}, TTL: time.Hour},
+	}
+}
+
+func (product) JSONSchema() map[string]any {
+	return map[string]any{
+		"bsonType": "object",
+		"required": []string{"sku", "price"},
+		"properties": map[string]any{
+			"price": map[string]any{"bsonType": []string{"double", "int"}, "minimum": 0},
+		},
+	}
+}
+
+// serverIndexes returns the indexes as the server lists them, by name.
+func serverIndexes(t *testing.T, docs ...bson.D) map[string]indexSpec {
+	t.Helper()
+
+	res := map[string]indexSpec{}
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		require.NoError(t, err)
+
+		var spec indexSpec
+		require.NoError(t, bson.Unmarshal(data, &spec))
+		res[spec.Name] = spec
+	}
+
+	return res
+}
+
+// serverValidator returns a $jsonSchema as the server returns it, with its
+// own numeric and array types.
+func serverValidator(t *testing.T, schema map[string]any) bson.M {
+	t.Helper()
+
+	data, err := bson.Marshal(bson.M{"$jsonSchema": schema})
+	require.NoError(t, err)
+
+	var res struct {
+		JSONSchema bson.M `bson:"$jsonSchema"`
+	}
+	require.NoError(t, bson.Unmarshal(data, &res))
+
+	return res.JSONSchema
+}
+
+var (
+	idIndex     = bson.D{{Key: "v", Value: int32(2)}, {Key: "key", Value: bson.D{{Key: "_id", Value: int32(1)}}}, {Key: "name", Value: "_id_"}}
+	skuIndex    = bson.D{{Key: "key", Value: bson.D{{Key: "tenant_id", Value: int32(1)}, {Key: "sku", Value: int32(1)}}}, {Key: "name", Value: "idx_products_tenant_id_sku"}, {Key: "unique", Value: true}, {Key: "partialFilterExpression", Value: bson.D{{Key: "deleted_at", Value: nil}}}}
+	recentIndex = bson.D{{Key: "key", Value: bson.D{{Key: "created_at", Value: int32(-1)}}}, {Key: "name", Value: "idx_products_recent"}}
+	expiryIndex = bson.D{{Key: "key", Value: bson.D{{Key: "expires_at", Value: int32(1)}}}, {Key: "name", Value: "idx_products_expires_at"}, {Key: "expireAfterSeconds", Value: int32(3600)}}
+)
+
+// with returns doc with the value of key replaced or added.
+func with(doc bson.D, key string, value any) bson.D {
+	res := append(bson.D(nil), doc...)
+	for i, e := range res {
+		if e.Key == key {
+			res[i].Value = value
+			return res
+		}
+	}
+
+	return append(res, bson.E{Key: key, Value: value})
+}
+
+func TestEntityDrift(t *testing.T) {
+	inSync := serverValidator(t, product{}.JSONSchema())
+
+	changedSchema := product{}.JSONSchema()
+	changedSchema["required"] = []string{"sku"}
+
+	cases := []struct {
+		name      string
+		validator bson.M
+		indexes   []bson.D
+		want      []string
+	}{
+		{
+			name:      "in sync",
+			validator: inSync,
+			indexes:   []bson.D{idIndex, skuIndex, recentIndex, expiryIndex},
+		},
+		{
+			name: "missing collection",
+			want: []string{
+				"missing validator of products",
+				"missing index idx_products_tenant_id_sku on products",
+				"missing index idx_products_recent on products",
+				"missing index idx_products_expires_at on products",
+			},
+		},
+		{
+			name:      "changed validator",
+			validator: serverValidator(t, changedSchema),
+			indexes:   []bson.D{idIndex, skuIndex, recentIndex, expiryIndex},
+			want:      []string{"changed validator of products"},
+		},
+		{
+			name:      "changed uniqueness",
+			validator: inSync,
+			indexes:   []bson.D{idIndex, with(skuIndex, "unique", false), recentIndex, expiryIndex},
+			want:      []string{"changed index idx_products_tenant_id_sku on products"},
+		},
+		{
+			name:      "changed partial filter",
+			validator: inSync,
+			indexes:   []bson.D{idIndex, with(skuIndex, "partialFilterExpression", bson.D{{Key: "status", Value: "active"}}), recentIndex, expiryIndex},
+			want:      []string{"changed index idx_products_tenant_id_sku on products"},
+		},
+		{
+			name:      "changed direction",
+			validator: inSync,
+			indexes:   []bson.D{idIndex, skuIndex, with(recentIndex, "key", bson.D{{Key: "created_at", Value: int32(1)}}), expiryIndex},
+			want:      []string{"changed index idx_products_recent on products"},
+		},
+		{
+			name:      "changed ttl",
+			validator: inSync,
+			indexes:   []bson.D{idIndex, skuIndex, recentIndex, with(expiryIndex, "expireAfterSeconds", int32(60))},
+			want:      []string{"changed index idx_products_expires_at on products"},
+		},
+		{
+			name:      "undeclared",
+			validator: inSync,
+			indexes: []bson.D{
+				idIndex, skuIndex, recentIndex, expiryIndex,
+				with(recentIndex, "name", "sku_1"),
+				with(recentIndex, "name", "name_1"),
+			},
+			want: []string{
+				"undeclared index name_1 on products",
+				"undeclared index sku_1 on products",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			drifts := entityDrift(product{}, tc.validator, serverIndexes(t, tc.indexes...))
+
+			var got []string
+			for _, drift := range drifts {
+				got = append(got, drift.String())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEntityDrift_NothingDeclared(t *testing.T) {
+	// Entities declaring neither have nothing to drift from.
+	drifts := entityDrift(account{}, nil, nil)
+
+	assert.Empty(t, drifts)
+}
+
+func TestSameDocument(t *testing.T) {
+	server := bson.M{"bsonType": "object", "minimum": int32(0), "enum": bson.A{"a", "b"}}
+
+	assert.True(t, sameDocument(server, map[string]any{"enum": []string{"a", "b"}, "minimum": 0, "bsonType": "object"}))
+	assert.True(t, sameDocument(server, map[string]any{"enum": []string{"a", "b"}, "minimum": 0.0, "bsonType": "object"}))
+	assert.False(t, sameDocument(server, map[string]any{"enum": []string{"b", "a"}, "minimum": 0, "bsonType": "object"}))
+	assert.False(t, sameDocument(server, map[string]any{"enum": []string{"a", "b"}, "bsonType": "object"}))
+}
+
+func TestIndexModel(t *testing.T) {
+	indexes := product{}.Indexes()
+
+	model := indexModel("products", indexes[0])
+	assert.Equal(t, bson.D{{Key: "tenant_id", Value: 1}, {Key: "sku", Value: 1}}, model.Keys)
+
+	opts := applyIndexOptions(t, model)
+	assert.Equal(t, "idx_products_tenant_id_sku", *opts.Name)
+	assert.True(t, *opts.Unique)
+	assert.Equal(t, map[string]any{"deleted_at": nil}, opts.PartialFilterExpression)
+	assert.Nil(t, opts.ExpireAfterSeconds)
+
+	model = indexModel("products", indexes[1])
+	assert.Equal(t, bson.D{{Key: "created_at", Value: -1}}, model.Keys)
+	assert.Equal(t, "idx_products_recent", *applyIndexOptions(t, model).Name)
+
+	opts = applyIndexOptions(t, indexModel("products", indexes[2]))
+	assert.Equal(t, int32(3600), *opts.ExpireAfterSeconds)
+	assert.Nil(t, opts.Unique)
+}
+
+// applyIndexOptions returns the options set on an index model.
+func applyIndexOptions(t *testing.T, model mongo.IndexModel) *options.IndexOptions {
+	t.Helper()
+
+	var opts options.IndexOptions
+	for _, set := range model.Options.List() {
+		require.NoError(t, set(&opts))
+	}
+
+	return &opts
+}
@@ -0,0 +1,49 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+)
+
+// NewMigrator returns a migrator of the embedded MySQL migrations on a
+// connection of its own, since they hold several statements per file and
+// closing the migrator closes it. Runs hold a GET_LOCK named lock, so
+// concurrent replicas migrate one at a time.
+func NewMigrator(ctx context.Context) (*database.Migrator, error) {
+	cfg, err := migrationConfig(setConfig().Master.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	connector, err := mysqldriver.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB := sql.OpenDB(connector)
+
+	migrateDriver, err := migratemysql.WithInstance(sqlDB, &migratemysql.Config{})
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return database.NewMigrator(ctx, "mysql", "mysql", migrateDriver)
+}
+
+// migrationConfig returns the configuration of dsn allowing several
+// statements per query, as migration files hold.
+func migrationConfig(dsn string) (*mysqldriver.Config, error) {
+	cfg, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.MultiStatements = true
+
+	return cfg, nil
+}
@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationConfig(t *testing.T) {
+	previous := config.MySQL
+	t.Cleanup(func() {
+		config.MySQL = previous
+	})
+
+	config.MySQL.Host = "localhost"
+	config.MySQL.Port = 3306
+	config.MySQL.Username = "app"
+	config.MySQL.Password = "p&ss?word"
+	config.MySQL.Database = "shop"
+	config.MySQL.MasterHost = ""
+
+	cfg, err := migrationConfig(setConfig().Master.DSN)
+	require.NoError(t, err)
+
+	assert.True(t, cfg.MultiStatements)
+	assert.True(t, cfg.ParseTime)
+	assert.Equal(t, "p&ss?word", cfg.Passwd)
+	assert.Equal(t, "shop", cfg.DBName)
+	assert.Equal(t, "localhost:3306", cfg.Addr)
+}
+
+func TestMigrationConfig_Invalid(t *testing.T) {
+	_, err := migrationConfig("app@tcp(localhost:3306)")
+
+	assert.Error(t, err)
+}
@@ -2,14 +2,14 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
-	"github.com/golang-migrate/migrate/v4"
-	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 	"github.com/goodone-dev/go-boilerplate/internal/utils/retry"
 	"gorm.io/driver/mysql"
@@ -20,7 +20,7 @@ import (
 
 type mysqlConfig struct {
 	Master mysql.Config
-	Slave  mysql.Config
+	Slaves map[string]mysql.Config
 }
 
 func setConfig() mysqlConfig {
@@ -35,44 +35,80 @@ func setConfig() mysqlConfig {
 		masterConfig.DSN = fmt.Sprintf(dsn, cfg.MasterUsername, cfg.MasterPassword, cfg.MasterHost, cfg.MasterPort, cfg.Database)
 	}
 
-	slaveConfig := mysql.Config{
-		DSN: fmt.Sprintf(dsn, cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Database),
-	}
-
-	if len(cfg.SlaveHost) > 0 {
-		slaveConfig.DSN = fmt.Sprintf(dsn, cfg.SlaveUsername, cfg.SlavePassword, cfg.SlaveHost, cfg.SlavePort, cfg.Database)
+	slaveConfigs := map[string]mysql.Config{}
+	for _, addr := range database.ReplicaAddrs(cfg.SlaveHost, cfg.SlaveHosts, cfg.SlavePort) {
+		slaveConfigs[addr.String()] = mysql.Config{
+			DSN: fmt.Sprintf(dsn, cfg.SlaveUsername, cfg.SlavePassword, addr.Host, addr.Port, cfg.Database),
+		}
 	}
 
 	return mysqlConfig{
 		Master: masterConfig,
-		Slave:  slaveConfig,
+		Slaves: slaveConfigs,
 	}
 }
 
+// Connection is the MySQL connection returned by Open.
+type Connection = mysqlConnection
+
 type mysqlConnection struct {
 	Master *gorm.DB
-	Slave  *gorm.DB
+	Slaves *database.ReplicaPool[*gorm.DB]
 }
 
 func Open(ctx context.Context) *mysqlConnection {
+	switch UUIDFormat(config.MySQL.UUIDFormat) {
+	case UUIDBinary, UUIDChar:
+	default:
+		logger.Fatalf(ctx, nil, "❌ MySQL does not support the %q UUID format", config.MySQL.UUIDFormat).Write()
+	}
+
+	switch database.CurrentTenantMode() {
+	case database.TenantSchema:
+		logger.Fatal(ctx, nil, "❌ MySQL does not support the schema tenant mode").Write()
+	case database.TenantRLS:
+		logger.Warn(ctx, "⚠️ MySQL has no row-level security, tenants are scoped by column only").Write()
+	}
+
 	mysqlConfig := setConfig()
 
+	master := open(ctx, mysqlConfig.Master)
+	if config.MySQL.AutoMigrate {
+		migrateUp(ctx)
+	}
+
+	replicas := make([]*database.Replica[*gorm.DB], 0, len(mysqlConfig.Slaves))
+	for name, slaveConfig := range mysqlConfig.Slaves {
+		replicas = append(replicas, database.NewReplica(name, open(ctx, slaveConfig)))
+	}
+
 	conn := &mysqlConnection{
-		Master: open(ctx, mysqlConfig.Master),
-		Slave:  open(ctx, mysqlConfig.Slave),
+		Master: master,
+		Slaves: database.NewReplicaPool(master, replicas, database.Balancer(config.Database.ReplicaBalancer), inUse),
 	}
 
 	go conn.Monitor(ctx)
 
+	if err := database.SetTransactor(conn); err != nil {
+		logger.Fatal(ctx, err, "❌ MySQL connection cannot serve WithTransaction").Write()
+	}
+
 	return conn
 }
 
 func open(ctx context.Context, mysqlConfig mysql.Config) *gorm.DB {
 	gormConfig := &gorm.Config{
-		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+		Logger:  gormlogger.Default.LogMode(gormlogger.Silent),
+		NowFunc: database.Now,
 	}
 
 	db, err := retry.RetryWithBackoff(ctx, "MySQL connection", func() (*gorm.DB, error) {
+		conn, err := connect(mysqlConfig.DSN)
+		if err != nil {
+			return nil, err
+		}
+
+		mysqlConfig.Conn = conn
 		return gorm.Open(mysql.New(mysqlConfig), gormConfig)
 	})
 	if err != nil {
@@ -99,26 +135,30 @@ func open(ctx context.Context, mysqlConfig mysql.Config) *gorm.DB {
 		logger.Fatal(ctx, err, "❌ MySQL connection test failed").Write()
 	}
 
-	if !config.MySQL.AutoMigrate {
-		return db
-	}
-
-	migrateDriver, err := migratemysql.WithInstance(sqlDB, &migratemysql.Config{})
-	if err != nil {
-		logger.Fatal(ctx, err, "❌ MySQL failed to initialize migration driver").Write()
-	}
+	return db
+}
 
-	m, err := migrate.NewWithDatabaseInstance("file://migrations/mysql", "mysql", migrateDriver)
+func migrateUp(ctx context.Context) {
+	m, err := NewMigrator(ctx)
 	if err != nil {
 		logger.Fatal(ctx, err, "❌ MySQL failed to create migration instance").Write()
 	}
+	defer m.Close()
 
-	err = m.Up()
-	if err != nil && err != migrate.ErrNoChange {
+	if err := m.Up(); err != nil {
 		logger.Fatal(ctx, err, "❌ MySQL failed migration").Write()
 	}
+}
 
-	return db
+// inUse reports the number of connections currently in use, used by the
+// least-connections balancer.
+func inUse(db *gorm.DB) int {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return 0
+	}
+
+	return sqlDB.Stats().InUse
 }
 
 func (c *mysqlConnection) Shutdown(ctx context.Context) error {
@@ -126,8 +166,10 @@ func (c *mysqlConnection) Shutdown(ctx context.Context) error {
 		return err
 	}
 
-	if err := close(c.Slave); err != nil {
-		return err
+	for _, replica := range c.Slaves.Replicas() {
+		if err := close(replica.DB); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -142,22 +184,19 @@ func close(conn *gorm.DB) error {
 	return sqlDB.Close()
 }
 
+// Ping checks the primary only; replicas are ejected from the read pool
+// by the monitor instead of failing the whole connection.
 func (c *mysqlConnection) Ping(ctx context.Context) error {
-	masterDB, err := c.Master.DB()
-	if err != nil {
-		return err
-	} else if err := masterDB.Ping(); err != nil {
-		return err
-	}
+	return ping(ctx, c.Master)
+}
 
-	slaveDB, err := c.Slave.DB()
+func ping(ctx context.Context, conn *gorm.DB) error {
+	sqlDB, err := conn.DB()
 	if err != nil {
 		return err
-	} else if err := slaveDB.Ping(); err != nil {
-		return err
 	}
 
-	return nil
+	return sqlDB.PingContext(ctx)
 }
 
 func (c *mysqlConnection) Monitor(ctx context.Context) {
@@ -183,6 +222,98 @@ func (c *mysqlConnection) Monitor(ctx context.Context) {
 					wasLost = false
 				}
 			}
+
+			for _, replica := range c.Slaves.Replicas() {
+				c.checkReplica(ctx, replica)
+			}
 		}
 	}
 }
+
+func (c *mysqlConnection) ReplicaLag(ctx context.Context, replica *gorm.DB) (time.Duration, error) {
+	rows, err := replica.WithContext(ctx).Raw("SHOW REPLICA STATUS").Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	// Not a replica, so there is nothing to lag behind
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+
+	status := map[string]any{}
+	if err := replica.ScanRows(rows, &status); err != nil {
+		return 0, err
+	}
+
+	var lag sql.NullInt64
+	if err := lag.Scan(status["Seconds_Behind_Source"]); err != nil {
+		return 0, err
+	} else if !lag.Valid {
+		return 0, fmt.Errorf("replication is not running")
+	}
+
+	return time.Duration(lag.Int64) * time.Second, nil
+}
+
+func (c *mysqlConnection) checkReplica(ctx context.Context, replica *database.Replica[*gorm.DB]) {
+	err := ping(ctx, replica.DB)
+	if err != nil {
+		if replica.SetHealthy(false) {
+			logger.Errorf(ctx, err, "🛑 MySQL replica %s ejected from read pool", replica.Name).Write()
+		}
+		return
+	} else if replica.SetHealthy(true) {
+		logger.Infof(ctx, "✅ MySQL replica %s restored to read pool", replica.Name).Write()
+	}
+
+	lag, err := c.ReplicaLag(ctx, replica.DB)
+	stale := err != nil || lag > config.Database.MaxReplicaLag
+
+	if replica.SetStale(stale) {
+		if stale {
+			logger.Warnf(ctx, "🐢 MySQL replica %s is %v behind, skipping it for reads", replica.Name, lag).Write()
+		} else {
+			logger.Infof(ctx, "✅ MySQL replica %s caught up, serving reads again", replica.Name).Write()
+		}
+	}
+}
+
+// Transaction runs fn in a transaction carried by the context. Nested calls
+// run in a savepoint of the outer transaction, and the outermost call is
+// retried on deadlocks.
+func (c *mysqlConnection) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	database.MarkWrite(ctx)
+
+	if tx, ok := database.TxFromContext[*gorm.DB](ctx); ok {
+		return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(database.ContextWithTx(ctx, tx))
+		})
+	}
+
+	_, err := retry.RetryWithPolicyIf(ctx, "MySQL transaction", database.TransactionRetry(), retryable, func() (any, error) {
+		return nil, c.Master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(database.ContextWithTx(ctx, tx))
+		})
+	})
+
+	return err
+}
+
+// pinsTenant always reports false; MySQL has neither row-level security nor
+// a search_path, so tenants are only scoped by column.
+func (c *mysqlConnection) pinsTenant(ctx context.Context) bool {
+	return false
+}
+
+// retryable reports whether the transaction failed on a deadlock (1213)
+// and can safely be run again.
+func retryable(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+
+	return mysqlErr.Number == 1213
+}
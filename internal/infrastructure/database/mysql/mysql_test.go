@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+// newTestReplica returns a replica whose statements are checked against the
+// expectations of the returned mock.
+func newTestReplica(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		sqlDB.Close()
+	})
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger:               gormlogger.Default.LogMode(gormlogger.Silent),
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	return db, mock
+}
+
+func TestMysqlConnection_ReplicaLag(t *testing.T) {
+	columns := []string{"Replica_IO_Running", "Seconds_Behind_Source"}
+
+	cases := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "caught up", rows: sqlmock.NewRows(columns).AddRow("Yes", 0)},
+		{name: "behind", rows: sqlmock.NewRows(columns).AddRow("Yes", 42), want: 42 * time.Second},
+		{name: "not a replica", rows: sqlmock.NewRows(columns)},
+		{name: "stopped", rows: sqlmock.NewRows(columns).AddRow("No", nil), wantErr: "replication is not running"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			replica, mock := newTestReplica(t)
+			mock.ExpectQuery(`SHOW REPLICA STATUS`).WillReturnRows(tc.rows)
+
+			lag, err := (&mysqlConnection{}).ReplicaLag(t.Context(), replica)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, lag)
+		})
+	}
+}
@@ -2,37 +2,228 @@ package mysql
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
 	"math"
+	"reflect"
+	"slices"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/goodone-dev/go-boilerplate/internal/config"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
-type baseRepo[D any, I any, E database.Entity] struct {
+// ErrTenantUpsert is returned by upserts of tenant-scoped entities in row
+// mode: ON DUPLICATE KEY UPDATE takes no condition, so the upsert could
+// overwrite a record of another tenant.
+var ErrTenantUpsert = errors.New("mysql cannot restrict an upsert to a tenant")
+
+type baseRepo[I any, E database.Entity] struct {
 	Entity   E
+	dbConn   *mysqlConnection
 	dbMaster *gorm.DB
-	dbSlave  *gorm.DB
+	preloads []string
 }
 
-func NewBaseRepository[D any, I any, E database.Entity](dbConn *mysqlConnection) database.BaseRepository[D, I, E] {
-	return &baseRepo[D, I, E]{
+func NewBaseRepository[I any, E database.Entity](dbConn *mysqlConnection) database.BaseRepository[I, E] {
+	return &baseRepo[I, E]{
+		dbConn:   dbConn,
 		dbMaster: dbConn.Master,
-		dbSlave:  dbConn.Slave,
 	}
 }
 
-func (r *baseRepo[D, I, E]) MasterDB() *D {
-	return any(r.dbMaster).(*D)
+// reader returns the transaction carried by the context if any, otherwise
+// the connection for reads, falling back to the master when the context asks
+// for read-your-writes or every slave is down or lagging.
+func (r *baseRepo[I, E]) reader(ctx context.Context) *gorm.DB {
+	if tx, ok := database.TxFromContext[*gorm.DB](ctx); ok {
+		return tx
+	}
+
+	if database.ReadFromPrimary(ctx) {
+		return r.dbMaster
+	}
+
+	return r.dbConn.Slaves.Pick()
+}
+
+// writer returns the given transaction, the one carried by the context or
+// the master otherwise, scoped to the tenant of the context, and records the
+// write so subsequent reads in the same request hit the master.
+func (r *baseRepo[I, E]) writer(ctx context.Context, trx *database.Tx) (*gorm.DB, error) {
+	database.MarkWrite(ctx)
+
+	if trx != nil {
+		tx, ok := database.TxHandle[*gorm.DB](trx)
+		if !ok {
+			return nil, database.ErrForeignTx
+		}
+
+		return scopeWrite(ctx, r.Entity, tx)
+	}
+
+	if tx, ok := database.TxFromContext[*gorm.DB](ctx); ok {
+		return scopeWrite(ctx, r.Entity, tx)
+	}
+
+	return scopeWrite(ctx, r.Entity, r.dbMaster)
+}
+
+// scope restricts a read on entity to the tenant carried by the context. A
+// read of a tenant-scoped entity without a tenant fails to build.
+func scope(ctx context.Context, entity any, builder sq.SelectBuilder) sq.SelectBuilder {
+	tenant, ok, err := database.TenantScope(ctx, entity)
+	if err != nil {
+		return builder.Where(failedScope{err})
+	}
+
+	if ok {
+		return builder.Where(sq.Eq{"tenant_id": tenant})
+	}
+
+	return builder
+}
+
+// failedScope is a condition that fails the query it is added to.
+type failedScope struct{ err error }
+
+func (s failedScope) ToSql() (string, []any, error) {
+	return "", nil, s.err
+}
+
+// scopeWrite restricts the updates and deletes made on db to the tenant
+// carried by the context, failing when a tenant-scoped entity has none.
+func scopeWrite(ctx context.Context, entity any, db *gorm.DB) (*gorm.DB, error) {
+	tenant, ok, err := database.TenantScope(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return db.Where("tenant_id = ?", tenant), nil
+	}
+
+	return db, nil
+}
+
+// session runs fn on db, within a short transaction when the tenant has to
+// be pinned on the database session for row-level security or schema
+// routing and db is not in a transaction already.
+func (r *baseRepo[I, E]) session(ctx context.Context, db *gorm.DB, fn func(db *gorm.DB) error) error {
+	db = db.WithContext(ctx)
+
+	if !r.dbConn.pinsTenant(ctx) {
+		return fn(db)
+	}
+
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return fn(db)
+	}
+
+	return db.Transaction(fn)
+}
+
+// scan runs a raw read on db into dest.
+func (r *baseRepo[I, E]) scan(ctx context.Context, db *gorm.DB, qry string, args []any, dest any) error {
+	return r.session(ctx, db, func(db *gorm.DB) error {
+		return db.Raw(qry, args...).Scan(dest).Error
+	})
 }
 
-func (r *baseRepo[D, I, E]) SlaveDB() *D {
-	return any(r.dbSlave).(*D)
+// audit runs write on db and, when the audit trail is enabled, records an
+// audit log for every record it changed within the same transaction. where
+// selects the records about to change, which are locked and read first;
+// write returns the records it inserted, any others are read back after.
+func (r *baseRepo[I, E]) audit(ctx context.Context, db *gorm.DB, op database.AuditOperation, where func(*gorm.DB) *gorm.DB, write func(tx *gorm.DB) ([]E, error)) error {
+	db = db.WithContext(ctx)
+
+	if !database.AuditEnabled() {
+		_, err := write(db)
+		return err
+	}
+
+	record := func(tx *gorm.DB) error {
+		var before []E
+		if where != nil {
+			if err := where(r.auditSession(ctx, tx)).Clauses(clause.Locking{Strength: "UPDATE"}).Find(&before).Error; err != nil {
+				return err
+			}
+		}
+
+		after, err := write(tx)
+		if err != nil {
+			return err
+		}
+
+		if after == nil && len(before) > 0 {
+			ids := make([]I, 0, len(before))
+			for _, model := range before {
+				if pk, ok := any(model).(interface{ PrimaryKey() I }); ok {
+					ids = append(ids, pk.PrimaryKey())
+				}
+			}
+
+			if err := r.auditSession(ctx, tx).Where("id IN ?", ids).Find(&after).Error; err != nil {
+				return err
+			}
+		}
+
+		logs, err := database.NewAuditLogs[I](ctx, op, before, after)
+		if err != nil || len(logs) == 0 {
+			return err
+		}
+
+		return tx.Session(&gorm.Session{NewDB: true}).Create(&logs).Error
+	}
+
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return record(db)
+	}
+
+	return db.Transaction(record)
 }
 
-func (r *baseRepo[D, I, E]) FindAll(ctx context.Context, filter map[string]any) (res []E, err error) {
+// auditSession returns a statement on the connection of tx for the audit
+// reads, free of the conditions, selects and omits the write chained on tx
+// and scoped to the tenant of the context again.
+func (r *baseRepo[I, E]) auditSession(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	fresh := tx.Session(&gorm.Session{NewDB: true})
+
+	// The writer has failed already when the context carries no tenant.
+	if scoped, err := scopeWrite(ctx, r.Entity, fresh); err == nil {
+		return scoped
+	}
+
+	return fresh
+}
+
+// withDeletedAt returns a copy of filter also matching deleted_at against
+// deleted, leaving the caller's filter untouched. A nil filter matches every
+// record.
+func withDeletedAt(filter map[string]any, deleted any) map[string]any {
+	res := make(map[string]any, len(filter)+1)
+	maps.Copy(res, filter)
+	res["deleted_at"] = deleted
+
+	return res
+}
+
+// where returns the condition selecting the records a write is about to
+// change, as passed to audit.
+func where(query any, args ...any) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(query, args...)
+	}
+}
+
+func (r *baseRepo[I, E]) FindAll(ctx context.Context, filter map[string]any) (res []E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter": filter,
@@ -51,12 +242,17 @@ func (r *baseRepo[D, I, E]) FindAll(ctx context.Context, filter map[string]any)
 		From(r.Entity.TableName()).
 		Where(filter)
 
-	qry, args, err := builder.ToSql()
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	err = r.scan(ctx, r.reader(ctx), qry, args, &res)
 	if err != nil {
 		return
 	}
 
-	err = r.dbSlave.WithContext(ctx).Raw(qry, args...).Scan(&res).Error
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
 	if err != nil {
 		return
 	}
@@ -64,7 +260,7 @@ func (r *baseRepo[D, I, E]) FindAll(ctx context.Context, filter map[string]any)
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindById(ctx context.Context, ID I) (res *E, err error) {
+func (r *baseRepo[I, E]) FindById(ctx context.Context, ID I) (res *E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"id": ID,
@@ -84,12 +280,17 @@ func (r *baseRepo[D, I, E]) FindById(ctx context.Context, ID I) (res *E, err err
 			"deleted_at": nil,
 		})
 
-	qry, args, err := builder.ToSql()
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
 	if err != nil {
 		return
 	}
 
-	err = r.dbSlave.WithContext(ctx).Raw(qry, args...).Scan(&res).Error
+	err = r.scan(ctx, r.reader(ctx), qry, args, &res)
+	if err != nil {
+		return
+	}
+
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
 	if err != nil {
 		return
 	}
@@ -97,7 +298,7 @@ func (r *baseRepo[D, I, E]) FindById(ctx context.Context, ID I) (res *E, err err
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindByIdAndLock(ctx context.Context, ID I, trx *D) (res *E, err error) {
+func (r *baseRepo[I, E]) FindByIdAndLock(ctx context.Context, ID I, trx *database.Tx) (res *E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"id": ID,
@@ -118,17 +319,17 @@ func (r *baseRepo[D, I, E]) FindByIdAndLock(ctx context.Context, ID I, trx *D) (
 		}).
 		Suffix("FOR UPDATE")
 
-	qry, args, err := builder.ToSql()
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
 	if err != nil {
 		return
 	}
 
-	db := r.dbSlave
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
 	}
 
-	err = db.WithContext(ctx).Raw(qry, args...).Scan(&res).Error
+	err = r.scan(ctx, db, qry, args, &res)
 	if err != nil {
 		return
 	}
@@ -136,7 +337,7 @@ func (r *baseRepo[D, I, E]) FindByIdAndLock(ctx context.Context, ID I, trx *D) (
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, err error) {
+func (r *baseRepo[I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"ids": IDs,
@@ -156,12 +357,17 @@ func (r *baseRepo[D, I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, er
 			"deleted_at": nil,
 		})
 
-	qry, args, err := builder.ToSql()
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	err = r.scan(ctx, r.reader(ctx), qry, args, &res)
 	if err != nil {
 		return
 	}
 
-	err = r.dbSlave.WithContext(ctx).Raw(qry, args...).Scan(&res).Error
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
 	if err != nil {
 		return
 	}
@@ -169,7 +375,7 @@ func (r *baseRepo[D, I, E]) FindByIds(ctx context.Context, IDs []I) (res []E, er
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]any, sort []string, size int, page int) (res database.Pagination[E], err error) {
+func (r *baseRepo[I, E]) FindByOffset(ctx context.Context, filter map[string]any, sort []string, size int, page int) (res database.Pagination[E], err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter": filter,
@@ -198,13 +404,13 @@ func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]
 		From(r.Entity.TableName()).
 		Where(filter)
 
-	qry, args, err := builder.ToSql()
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
 	if err != nil {
 		return
 	}
 
 	var total int64
-	err = r.dbSlave.WithContext(ctx).Raw(qry, args...).Scan(&total).Error
+	err = r.scan(ctx, r.reader(ctx), qry, args, &total)
 	if err != nil {
 		return
 	}
@@ -217,13 +423,18 @@ func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]
 		Limit(uint64(size)).
 		Offset(uint64((page - 1) * size))
 
-	qry, args, err = builder.ToSql()
+	qry, args, err = scope(ctx, r.Entity, builder).ToSql()
 	if err != nil {
 		return
 	}
 
 	var models []E
-	err = r.dbSlave.WithContext(ctx).Raw(qry, args...).Scan(&models).Error
+	err = r.scan(ctx, r.reader(ctx), qry, args, &models)
+	if err != nil {
+		return
+	}
+
+	err = database.LoadRelations(ctx, r.preloads, &models, r.fetchRelation)
 	if err != nil {
 		return
 	}
@@ -242,7 +453,7 @@ func (r *baseRepo[D, I, E]) FindByOffset(ctx context.Context, filter map[string]
 	return
 }
 
-func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]any, sort []string, size int, next *I) (res database.Pagination[E], err error) {
+func (r *baseRepo[I, E]) FindByCursor(ctx context.Context, filter map[string]any, sort []string, size int, next *I) (res database.Pagination[E], err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter": filter,
@@ -271,13 +482,13 @@ func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]
 		From(r.Entity.TableName()).
 		Where(filter)
 
-	qry, args, err := builder.ToSql()
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
 	if err != nil {
 		return
 	}
 
 	var total int64
-	err = r.dbSlave.WithContext(ctx).Raw(qry, args...).Scan(&total).Error
+	err = r.scan(ctx, r.reader(ctx), qry, args, &total)
 	if err != nil {
 		return
 	}
@@ -289,13 +500,18 @@ func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]
 		OrderBy(sort...).
 		Limit(uint64(size))
 
-	qry, args, err = builder.ToSql()
+	qry, args, err = scope(ctx, r.Entity, builder).ToSql()
 	if err != nil {
 		return
 	}
 
 	var models []E
-	err = r.dbSlave.WithContext(ctx).Raw(qry, args...).Scan(&models).Error
+	err = r.scan(ctx, r.reader(ctx), qry, args, &models)
+	if err != nil {
+		return
+	}
+
+	err = database.LoadRelations(ctx, r.preloads, &models, r.fetchRelation)
 	if err != nil {
 		return
 	}
@@ -313,8 +529,351 @@ func (r *baseRepo[D, I, E]) FindByCursor(ctx context.Context, filter map[string]
 	return
 }
 
+func (r *baseRepo[I, E]) FindWithTrashed(ctx context.Context, filter map[string]any) (res []E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	builder := sq.
+		Select("*").
+		From(r.Entity.TableName()).
+		Where(filter)
+
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	err = r.scan(ctx, r.reader(ctx), qry, args, &res)
+	if err != nil {
+		return
+	}
+
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) FindOnlyTrashed(ctx context.Context, filter map[string]any) (res []E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	builder := sq.
+		Select("*").
+		From(r.Entity.TableName()).
+		Where(filter).
+		Where(sq.NotEq{"deleted_at": nil})
+
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	err = r.scan(ctx, r.reader(ctx), qry, args, &res)
+	if err != nil {
+		return
+	}
+
+	err = database.LoadRelations(ctx, r.preloads, &res, r.fetchRelation)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// fetchRelation loads the related records of a preloaded relation in a
+// single query.
+func (r *baseRepo[I, E]) fetchRelation(ctx context.Context, related database.Entity, column string, keys []any, dest any) error {
+	builder := sq.
+		Select("*").
+		From(related.TableName()).
+		Where(sq.Eq{
+			column:       keys,
+			"deleted_at": nil,
+		})
+
+	qry, args, err := scope(ctx, related, builder).ToSql()
+	if err != nil {
+		return err
+	}
+
+	return r.scan(ctx, r.reader(ctx), qry, args, dest)
+}
+
+// Preload returns a copy of the repository whose reads also load the given
+// relations, leaving r untouched.
+func (r *baseRepo[I, E]) Preload(relations ...string) database.BaseRepository[I, E] {
+	preloaded := *r
+	preloaded.preloads = append(slices.Clone(r.preloads), relations...)
+
+	return &preloaded
+}
+
+func (r *baseRepo[I, E]) FindProjected(ctx context.Context, filter map[string]any, fields []string, dest any) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+		"fields": fields,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.End(err)
+	}()
+
+	if err = database.ValidateIdentifiers(fields...); err != nil {
+		return
+	}
+
+	builder := sq.
+		Select(fields...).
+		From(r.Entity.TableName()).
+		Where(withDeletedAt(filter, nil))
+
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	err = r.scan(ctx, r.reader(ctx), qry, args, dest)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) Count(ctx context.Context, filter map[string]any) (res int64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	builder := sq.
+		Select("COUNT(*)").
+		From(r.Entity.TableName()).
+		Where(withDeletedAt(filter, nil))
+
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	err = r.scan(ctx, r.reader(ctx), qry, args, &res)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) Exists(ctx context.Context, filter map[string]any) (res bool, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"filter": filter,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	builder := sq.
+		Select("1").
+		From(r.Entity.TableName()).
+		Where(withDeletedAt(filter, nil)).
+		Limit(1)
+
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	var found []int
+	err = r.scan(ctx, r.reader(ctx), qry, args, &found)
+	if err != nil {
+		return false, err
+	}
+
+	return len(found) > 0, nil
+}
+
+func (r *baseRepo[I, E]) Aggregate(ctx context.Context, query database.AggregateQuery, dest any) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"query": query,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.End(err)
+	}()
+
+	if err = query.Validate(); err != nil {
+		return
+	}
+
+	columns := append([]string{}, query.GroupBy...)
+	for _, agg := range query.Aggregations {
+		field := agg.Field
+		if agg.Func == database.AggregateCount {
+			field = "*"
+		}
+
+		columns = append(columns, fmt.Sprintf("%s(%s) AS %s", agg.Func, field, agg.As))
+	}
+
+	builder := sq.
+		Select(columns...).
+		From(r.Entity.TableName()).
+		Where(withDeletedAt(query.Filter, nil)).
+		GroupBy(query.GroupBy...).
+		OrderBy(query.Sort...)
+
+	qry, args, err := scope(ctx, r.Entity, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	err = r.scan(ctx, r.reader(ctx), qry, args, dest)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Stream iterates over the records matching filter without loading them all
+// into memory. Records are fetched in batches of batchSize ordered by ID, and
+// the span stays open until the iteration ends.
+func (r *baseRepo[I, E]) Stream(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		ctx, span := tracer.Start(ctx)
+		span.SetFunctionInput(tracer.Metadata{
+			"filter":     filter,
+			"batch_size": batchSize,
+		}).AddAttribute("table.name", r.Entity.TableName())
+
+		var count int
+		var err error
+
+		defer func() {
+			span.SetFunctionOutput(tracer.Metadata{
+				"count": count,
+			}).End(err)
+		}()
+
+		if batchSize <= 0 {
+			batchSize = config.Database.StreamBatchSize
+		}
+
+		if _, ok := any(*new(E)).(interface{ PrimaryKey() I }); !ok {
+			err = fmt.Errorf("stream %s: %w", r.Entity.TableName(), database.ErrNoPrimaryKey)
+			yield(*new(E), err)
+			return
+		}
+
+		filter = withDeletedAt(filter, nil)
+
+		var last *I
+		for {
+			builder := sq.
+				Select("*").
+				From(r.Entity.TableName()).
+				Where(filter).
+				OrderBy("id").
+				Limit(uint64(batchSize))
+
+			if last != nil {
+				builder = builder.Where(sq.Gt{"id": *last})
+			}
+
+			var qry string
+			var args []any
+			qry, args, err = scope(ctx, r.Entity, builder).ToSql()
+			if err != nil {
+				yield(*new(E), err)
+				return
+			}
+
+			var fetched int
+			fetched, last, err = r.streamBatch(ctx, qry, args, yield, &count)
+			if err != nil || fetched < batchSize || last == nil {
+				return
+			}
+		}
+	}
+}
+
+// streamBatch yields the rows of a single batch and returns how many were
+// read along with the ID of the last one. A nil ID means the caller stopped
+// the iteration.
+func (r *baseRepo[I, E]) streamBatch(ctx context.Context, qry string, args []any, yield func(E, error) bool, count *int) (fetched int, last *I, err error) {
+	err = r.session(ctx, r.reader(ctx), func(db *gorm.DB) error {
+		rows, err := db.Raw(qry, args...).Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+
+			var model E
+			if err = db.ScanRows(rows, &model); err != nil {
+				return err
+			}
+
+			fetched++
+			*count++
+
+			if !yield(model, nil) {
+				last = nil
+				return nil
+			}
+
+			id := any(model).(interface{ PrimaryKey() I }).PrimaryKey()
+			last = &id
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		yield(*new(E), err)
+		return fetched, nil, err
+	}
+
+	return fetched, last, nil
+}
+
 // TODO: Check 'res' is still necessary
-func (r *baseRepo[D, I, E]) Insert(ctx context.Context, payload E, trx *D) (res E, err error) {
+func (r *baseRepo[I, E]) Insert(ctx context.Context, payload E, trx *database.Tx) (res E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"payload": payload,
@@ -326,12 +885,18 @@ func (r *baseRepo[D, I, E]) Insert(ctx context.Context, payload E, trx *D) (res
 		}).End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	database.StampTenant(ctx, &payload)
+	database.StampCreated(&payload)
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
 	}
 
-	err = db.WithContext(ctx).Create(&payload).Error
+	err = r.audit(ctx, db, database.AuditInsert, nil, func(tx *gorm.DB) ([]E, error) {
+		err := tx.Create(&payload).Error
+		return []E{payload}, err
+	})
 	if err != nil {
 		return payload, err
 	}
@@ -340,10 +905,44 @@ func (r *baseRepo[D, I, E]) Insert(ctx context.Context, payload E, trx *D) (res
 }
 
 // TODO: Check 'res' is still necessary
-func (r *baseRepo[D, I, E]) InsertMany(ctx context.Context, payload []E, trx *D) (res []E, err error) {
+func (r *baseRepo[I, E]) InsertMany(ctx context.Context, payload []E, trx *database.Tx) (res []E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"payload": payload,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	for i := range payload {
+		database.StampTenant(ctx, &payload[i])
+		database.StampCreated(&payload[i])
+	}
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+
+	err = r.audit(ctx, db, database.AuditInsert, nil, func(tx *gorm.DB) ([]E, error) {
+		err := tx.CreateInBatches(payload, config.MySQL.InsertBatchSize).Error
+		return payload, err
+	})
+	if err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+func (r *baseRepo[I, E]) Upsert(ctx context.Context, payload E, opts database.UpsertOptions, trx *database.Tx) (res E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"payload": payload,
+		"options": opts,
 	}).AddAttribute("table.name", r.Entity.TableName())
 
 	defer func() {
@@ -352,12 +951,27 @@ func (r *baseRepo[D, I, E]) InsertMany(ctx context.Context, payload []E, trx *D)
 		}).End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	database.StampTenant(ctx, &payload)
+	database.StampCreated(&payload)
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+
+	if _, ok, _ := database.TenantScope(ctx, r.Entity); ok {
+		return payload, ErrTenantUpsert
 	}
 
-	err = db.WithContext(ctx).CreateInBatches(payload, config.MySQL.InsertBatchSize).Error
+	conflicting := r.conflicting(opts, []E{payload})
+
+	err = r.audit(ctx, db, database.AuditUpsert, conflicting, func(tx *gorm.DB) ([]E, error) {
+		if err := tx.Clauses(r.onConflict(opts), clause.Returning{}).Create(&payload).Error; err != nil {
+			return nil, err
+		}
+
+		return r.written(ctx, tx, conflicting)
+	})
 	if err != nil {
 		return payload, err
 	}
@@ -365,7 +979,219 @@ func (r *baseRepo[D, I, E]) InsertMany(ctx context.Context, payload []E, trx *D)
 	return payload, nil
 }
 
-func (r *baseRepo[D, I, E]) Update(ctx context.Context, payload E, trx *D) (err error) {
+func (r *baseRepo[I, E]) UpsertMany(ctx context.Context, payload []E, opts database.UpsertOptions, trx *database.Tx) (res []E, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"payload": payload,
+		"options": opts,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	for i := range payload {
+		database.StampTenant(ctx, &payload[i])
+		database.StampCreated(&payload[i])
+	}
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+
+	if _, ok, _ := database.TenantScope(ctx, r.Entity); ok {
+		return payload, ErrTenantUpsert
+	}
+
+	conflicting := r.conflicting(opts, payload)
+
+	err = r.audit(ctx, db, database.AuditUpsert, conflicting, func(tx *gorm.DB) ([]E, error) {
+		if err := tx.Clauses(r.onConflict(opts), clause.Returning{}).CreateInBatches(payload, config.MySQL.InsertBatchSize).Error; err != nil {
+			return nil, err
+		}
+
+		return r.written(ctx, tx, conflicting)
+	})
+	if err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// conflicting returns the condition selecting the records an upsert of
+// payload may overwrite, matched on the conflict columns, as passed to
+// audit.
+func (r *baseRepo[I, E]) conflicting(opts database.UpsertOptions, payload []E) func(*gorm.DB) *gorm.DB {
+	columns := opts.Conflict
+	if len(columns) == 0 {
+		columns = []string{"id"}
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(&r.Entity); err != nil {
+			db.AddError(err)
+			return db
+		}
+
+		fields := make([]*schema.Field, 0, len(columns))
+		tuple := make([]clause.Column, 0, len(columns))
+		for _, column := range columns {
+			field := stmt.Schema.LookUpField(column)
+			if field == nil {
+				db.AddError(fmt.Errorf("unknown conflict column %q", column))
+				return db
+			}
+
+			fields = append(fields, field)
+			tuple = append(tuple, clause.Column{Name: field.DBName})
+		}
+
+		keys := make([]any, 0, len(payload))
+		for i := range payload {
+			key := make([]any, 0, len(fields))
+			for _, field := range fields {
+				value, _ := field.ValueOf(db.Statement.Context, reflect.ValueOf(payload[i]))
+				key = append(key, value)
+			}
+
+			keys = append(keys, key)
+		}
+
+		return db.Where(clause.IN{Column: tuple, Values: keys})
+	}
+}
+
+// written reads back the records an upsert wrote; rows updated on conflict
+// keep their own ID, which the payload does not carry.
+func (r *baseRepo[I, E]) written(ctx context.Context, tx *gorm.DB, conflicting func(*gorm.DB) *gorm.DB) ([]E, error) {
+	var after []E
+	if !database.AuditEnabled() {
+		return after, nil
+	}
+
+	err := conflicting(r.auditSession(ctx, tx)).Find(&after).Error
+
+	return after, err
+}
+
+// onConflict builds the conflict clause, rendered as ON DUPLICATE KEY
+// UPDATE, which ignores any condition; upserts under tenant scope are
+// refused before reaching it.
+func (r *baseRepo[I, E]) onConflict(opts database.UpsertOptions) clause.OnConflict {
+	if len(opts.Conflict) == 0 {
+		opts.Conflict = []string{"id"}
+	}
+
+	conflict := clause.OnConflict{
+		UpdateAll: len(opts.Update) == 0,
+	}
+
+	for _, column := range opts.Conflict {
+		conflict.Columns = append(conflict.Columns, clause.Column{Name: column})
+	}
+
+	if len(opts.Update) > 0 {
+		conflict.DoUpdates = clause.AssignmentColumns(opts.Update)
+	}
+
+	return conflict
+}
+
+// BulkWrite runs the operations in order within a single transaction,
+// batching consecutive inserts into one statement.
+func (r *baseRepo[I, E]) BulkWrite(ctx context.Context, ops []database.WriteOperation[E], trx *database.Tx) (res database.BulkWriteResult, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"operations": ops,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var inserts []E
+
+		flush := func() error {
+			if len(inserts) == 0 {
+				return nil
+			}
+
+			err := r.audit(ctx, tx, database.AuditInsert, nil, func(tx *gorm.DB) ([]E, error) {
+				result := tx.CreateInBatches(inserts, config.MySQL.InsertBatchSize)
+				if result.Error != nil {
+					return nil, result.Error
+				}
+
+				res.Inserted += result.RowsAffected
+
+				return inserts, nil
+			})
+			inserts = nil
+
+			return err
+		}
+
+		for _, op := range ops {
+			if op.Kind == database.WriteInsert {
+				database.StampTenant(ctx, &op.Model)
+				database.StampCreated(&op.Model)
+				inserts = append(inserts, op.Model)
+				continue
+			}
+
+			if err := flush(); err != nil {
+				return err
+			}
+
+			var err error
+			switch op.Kind {
+			case database.WriteUpdate:
+				err = r.audit(ctx, tx, database.AuditUpdate, where(op.Filter), func(tx *gorm.DB) ([]E, error) {
+					result := tx.Model(&r.Entity).Where(op.Filter).Updates(database.Touch(op.Payload))
+					res.Updated += result.RowsAffected
+					return nil, result.Error
+				})
+			case database.WriteDelete:
+				trashable := func(db *gorm.DB) *gorm.DB {
+					return db.Where(op.Filter).Where("deleted_at IS NULL")
+				}
+
+				err = r.audit(ctx, tx, database.AuditDelete, trashable, func(tx *gorm.DB) ([]E, error) {
+					result := trashable(tx.Model(&r.Entity)).Updates(database.Touch(map[string]any{"deleted_at": database.Now()}))
+					res.Deleted += result.RowsAffected
+					return nil, result.Error
+				})
+			default:
+				err = fmt.Errorf("unknown write operation %q", op.Kind)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return flush()
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r *baseRepo[I, E]) Update(ctx context.Context, payload E, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"payload": payload,
@@ -375,12 +1201,30 @@ func (r *baseRepo[D, I, E]) Update(ctx context.Context, payload E, trx *D) (err
 		span.End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	database.StampUpdated(&payload)
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+	db = db.Omit("created_at")
+
+	// Save falls back to an insert when no row matched, which would let a
+	// tenant overwrite another one's record; selecting the columns disables
+	// that fallback.
+	if _, ok, _ := database.TenantScope(ctx, r.Entity); ok {
+		database.StampTenant(ctx, &payload)
+		db = db.Select("*")
+	}
+
+	var condition func(*gorm.DB) *gorm.DB
+	if pk, ok := any(payload).(interface{ PrimaryKey() I }); ok {
+		condition = where("id = ?", pk.PrimaryKey())
 	}
 
-	err = db.WithContext(ctx).Save(&payload).Error
+	err = r.audit(ctx, db, database.AuditUpdate, condition, func(tx *gorm.DB) ([]E, error) {
+		return nil, tx.Save(&payload).Error
+	})
 	if err != nil {
 		return err
 	}
@@ -388,7 +1232,7 @@ func (r *baseRepo[D, I, E]) Update(ctx context.Context, payload E, trx *D) (err
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) UpdateById(ctx context.Context, ID I, payload map[string]any, trx *D) (res E, err error) {
+func (r *baseRepo[I, E]) UpdateById(ctx context.Context, ID I, payload map[string]any, trx *database.Tx) (res E, err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"id":      ID,
@@ -401,12 +1245,16 @@ func (r *baseRepo[D, I, E]) UpdateById(ctx context.Context, ID I, payload map[st
 		}).End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	payload = database.Touch(payload)
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
 	}
 
-	err = db.WithContext(ctx).Model(&res).Where("id=?", ID).Updates(payload).Scan(&res).Error
+	err = r.audit(ctx, db, database.AuditUpdate, where("id = ?", ID), func(tx *gorm.DB) ([]E, error) {
+		return nil, tx.Model(&res).Where("id=?", ID).Updates(payload).Scan(&res).Error
+	})
 	if err != nil {
 		return res, err
 	}
@@ -415,7 +1263,7 @@ func (r *baseRepo[D, I, E]) UpdateById(ctx context.Context, ID I, payload map[st
 }
 
 // TODO: Check 'res' is needed
-func (r *baseRepo[D, I, E]) UpdateByIds(ctx context.Context, IDs []I, payload map[string]any, trx *D) (err error) {
+func (r *baseRepo[I, E]) UpdateByIds(ctx context.Context, IDs []I, payload map[string]any, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"ids":     IDs,
@@ -426,12 +1274,16 @@ func (r *baseRepo[D, I, E]) UpdateByIds(ctx context.Context, IDs []I, payload ma
 		span.End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	payload = database.Touch(payload)
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
 	}
 
-	err = db.WithContext(ctx).Model(&r.Entity).Where("id IN ?", IDs).Updates(payload).Error
+	err = r.audit(ctx, db, database.AuditUpdate, where("id IN ?", IDs), func(tx *gorm.DB) ([]E, error) {
+		return nil, tx.Model(&r.Entity).Where("id IN ?", IDs).Updates(payload).Error
+	})
 	if err != nil {
 		return err
 	}
@@ -440,7 +1292,7 @@ func (r *baseRepo[D, I, E]) UpdateByIds(ctx context.Context, IDs []I, payload ma
 }
 
 // TODO: Check 'res' is needed
-func (r *baseRepo[D, I, E]) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *D) (err error) {
+func (r *baseRepo[I, E]) UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter":  filter,
@@ -451,12 +1303,16 @@ func (r *baseRepo[D, I, E]) UpdateMany(ctx context.Context, filter map[string]an
 		span.End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	payload = database.Touch(payload)
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
 	}
 
-	err = db.WithContext(ctx).Model(&r.Entity).Where(filter).Updates(payload).Error
+	err = r.audit(ctx, db, database.AuditUpdate, where(filter), func(tx *gorm.DB) ([]E, error) {
+		return nil, tx.Model(&r.Entity).Where(filter).Updates(payload).Error
+	})
 	if err != nil {
 		return err
 	}
@@ -464,7 +1320,7 @@ func (r *baseRepo[D, I, E]) UpdateMany(ctx context.Context, filter map[string]an
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) DeleteById(ctx context.Context, ID I, trx *D) (err error) {
+func (r *baseRepo[I, E]) DeleteById(ctx context.Context, ID I, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"id": ID,
@@ -474,12 +1330,14 @@ func (r *baseRepo[D, I, E]) DeleteById(ctx context.Context, ID I, trx *D) (err e
 		span.End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
 	}
 
-	err = db.WithContext(ctx).Delete(&r.Entity, ID).Error
+	err = r.audit(ctx, db, database.AuditDelete, where("id = ? AND deleted_at IS NULL", ID), func(tx *gorm.DB) ([]E, error) {
+		return nil, tx.Model(&r.Entity).Where("id = ? AND deleted_at IS NULL", ID).Updates(database.Touch(map[string]any{"deleted_at": database.Now()})).Error
+	})
 	if err != nil {
 		return err
 	}
@@ -487,7 +1345,7 @@ func (r *baseRepo[D, I, E]) DeleteById(ctx context.Context, ID I, trx *D) (err e
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *D) (err error) {
+func (r *baseRepo[I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"ids": IDs,
@@ -497,12 +1355,14 @@ func (r *baseRepo[D, I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *D) (e
 		span.End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
 	}
 
-	err = db.WithContext(ctx).Delete(&r.Entity, IDs).Error
+	err = r.audit(ctx, db, database.AuditDelete, where("id IN ? AND deleted_at IS NULL", IDs), func(tx *gorm.DB) ([]E, error) {
+		return nil, tx.Model(&r.Entity).Where("id IN ? AND deleted_at IS NULL", IDs).Updates(database.Touch(map[string]any{"deleted_at": database.Now()})).Error
+	})
 	if err != nil {
 		return err
 	}
@@ -510,7 +1370,7 @@ func (r *baseRepo[D, I, E]) DeleteByIds(ctx context.Context, IDs []I, trx *D) (e
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) DeleteMany(ctx context.Context, filter map[string]any, trx *D) (err error) {
+func (r *baseRepo[I, E]) DeleteMany(ctx context.Context, filter map[string]any, trx *database.Tx) (err error) {
 	ctx, span := tracer.Start(ctx)
 	span.SetFunctionInput(tracer.Metadata{
 		"filter": filter,
@@ -520,12 +1380,43 @@ func (r *baseRepo[D, I, E]) DeleteMany(ctx context.Context, filter map[string]an
 		span.End(err)
 	}()
 
-	db := r.dbMaster
-	if trx, ok := any(trx).(*gorm.DB); ok {
-		db = trx
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+
+	live := func(db *gorm.DB) *gorm.DB {
+		return db.Where(filter).Where("deleted_at IS NULL")
+	}
+
+	err = r.audit(ctx, db, database.AuditDelete, live, func(tx *gorm.DB) ([]E, error) {
+		return nil, live(tx.Model(&r.Entity)).Updates(database.Touch(map[string]any{"deleted_at": database.Now()})).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *baseRepo[I, E]) Restore(ctx context.Context, ID I, trx *database.Tx) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"id": ID,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.End(err)
+	}()
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
 	}
 
-	err = db.WithContext(ctx).Delete(&r.Entity, filter).Error
+	err = r.audit(ctx, db, database.AuditRestore, where("id = ? AND deleted_at IS NOT NULL", ID), func(tx *gorm.DB) ([]E, error) {
+		return nil, tx.Model(&r.Entity).Where("id = ? AND deleted_at IS NOT NULL", ID).Updates(database.Touch(map[string]any{"deleted_at": nil})).Error
+	})
 	if err != nil {
 		return err
 	}
@@ -533,33 +1424,140 @@ func (r *baseRepo[D, I, E]) DeleteMany(ctx context.Context, filter map[string]an
 	return nil
 }
 
-func (r *baseRepo[D, I, E]) Begin(ctx context.Context) (trx *D, err error) {
+func (r *baseRepo[I, E]) ForceDelete(ctx context.Context, ID I, trx *database.Tx) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"id": ID,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.End(err)
+	}()
+
+	db, err := r.writer(ctx, trx)
+	if err != nil {
+		return
+	}
+
+	err = r.audit(ctx, db, database.AuditDelete, where("id = ?", ID), func(tx *gorm.DB) ([]E, error) {
+		return nil, tx.Where("id = ?", ID).Delete(&r.Entity).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *baseRepo[I, E]) PurgeTrashed(ctx context.Context, before time.Time) (res int64, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"before": before,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	db, err := r.writer(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	trashed := where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+
+	err = r.audit(ctx, db, database.AuditDelete, trashed, func(tx *gorm.DB) ([]E, error) {
+		result := trashed(tx).Delete(&r.Entity)
+		res = result.RowsAffected
+		return nil, result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+// History lists the audit logs of the record, oldest first.
+func (r *baseRepo[I, E]) History(ctx context.Context, ID I) (res []database.AuditLog, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"id": ID,
+	}).AddAttribute("table.name", r.Entity.TableName())
+
+	defer func() {
+		span.SetFunctionOutput(tracer.Metadata{
+			"result": res,
+		}).End(err)
+	}()
+
+	var audit database.AuditLog
+
+	builder := sq.
+		Select("*").
+		From(audit.TableName()).
+		Where(sq.Eq{
+			"entity":    r.Entity.TableName(),
+			"entity_id": fmt.Sprint(ID),
+		}).
+		OrderBy("created_at", "id")
+
+	qry, args, err := scope(ctx, audit, builder).ToSql()
+	if err != nil {
+		return
+	}
+
+	err = r.scan(ctx, r.reader(ctx), qry, args, &res)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *baseRepo[I, E]) Begin(ctx context.Context) (trx *database.Tx, err error) {
 	db := r.dbMaster.WithContext(ctx).Begin()
 	if db.Error != nil {
 		return trx, db.Error
 	}
 
-	return any(db).(*D), nil
+	return database.NewTx(db), nil
 }
 
-func (r *baseRepo[D, I, E]) Rollback(trx *D) *D {
-	db, ok := any(trx).(*gorm.DB)
+func (r *baseRepo[I, E]) Rollback(trx *database.Tx) error {
+	if trx == nil {
+		return nil
+	}
+
+	db, ok := database.TxHandle[*gorm.DB](trx)
 	if !ok {
-		return trx
+		return database.ErrForeignTx
 	}
 
-	db = db.Rollback()
+	err := db.Rollback().Error
+	database.RolledBack(trx)
 
-	return any(db).(*D)
+	return err
 }
 
-func (r *baseRepo[D, I, E]) Commit(trx *D) *D {
-	db, ok := any(trx).(*gorm.DB)
+func (r *baseRepo[I, E]) Commit(trx *database.Tx) error {
+	if trx == nil {
+		return nil
+	}
+
+	db, ok := database.TxHandle[*gorm.DB](trx)
 	if !ok {
-		return trx
+		return database.ErrForeignTx
 	}
 
-	db = db.Commit()
+	if err := db.Commit().Error; err != nil {
+		database.RolledBack(trx)
+		return err
+	}
+
+	database.Committed(trx)
 
-	return any(db).(*D)
+	return nil
 }
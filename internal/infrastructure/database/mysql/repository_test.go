@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	database.BaseEntity[int64]
+	database.TenantModel
+	Name string `json:"name" gorm:"column:name"`
+}
+
+func (account) TableName() string {
+	return "accounts"
+}
+
+func (account) RepositoryName() string {
+	return "AccountRepository"
+}
+
+func TestBaseRepo_Upsert_Tenant(t *testing.T) {
+	mode := config.Tenant.Mode
+	t.Cleanup(func() {
+		config.Tenant.Mode = mode
+	})
+
+	config.Tenant.Mode = string(database.TenantColumn)
+
+	// No statement may reach the database, which would overwrite the row of
+	// another tenant on a duplicate key.
+	db, _ := newTestReplica(t)
+	repo := NewBaseRepository[int64, account](&mysqlConnection{Master: db})
+	ctx := database.WithTenant(context.Background(), "acme")
+
+	_, err := repo.Upsert(ctx, account{Name: "Acme"}, database.UpsertOptions{}, nil)
+	assert.ErrorIs(t, err, ErrTenantUpsert)
+
+	_, err = repo.UpsertMany(ctx, []account{{Name: "Acme"}}, database.UpsertOptions{}, nil)
+	assert.ErrorIs(t, err, ErrTenantUpsert)
+}
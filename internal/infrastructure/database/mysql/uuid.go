@@ -0,0 +1,149 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/google/uuid"
+)
+
+// UUIDFormat is how UUIDs are stored in MySQL, which has no UUID type.
+type UUIDFormat string
+
+const (
+	// UUIDBinary stores UUIDs in BINARY(16) columns, as the migrations do.
+	UUIDBinary UUIDFormat = "binary"
+	// UUIDChar stores UUIDs as text in CHAR(36) columns.
+	UUIDChar UUIDFormat = "char"
+)
+
+// connect opens a connection pool on dsn. In binary format, UUID arguments
+// are sent as their 16 bytes; reading them back needs nothing since
+// uuid.UUID scans both its binary and text forms.
+func connect(dsn string) (*sql.DB, error) {
+	cfg, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	connector, err := mysqldriver.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if UUIDFormat(config.MySQL.UUIDFormat) == UUIDChar {
+		return sql.OpenDB(connector), nil
+	}
+
+	return sql.OpenDB(binaryUUIDConnector{connector}), nil
+}
+
+// binaryUUID converts UUID arguments to their 16 bytes and reports whether
+// it did.
+func binaryUUID(nv *driver.NamedValue) bool {
+	switch v := nv.Value.(type) {
+	case uuid.UUID:
+		nv.Value = v[:]
+	case *uuid.UUID:
+		if v == nil {
+			nv.Value = nil
+		} else {
+			nv.Value = v[:]
+		}
+	case uuid.NullUUID:
+		if !v.Valid {
+			nv.Value = nil
+		} else {
+			nv.Value = v.UUID[:]
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+type binaryUUIDConnector struct {
+	driver.Connector
+}
+
+func (c binaryUUIDConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return binaryUUIDConn{conn}, nil
+}
+
+// binaryUUIDConn checks the arguments of queries run on a connection of the
+// MySQL driver, which implements every optional interface forwarded here.
+type binaryUUIDConn struct {
+	driver.Conn
+}
+
+func (c binaryUUIDConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if binaryUUID(nv) {
+		return nil
+	}
+
+	return c.Conn.(driver.NamedValueChecker).CheckNamedValue(nv)
+}
+
+func (c binaryUUIDConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c binaryUUIDConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return binaryUUIDStmt{stmt}, nil
+}
+
+func (c binaryUUIDConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c binaryUUIDConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c binaryUUIDConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+func (c binaryUUIDConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (c binaryUUIDConn) IsValid() bool {
+	return c.Conn.(driver.Validator).IsValid()
+}
+
+// binaryUUIDStmt checks the arguments of prepared statements, which the
+// driver would otherwise check itself.
+type binaryUUIDStmt struct {
+	driver.Stmt
+}
+
+func (s binaryUUIDStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if binaryUUID(nv) {
+		return nil
+	}
+
+	return s.Stmt.(driver.NamedValueChecker).CheckNamedValue(nv)
+}
+
+func (s binaryUUIDStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+}
+
+func (s binaryUUIDStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+}
@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConn stands for a connection of the MySQL driver, recording the
+// arguments it checks and the queries it runs.
+type fakeConn struct {
+	driver.Conn
+	checked []any
+	queries []string
+}
+
+func (c *fakeConn) CheckNamedValue(nv *driver.NamedValue) error {
+	c.checked = append(c.checked, nv.Value)
+	return driver.ErrSkip
+}
+
+func (c *fakeConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if query == "" {
+		return nil, errors.New("empty query")
+	}
+
+	return &fakeStmt{conn: c}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.queries = append(c.queries, query)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (c *fakeConn) IsValid() bool {
+	return true
+}
+
+type fakeStmt struct {
+	driver.Stmt
+	conn *fakeConn
+}
+
+func (s *fakeStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	return s.conn.CheckNamedValue(nv)
+}
+
+func TestBinaryUUID(t *testing.T) {
+	id := uuid.MustParse("0192b1e4-5f3a-7c1d-9e2f-3a4b5c6d7e8f")
+
+	cases := []struct {
+		name      string
+		value     any
+		want      any
+		converted bool
+	}{
+		{name: "uuid", value: id, want: id[:], converted: true},
+		{name: "pointer", value: &id, want: id[:], converted: true},
+		{name: "nil pointer", value: (*uuid.UUID)(nil), want: nil, converted: true},
+		{name: "valid null uuid", value: uuid.NullUUID{UUID: id, Valid: true}, want: id[:], converted: true},
+		{name: "invalid null uuid", value: uuid.NullUUID{}, want: nil, converted: true},
+		{name: "string", value: id.String(), want: id.String(), converted: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			nv := &driver.NamedValue{Value: tc.value}
+
+			assert.Equal(t, tc.converted, binaryUUID(nv))
+			assert.Equal(t, tc.want, nv.Value)
+		})
+	}
+}
+
+func TestBinaryUUIDConn_CheckNamedValue(t *testing.T) {
+	id := uuid.New()
+	conn := &fakeConn{}
+	wrapped := binaryUUIDConn{conn}
+
+	nv := &driver.NamedValue{Value: id}
+	require.NoError(t, wrapped.CheckNamedValue(nv))
+	assert.Equal(t, id[:], nv.Value)
+
+	// Other arguments are left to the driver.
+	assert.ErrorIs(t, wrapped.CheckNamedValue(&driver.NamedValue{Value: "acme"}), driver.ErrSkip)
+	assert.Equal(t, []any{"acme"}, conn.checked)
+}
+
+func TestBinaryUUIDConn_PrepareContext(t *testing.T) {
+	id := uuid.New()
+	conn := &fakeConn{}
+	wrapped := binaryUUIDConn{conn}
+
+	stmt, err := wrapped.PrepareContext(context.Background(), "SELECT * FROM accounts WHERE id = ?")
+	require.NoError(t, err)
+	require.IsType(t, binaryUUIDStmt{}, stmt)
+
+	checker := stmt.(driver.NamedValueChecker)
+
+	nv := &driver.NamedValue{Value: &id}
+	require.NoError(t, checker.CheckNamedValue(nv))
+	assert.Equal(t, id[:], nv.Value)
+
+	assert.ErrorIs(t, checker.CheckNamedValue(&driver.NamedValue{Value: 7}), driver.ErrSkip)
+	assert.Equal(t, []any{7}, conn.checked)
+
+	_, err = wrapped.PrepareContext(context.Background(), "")
+	assert.EqualError(t, err, "empty query")
+}
+
+func TestBinaryUUIDConn_Forwards(t *testing.T) {
+	conn := &fakeConn{}
+	wrapped := binaryUUIDConn{conn}
+
+	_, err := wrapped.ExecContext(context.Background(), "DELETE FROM accounts", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"DELETE FROM accounts"}, conn.queries)
+	assert.NoError(t, wrapped.Ping(context.Background()))
+	assert.True(t, wrapped.IsValid())
+}
@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withAudit enables the audit trail for the duration of the test.
+func withAudit(t *testing.T) {
+	t.Helper()
+
+	previous := config.Audit.Enabled
+	t.Cleanup(func() {
+		config.Audit.Enabled = previous
+	})
+
+	config.Audit.Enabled = true
+}
+
+func TestBaseRepo_Upsert_Audit(t *testing.T) {
+	withAudit(t)
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE \("id"\) IN \(\(\$1\)\) FOR UPDATE`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+	mock.ExpectQuery(`INSERT INTO "accounts" .* ON CONFLICT \("id"\) DO UPDATE SET .* RETURNING \*`).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Globex"))
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE \("id"\) IN \(\(\$1\)\)`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Globex"))
+	mock.ExpectExec(`INSERT INTO "audit_logs"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "alice", "accounts", "7", database.AuditUpsert, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	payload := account{Name: "Globex"}
+	payload.ID = 7
+
+	repo := NewBaseRepository[int64, account](conn)
+	_, err := repo.Upsert(database.WithActor(context.Background(), "alice"), payload, database.UpsertOptions{}, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestBaseRepo_Upsert_AuditConflict(t *testing.T) {
+	withAudit(t)
+	conn, mock := newTestConnection(t)
+
+	// Conflict columns are quoted as identifiers.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE \("tenant_id","name"\) IN \(\(\$1,\$2\)\) FOR UPDATE`).
+		WithArgs("", "Acme").
+		WillReturnRows(sqlmock.NewRows(accountColumns))
+	mock.ExpectQuery(`INSERT INTO "accounts" .* ON CONFLICT \("tenant_id","name"\) DO UPDATE SET .* RETURNING \*`).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE \("tenant_id","name"\) IN \(\(\$1,\$2\)\)`).
+		WithArgs("", "Acme").
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+	mock.ExpectExec(`INSERT INTO "audit_logs"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewBaseRepository[int64, account](conn)
+	_, err := repo.Upsert(context.Background(), account{Name: "Acme"}, database.UpsertOptions{Conflict: []string{"tenant_id", "name"}}, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestBaseRepo_Upsert_UnknownConflictColumn(t *testing.T) {
+	withAudit(t)
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	repo := NewBaseRepository[int64, account](conn)
+	_, err := repo.Upsert(context.Background(), account{Name: "Acme"}, database.UpsertOptions{Conflict: []string{"name) OR (1=1"}}, nil)
+
+	assert.EqualError(t, err, `unknown conflict column "name) OR (1=1"`)
+}
+
+func TestBaseRepo_PurgeTrashed_Audit(t *testing.T) {
+	withAudit(t)
+	conn, mock := newTestConnection(t)
+
+	before := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE deleted_at IS NOT NULL AND deleted_at < \$1 FOR UPDATE`).
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+	mock.ExpectExec(`DELETE FROM "accounts" WHERE deleted_at IS NOT NULL AND deleted_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id IN \(\$1\)`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(accountColumns))
+	mock.ExpectExec(`INSERT INTO "audit_logs"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), database.SystemActor, "accounts", "7", database.AuditDelete, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewBaseRepository[int64, account](conn)
+	res, err := repo.PurgeTrashed(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res)
+}
+
+func TestBaseRepo_DeleteMany_Audit(t *testing.T) {
+	withAudit(t)
+	conn, mock := newTestConnection(t)
+
+	// Records trashed already are neither deleted again nor audited.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE "accounts"."name" = \$1 AND deleted_at IS NULL FOR UPDATE`).
+		WithArgs("Acme").
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+	mock.ExpectExec(`UPDATE "accounts" SET .* WHERE "accounts"."name" = \$\d+ AND deleted_at IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id IN \(\$1\)`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(append(accountColumns, "deleted_at")).AddRow(7, "", "Acme", time.Now()))
+	mock.ExpectExec(`INSERT INTO "audit_logs"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), database.SystemActor, "accounts", "7", database.AuditDelete, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewBaseRepository[int64, account](conn)
+	err := repo.DeleteMany(context.Background(), map[string]any{"name": "Acme"}, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestBaseRepo_Update_Audit(t *testing.T) {
+	withAudit(t)
+	withTenantMode(t, database.TenantColumn)
+	conn, mock := newTestConnection(t)
+
+	// The audit reads and the log insert keep the tenant scope but none of
+	// the selects and omits of the update, so the log has its created_at.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE tenant_id = \$1 AND id = \$2 FOR UPDATE$`).
+		WithArgs("acme", 7).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "acme", "Acme"))
+	mock.ExpectExec(`UPDATE "accounts" SET .* WHERE tenant_id = \$\d+ AND "id" = \$\d+`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE tenant_id = \$1 AND id IN \(\$2\)$`).
+		WithArgs("acme", 7).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "acme", "Globex"))
+	mock.ExpectExec(`INSERT INTO "audit_logs" \(.*"created_at".*\) VALUES`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), database.SystemActor, "accounts", "7", database.AuditUpdate, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	payload := account{Name: "Globex"}
+	payload.ID = 7
+
+	repo := NewBaseRepository[int64, account](conn)
+	err := repo.Update(database.WithTenant(context.Background(), "acme"), payload, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestBaseRepo_BulkWrite_Audit(t *testing.T) {
+	withAudit(t)
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE "accounts"."name" = \$1 FOR UPDATE`).
+		WithArgs("Acme").
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+	mock.ExpectExec(`UPDATE "accounts" SET .* WHERE "accounts"."name" = \$\d+`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id IN \(\$1\)`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Globex"))
+	mock.ExpectExec(`INSERT INTO "audit_logs"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), database.SystemActor, "accounts", "7", database.AuditUpdate, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewBaseRepository[int64, account](conn)
+	res, err := repo.BulkWrite(context.Background(), []database.WriteOperation[account]{
+		database.UpdateOperation[account](map[string]any{"name": "Acme"}, map[string]any{"name": "Globex"}),
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, database.BulkWriteResult{Updated: 1}, res)
+}
+
+func TestBaseRepo_History(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectQuery(`SELECT \* FROM audit_logs WHERE entity = \$1 AND entity_id = \$2 ORDER BY created_at, id`).
+		WithArgs("accounts", "7").
+		WillReturnRows(sqlmock.NewRows([]string{"actor", "entity", "entity_id", "operation", "changes"}).
+			AddRow("alice", "accounts", "7", "insert", `{"name":{"after":"Acme"}}`).
+			AddRow("bob", "accounts", "7", "update", `{"name":{"before":"Acme","after":"Globex"}}`))
+
+	repo := NewBaseRepository[int64, account](conn)
+	res, err := repo.History(context.Background(), 7)
+
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, database.AuditInsert, res[0].Operation)
+	assert.Equal(t, "alice", res[0].Actor)
+	assert.Equal(t, map[string]database.FieldChange{"name": {Before: "Acme", After: "Globex"}}, res[1].Changes)
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	migratepostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+)
+
+// NewMigrator returns a migrator of the embedded PostgreSQL migrations on a
+// connection of its own, since closing the migrator closes it. Runs hold a
+// session advisory lock, so concurrent replicas migrate one at a time.
+func NewMigrator(ctx context.Context) (*database.Migrator, error) {
+	sqlDB, err := sql.Open("postgres", setConfig().Master.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	migrateDriver, err := migratepostgres.WithInstance(sqlDB, &migratepostgres.Config{})
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return database.NewMigrator(ctx, "postgres", "postgres", migrateDriver)
+}
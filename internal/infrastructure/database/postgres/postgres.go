@@ -2,15 +2,16 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	migratepostgres "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 	"github.com/goodone-dev/go-boilerplate/internal/utils/retry"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/lib/pq"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -20,7 +21,7 @@ import (
 
 type postgresConfig struct {
 	Master postgres.Config
-	Slave  postgres.Config
+	Slaves map[string]postgres.Config
 }
 
 func setConfig() postgresConfig {
@@ -36,42 +37,65 @@ func setConfig() postgresConfig {
 		masterConfig.DSN = fmt.Sprintf(dsn, cfg.MasterHost, cfg.MasterUsername, cfg.MasterPassword, cfg.Database, cfg.MasterPort, cfg.MasterSSLMode, cfg.Timezone)
 	}
 
-	slaveConfig := postgres.Config{
-		DSN:                  fmt.Sprintf(dsn, cfg.Host, cfg.Username, cfg.Password, cfg.Database, cfg.Port, cfg.SSLMode, cfg.Timezone),
-		PreferSimpleProtocol: true,
-	}
-
-	if len(cfg.SlaveHost) > 0 {
-		slaveConfig.DSN = fmt.Sprintf(dsn, cfg.SlaveHost, cfg.SlaveUsername, cfg.SlavePassword, cfg.Database, cfg.SlavePort, cfg.SlaveSSLMode, cfg.Timezone)
+	slaveConfigs := map[string]postgres.Config{}
+	for _, addr := range database.ReplicaAddrs(cfg.SlaveHost, cfg.SlaveHosts, cfg.SlavePort) {
+		slaveConfigs[addr.String()] = postgres.Config{
+			DSN:                  fmt.Sprintf(dsn, addr.Host, cfg.SlaveUsername, cfg.SlavePassword, cfg.Database, addr.Port, cfg.SlaveSSLMode, cfg.Timezone),
+			PreferSimpleProtocol: true,
+		}
 	}
 
 	return postgresConfig{
 		Master: masterConfig,
-		Slave:  slaveConfig,
+		Slaves: slaveConfigs,
 	}
 }
 
+// Connection is the PostgreSQL connection returned by Open.
+type Connection = postgresConnection
+
 type postgresConnection struct {
 	Master *gorm.DB
-	Slave  *gorm.DB
+	Slaves *database.ReplicaPool[*gorm.DB]
 }
 
 func Open(ctx context.Context) *postgresConnection {
 	pgConfig := setConfig()
 
+	master := open(ctx, pgConfig.Master)
+	if config.Postgres.AutoMigrate {
+		migrateUp(ctx)
+	}
+
+	if database.CurrentTenantMode() == database.TenantRLS {
+		if err := checkRowSecurity(ctx, master); err != nil {
+			logger.Fatal(ctx, err, "❌ PostgreSQL role bypasses row-level security").Write()
+		}
+	}
+
+	replicas := make([]*database.Replica[*gorm.DB], 0, len(pgConfig.Slaves))
+	for name, slaveConfig := range pgConfig.Slaves {
+		replicas = append(replicas, database.NewReplica(name, open(ctx, slaveConfig)))
+	}
+
 	conn := &postgresConnection{
-		Master: open(ctx, pgConfig.Master),
-		Slave:  open(ctx, pgConfig.Slave),
+		Master: master,
+		Slaves: database.NewReplicaPool(master, replicas, database.Balancer(config.Database.ReplicaBalancer), inUse),
 	}
 
 	go conn.Monitor(ctx)
 
+	if err := database.SetTransactor(conn); err != nil {
+		logger.Fatal(ctx, err, "❌ PostgreSQL connection cannot serve WithTransaction").Write()
+	}
+
 	return conn
 }
 
 func open(ctx context.Context, pgConfig postgres.Config) *gorm.DB {
 	gormConfig := &gorm.Config{
-		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+		Logger:  gormlogger.Default.LogMode(gormlogger.Silent),
+		NowFunc: database.Now,
 	}
 
 	db, err := retry.RetryWithBackoff(ctx, "PostgreSQL connection", func() (*gorm.DB, error) {
@@ -85,6 +109,10 @@ func open(ctx context.Context, pgConfig postgres.Config) *gorm.DB {
 		logger.Fatal(ctx, err, "❌ PostgreSQL failed to initialize tracing plugin").Write()
 	}
 
+	if err := registerTenantCallbacks(db); err != nil {
+		logger.Fatal(ctx, err, "❌ PostgreSQL failed to register tenant callbacks").Write()
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		logger.Fatal(ctx, err, "❌ PostgreSQL failed to access connection pool").Write()
@@ -101,26 +129,55 @@ func open(ctx context.Context, pgConfig postgres.Config) *gorm.DB {
 		logger.Fatal(ctx, err, "❌ PostgreSQL connection test failed").Write()
 	}
 
-	if !config.Postgres.AutoMigrate {
-		return db
-	}
+	return db
+}
 
-	migrateDriver, err := migratepostgres.WithInstance(sqlDB, &migratepostgres.Config{})
+func migrateUp(ctx context.Context) {
+	m, err := NewMigrator(ctx)
 	if err != nil {
-		logger.Fatal(ctx, err, "❌ PostgreSQL failed to initialize migration driver").Write()
+		logger.Fatal(ctx, err, "❌ PostgreSQL failed to create migration instance").Write()
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil {
+		logger.Fatal(ctx, err, "❌ PostgreSQL failed migration").Write()
+	}
+}
+
+// checkRowSecurity fails when the role of db is exempt from the row-level
+// security policies isolating tenants, being a superuser, having BYPASSRLS
+// or owning the tables, so that TENANT_MODE=rls never runs unenforced. Such
+// deployments run migrations as the owner and connect the application as
+// another role.
+func checkRowSecurity(ctx context.Context, db *gorm.DB) error {
+	var role struct {
+		Name     string
+		Bypasses bool
 	}
 
-	m, err := migrate.NewWithDatabaseInstance("file://migrations/postgres", "postgres", migrateDriver)
+	err := db.WithContext(ctx).Raw(`SELECT r.rolname AS name, r.rolsuper OR r.rolbypassrls OR EXISTS (
+		SELECT 1 FROM pg_tables t WHERE t.tableowner = r.rolname AND t.schemaname = current_schema()
+	) AS bypasses FROM pg_roles r WHERE r.rolname = current_user`).Scan(&role).Error
 	if err != nil {
-		logger.Fatal(ctx, err, "❌ PostgreSQL failed to create migration instance").Write()
+		return err
 	}
 
-	err = m.Up()
-	if err != nil && err != migrate.ErrNoChange {
-		logger.Fatal(ctx, err, "❌ PostgreSQL failed migration").Write()
+	if role.Bypasses {
+		return fmt.Errorf("role %q is a superuser, has BYPASSRLS or owns the tables, connect as another role with TENANT_MODE=rls", role.Name)
 	}
 
-	return db
+	return nil
+}
+
+// inUse reports the number of connections currently in use, used by the
+// least-connections balancer.
+func inUse(db *gorm.DB) int {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return 0
+	}
+
+	return sqlDB.Stats().InUse
 }
 
 func (c *postgresConnection) Shutdown(ctx context.Context) error {
@@ -128,8 +185,10 @@ func (c *postgresConnection) Shutdown(ctx context.Context) error {
 		return err
 	}
 
-	if err := close(c.Slave); err != nil {
-		return err
+	for _, replica := range c.Slaves.Replicas() {
+		if err := close(replica.DB); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -144,22 +203,19 @@ func close(conn *gorm.DB) error {
 	return sqlDB.Close()
 }
 
+// Ping checks the primary only; replicas are ejected from the read pool
+// by the monitor instead of failing the whole connection.
 func (c *postgresConnection) Ping(ctx context.Context) error {
-	masterDB, err := c.Master.DB()
-	if err != nil {
-		return err
-	} else if err := masterDB.Ping(); err != nil {
-		return err
-	}
+	return ping(ctx, c.Master)
+}
 
-	slaveDB, err := c.Slave.DB()
+func ping(ctx context.Context, conn *gorm.DB) error {
+	sqlDB, err := conn.DB()
 	if err != nil {
 		return err
-	} else if err := slaveDB.Ping(); err != nil {
-		return err
 	}
 
-	return nil
+	return sqlDB.PingContext(ctx)
 }
 
 func (c *postgresConnection) Monitor(ctx context.Context) {
@@ -185,6 +241,155 @@ func (c *postgresConnection) Monitor(ctx context.Context) {
 					wasLost = false
 				}
 			}
+
+			for _, replica := range c.Slaves.Replicas() {
+				c.checkReplica(ctx, replica)
+			}
 		}
 	}
 }
+
+func (c *postgresConnection) ReplicaLag(ctx context.Context, replica *gorm.DB) (time.Duration, error) {
+	var lag float64
+	err := replica.WithContext(ctx).Raw(`
+		SELECT CASE
+			WHEN NOT pg_is_in_recovery() THEN 0
+			WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+		END`).Scan(&lag).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(lag * float64(time.Second)), nil
+}
+
+func (c *postgresConnection) checkReplica(ctx context.Context, replica *database.Replica[*gorm.DB]) {
+	err := ping(ctx, replica.DB)
+	if err != nil {
+		if replica.SetHealthy(false) {
+			logger.Errorf(ctx, err, "🛑 PostgreSQL replica %s ejected from read pool", replica.Name).Write()
+		}
+		return
+	} else if replica.SetHealthy(true) {
+		logger.Infof(ctx, "✅ PostgreSQL replica %s restored to read pool", replica.Name).Write()
+	}
+
+	lag, err := c.ReplicaLag(ctx, replica.DB)
+	stale := err != nil || lag > config.Database.MaxReplicaLag
+
+	if replica.SetStale(stale) {
+		if stale {
+			logger.Warnf(ctx, "🐢 PostgreSQL replica %s is %v behind, skipping it for reads", replica.Name, lag).Write()
+		} else {
+			logger.Infof(ctx, "✅ PostgreSQL replica %s caught up, serving reads again", replica.Name).Write()
+		}
+	}
+}
+
+// Transaction runs fn in a transaction carried by the context. Nested calls
+// run in a savepoint of the outer transaction, and the outermost call is
+// retried on serialization failures and deadlocks.
+func (c *postgresConnection) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	database.MarkWrite(ctx)
+
+	if tx, ok := database.TxFromContext[*gorm.DB](ctx); ok {
+		return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(database.ContextWithTx(ctx, tx))
+		})
+	}
+
+	_, err := retry.RetryWithPolicyIf(ctx, "PostgreSQL transaction", database.TransactionRetry(), retryable, func() (any, error) {
+		return nil, c.Master.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(database.ContextWithTx(ctx, tx))
+		})
+	})
+
+	return err
+}
+
+// pinsTenant reports whether statements made with ctx must run in a
+// transaction that pins the tenant on the session.
+func (c *postgresConnection) pinsTenant(ctx context.Context) bool {
+	mode := database.CurrentTenantMode()
+	if mode == database.TenantRLS && database.AllTenants(ctx) {
+		return true
+	}
+
+	if _, ok := database.TenantFromContext(ctx); !ok {
+		return false
+	}
+
+	return mode == database.TenantRLS || mode == database.TenantSchema
+}
+
+// registerTenantCallbacks pins the tenant on the session before every
+// statement run in a transaction, including the implicit one gorm opens for
+// writes.
+func registerTenantCallbacks(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().After("gorm:begin_transaction").Register("tenant:pin", pinTenant); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:begin_transaction").Register("tenant:pin", pinTenant); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:begin_transaction").Register("tenant:pin", pinTenant); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:pin", pinTenant); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:pin", pinTenant); err != nil {
+		return err
+	}
+
+	return cb.Raw().Before("gorm:raw").Register("tenant:pin", pinTenant)
+}
+
+// pinTenant runs SET LOCAL through set_config, exposing the tenant to
+// row-level security policies as app.tenant_id, or app.all_tenants for
+// contexts made with database.WithAllTenants, or pointing the search_path at
+// the tenant schema. Settings are local to the transaction, so pooled
+// connections never leak them.
+func pinTenant(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); !ok {
+		return
+	}
+
+	ctx := db.Statement.Context
+
+	var name, value string
+	if schema, ok := database.TenantSchemaName(ctx); ok {
+		name, value = "search_path", pgx.Identifier{schema}.Sanitize()
+	} else if database.CurrentTenantMode() != database.TenantRLS {
+		return
+	} else if tenant, ok := database.TenantFromContext(ctx); ok {
+		name, value = "app.tenant_id", tenant
+	} else if database.AllTenants(ctx) {
+		name, value = "app.all_tenants", "on"
+	} else {
+		return
+	}
+
+	_, err := db.Statement.ConnPool.ExecContext(ctx, "SELECT set_config($1, $2, true)", name, value)
+	if err != nil {
+		db.AddError(err)
+	}
+}
+
+// retryable reports whether the transaction failed on a serialization
+// failure (40001) or a deadlock (40P01) and can safely be run again.
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
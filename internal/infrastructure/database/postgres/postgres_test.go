@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+// newTestDB returns a database whose statements are checked against the
+// expectations of the returned mock, and its pings too with monitorPings.
+func newTestDB(t *testing.T, monitorPings bool) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(monitorPings))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		sqlDB.Close()
+	})
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:               gormlogger.Default.LogMode(gormlogger.Silent),
+		NowFunc:              database.Now,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, registerTenantCallbacks(db))
+
+	return db, mock
+}
+
+// newTestConnection returns a connection without replicas whose statements
+// are checked against the expectations of the returned mock.
+func newTestConnection(t *testing.T) (*postgresConnection, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock := newTestDB(t, false)
+
+	return &postgresConnection{
+		Master: db,
+		Slaves: database.NewReplicaPool(db, nil, database.RoundRobin, nil),
+	}, mock
+}
+
+// newTestReplicaConnection returns a connection reading from a single
+// replica, with the mocks of the master and of the replica.
+func newTestReplicaConnection(t *testing.T) (*postgresConnection, *database.Replica[*gorm.DB], sqlmock.Sqlmock, sqlmock.Sqlmock) {
+	t.Helper()
+
+	master, masterMock := newTestDB(t, false)
+	replicaDB, replicaMock := newTestDB(t, true)
+	replica := database.NewReplica("replica", replicaDB)
+
+	return &postgresConnection{
+		Master: master,
+		Slaves: database.NewReplicaPool(master, []*database.Replica[*gorm.DB]{replica}, database.RoundRobin, nil),
+	}, replica, masterMock, replicaMock
+}
+
+// withTenantMode switches the tenant mode for the duration of the test.
+func withTenantMode(t *testing.T, mode database.TenantMode) {
+	t.Helper()
+
+	previous, prefix := config.Tenant.Mode, config.Tenant.SchemaPrefix
+	t.Cleanup(func() {
+		config.Tenant.Mode, config.Tenant.SchemaPrefix = previous, prefix
+	})
+
+	config.Tenant.Mode = string(mode)
+	config.Tenant.SchemaPrefix = "tenant_"
+}
+
+func TestCheckRowSecurity(t *testing.T) {
+	cases := []struct {
+		name     string
+		bypasses bool
+		err      bool
+	}{
+		{name: "application role", bypasses: false},
+		{name: "owner or superuser", bypasses: true, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, mock := newTestConnection(t)
+			mock.ExpectQuery(`rolbypassrls`).
+				WillReturnRows(sqlmock.NewRows([]string{"name", "bypasses"}).AddRow("app", tc.bypasses))
+
+			err := checkRowSecurity(t.Context(), conn.Master)
+
+			if tc.err {
+				assert.ErrorContains(t, err, `role "app"`)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// withMaxReplicaLag sets how far behind replicas may lag for the duration of
+// the test.
+func withMaxReplicaLag(t *testing.T, lag time.Duration) {
+	t.Helper()
+
+	previous := config.Database.MaxReplicaLag
+	t.Cleanup(func() {
+		config.Database.MaxReplicaLag = previous
+	})
+
+	config.Database.MaxReplicaLag = lag
+}
+
+func TestPostgresConnection_ReplicaLag(t *testing.T) {
+	cases := []struct {
+		name  string
+		lag   float64
+		stale bool
+	}{
+		{name: "caught up", lag: 0},
+		{name: "within bounds", lag: 2.5},
+		{name: "too far behind", lag: 30, stale: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withMaxReplicaLag(t, 5*time.Second)
+			conn, replica, _, mock := newTestReplicaConnection(t)
+
+			mock.ExpectPing()
+			mock.ExpectQuery(`pg_last_xact_replay_timestamp`).
+				WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(tc.lag))
+
+			conn.checkReplica(t.Context(), replica)
+
+			assert.Equal(t, !tc.stale, replica.Available())
+		})
+	}
+}
+
+func TestPostgresConnection_ReplicaLag_Failed(t *testing.T) {
+	withMaxReplicaLag(t, 5*time.Second)
+	conn, replica, _, mock := newTestReplicaConnection(t)
+
+	// A replica whose lag cannot be told is skipped, then served again once
+	// it caught up.
+	mock.ExpectPing()
+	mock.ExpectQuery(`pg_last_xact_replay_timestamp`).WillReturnError(errors.New("recovery is paused"))
+	mock.ExpectPing()
+	mock.ExpectQuery(`pg_last_xact_replay_timestamp`).
+		WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(0))
+
+	conn.checkReplica(t.Context(), replica)
+	assert.False(t, replica.Available())
+
+	conn.checkReplica(t.Context(), replica)
+	assert.True(t, replica.Available())
+}
+
+func TestPostgresConnection_CheckReplica_Unreachable(t *testing.T) {
+	withMaxReplicaLag(t, 5*time.Second)
+	conn, replica, masterMock, replicaMock := newTestReplicaConnection(t)
+
+	// An unreachable replica is ejected and its reads go to the primary.
+	replicaMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	conn.checkReplica(t.Context(), replica)
+	assert.False(t, replica.Available())
+
+	masterMock.ExpectQuery(`SELECT \* FROM accounts`).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+	_, err := NewBaseRepository[int64, account](conn).FindById(t.Context(), 7)
+	require.NoError(t, err)
+
+	// It is restored once it answers again.
+	replicaMock.ExpectPing()
+	replicaMock.ExpectQuery(`pg_last_xact_replay_timestamp`).
+		WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(0))
+	conn.checkReplica(t.Context(), replica)
+	assert.True(t, replica.Available())
+}
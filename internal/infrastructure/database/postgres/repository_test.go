@@ -0,0 +1,363 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type accountTotal struct {
+	Name  string
+	Count int64
+}
+
+func TestBaseRepo_Aggregate(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectQuery(`SELECT name, COUNT\(\*\) AS count FROM accounts WHERE deleted_at IS NULL GROUP BY name ORDER BY count DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).AddRow("Acme", 2))
+
+	repo := NewBaseRepository[int64, account](conn)
+	res, err := database.AggregateInto[accountTotal](context.Background(), repo, database.AggregateQuery{
+		GroupBy:      []string{"name"},
+		Aggregations: []database.Aggregation{database.CountOf("count")},
+		Sort:         []string{"count DESC"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []accountTotal{{Name: "Acme", Count: 2}}, res)
+}
+
+func TestBaseRepo_Aggregate_InvalidSort(t *testing.T) {
+	conn, _ := newTestConnection(t)
+
+	repo := NewBaseRepository[int64, account](conn)
+	_, err := database.AggregateInto[accountTotal](context.Background(), repo, database.AggregateQuery{
+		GroupBy:      []string{"name"},
+		Aggregations: []database.Aggregation{database.CountOf("count")},
+		Sort:         []string{"(SELECT password FROM users LIMIT 1)"},
+	})
+
+	assert.EqualError(t, err, `invalid sort "(SELECT password FROM users LIMIT 1)"`)
+}
+
+func TestBaseRepo_Aggregate_SoftDeleted(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	// A caller's filter cannot bring trashed records back into the totals.
+	mock.ExpectQuery(`SELECT name, COUNT\(\*\) AS count FROM accounts WHERE deleted_at IS NULL AND name = \$1 GROUP BY name`).
+		WithArgs("Acme").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).AddRow("Acme", 2))
+
+	repo := NewBaseRepository[int64, account](conn)
+	_, err := database.AggregateInto[accountTotal](context.Background(), repo, database.AggregateQuery{
+		Filter:       map[string]any{"name": "Acme", "deleted_at": sq.NotEq{"deleted_at": nil}},
+		GroupBy:      []string{"name"},
+		Aggregations: []database.Aggregation{database.CountOf("count")},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestBaseRepo_Count(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM accounts WHERE deleted_at IS NULL$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM accounts WHERE deleted_at IS NULL AND name = \$1$`).
+		WithArgs("Acme").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo := NewBaseRepository[int64, account](conn)
+
+	res, err := repo.Count(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), res)
+
+	filter := map[string]any{"name": "Acme"}
+	res, err = repo.Count(context.Background(), filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res)
+	assert.Equal(t, map[string]any{"name": "Acme"}, filter)
+}
+
+func TestBaseRepo_Exists(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectQuery(`SELECT 1 FROM accounts WHERE deleted_at IS NULL AND name = \$1 LIMIT 1`).
+		WithArgs("Acme").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery(`SELECT name FROM accounts WHERE deleted_at IS NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Acme"))
+
+	repo := NewBaseRepository[int64, account](conn)
+
+	filter := map[string]any{"name": "Acme"}
+	res, err := repo.Exists(context.Background(), filter)
+	require.NoError(t, err)
+	assert.False(t, res)
+	assert.Equal(t, map[string]any{"name": "Acme"}, filter)
+
+	var names []string
+	require.NoError(t, repo.FindProjected(context.Background(), nil, []string{"name"}, &names))
+	assert.Equal(t, []string{"Acme"}, names)
+}
+
+type team struct {
+	database.BaseEntity[int64]
+	Name    string   `json:"name"`
+	Members []member `json:"members,omitempty" gorm:"-" relation:"has_many:team_id"`
+}
+
+func (team) TableName() string {
+	return "teams"
+}
+
+func (team) RepositoryName() string {
+	return "TeamRepository"
+}
+
+type member struct {
+	database.BaseEntity[int64]
+	TeamID int64  `json:"team_id"`
+	Name   string `json:"name"`
+}
+
+func (member) TableName() string {
+	return "members"
+}
+
+func (member) RepositoryName() string {
+	return "MemberRepository"
+}
+
+func TestBaseRepo_Preload(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectQuery(`SELECT \* FROM teams WHERE deleted_at IS NULL AND id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Core"))
+	mock.ExpectQuery(`SELECT \* FROM members WHERE deleted_at IS NULL AND team_id IN \(\$1\)`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).AddRow(10, 1, "Ada").AddRow(11, 1, "Linus"))
+
+	// Reads through the repository itself, or of other entities with the
+	// same context, load no relation.
+	mock.ExpectQuery(`SELECT \* FROM teams WHERE deleted_at IS NULL AND id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Core"))
+	mock.ExpectQuery(`SELECT \* FROM members WHERE deleted_at IS NULL AND id = \$1`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "team_id", "name"}).AddRow(10, 1, "Ada"))
+
+	ctx := context.Background()
+	teams := NewBaseRepository[int64, team](conn)
+	members := NewBaseRepository[int64, member](conn)
+
+	preloaded, err := teams.Preload("Members").FindById(ctx, 1)
+	assert.NoError(t, err)
+	plain, err := teams.FindById(ctx, 1)
+	assert.NoError(t, err)
+	ada, err := members.FindById(ctx, 10)
+	assert.NoError(t, err)
+
+	assert.Len(t, preloaded.Members, 2)
+	assert.Empty(t, plain.Members)
+	assert.Equal(t, "Ada", ada.Name)
+}
+
+func TestBaseRepo_Preload_UnknownRelation(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectQuery(`SELECT \* FROM teams`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Core"))
+
+	teams := NewBaseRepository[int64, team](conn)
+	_, err := teams.Preload("Owner").FindById(context.Background(), 1)
+
+	assert.EqualError(t, err, `unknown relation "Owner" on team`)
+}
+
+type ledgerLine struct {
+	Name string `json:"name"`
+}
+
+func (ledgerLine) TableName() string {
+	return "ledger_lines"
+}
+
+func (ledgerLine) RepositoryName() string {
+	return "LedgerLineRepository"
+}
+
+func TestBaseRepo_Stream(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectQuery(`SELECT \* FROM accounts WHERE deleted_at IS NULL ORDER BY id LIMIT 2`).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(1, "acme", "Acme").AddRow(2, "acme", "Globex"))
+	mock.ExpectQuery(`SELECT \* FROM accounts WHERE deleted_at IS NULL AND id > \$1 ORDER BY id LIMIT 2`).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(3, "acme", "Initech"))
+
+	repo := NewBaseRepository[int64, account](conn)
+
+	var names []string
+	for res, err := range repo.Stream(context.Background(), nil, 2) {
+		require.NoError(t, err)
+		names = append(names, res.Name)
+	}
+
+	assert.Equal(t, []string{"Acme", "Globex", "Initech"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBaseRepo_Stream_KeepsFilter(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectQuery(`SELECT \* FROM accounts WHERE deleted_at IS NULL AND name = \$1 ORDER BY id LIMIT 10`).
+		WithArgs("Acme").
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(1, "acme", "Acme"))
+
+	repo := NewBaseRepository[int64, account](conn)
+	filter := map[string]any{"name": "Acme"}
+
+	for _, err := range repo.Stream(context.Background(), filter, 10) {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, map[string]any{"name": "Acme"}, filter)
+}
+
+func TestBaseRepo_Stream_NoPrimaryKey(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	repo := NewBaseRepository[int64, ledgerLine](conn)
+
+	var errs []error
+	for _, err := range repo.Stream(context.Background(), nil, 10) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], database.ErrNoPrimaryKey)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBaseRepo_Reader(t *testing.T) {
+	written := database.WithConsistency(context.Background())
+	database.MarkWrite(written)
+
+	cases := []struct {
+		name    string
+		ctx     context.Context
+		primary bool
+	}{
+		{name: "replica", ctx: context.Background()},
+		{name: "scope without writes", ctx: database.WithConsistency(context.Background())},
+		{name: "primary", ctx: database.WithPrimary(context.Background()), primary: true},
+		{name: "after a write", ctx: written, primary: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withStickyPrimary(t, time.Minute)
+			conn, _, masterMock, replicaMock := newTestReplicaConnection(t)
+
+			mock := replicaMock
+			if tc.primary {
+				mock = masterMock
+			}
+			mock.ExpectQuery(`SELECT \* FROM accounts WHERE deleted_at IS NULL AND id = \$1`).
+				WithArgs(int64(7)).
+				WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+
+			repo := NewBaseRepository[int64, account](conn)
+			res, err := repo.FindById(tc.ctx, 7)
+
+			require.NoError(t, err)
+			assert.Equal(t, "Acme", res.Name)
+		})
+	}
+}
+
+func TestBaseRepo_ReadYourWrites(t *testing.T) {
+	withStickyPrimary(t, time.Minute)
+	conn, _, masterMock, _ := newTestReplicaConnection(t)
+	ctx := database.WithConsistency(context.Background())
+
+	masterMock.ExpectBegin()
+	masterMock.ExpectQuery(`INSERT INTO "accounts"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	masterMock.ExpectCommit()
+	// The replica may not have the record yet, so the read that follows
+	// the write goes to the primary.
+	masterMock.ExpectQuery(`SELECT \* FROM accounts WHERE deleted_at IS NULL AND id = \$1`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "", "Acme"))
+
+	repo := NewBaseRepository[int64, account](conn)
+	_, err := repo.Insert(ctx, account{Name: "Acme"}, nil)
+	require.NoError(t, err)
+
+	res, err := repo.FindById(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", res.Name)
+}
+
+// withStickyPrimary keeps reads on the primary this long after a write for
+// the duration of the test.
+func withStickyPrimary(t *testing.T, d time.Duration) {
+	t.Helper()
+
+	previous := config.Database.StickyPrimaryDuration
+	t.Cleanup(func() {
+		config.Database.StickyPrimaryDuration = previous
+	})
+
+	config.Database.StickyPrimaryDuration = d
+}
+
+func TestBaseRepo_Begin(t *testing.T) {
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "accounts"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	repo := NewBaseRepository[int64, account](conn)
+	ctx := context.Background()
+
+	trx, err := repo.Begin(ctx)
+	require.NoError(t, err)
+
+	committed := false
+	database.AfterCommit(ctx, trx, func() { committed = true })
+
+	res, err := repo.Insert(ctx, account{Name: "Acme"}, trx)
+	require.NoError(t, err)
+	require.NoError(t, repo.Commit(trx))
+
+	assert.Equal(t, int64(7), res.ID)
+	assert.True(t, committed)
+}
+
+func TestBaseRepo_ForeignTx(t *testing.T) {
+	conn, _ := newTestConnection(t)
+
+	// A transaction of another driver is refused rather than written around.
+	repo := NewBaseRepository[int64, account](conn)
+	trx := database.NewTx(struct{}{})
+
+	_, err := repo.Insert(context.Background(), account{Name: "Acme"}, trx)
+	assert.ErrorIs(t, err, database.ErrForeignTx)
+	assert.ErrorIs(t, repo.Commit(trx), database.ErrForeignTx)
+	assert.ErrorIs(t, repo.Rollback(trx), database.ErrForeignTx)
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	database.BaseEntity[int64]
+	database.TenantModel
+	Name string `json:"name"`
+}
+
+func (account) TableName() string {
+	return "accounts"
+}
+
+func (account) RepositoryName() string {
+	return "AccountRepository"
+}
+
+var accountColumns = []string{"id", "tenant_id", "name"}
+
+func TestBaseRepo_FindById_Tenant(t *testing.T) {
+	acme := database.WithTenant(context.Background(), "acme")
+
+	cases := []struct {
+		name   string
+		mode   database.TenantMode
+		ctx    context.Context
+		pin    []driver.Value
+		query  string
+		args   []driver.Value
+		scoped bool
+		err    error
+	}{
+		{
+			name:  "column",
+			mode:  database.TenantColumn,
+			ctx:   acme,
+			query: `SELECT \* FROM accounts WHERE deleted_at IS NULL AND id = \$1 AND tenant_id = \$2`,
+			args:  []driver.Value{int64(7), "acme"},
+		},
+		{
+			name:  "rls",
+			mode:  database.TenantRLS,
+			ctx:   acme,
+			pin:   []driver.Value{"app.tenant_id", "acme"},
+			query: `SELECT \* FROM accounts WHERE deleted_at IS NULL AND id = \$1 AND tenant_id = \$2`,
+			args:  []driver.Value{int64(7), "acme"},
+		},
+		{
+			name: "rls without tenant",
+			mode: database.TenantRLS,
+			ctx:  context.Background(),
+			err:  database.ErrNoTenant,
+		},
+		{
+			name:  "rls across tenants",
+			mode:  database.TenantRLS,
+			ctx:   database.WithAllTenants(context.Background()),
+			pin:   []driver.Value{"app.all_tenants", "on"},
+			query: `SELECT \* FROM accounts WHERE deleted_at IS NULL AND id = \$1$`,
+			args:  []driver.Value{int64(7)},
+		},
+		{
+			name:  "schema",
+			mode:  database.TenantSchema,
+			ctx:   acme,
+			pin:   []driver.Value{"search_path", `"tenant_acme"`},
+			query: `SELECT \* FROM accounts WHERE deleted_at IS NULL AND id = \$1$`,
+			args:  []driver.Value{int64(7)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withTenantMode(t, tc.mode)
+			conn, mock := newTestConnection(t)
+
+			if tc.pin != nil {
+				mock.ExpectBegin()
+				mock.ExpectExec(`SELECT set_config\(\$1, \$2, true\)`).
+					WithArgs(tc.pin...).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			if tc.query != "" {
+				mock.ExpectQuery(tc.query).
+					WithArgs(tc.args...).
+					WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(7, "acme", "Acme"))
+			}
+			if tc.pin != nil {
+				mock.ExpectCommit()
+			}
+
+			repo := NewBaseRepository[int64, account](conn)
+			res, err := repo.FindById(tc.ctx, 7)
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "Acme", res.Name)
+		})
+	}
+}
+
+func TestBaseRepo_Insert_StampsTenant(t *testing.T) {
+	withTenantMode(t, database.TenantColumn)
+	conn, mock := newTestConnection(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "accounts"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "acme", "Acme").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	repo := NewBaseRepository[int64, account](conn)
+	res, err := repo.Insert(database.WithTenant(context.Background(), "acme"), account{
+		TenantModel: database.TenantModel{TenantID: "globex"},
+		Name:        "Acme",
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", res.TenantID)
+}
+
+func TestBaseRepo_UpdateById_OtherTenant(t *testing.T) {
+	withTenantMode(t, database.TenantColumn)
+	conn, mock := newTestConnection(t)
+
+	// A record of another tenant is out of reach, so nothing is updated.
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "accounts" SET .* WHERE tenant_id = \$\d+ AND id=\$\d+`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE tenant_id = \$1 AND id=\$2`).
+		WithArgs("acme", 7).
+		WillReturnRows(sqlmock.NewRows(accountColumns))
+
+	repo := NewBaseRepository[int64, account](conn)
+	res, err := repo.UpdateById(database.WithTenant(context.Background(), "acme"), 7, map[string]any{"name": "Globex"}, nil)
+
+	assert.NoError(t, err)
+	assert.Zero(t, res.ID)
+}
+
+func TestBaseRepo_UpdateById_NoTenant(t *testing.T) {
+	withTenantMode(t, database.TenantColumn)
+	conn, _ := newTestConnection(t)
+
+	// Without a tenant the update would reach the records of every tenant,
+	// so nothing is sent to the database.
+	repo := NewBaseRepository[int64, account](conn)
+	_, err := repo.UpdateById(context.Background(), 7, map[string]any{"name": "Globex"}, nil)
+
+	assert.ErrorIs(t, err, database.ErrNoTenant)
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type AggregateFunc string
+
+const (
+	AggregateCount AggregateFunc = "COUNT"
+	AggregateSum   AggregateFunc = "SUM"
+	AggregateAvg   AggregateFunc = "AVG"
+	AggregateMin   AggregateFunc = "MIN"
+	AggregateMax   AggregateFunc = "MAX"
+)
+
+// Aggregation computes Func over Field and stores it under As. Field is
+// ignored by AggregateCount, which counts records.
+type Aggregation struct {
+	Func  AggregateFunc
+	Field string
+	As    string
+}
+
+func CountOf(as string) Aggregation {
+	return Aggregation{Func: AggregateCount, As: as}
+}
+
+func SumOf(field, as string) Aggregation {
+	return Aggregation{Func: AggregateSum, Field: field, As: as}
+}
+
+func AvgOf(field, as string) Aggregation {
+	return Aggregation{Func: AggregateAvg, Field: field, As: as}
+}
+
+func MinOf(field, as string) Aggregation {
+	return Aggregation{Func: AggregateMin, Field: field, As: as}
+}
+
+func MaxOf(field, as string) Aggregation {
+	return Aggregation{Func: AggregateMax, Field: field, As: as}
+}
+
+// AggregateQuery groups the records matching Filter by the GroupBy fields
+// and computes the Aggregations for each group. Each result row holds the
+// group fields and the aggregations under their aliases, ordered by Sort
+// (e.g. "revenue DESC").
+type AggregateQuery struct {
+	Filter       map[string]any
+	GroupBy      []string
+	Aggregations []Aggregation
+	Sort         []string
+}
+
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// ValidateIdentifiers rejects field names that are not plain identifiers,
+// since they are written into the query as is.
+func ValidateIdentifiers(fields ...string) error {
+	for _, field := range fields {
+		if !identifierRegex.MatchString(field) {
+			return fmt.Errorf("invalid field name %q", field)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the aggregate functions and every field name of the
+// query, and that it is sorted by group fields or aggregation aliases only,
+// each optionally followed by ASC or DESC.
+func (q AggregateQuery) Validate() error {
+	if len(q.Aggregations) == 0 {
+		return fmt.Errorf("at least one aggregation is required")
+	}
+
+	if err := ValidateIdentifiers(q.GroupBy...); err != nil {
+		return err
+	}
+
+	for _, agg := range q.Aggregations {
+		switch agg.Func {
+		case AggregateCount:
+		case AggregateSum, AggregateAvg, AggregateMin, AggregateMax:
+			if err := ValidateIdentifiers(agg.Field); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown aggregate function %q", agg.Func)
+		}
+
+		if err := ValidateIdentifiers(agg.As); err != nil {
+			return err
+		}
+	}
+
+	for _, sort := range q.Sort {
+		if !q.sortable(sort) {
+			return fmt.Errorf("invalid sort %q", sort)
+		}
+	}
+
+	return nil
+}
+
+// sortable reports whether sort orders the rows by one of their columns.
+func (q AggregateQuery) sortable(sort string) bool {
+	parts := strings.Fields(sort)
+	if len(parts) == 0 || len(parts) > 2 {
+		return false
+	}
+
+	if len(parts) == 2 && !strings.EqualFold(parts[1], "ASC") && !strings.EqualFold(parts[1], "DESC") {
+		return false
+	}
+
+	if slices.Contains(q.GroupBy, parts[0]) {
+		return true
+	}
+
+	return slices.ContainsFunc(q.Aggregations, func(agg Aggregation) bool {
+		return agg.As == parts[0]
+	})
+}
+
+// Project selects the given fields of the records matching filter into P.
+func Project[P any, I any, E Entity](ctx context.Context, repo BaseRepository[I, E], filter map[string]any, fields ...string) (res []P, err error) {
+	err = repo.FindProjected(ctx, filter, fields, &res)
+	return
+}
+
+// AggregateInto runs the aggregate query and scans each group into R.
+func AggregateInto[R any, I any, E Entity](ctx context.Context, repo BaseRepository[I, E], query AggregateQuery) (res []R, err error) {
+	err = repo.Aggregate(ctx, query, &res)
+	return
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateQuery_Validate(t *testing.T) {
+	valid := AggregateQuery{
+		GroupBy:      []string{"customer_id"},
+		Aggregations: []Aggregation{CountOf("order_count"), SumOf("total_amount", "revenue")},
+	}
+
+	cases := []struct {
+		name   string
+		modify func(q *AggregateQuery)
+		err    string
+	}{
+		{name: "valid", modify: func(q *AggregateQuery) {}},
+		{name: "sort by group field", modify: func(q *AggregateQuery) { q.Sort = []string{"customer_id"} }},
+		{name: "sort by alias", modify: func(q *AggregateQuery) { q.Sort = []string{"revenue DESC", "order_count asc"} }},
+		{name: "no aggregation", modify: func(q *AggregateQuery) { q.Aggregations = nil }, err: "at least one aggregation is required"},
+		{name: "invalid group field", modify: func(q *AggregateQuery) { q.GroupBy = []string{"customer_id; --"} }, err: `invalid field name "customer_id; --"`},
+		{name: "invalid field", modify: func(q *AggregateQuery) { q.Aggregations = []Aggregation{SumOf("1)", "revenue")} }, err: `invalid field name "1)"`},
+		{name: "unknown function", modify: func(q *AggregateQuery) {
+			q.Aggregations = []Aggregation{{Func: "STDDEV", Field: "total_amount", As: "x"}}
+		}, err: `unknown aggregate function "STDDEV"`},
+		{name: "sort by other column", modify: func(q *AggregateQuery) { q.Sort = []string{"total_amount"} }, err: `invalid sort "total_amount"`},
+		{name: "sort injection", modify: func(q *AggregateQuery) { q.Sort = []string{"revenue; DROP TABLE orders"} }, err: `invalid sort "revenue; DROP TABLE orders"`},
+		{name: "sort expression", modify: func(q *AggregateQuery) { q.Sort = []string{"(SELECT 1)"} }, err: `invalid sort "(SELECT 1)"`},
+		{name: "unknown direction", modify: func(q *AggregateQuery) { q.Sort = []string{"revenue NULLS"} }, err: `invalid sort "revenue NULLS"`},
+		{name: "empty sort", modify: func(q *AggregateQuery) { q.Sort = []string{" "} }, err: `invalid sort " "`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := valid
+			tc.modify(&q)
+
+			err := q.Validate()
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
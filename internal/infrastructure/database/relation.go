@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Relations are declared on entity fields with a `relation` tag naming the
+// kind and the foreign key column:
+//
+//	Items   []OrderItem      `relation:"has_many:order_id"`    // order_items.order_id = orders.id
+//	Product *product.Product `relation:"belongs_to:product_id"` // order_items.product_id = products.id
+//
+// Relation fields must also be excluded from persistence with `gorm:"-"`
+// and `bson:"-"`.
+type RelationKind string
+
+const (
+	HasMany   RelationKind = "has_many"
+	BelongsTo RelationKind = "belongs_to"
+)
+
+// RelationFetcher loads the related records whose column is one of keys
+// into dest, a pointer to a slice of the related entity.
+type RelationFetcher func(ctx context.Context, related Entity, column string, keys []any, dest any) error
+
+type relationTree map[string]relationTree
+
+// LoadRelations loads relations, e.g. "Items.Product" for the order items
+// and the product of each item, into models, which is a pointer to an
+// entity or to a slice of entities. Each relation costs one extra query no
+// matter how many records were read.
+func LoadRelations(ctx context.Context, relations []string, models any, fetch RelationFetcher) error {
+	if len(relations) == 0 {
+		return nil
+	}
+
+	tree := relationTree{}
+	for _, relation := range relations {
+		node := tree
+		for _, name := range strings.Split(relation, ".") {
+			if _, ok := node[name]; !ok {
+				node[name] = relationTree{}
+			}
+			node = node[name]
+		}
+	}
+
+	return loadRelations(ctx, structValues(reflect.ValueOf(models)), tree, fetch)
+}
+
+func loadRelations(ctx context.Context, parents []reflect.Value, tree relationTree, fetch RelationFetcher) error {
+	if len(parents) == 0 {
+		return nil
+	}
+
+	parentType := parents[0].Type()
+
+	for name, children := range tree {
+		field, ok := parentType.FieldByName(name)
+		if !ok {
+			return fmt.Errorf("unknown relation %q on %s", name, parentType.Name())
+		}
+
+		kind, foreignKey, ok := strings.Cut(field.Tag.Get("relation"), ":")
+		if !ok {
+			return fmt.Errorf("field %q on %s is not a relation", name, parentType.Name())
+		}
+
+		relatedType := field.Type
+		if relatedType.Kind() == reflect.Slice || relatedType.Kind() == reflect.Pointer {
+			relatedType = relatedType.Elem()
+		}
+
+		related, ok := reflect.New(relatedType).Interface().(Entity)
+		if !ok {
+			return fmt.Errorf("relation %q on %s is not an entity", name, parentType.Name())
+		}
+
+		var keys []any
+		var column string
+
+		switch RelationKind(kind) {
+		case HasMany:
+			keys, column = distinct(parents, "id"), foreignKey
+		case BelongsTo:
+			keys, column = distinct(parents, foreignKey), "id"
+		default:
+			return fmt.Errorf("unknown relation kind %q on %s.%s", kind, parentType.Name(), name)
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		dest := reflect.New(reflect.SliceOf(relatedType))
+		if err := fetch(ctx, related, column, keys, dest.Interface()); err != nil {
+			return err
+		}
+
+		records := structValues(dest)
+		if err := loadRelations(ctx, records, children, fetch); err != nil {
+			return err
+		}
+
+		switch RelationKind(kind) {
+		case HasMany:
+			groups := map[any]reflect.Value{}
+			for _, record := range records {
+				key := columnValue(record, foreignKey)
+				if _, ok := groups[key]; !ok {
+					groups[key] = reflect.MakeSlice(field.Type, 0, 1)
+				}
+				groups[key] = reflect.Append(groups[key], record)
+			}
+
+			for _, parent := range parents {
+				if group, ok := groups[columnValue(parent, "id")]; ok {
+					parent.FieldByIndex(field.Index).Set(group)
+				}
+			}
+		case BelongsTo:
+			byID := map[any]reflect.Value{}
+			for _, record := range records {
+				byID[columnValue(record, "id")] = record
+			}
+
+			for _, parent := range parents {
+				record, ok := byID[columnValue(parent, foreignKey)]
+				if !ok {
+					continue
+				}
+
+				if field.Type.Kind() == reflect.Pointer {
+					parent.FieldByIndex(field.Index).Set(record.Addr())
+				} else {
+					parent.FieldByIndex(field.Index).Set(record)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// structValues returns the addressable structs held by v, which may be a
+// pointer to a struct or to a slice of structs.
+func structValues(v reflect.Value) []reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return []reflect.Value{v}
+	case reflect.Slice:
+		values := make([]reflect.Value, 0, v.Len())
+		for i := range v.Len() {
+			values = append(values, v.Index(i))
+		}
+		return values
+	}
+
+	return nil
+}
+
+// distinct returns the distinct non-zero values of column across records.
+func distinct(records []reflect.Value, column string) []any {
+	seen := map[any]struct{}{}
+	keys := make([]any, 0, len(records))
+
+	for _, record := range records {
+		key := columnValue(record, column)
+		if key == nil || reflect.ValueOf(key).IsZero() {
+			continue
+		}
+
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// columnValue returns the value of the field whose JSON name is column.
+func columnValue(record reflect.Value, column string) any {
+	for _, field := range reflect.VisibleFields(record.Type()) {
+		if field.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == column {
+			return record.FieldByIndex(field.Index).Interface()
+		}
+	}
+
+	return nil
+}
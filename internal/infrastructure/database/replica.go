@@ -0,0 +1,143 @@
+package database
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+type Balancer string
+
+const (
+	RoundRobin       Balancer = "round_robin"
+	LeastConnections Balancer = "least_connections"
+)
+
+// ReplicaAddr is the network address of a single read replica.
+type ReplicaAddr struct {
+	Host string
+	Port int
+}
+
+func (a ReplicaAddr) String() string {
+	return net.JoinHostPort(a.Host, strconv.Itoa(a.Port))
+}
+
+// ReplicaAddrs merges the single replica host with the list of replica hosts.
+// Each entry is either "host" or "host:port"; the default port is used when
+// none is given.
+func ReplicaAddrs(host string, hosts []string, port int) []ReplicaAddr {
+	if len(host) > 0 {
+		hosts = append([]string{host}, hosts...)
+	}
+
+	addrs := make([]ReplicaAddr, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.TrimSpace(h)
+		if len(h) == 0 {
+			continue
+		}
+
+		addr := ReplicaAddr{Host: h, Port: port}
+		if name, p, err := net.SplitHostPort(h); err == nil {
+			if n, err := strconv.Atoi(p); err == nil {
+				addr = ReplicaAddr{Host: name, Port: n}
+			}
+		}
+
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
+
+type Replica[T any] struct {
+	Name    string
+	DB      T
+	healthy atomic.Bool
+	stale   atomic.Bool
+}
+
+func NewReplica[T any](name string, db T) *Replica[T] {
+	replica := &Replica[T]{
+		Name: name,
+		DB:   db,
+	}
+	replica.healthy.Store(true)
+
+	return replica
+}
+
+// SetHealthy marks the replica as reachable or not and reports whether the state changed.
+func (r *Replica[T]) SetHealthy(healthy bool) bool {
+	return r.healthy.Swap(healthy) != healthy
+}
+
+// SetStale marks the replica as lagging too far behind or not and reports whether the state changed.
+func (r *Replica[T]) SetStale(stale bool) bool {
+	return r.stale.Swap(stale) != stale
+}
+
+func (r *Replica[T]) Available() bool {
+	return r.healthy.Load() && !r.stale.Load()
+}
+
+// ReplicaPool balances reads across replicas, skipping the ones that are
+// unhealthy or stale, and falls back to the primary when none is available.
+type ReplicaPool[T any] struct {
+	primary  T
+	replicas []*Replica[T]
+	balancer Balancer
+	load     func(T) int
+	next     atomic.Uint64
+}
+
+// NewReplicaPool creates a pool over the given replicas. load reports the
+// number of connections in use and is required by LeastConnections.
+func NewReplicaPool[T any](primary T, replicas []*Replica[T], balancer Balancer, load func(T) int) *ReplicaPool[T] {
+	if balancer == LeastConnections && load == nil {
+		balancer = RoundRobin
+	}
+
+	return &ReplicaPool[T]{
+		primary:  primary,
+		replicas: replicas,
+		balancer: balancer,
+		load:     load,
+	}
+}
+
+func (p *ReplicaPool[T]) Replicas() []*Replica[T] {
+	return p.replicas
+}
+
+// Pick returns the replica to read from, or the primary when no replica is available.
+func (p *ReplicaPool[T]) Pick() T {
+	available := make([]*Replica[T], 0, len(p.replicas))
+	for _, replica := range p.replicas {
+		if replica.Available() {
+			available = append(available, replica)
+		}
+	}
+
+	if len(available) == 0 {
+		return p.primary
+	}
+
+	if p.balancer == LeastConnections {
+		least := available[0]
+		leastLoad := p.load(least.DB)
+
+		for _, replica := range available[1:] {
+			if load := p.load(replica.DB); load < leastLoad {
+				least, leastLoad = replica, load
+			}
+		}
+
+		return least.DB
+	}
+
+	idx := p.next.Add(1) - 1
+	return available[idx%uint64(len(available))].DB
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicaAddrs(t *testing.T) {
+	cases := []struct {
+		name  string
+		host  string
+		hosts []string
+		want  []string
+	}{
+		{name: "none"},
+		{name: "single host", host: "replica-1", want: []string{"replica-1:5432"}},
+		{name: "host list", hosts: []string{"replica-1", "replica-2:5433"}, want: []string{"replica-1:5432", "replica-2:5433"}},
+		{name: "merged", host: "replica-1", hosts: []string{" replica-2 ", ""}, want: []string{"replica-1:5432", "replica-2:5432"}},
+		{name: "ipv6", hosts: []string{"[::1]:5433", "::1"}, want: []string{"[::1]:5433", "[::1]:5432"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var res []string
+			for _, addr := range ReplicaAddrs(tc.host, tc.hosts, 5432) {
+				res = append(res, addr.String())
+			}
+
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestReplica_State(t *testing.T) {
+	replica := NewReplica("replica-1", "db")
+	assert.True(t, replica.Available())
+
+	assert.True(t, replica.SetHealthy(false))
+	assert.False(t, replica.SetHealthy(false))
+	assert.False(t, replica.Available())
+
+	assert.True(t, replica.SetHealthy(true))
+	assert.True(t, replica.SetStale(true))
+	assert.False(t, replica.SetStale(true))
+	assert.False(t, replica.Available())
+
+	assert.True(t, replica.SetStale(false))
+	assert.True(t, replica.Available())
+}
+
+// newReplicas returns healthy replicas whose database is their name.
+func newReplicas(names ...string) []*Replica[string] {
+	replicas := make([]*Replica[string], 0, len(names))
+	for _, name := range names {
+		replicas = append(replicas, NewReplica(name, name))
+	}
+
+	return replicas
+}
+
+// picks returns the databases picked by n successive reads.
+func picks(pool *ReplicaPool[string], n int) []string {
+	res := make([]string, 0, n)
+	for range n {
+		res = append(res, pool.Pick())
+	}
+
+	return res
+}
+
+func TestReplicaPool_Pick_RoundRobin(t *testing.T) {
+	pool := NewReplicaPool("primary", newReplicas("a", "b", "c"), RoundRobin, nil)
+
+	assert.Equal(t, []string{"a", "b", "c", "a", "b", "c"}, picks(pool, 6))
+}
+
+func TestReplicaPool_Pick_SkipsUnavailable(t *testing.T) {
+	replicas := newReplicas("a", "b", "c")
+	pool := NewReplicaPool("primary", replicas, RoundRobin, nil)
+
+	replicas[0].SetHealthy(false)
+	replicas[2].SetStale(true)
+	assert.Equal(t, []string{"b", "b", "b"}, picks(pool, 3))
+
+	// Back in the pool once they recover.
+	replicas[0].SetHealthy(true)
+	replicas[2].SetStale(false)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, picks(pool, 3))
+}
+
+func TestReplicaPool_Pick_FallsBackToPrimary(t *testing.T) {
+	cases := []struct {
+		name     string
+		replicas []*Replica[string]
+	}{
+		{name: "no replicas"},
+		{name: "none available", replicas: newReplicas("a", "b")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, replica := range tc.replicas {
+				replica.SetHealthy(false)
+			}
+			pool := NewReplicaPool("primary", tc.replicas, LeastConnections, func(string) int { return 0 })
+
+			assert.Equal(t, "primary", pool.Pick())
+		})
+	}
+}
+
+func TestReplicaPool_Pick_LeastConnections(t *testing.T) {
+	replicas := newReplicas("a", "b", "c")
+	load := map[string]int{"a": 4, "b": 1, "c": 2}
+	pool := NewReplicaPool("primary", replicas, LeastConnections, func(db string) int { return load[db] })
+
+	assert.Equal(t, []string{"b", "b"}, picks(pool, 2))
+
+	load["b"] = 5
+	assert.Equal(t, "c", pool.Pick())
+
+	replicas[2].SetStale(true)
+	assert.Equal(t, "a", pool.Pick())
+}
+
+func TestReplicaPool_Pick_LeastConnectionsWithoutLoad(t *testing.T) {
+	// Without a way to tell the load, reads are spread in turn.
+	pool := NewReplicaPool("primary", newReplicas("a", "b"), LeastConnections, nil)
+
+	assert.Equal(t, []string{"a", "b", "a"}, picks(pool, 3))
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"iter"
+	"time"
 )
 
 type Pagination[E Entity] struct {
@@ -24,30 +26,49 @@ type PaginationNavigation struct {
 	Last  *string `json:"last,omitempty"`
 }
 
-type BaseRepository[D any, I any, E Entity] interface {
-	MasterDB() *D
-	SlaveDB() *D
-
+type BaseRepository[I any, E Entity] interface {
 	FindAll(ctx context.Context, filter map[string]any) ([]E, error)
 	FindById(ctx context.Context, ID I) (*E, error)
-	FindByIdAndLock(ctx context.Context, ID I, trx *D) (*E, error)
+	FindByIdAndLock(ctx context.Context, ID I, trx *Tx) (*E, error)
 	FindByIds(ctx context.Context, IDs []I) ([]E, error)
 	FindByOffset(ctx context.Context, filter map[string]any, sort []string, size int, page int) (res Pagination[E], err error)
 	FindByCursor(ctx context.Context, filter map[string]any, sort []string, size int, next *I) (res Pagination[E], err error)
+	FindWithTrashed(ctx context.Context, filter map[string]any) ([]E, error)
+	FindOnlyTrashed(ctx context.Context, filter map[string]any) ([]E, error)
+	Stream(ctx context.Context, filter map[string]any, batchSize int) iter.Seq2[E, error]
+	FindProjected(ctx context.Context, filter map[string]any, fields []string, dest any) error
+
+	// Preload returns the repository with its reads also loading the given
+	// relations, e.g. Preload("Items.Product").FindById(ctx, ID).
+	Preload(relations ...string) BaseRepository[I, E]
+
+	Count(ctx context.Context, filter map[string]any) (int64, error)
+	Exists(ctx context.Context, filter map[string]any) (bool, error)
+	Aggregate(ctx context.Context, query AggregateQuery, dest any) error
+
+	Insert(ctx context.Context, model E, trx *Tx) (E, error)
+	InsertMany(ctx context.Context, models []E, trx *Tx) ([]E, error)
+
+	Upsert(ctx context.Context, model E, opts UpsertOptions, trx *Tx) (E, error)
+	UpsertMany(ctx context.Context, models []E, opts UpsertOptions, trx *Tx) ([]E, error)
+	BulkWrite(ctx context.Context, ops []WriteOperation[E], trx *Tx) (BulkWriteResult, error)
+
+	Update(ctx context.Context, model E, trx *Tx) error
+	UpdateById(ctx context.Context, ID I, payload map[string]any, trx *Tx) (E, error)
+	UpdateByIds(ctx context.Context, IDs []I, payload map[string]any, trx *Tx) error
+	UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *Tx) error
 
-	Insert(ctx context.Context, model E, trx *D) (E, error)
-	InsertMany(ctx context.Context, models []E, trx *D) ([]E, error)
+	DeleteById(ctx context.Context, ID I, trx *Tx) error
+	DeleteByIds(ctx context.Context, IDs []I, trx *Tx) error
+	DeleteMany(ctx context.Context, filter map[string]any, trx *Tx) error
 
-	Update(ctx context.Context, model E, trx *D) error
-	UpdateById(ctx context.Context, ID I, payload map[string]any, trx *D) (E, error)
-	UpdateByIds(ctx context.Context, IDs []I, payload map[string]any, trx *D) error
-	UpdateMany(ctx context.Context, filter map[string]any, payload map[string]any, trx *D) error
+	Restore(ctx context.Context, ID I, trx *Tx) error
+	ForceDelete(ctx context.Context, ID I, trx *Tx) error
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
 
-	DeleteById(ctx context.Context, ID I, trx *D) error
-	DeleteByIds(ctx context.Context, IDs []I, trx *D) error
-	DeleteMany(ctx context.Context, filter map[string]any, trx *D) error
+	History(ctx context.Context, ID I) ([]AuditLog, error)
 
-	Begin(ctx context.Context) (*D, error)
-	Rollback(trx *D) *D
-	Commit(trx *D) *D
+	Begin(ctx context.Context) (*Tx, error)
+	Rollback(trx *Tx) error
+	Commit(trx *Tx) error
 }
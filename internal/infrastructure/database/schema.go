@@ -0,0 +1,97 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Index is an index declared by an entity, for databases whose indexes are
+// not all held by migration files. SQL databases declare theirs in
+// migrations instead.
+type Index struct {
+	// Name defaults to idx_<collection>_<fields>.
+	Name string
+	// Keys are the indexed fields in order, prefixed with "-" when
+	// descending, e.g. "tenant_id", "-created_at".
+	Keys   []string
+	Unique bool
+	// TTL expires records this long after the date in the single key.
+	TTL time.Duration
+	// Partial restricts the index to the records matching the filter, e.g.
+	// live records only for a unique index under soft deletes.
+	Partial map[string]any
+}
+
+// Fields returns the indexed fields without their direction.
+func (i Index) Fields() []string {
+	fields := make([]string, len(i.Keys))
+	for n, key := range i.Keys {
+		fields[n] = strings.TrimPrefix(key, "-")
+	}
+
+	return fields
+}
+
+// IndexName returns the name of the index in the collection.
+func (i Index) IndexName(collection string) string {
+	if i.Name != "" {
+		return i.Name
+	}
+
+	return "idx_" + collection + "_" + strings.Join(i.Fields(), "_")
+}
+
+// Indexer is implemented by entities declaring the indexes of their
+// collection.
+type Indexer interface {
+	Indexes() []Index
+}
+
+// SchemaValidator is implemented by entities declaring the JSON schema their
+// records are validated against on writes.
+type SchemaValidator interface {
+	JSONSchema() map[string]any
+}
+
+// SchemaDrift is a difference between the schema declared by an entity and
+// the one found in the database.
+type SchemaDrift struct {
+	Collection string
+	// Kind is "index" or "validator".
+	Kind string
+	Name string
+	// Problem is "missing", "changed" or "undeclared".
+	Problem string
+}
+
+func (d SchemaDrift) String() string {
+	if d.Name == "" {
+		return fmt.Sprintf("%s %s of %s", d.Problem, d.Kind, d.Collection)
+	}
+
+	return fmt.Sprintf("%s %s %s on %s", d.Problem, d.Kind, d.Name, d.Collection)
+}
+
+var (
+	schemasMu sync.RWMutex
+	schemas   []Entity
+)
+
+// RegisterSchema registers the entities whose declared indexes and
+// validators are applied with the migrations.
+func RegisterSchema(entities ...Entity) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+
+	schemas = append(schemas, entities...)
+}
+
+// Schemas returns the registered entities.
+func Schemas() []Entity {
+	schemasMu.RLock()
+	defer schemasMu.RUnlock()
+
+	return append([]Entity(nil), schemas...)
+}
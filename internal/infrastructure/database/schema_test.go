@@ -0,0 +1,41 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_IndexName(t *testing.T) {
+	cases := []struct {
+		name   string
+		index  Index
+		fields []string
+		want   string
+	}{
+		{
+			name:   "default",
+			index:  Index{Keys: []string{"tenant_id", "-created_at"}},
+			fields: []string{"tenant_id", "created_at"},
+			want:   "idx_orders_tenant_id_created_at",
+		},
+		{
+			name:   "named",
+			index:  Index{Keys: []string{"email"}, Name: "uniq_email"},
+			fields: []string{"email"},
+			want:   "uniq_email",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.fields, tc.index.Fields())
+			assert.Equal(t, tc.want, tc.index.IndexName("orders"))
+		})
+	}
+}
+
+func TestSchemaDrift_String(t *testing.T) {
+	assert.Equal(t, "missing validator of orders", SchemaDrift{Collection: "orders", Kind: "validator", Problem: "missing"}.String())
+	assert.Equal(t, "changed index idx_orders_status on orders", SchemaDrift{Collection: "orders", Kind: "index", Name: "idx_orders_status", Problem: "changed"}.String())
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+)
+
+// TenantMode selects how tenants are isolated from each other.
+type TenantMode string
+
+const (
+	// TenantColumn scopes every query and write of tenant-scoped entities by
+	// their tenant_id column.
+	TenantColumn TenantMode = "column"
+	// TenantRLS scopes by column and also pins the tenant on the PostgreSQL
+	// session, so row-level security policies enforce it in the database.
+	TenantRLS TenantMode = "rls"
+	// TenantSchema routes every query to a schema (or MongoDB database) of
+	// its own per tenant.
+	TenantSchema TenantMode = "schema"
+)
+
+// ErrNoTenant is returned for tenant-scoped entities when the context
+// carries no tenant, rather than reaching the records of every tenant.
+var ErrNoTenant = errors.New("no tenant to scope records to, use WithTenant or WithAllTenants")
+
+type tenantKey struct{}
+
+type allTenantsKey struct{}
+
+var tenantPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,63}$`)
+
+// CurrentTenantMode returns the configured tenant mode, empty when
+// multi-tenancy is disabled.
+func CurrentTenantMode() TenantMode {
+	return TenantMode(config.Tenant.Mode)
+}
+
+// ValidTenant reports whether id is safe to be used as a tenant ID, which
+// also ends up in schema and database names.
+func ValidTenant(id string) bool {
+	return tenantPattern.MatchString(id)
+}
+
+// WithTenant returns a context carrying the tenant ID.
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// TenantFromContext returns the tenant ID carried by ctx, if any.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok && id != ""
+}
+
+// WithAllTenants returns a context reaching the rows of every tenant, for
+// cross-tenant jobs such as purging trashed records. Without it, contexts
+// carrying no tenant reach no row at all under row-level security.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsKey{}, true)
+}
+
+// AllTenants reports whether ctx reaches the rows of every tenant.
+func AllTenants(ctx context.Context) bool {
+	all, _ := ctx.Value(allTenantsKey{}).(bool)
+	return all
+}
+
+// TenantScoped is implemented by entities stored with a tenant_id column,
+// usually by embedding TenantModel.
+type TenantScoped interface {
+	TenantScoped()
+}
+
+// TenantModel opts an entity into tenant scoping.
+type TenantModel struct {
+	TenantID string `json:"tenant_id,omitempty" bson:"tenant_id,omitempty"`
+}
+
+func (TenantModel) TenantScoped() {}
+
+func (t *TenantModel) SetTenantID(id string) {
+	t.TenantID = id
+}
+
+// TenantScope returns the tenant that queries and writes on entity must be
+// scoped to. It fails closed with ErrNoTenant when entity is tenant-scoped
+// but ctx carries no tenant; only contexts made with WithAllTenants reach
+// every tenant unscoped.
+func TenantScope(ctx context.Context, entity any) (tenant string, scoped bool, err error) {
+	mode := CurrentTenantMode()
+	if mode != TenantColumn && mode != TenantRLS {
+		return "", false, nil
+	}
+
+	if _, ok := entity.(TenantScoped); !ok {
+		return "", false, nil
+	}
+
+	if tenant, ok := TenantFromContext(ctx); ok {
+		return tenant, true, nil
+	}
+
+	if AllTenants(ctx) {
+		return "", false, nil
+	}
+
+	return "", false, ErrNoTenant
+}
+
+// StampTenant sets the tenant of ctx on model before it is inserted.
+// Repositories refuse to write without a tenant, so a context failing
+// TenantScope leaves model as is.
+func StampTenant(ctx context.Context, model any) {
+	tenant, ok, _ := TenantScope(ctx, model)
+	if !ok {
+		return
+	}
+
+	if m, ok := model.(interface{ SetTenantID(string) }); ok {
+		m.SetTenantID(tenant)
+	}
+}
+
+// TenantSchemaName returns the schema (or MongoDB database) holding the data
+// of the tenant carried by ctx in schema mode.
+func TenantSchemaName(ctx context.Context) (string, bool) {
+	if CurrentTenantMode() != TenantSchema {
+		return "", false
+	}
+
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	return config.Tenant.SchemaPrefix + tenant, true
+}
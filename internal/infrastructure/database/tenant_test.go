@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scopedEntity struct {
+	TenantModel
+}
+
+type globalEntity struct{}
+
+func TestTenantScope(t *testing.T) {
+	acme := WithTenant(context.Background(), "acme")
+
+	cases := []struct {
+		name   string
+		mode   TenantMode
+		ctx    context.Context
+		entity any
+		tenant string
+		scoped bool
+		err    error
+	}{
+		{name: "disabled", mode: "", ctx: acme, entity: scopedEntity{}},
+		{name: "column", mode: TenantColumn, ctx: acme, entity: scopedEntity{}, tenant: "acme", scoped: true},
+		{name: "rls", mode: TenantRLS, ctx: acme, entity: scopedEntity{}, tenant: "acme", scoped: true},
+		{name: "schema", mode: TenantSchema, ctx: acme, entity: scopedEntity{}},
+		{name: "global entity", mode: TenantColumn, ctx: acme, entity: globalEntity{}},
+		{name: "no tenant", mode: TenantColumn, ctx: context.Background(), entity: scopedEntity{}, err: ErrNoTenant},
+		{name: "empty tenant", mode: TenantColumn, ctx: WithTenant(context.Background(), ""), entity: scopedEntity{}, err: ErrNoTenant},
+		{name: "all tenants", mode: TenantRLS, ctx: WithAllTenants(context.Background()), entity: scopedEntity{}},
+		{name: "no tenant global entity", mode: TenantColumn, ctx: context.Background(), entity: globalEntity{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withTenantMode(t, tc.mode)
+
+			tenant, scoped, err := TenantScope(tc.ctx, tc.entity)
+
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.tenant, tenant)
+			assert.Equal(t, tc.scoped, scoped)
+		})
+	}
+}
+
+func TestStampTenant(t *testing.T) {
+	withTenantMode(t, TenantColumn)
+
+	stamped := &scopedEntity{TenantModel{TenantID: "globex"}}
+	StampTenant(WithTenant(context.Background(), "acme"), stamped)
+
+	unscoped := &scopedEntity{TenantModel{TenantID: "globex"}}
+	StampTenant(context.Background(), unscoped)
+
+	assert.Equal(t, "acme", stamped.TenantID)
+	assert.Equal(t, "globex", unscoped.TenantID)
+}
+
+func TestTenantSchemaName(t *testing.T) {
+	cases := []struct {
+		name   string
+		mode   TenantMode
+		ctx    context.Context
+		schema string
+		ok     bool
+	}{
+		{name: "schema", mode: TenantSchema, ctx: WithTenant(context.Background(), "acme"), schema: "tenant_acme", ok: true},
+		{name: "no tenant", mode: TenantSchema, ctx: context.Background()},
+		{name: "column", mode: TenantColumn, ctx: WithTenant(context.Background(), "acme")},
+		{name: "rls", mode: TenantRLS, ctx: WithTenant(context.Background(), "acme")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withTenantMode(t, tc.mode)
+
+			schema, ok := TenantSchemaName(tc.ctx)
+
+			assert.Equal(t, tc.schema, schema)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestAllTenants(t *testing.T) {
+	assert.False(t, AllTenants(context.Background()))
+	assert.False(t, AllTenants(WithTenant(context.Background(), "acme")))
+	assert.True(t, AllTenants(WithAllTenants(context.Background())))
+}
+
+func TestValidTenant(t *testing.T) {
+	assert.True(t, ValidTenant("acme_01-eu"))
+	assert.False(t, ValidTenant(""))
+	assert.False(t, ValidTenant("acme; DROP SCHEMA public"))
+	assert.False(t, ValidTenant("a.b"))
+}
@@ -0,0 +1,229 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/utils/retry"
+)
+
+// Transactor runs a function inside a database transaction.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type afterCommitKey struct{}
+
+// afterCommit holds the functions to run once a transaction commits.
+type afterCommit struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+func (a *afterCommit) add(fns ...func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.fns = append(a.fns, fns...)
+}
+
+// take removes and returns the functions held so far.
+func (a *afterCommit) take() []func() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	fns := a.fns
+	a.fns = nil
+
+	return fns
+}
+
+func (a *afterCommit) run() {
+	for _, fn := range a.take() {
+		fn()
+	}
+}
+
+// ErrNoTransactor is returned by WithTransaction until a database
+// connection registered its transactor, rather than running the function
+// without a transaction.
+var ErrNoTransactor = errors.New("no transactor registered, open a database connection first")
+
+// ErrTransactorRegistered is returned by SetTransactor when another
+// connection registered its transactor already, since WithTransaction can
+// only serve one database.
+var ErrTransactorRegistered = errors.New("a transactor is registered already, WithTransaction serves a single database")
+
+// Tx is a transaction begun by the Begin of a repository. It is opaque to
+// its callers, which hand it back to the repositories of the same database
+// to write within it, and only the driver that began it unwraps it.
+type Tx struct {
+	handle any
+}
+
+// ErrForeignTx is returned by repositories handed a transaction they did not
+// begin, rather than writing outside of it.
+var ErrForeignTx = errors.New("transaction was begun on another database")
+
+// NewTx wraps the transaction handle of a driver.
+func NewTx(handle any) *Tx {
+	return &Tx{handle: handle}
+}
+
+// TxHandle returns the driver handle of trx, reporting false when trx is
+// nil or was begun by another driver.
+func TxHandle[T any](trx *Tx) (handle T, ok bool) {
+	if trx == nil {
+		return handle, false
+	}
+
+	handle, ok = trx.handle.(T)
+	return
+}
+
+var transactor Transactor
+
+var (
+	pendingMu sync.Mutex
+	pending   = map[*Tx]*afterCommit{}
+)
+
+// SetTransactor registers the transactor used by WithTransaction, or
+// unregisters it when t is nil.
+func SetTransactor(t Transactor) error {
+	if t != nil && transactor != nil {
+		return ErrTransactorRegistered
+	}
+
+	transactor = t
+	return nil
+}
+
+// TransactionRetry returns how transactions failing on serialization
+// failures or deadlocks are retried. Callers such as HTTP requests wait on
+// them, so the policy is much shorter than the startup one.
+func TransactionRetry() retry.Policy {
+	return retry.Policy{
+		MaxRetries:     config.Database.TxMaxRetries,
+		InitialBackoff: config.Database.TxInitialBackoff,
+		MaxBackoff:     config.Database.TxMaxBackoff,
+	}
+}
+
+// WithTransaction runs fn inside a transaction carried by the context, so
+// repositories called with that context join it without a trx parameter.
+// The transaction is committed when fn returns nil and rolled back otherwise.
+// Nested calls run in a savepoint where the database supports it, and the
+// whole transaction is retried on serialization failures and deadlocks.
+// Functions registered through AfterCommit run once it committed.
+func WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if transactor == nil {
+		return ErrNoTransactor
+	}
+
+	if InTransaction(ctx) {
+		return savepoint(ctx, fn)
+	}
+
+	hooks := &afterCommit{}
+	err := transactor.Transaction(context.WithValue(ctx, afterCommitKey{}, hooks), func(ctx context.Context) error {
+		// Only the attempt that commits counts once the transaction is retried.
+		hooks.take()
+
+		return fn(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	hooks.run()
+
+	return nil
+}
+
+// savepoint runs fn nested in the transaction carried by ctx. Functions fn
+// registers through AfterCommit are handed to the outer transaction once
+// the savepoint is released, and dropped when it rolls back.
+func savepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	hooks := &afterCommit{}
+	if err := transactor.Transaction(context.WithValue(ctx, afterCommitKey{}, hooks), fn); err != nil {
+		return err
+	}
+
+	if outer, ok := ctx.Value(afterCommitKey{}).(*afterCommit); ok {
+		outer.add(hooks.take()...)
+		return nil
+	}
+
+	hooks.run()
+
+	return nil
+}
+
+// InTransaction reports whether ctx carries a transaction.
+func InTransaction(ctx context.Context) bool {
+	return ctx.Value(afterCommitKey{}) != nil || ctx.Value(txKey{}) != nil
+}
+
+// AfterCommit runs fn once the transaction fn is part of commits: trx when
+// it is not nil, the transaction carried by ctx otherwise. fn is dropped
+// when the transaction rolls back, and runs right away outside
+// transactions.
+func AfterCommit(ctx context.Context, trx *Tx, fn func()) {
+	if trx != nil {
+		pendingMu.Lock()
+		hooks, ok := pending[trx]
+		if !ok {
+			hooks = &afterCommit{}
+			pending[trx] = hooks
+		}
+		pendingMu.Unlock()
+
+		hooks.add(fn)
+		return
+	}
+
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommit); ok {
+		hooks.add(fn)
+		return
+	}
+
+	fn()
+}
+
+// Committed runs the functions registered through AfterCommit for trx.
+// Repositories call it once they committed trx.
+func Committed(trx *Tx) {
+	pendingMu.Lock()
+	hooks, ok := pending[trx]
+	delete(pending, trx)
+	pendingMu.Unlock()
+
+	if ok {
+		hooks.run()
+	}
+}
+
+// RolledBack drops the functions registered through AfterCommit for trx.
+// Repositories call it once they rolled back trx.
+func RolledBack(trx *Tx) {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+
+	delete(pending, trx)
+}
+
+// ContextWithTx returns a context carrying the given transaction.
+func ContextWithTx[T any](ctx context.Context, tx T) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any.
+func TxFromContext[T any](ctx context.Context) (tx T, ok bool) {
+	tx, ok = ctx.Value(txKey{}).(T)
+	return
+}
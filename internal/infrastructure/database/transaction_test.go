@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAfterCommit(t *testing.T) {
+	withTransactor(t, txTransactor{})
+
+	var ran []string
+	record := func(name string) func() {
+		return func() { ran = append(ran, name) }
+	}
+
+	AfterCommit(context.Background(), nil, record("outside"))
+
+	err := WithTransaction(context.Background(), func(ctx context.Context) error {
+		AfterCommit(ctx, nil, record("committed"))
+
+		assert.True(t, InTransaction(ctx))
+		assert.Equal(t, []string{"outside"}, ran)
+
+		return WithTransaction(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, nil, record("nested"))
+			return nil
+		})
+	})
+	assert.NoError(t, err)
+
+	err = WithTransaction(context.Background(), func(ctx context.Context) error {
+		AfterCommit(ctx, nil, record("rolled back"))
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"outside", "committed", "nested"}, ran)
+	assert.False(t, InTransaction(context.Background()))
+}
+
+func TestAfterCommit_Trx(t *testing.T) {
+	committed, rolledBack := NewTx(nil), NewTx(nil)
+
+	var ran []string
+	AfterCommit(context.Background(), committed, func() { ran = append(ran, "committed") })
+	AfterCommit(context.Background(), rolledBack, func() { ran = append(ran, "rolled back") })
+
+	assert.Empty(t, ran)
+
+	RolledBack(rolledBack)
+	Committed(committed)
+	Committed(committed)
+
+	assert.Equal(t, []string{"committed"}, ran)
+}
+
+func TestWithTransaction_NoTransactor(t *testing.T) {
+	withTransactor(t, nil)
+
+	var ran bool
+	err := WithTransaction(context.Background(), func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+
+	assert.ErrorIs(t, err, ErrNoTransactor)
+	assert.False(t, ran)
+}
+
+func TestAfterCommit_SavepointRolledBack(t *testing.T) {
+	withTransactor(t, txTransactor{})
+
+	var ran []string
+	record := func(name string) func() {
+		return func() { ran = append(ran, name) }
+	}
+
+	err := WithTransaction(context.Background(), func(ctx context.Context) error {
+		AfterCommit(ctx, nil, record("outer"))
+
+		// The outer transaction goes on without what the savepoint wrote.
+		err := WithTransaction(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, nil, record("rolled back"))
+			return errors.New("rollback")
+		})
+		assert.Error(t, err)
+
+		err = WithTransaction(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, nil, record("released"))
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, ran)
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer", "released"}, ran)
+}
+
+func TestSetTransactor(t *testing.T) {
+	withTransactor(t, nil)
+
+	require.NoError(t, SetTransactor(txTransactor{}))
+
+	// Another connection would take WithTransaction away from the first.
+	assert.ErrorIs(t, SetTransactor(txTransactor{}), ErrTransactorRegistered)
+
+	require.NoError(t, SetTransactor(nil))
+	assert.NoError(t, SetTransactor(txTransactor{}))
+}
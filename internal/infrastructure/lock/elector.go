@@ -0,0 +1,73 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+)
+
+// Elector elects a single leader among the instances campaigning under
+// the same name, e.g. to run a scheduled task on one replica only.
+type Elector struct {
+	locker *Locker
+	name   string
+	leader atomic.Bool
+}
+
+func NewElector(locker *Locker, name string) *Elector {
+	return &Elector{
+		locker: locker,
+		name:   "leader:" + name,
+	}
+}
+
+// Run campaigns until ctx is done, calling lead whenever this instance is
+// elected with a context done once it no longer leads. lead should run
+// until its context is done; leadership is given up when it returns, and
+// the instance campaigns again. Run returns ErrNotShared at once if the
+// locks are not shared, rather than electing every instance.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) error {
+	if !e.locker.Shared() {
+		return ErrNotShared
+	}
+
+	for ctx.Err() == nil {
+		m, err := e.locker.Lock(ctx, e.name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			logger.Errorf(ctx, err, "❌ Failed to campaign for %s", e.name).Write()
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(config.Lock.RetryInterval):
+			}
+			continue
+		}
+
+		logger.Infof(ctx, "👑 Elected as %s with fence %d", e.name, m.Fence()).Write()
+
+		e.leader.Store(true)
+		lead(m.Context())
+		e.leader.Store(false)
+
+		err = m.Unlock(context.WithoutCancel(ctx))
+		if err != nil && !errors.Is(err, ErrLost) {
+			logger.Errorf(ctx, err, "❌ Failed to step down as %s", e.name).Write()
+		}
+	}
+
+	return nil
+}
+
+// IsLeader reports whether this instance currently leads.
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
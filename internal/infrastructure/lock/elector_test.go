@@ -0,0 +1,109 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElector_Run_NotShared(t *testing.T) {
+	withLockConfig(t, time.Minute)
+
+	var led atomic.Bool
+	err := NewElector(NewLocker(newTestCache(t)), "purge").Run(context.Background(), func(ctx context.Context) {
+		led.Store(true)
+	})
+
+	assert.ErrorIs(t, err, ErrNotShared)
+	assert.False(t, led.Load())
+}
+
+func TestElector_Run(t *testing.T) {
+	withLockConfig(t, time.Minute)
+	locker := NewLocker(sharedCache{newTestCache(t)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	electors := []*Elector{NewElector(locker, "purge"), NewElector(locker, "purge")}
+
+	var (
+		leaders   atomic.Int32
+		overlaps  atomic.Int32
+		elections atomic.Int32
+	)
+	lead := func(e *Elector) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			if leaders.Add(1) != 1 {
+				overlaps.Add(1)
+			}
+			for _, other := range electors {
+				if other.IsLeader() != (other == e) {
+					overlaps.Add(1)
+				}
+			}
+
+			// Step down after a while, as a leader whose task ended.
+			select {
+			case <-ctx.Done():
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			leaders.Add(-1)
+			elections.Add(1)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, e := range electors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, e.Run(ctx, lead(e)))
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		return elections.Load() >= 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	wg.Wait()
+
+	assert.Zero(t, overlaps.Load())
+	for _, e := range electors {
+		assert.False(t, e.IsLeader())
+	}
+}
+
+func TestElector_Run_StepsDownOnCancel(t *testing.T) {
+	withLockConfig(t, time.Minute)
+	locker := NewLocker(sharedCache{newTestCache(t)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	elected := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- NewElector(locker, "purge").Run(ctx, func(ctx context.Context) {
+			close(elected)
+			<-ctx.Done()
+		})
+	}()
+
+	<-elected
+	cancel()
+	require.NoError(t, <-done)
+
+	// The lock was released, so another instance is elected at once.
+	m, err := locker.TryLock(context.Background(), "leader:purge")
+	require.NoError(t, err)
+	defer m.Unlock(context.Background())
+
+	assert.Equal(t, int64(2), m.Fence())
+}
@@ -0,0 +1,223 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotAcquired is returned by TryLock when another owner holds the
+	// lock.
+	ErrNotAcquired = errors.New("lock is held by another owner")
+	// ErrLost is the cause of the context of a Mutex that expired before
+	// it could be extended, e.g. while the cache was unreachable, and is
+	// returned by Unlock then.
+	ErrLost = errors.New("lock was lost")
+	// ErrNotShared is returned by an Elector whose locks are held within
+	// the process, which would elect every instance.
+	ErrNotShared = errors.New("locks are not shared between instances")
+)
+
+// Locker takes locks shared by every instance of the application through
+// the cache. A lock expires config.Lock.TTL after its holder stopped
+// extending it, e.g. after a crash.
+type Locker struct {
+	cache cache.Locker
+}
+
+func NewLocker(locker cache.Locker) *Locker {
+	return &Locker{
+		cache: locker,
+	}
+}
+
+// Shared reports whether the locks exclude the other instances, which they
+// do not when held by a cache within the process, e.g. the memory driver.
+func (l *Locker) Shared() bool {
+	local, ok := l.cache.(cache.Local)
+	return !ok || !local.Local()
+}
+
+// Mutex is a held lock, extended every third of config.Lock.TTL until it
+// is unlocked.
+type Mutex struct {
+	locker *Locker
+	name   string
+	key    string
+	token  string
+	fence  int64
+
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// TryLock takes the lock name, returning ErrNotAcquired if it is held.
+func (l *Locker) TryLock(ctx context.Context, name string) (res *Mutex, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"name": name,
+	})
+
+	defer func() {
+		var fence int64
+		if res != nil {
+			fence = res.fence
+		}
+
+		span.SetFunctionOutput(tracer.Metadata{
+			"fence": fence,
+		}).End(err)
+	}()
+
+	return l.tryLock(ctx, name)
+}
+
+// Lock takes the lock name, waiting config.Lock.RetryInterval between
+// attempts while it is held, until ctx is done.
+func (l *Locker) Lock(ctx context.Context, name string) (res *Mutex, err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"name": name,
+	})
+
+	var attempts int
+	defer func() {
+		var fence int64
+		if res != nil {
+			fence = res.fence
+		}
+
+		span.SetFunctionOutput(tracer.Metadata{
+			"fence":    fence,
+			"attempts": attempts,
+		}).End(err)
+	}()
+
+	for {
+		attempts++
+
+		res, err = l.tryLock(ctx, name)
+		if !errors.Is(err, ErrNotAcquired) {
+			return res, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(config.Lock.RetryInterval):
+		}
+	}
+}
+
+func (l *Locker) tryLock(ctx context.Context, name string) (*Mutex, error) {
+	m := &Mutex{
+		locker: l,
+		name:   name,
+		key:    key(name),
+		token:  uuid.NewString(),
+		done:   make(chan struct{}),
+	}
+
+	fence, err := l.cache.Acquire(ctx, m.key, m.token, config.Lock.TTL)
+	if err != nil {
+		return nil, err
+	}
+	if fence == 0 {
+		return nil, ErrNotAcquired
+	}
+
+	m.fence = fence
+	m.ctx, m.cancel = context.WithCancelCause(ctx)
+	go m.extend()
+
+	return m, nil
+}
+
+// Fence returns the fencing token of the hold, greater than that of every
+// previous hold of the lock. Writes made under the lock should carry it, so
+// that the store refuses those of a holder that lost the lock without
+// knowing, e.g. after a long GC pause.
+func (m *Mutex) Fence() int64 {
+	return m.fence
+}
+
+// Context returns a context done once the lock is unlocked or lost, with
+// ErrLost as its cause then. Work under the lock should stop with it.
+func (m *Mutex) Context() context.Context {
+	return m.ctx
+}
+
+// Unlock releases the lock, returning ErrLost if it was no longer held.
+func (m *Mutex) Unlock(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx)
+	span.SetFunctionInput(tracer.Metadata{
+		"name":  m.name,
+		"fence": m.fence,
+	})
+
+	defer func() {
+		span.End(err)
+	}()
+
+	m.cancel(nil)
+	<-m.done
+
+	released, err := m.locker.cache.Release(ctx, m.key, m.token)
+	if err != nil {
+		return err
+	}
+	if !released {
+		return ErrLost
+	}
+
+	return nil
+}
+
+// extend keeps the lock until m.ctx is done. A failed extension is retried
+// until the lock would have expired.
+func (m *Mutex) extend() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(config.Lock.TTL / 3)
+	defer ticker.Stop()
+
+	expiresAt := time.Now().Add(config.Lock.TTL)
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			extended, err := m.locker.cache.Extend(m.ctx, m.key, m.token, config.Lock.TTL)
+			if err == nil && extended {
+				expiresAt = now.Add(config.Lock.TTL)
+				continue
+			}
+			if m.ctx.Err() != nil {
+				return
+			}
+
+			if err != nil && time.Now().Before(expiresAt) {
+				logger.Warnf(m.ctx, "⚠️ Failed to extend the lock %s: %v", m.name, err).Write()
+				continue
+			}
+
+			logger.Warnf(m.ctx, "⚠️ Lost the lock %s", m.name).Write()
+			m.cancel(ErrLost)
+			return
+		}
+	}
+}
+
+// key returns the cache key of the lock name. The braces make Redis
+// Cluster keep it and its fencing counter in the same slot.
+func key(name string) string {
+	return "lock:{" + name + "}"
+}
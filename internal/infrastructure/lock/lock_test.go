@@ -0,0 +1,124 @@
+package lock
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/memory"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+// sharedCache hides that the memory cache is local, standing for a cache
+// shared by every instance.
+type sharedCache struct {
+	cache.Locker
+}
+
+// withLockConfig restores the lock configuration after the test.
+func withLockConfig(t *testing.T, ttl time.Duration) {
+	t.Helper()
+
+	previous := config.Lock
+	t.Cleanup(func() {
+		config.Lock = previous
+	})
+
+	config.Lock.TTL = ttl
+	config.Lock.RetryInterval = 5 * time.Millisecond
+}
+
+// newTestCache returns a memory cache shut down with the test.
+func newTestCache(t *testing.T) cache.Cache {
+	t.Helper()
+
+	c := memory.NewClient(context.Background())
+	t.Cleanup(func() {
+		c.Shutdown(context.Background())
+	})
+
+	return c
+}
+
+func TestLocker_Shared(t *testing.T) {
+	c := newTestCache(t)
+
+	assert.False(t, NewLocker(c).Shared())
+	assert.True(t, NewLocker(sharedCache{c}).Shared())
+}
+
+func TestLocker_TryLock_Fence(t *testing.T) {
+	withLockConfig(t, time.Minute)
+	locker := NewLocker(sharedCache{newTestCache(t)})
+	ctx := context.Background()
+
+	first, err := locker.TryLock(ctx, "report")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Fence())
+
+	_, err = locker.TryLock(ctx, "report")
+	assert.ErrorIs(t, err, ErrNotAcquired)
+
+	require.NoError(t, first.Unlock(ctx))
+	assert.Error(t, first.Context().Err())
+
+	second, err := locker.TryLock(ctx, "report")
+	require.NoError(t, err)
+	defer second.Unlock(ctx)
+
+	assert.Greater(t, second.Fence(), first.Fence())
+}
+
+func TestLocker_Lock_WaitsForRelease(t *testing.T) {
+	withLockConfig(t, time.Minute)
+	locker := NewLocker(sharedCache{newTestCache(t)})
+	ctx := context.Background()
+
+	first, err := locker.TryLock(ctx, "report")
+	require.NoError(t, err)
+
+	time.AfterFunc(20*time.Millisecond, func() {
+		first.Unlock(ctx)
+	})
+
+	second, err := locker.Lock(ctx, "report")
+	require.NoError(t, err)
+	defer second.Unlock(ctx)
+
+	assert.Equal(t, int64(2), second.Fence())
+}
+
+func TestMutex_Unlock_Lost(t *testing.T) {
+	withLockConfig(t, 30*time.Millisecond)
+	c := newTestCache(t)
+	locker := NewLocker(sharedCache{c})
+	ctx := context.Background()
+
+	m, err := locker.TryLock(ctx, "report")
+	require.NoError(t, err)
+
+	// Another owner cannot take over a held lock, but one that expired
+	// while unreachable is gone.
+	require.NoError(t, c.Del(ctx, key("report")))
+
+	select {
+	case <-m.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("the lost lock was not noticed")
+	}
+	assert.ErrorIs(t, context.Cause(m.Context()), ErrLost)
+	assert.ErrorIs(t, m.Unlock(ctx), ErrLost)
+}
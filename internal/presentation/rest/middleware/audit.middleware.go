@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+)
+
+// AuditHandler identifies the actor of the request, recorded in the audit
+// trail of every change it makes. The verified bearer token wins over the
+// actor header; requests without either are recorded as anonymous.
+func AuditHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, _ := bearerClaims(c)[config.Audit.ActorClaim].(string)
+		if actor == "" {
+			actor = strings.TrimSpace(c.GetHeader(config.Audit.ActorHeader))
+		}
+		if actor == "" {
+			actor = database.AnonymousActor
+		}
+
+		ctx := database.WithActor(c.Request.Context(), actor)
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+)
+
+// ConsistencyHandler gives every request its own read-your-writes scope, so
+// reads that follow a write in the same request are served by the primary.
+func ConsistencyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := database.WithConsistency(c.Request.Context())
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
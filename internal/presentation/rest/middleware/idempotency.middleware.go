@@ -2,13 +2,20 @@ package middleware
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"time"
+	"slices"
 
 	"github.com/gin-gonic/gin"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	htterror "github.com/goodone-dev/go-boilerplate/internal/utils/http_response/error"
 )
 
 type responseWriter struct {
@@ -20,12 +27,34 @@ func (r responseWriter) Write(b []byte) (int, error) {
 	return r.body.Write(b)
 }
 
-func IdempotencyHandler(cache cache.Cache, duration time.Duration) gin.HandlerFunc {
+func (r responseWriter) WriteString(s string) (int, error) {
+	return r.body.WriteString(s)
+}
+
+// idempotentResponse is the response stored under an idempotency key, with
+// the fingerprint of the request that produced it.
+type idempotentResponse struct {
+	Fingerprint string      `json:"fingerprint"`
+	Status      int         `json:"status"`
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+}
+
+// IdempotencyHandler makes requests carrying an Idempotency-Key header, as
+// in the IETF Idempotency-Key draft, run once per key and principal. The
+// first request holds a lock while it runs, so a duplicate sent meanwhile
+// gets 409; once it completes its status, headers and body are replayed to
+// every retry, unless the key is reused for another payload, which gets
+// 422. Failed requests are not stored, so they can be retried.
+func IdempotencyHandler(cacheClient cache.Cache) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var err error
 
-		idempotencyKey := c.GetHeader("X-Idempotency-Key")
+		idempotencyKey := c.GetHeader("Idempotency-Key")
 		if idempotencyKey == "" {
+			idempotencyKey = c.GetHeader("X-Idempotency-Key")
+		}
+		if idempotencyKey == "" || isSafeMethod(c.Request.Method) {
 			c.Next()
 			return
 		}
@@ -35,39 +64,88 @@ func IdempotencyHandler(cache cache.Cache, duration time.Duration) gin.HandlerFu
 			span.End(err)
 		}()
 
-		key := fmt.Sprintf("idempotency:%s:%s %s", idempotencyKey, c.Request.Method, c.Request.URL.Path)
-		val, err := cache.Get(ctx, key)
+		fingerprint, err := fingerprintOf(c)
 		if err != nil {
-			c.Error(err)
+			c.Error(htterror.NewBadRequestError("failed to read request body", err.Error()))
 			c.Abort()
 			return
 		}
 
-		var body any
-		if err = val.Decode(&body); err == nil && body != nil {
-			c.JSON(http.StatusOK, body)
+		key := fmt.Sprintf("idempotency:%s:%s:%s %s", idempotencyScope(c), idempotencyKey, c.Request.Method, c.FullPath())
+
+		replayed, err := replayIdempotent(c, cacheClient, key, fingerprint)
+		if err != nil || replayed {
+			return
+		}
+
+		// A fixed window allowing a single request is a lock taken
+		// atomically, which expires if the instance holding it dies.
+		lock, err := cacheClient.Allow(ctx, key+":lock", cache.Limit{
+			Rate:      1,
+			Period:    config.Idempotency.LockDuration,
+			Algorithm: cache.FixedWindow,
+		})
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if !lock.Allowed {
+			c.Header("Retry-After", seconds(lock.RetryAfter))
+			c.Error(htterror.NewConflictError("a request with the same idempotency key is being processed"))
 			c.Abort()
 			return
 		}
 
+		defer func() {
+			if delErr := cacheClient.Del(ctx, key+":lock"); delErr != nil && err == nil {
+				err = delErr
+			}
+		}()
+
+		// The request that held the lock before may have completed between
+		// the lookup and the lock.
+		replayed, err = replayIdempotent(c, cacheClient, key, fingerprint)
+		if err != nil || replayed {
+			return
+		}
+
+		before := c.Writer.Header().Clone()
+
+		writer := c.Writer
 		blw := &responseWriter{
 			body:           bytes.NewBufferString(""),
-			ResponseWriter: c.Writer,
+			ResponseWriter: writer,
 		}
 
 		c.Writer = blw
 		c.Next()
+		c.Writer = writer
+
+		// Errors are rendered by ErrorHandler, with their own status, once
+		// the chain returns, and are not stored so they can be retried.
+		if len(c.Errors) > 0 {
+			return
+		}
 
-		if len(c.Errors) == 0 && blw.body.String() != "" {
-			err = cache.Set(ctx, key, blw.body.String(), duration)
+		if blw.Status() < http.StatusInternalServerError {
+			var data []byte
+			data, err = json.Marshal(idempotentResponse{
+				Fingerprint: fingerprint,
+				Status:      blw.Status(),
+				Header:      headerChanges(before, writer.Header()),
+				Body:        blw.body.Bytes(),
+			})
+			if err == nil {
+				err = cacheClient.Set(ctx, key, data, config.Idempotency.Duration)
+			}
 			if err != nil {
 				c.Error(err)
-				c.Abort()
 				return
 			}
 		}
 
-		_, err = blw.ResponseWriter.Write(blw.body.Bytes())
+		_, err = writer.Write(blw.body.Bytes())
 		if err != nil {
 			c.Error(err)
 			c.Abort()
@@ -75,3 +153,91 @@ func IdempotencyHandler(cache cache.Cache, duration time.Duration) gin.HandlerFu
 		}
 	}
 }
+
+// replayIdempotent writes the response stored under key, if any, and
+// reports whether the request was answered.
+func replayIdempotent(c *gin.Context, cacheClient cache.Cache, key string, fingerprint string) (bool, error) {
+	val, err := cacheClient.Get(c.Request.Context(), key)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return false, err
+	}
+	if val.IsEmpty() {
+		return false, nil
+	}
+
+	var res idempotentResponse
+	if err := json.Unmarshal(val.ToBytes(), &res); err != nil {
+		c.Error(err)
+		c.Abort()
+		return false, err
+	}
+
+	if res.Fingerprint != fingerprint {
+		c.Error(htterror.NewUnprocessableEntityError("the idempotency key was used for a different request"))
+		c.Abort()
+		return true, nil
+	}
+
+	for name, values := range res.Header {
+		c.Writer.Header()[name] = values
+	}
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(res.Status, res.Header.Get("Content-Type"), res.Body)
+	c.Abort()
+
+	return true, nil
+}
+
+// idempotencyScope returns the tenant and the principal the key belongs to,
+// so that clients cannot replay the responses of each other, not even the
+// same principal across tenants.
+func idempotencyScope(c *gin.Context) string {
+	scope := "anonymous"
+	if principal, _ := bearerClaims(c)[config.Idempotency.PrincipalClaim].(string); principal != "" {
+		scope = "principal:" + principal
+	} else if apiKey := hashKey(c.GetHeader(config.RateLimiter.APIKeyHeader)); apiKey != "" {
+		scope = "api_key:" + apiKey
+	}
+
+	if tenant, ok := database.TenantFromContext(c.Request.Context()); ok {
+		return "tenant:" + tenant + ":" + scope
+	}
+
+	return scope
+}
+
+// fingerprintOf hashes the request body, leaving it readable by the
+// handler.
+func fingerprintOf(c *gin.Context) (string, error) {
+	var body []byte
+	if c.Request.Body != nil {
+		var err error
+		body, err = io.ReadAll(c.Request.Body)
+		if err != nil {
+			return "", err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// headerChanges returns the headers the handler set, leaving out those of
+// the middleware before it, which set their own on replay.
+func headerChanges(before http.Header, after http.Header) http.Header {
+	changes := http.Header{}
+	for name, values := range after {
+		if !slices.Equal(before[name], values) {
+			changes[name] = values
+		}
+	}
+
+	return changes
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
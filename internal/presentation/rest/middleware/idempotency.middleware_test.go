@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/memory"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	htterror "github.com/goodone-dev/go-boilerplate/internal/utils/http_response/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotencyRouter(t *testing.T, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	config.Idempotency.Duration = time.Minute
+	config.Idempotency.LockDuration = time.Minute
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.Use(IdempotencyHandler(memory.NewClient(context.Background())))
+	router.POST("/orders", handler)
+
+	return router
+}
+
+func post(router *gin.Engine, idempotencyKey string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestIdempotencyHandler_Replay(t *testing.T) {
+	var calls atomic.Int32
+	router := newIdempotencyRouter(t, func(c *gin.Context) {
+		n := calls.Add(1)
+		c.Header("Location", "/orders/1")
+		c.JSON(http.StatusCreated, gin.H{"call": n})
+	})
+
+	first := post(router, "key-1", `{"total":1}`)
+	retry := post(router, "key-1", `{"total":1}`)
+	other := post(router, "key-2", `{"total":1}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "/orders/1", retry.Header().Get("Location"))
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestIdempotencyHandler_WithoutKey(t *testing.T) {
+	var calls atomic.Int32
+	router := newIdempotencyRouter(t, func(c *gin.Context) {
+		calls.Add(1)
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	post(router, "", `{}`)
+	post(router, "", `{}`)
+
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestIdempotencyHandler_InFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	router := newIdempotencyRouter(t, func(c *gin.Context) {
+		calls.Add(1)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	var wg sync.WaitGroup
+	var first *httptest.ResponseRecorder
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		first = post(router, "key-1", `{}`)
+	}()
+
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	duplicate := post(router, "key-1", `{}`)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, http.StatusConflict, duplicate.Code)
+	assert.NotEmpty(t, duplicate.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestIdempotencyHandler_PayloadMismatch(t *testing.T) {
+	var calls atomic.Int32
+	router := newIdempotencyRouter(t, func(c *gin.Context) {
+		calls.Add(1)
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	post(router, "key-1", `{"total":1}`)
+	mismatch := post(router, "key-1", `{"total":2}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestIdempotencyHandler_ErrorNotStored(t *testing.T) {
+	var calls atomic.Int32
+	router := newIdempotencyRouter(t, func(c *gin.Context) {
+		if calls.Add(1) == 1 {
+			c.Error(htterror.NewBadRequestError("bad"))
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	failed := post(router, "key-1", `{}`)
+	retry := post(router, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, failed.Code)
+	assert.JSONEq(t, `{"message":"bad"}`, failed.Body.String())
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Empty(t, retry.Header().Get("Idempotent-Replayed"))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestIdempotencyHandler_ServerErrorNotStored(t *testing.T) {
+	var calls atomic.Int32
+	router := newIdempotencyRouter(t, func(c *gin.Context) {
+		calls.Add(1)
+		c.JSON(http.StatusServiceUnavailable, gin.H{})
+	})
+
+	first := post(router, "key-1", `{}`)
+	post(router, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusServiceUnavailable, first.Code)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestIdempotencyScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.RateLimiter.APIKeyHeader = "X-API-Key"
+
+	cases := []struct {
+		name   string
+		ctx    context.Context
+		apiKey string
+		scope  string
+	}{
+		{name: "anonymous", ctx: context.Background(), scope: "anonymous"},
+		{name: "api key", ctx: context.Background(), apiKey: "key-1", scope: "api_key:" + hashKey("key-1")},
+		{name: "tenant", ctx: database.WithTenant(context.Background(), "acme"), scope: "tenant:acme:anonymous"},
+		{name: "api key of a tenant", ctx: database.WithTenant(context.Background(), "globex"), apiKey: "key-1", scope: "tenant:globex:api_key:" + hashKey("key-1")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequestWithContext(tc.ctx, http.MethodPost, "/orders", nil)
+			if tc.apiKey != "" {
+				c.Request.Header.Set("X-API-Key", tc.apiKey)
+			}
+
+			assert.Equal(t, tc.scope, idempotencyScope(c))
+		})
+	}
+}
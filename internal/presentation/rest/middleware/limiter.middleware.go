@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -21,8 +23,14 @@ type RateLimitConfig struct {
 	Limit int
 	TTL   time.Duration
 	Mode  RateLimitMode
+	// Burst is the capacity of the token bucket and GCRA, Limit when zero.
+	Burst     int
+	Algorithm cache.Algorithm
 }
 
+// RateLimiterHandler counts requests in a single atomic round trip and
+// reports the limit through the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers, with Retry-After once it is exceeded.
 func RateLimiterHandler(cache cache.Cache, config RateLimitConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var err error
@@ -32,38 +40,48 @@ func RateLimiterHandler(cache cache.Cache, config RateLimitConfig) gin.HandlerFu
 			span.End(err)
 		}()
 
-		key := fmt.Sprintf("rate_limit:%s", c.ClientIP())
+		key := fmt.Sprintf("rate_limit:%s:%s", config.Algorithm, c.ClientIP())
 		if config.Mode == SingleLimiter {
 			key = fmt.Sprintf("%s:%s %s", key, c.Request.Method, c.Request.URL.Path)
 		}
 
-		val, err := cache.Get(ctx, key)
+		res, err := cache.Allow(ctx, key, limitOf(config))
 		if err != nil {
 			c.Error(err)
 			c.Abort()
 			return
 		}
 
-		if val.ToInt() >= config.Limit {
+		c.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		c.Header("RateLimit-Reset", seconds(res.ResetAfter))
+
+		if !res.Allowed {
+			c.Header("Retry-After", seconds(res.RetryAfter))
 			c.Error(htterror.NewTooManyRequestError("rate limit exceeded, please try again later"))
 			c.Abort()
 			return
 		}
 
-		_, err = cache.Incr(ctx, key)
-		if err != nil {
-			c.Error(err)
-			c.Abort()
-			return
-		}
+		c.Next()
+	}
+}
 
-		err = cache.Expire(ctx, key, config.TTL)
-		if err != nil {
-			c.Error(err)
-			c.Abort()
-			return
-		}
+func limitOf(config RateLimitConfig) cache.Limit {
+	algorithm := config.Algorithm
+	if algorithm == "" {
+		algorithm = cache.SlidingWindow
+	}
 
-		c.Next()
+	return cache.Limit{
+		Rate:      config.Limit,
+		Period:    config.TTL,
+		Burst:     config.Burst,
+		Algorithm: algorithm,
 	}
 }
+
+// seconds rounds a delay up to whole seconds, as the headers expect.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,460 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+	htterror "github.com/goodone-dev/go-boilerplate/internal/utils/http_response/error"
+)
+
+// RateLimitKey is what requests are counted by.
+type RateLimitKey string
+
+const (
+	// KeyByAuto counts by the authenticated principal, else the API key,
+	// else the client IP.
+	KeyByAuto      RateLimitKey = "auto"
+	KeyByIP        RateLimitKey = "ip"
+	KeyByPrincipal RateLimitKey = "principal"
+	KeyByAPIKey    RateLimitKey = "api_key"
+	KeyByHeader    RateLimitKey = "header"
+)
+
+// QuotaPeriod is the calendar period a quota is counted over, in UTC.
+type QuotaPeriod string
+
+const (
+	QuotaDaily   QuotaPeriod = "day"
+	QuotaMonthly QuotaPeriod = "month"
+)
+
+// Quota allows Limit requests per calendar day or month.
+type Quota struct {
+	Limit int         `json:"limit"`
+	Per   QuotaPeriod `json:"per"`
+}
+
+// RateLimitPolicy limits the requests matching its route and tier. Every
+// matching policy applies, each with its own counters.
+type RateLimitPolicy struct {
+	Name string `json:"name"`
+	// Route is a method and a route pattern, e.g. "POST /api/v1/orders" or
+	// "* /api/v1/*"; empty matches every route.
+	Route string `json:"route"`
+	// Tier is the tier of the principal, empty for every tier.
+	Tier   string            `json:"tier"`
+	Key    RateLimitKey      `json:"key"`
+	Header string            `json:"header"`
+	Limits []RateLimitConfig `json:"limits"`
+	Quotas []Quota           `json:"quotas"`
+}
+
+// RateLimitPolicies is the policy table, with the principals, API keys and
+// client IPs or CIDRs that bypass it. Requests are only counted by the API
+// keys listed in APIKeys or in the allow list; any other key could be made
+// up by the client to get a fresh counter, so it counts by the client IP.
+type RateLimitPolicies struct {
+	Policies  []RateLimitPolicy `json:"policies"`
+	AllowList []string          `json:"allow_list"`
+	APIKeys   []string          `json:"api_keys"`
+}
+
+// UnmarshalJSON reads a limit as {"limit": 60, "period": "1m", "mode":
+// "single", "algorithm": "gcra", "burst": 10}.
+func (r *RateLimitConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Limit     int             `json:"limit"`
+		Period    string          `json:"period"`
+		Mode      RateLimitMode   `json:"mode"`
+		Burst     int             `json:"burst"`
+		Algorithm cache.Algorithm `json:"algorithm"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	period, err := time.ParseDuration(raw.Period)
+	if err != nil {
+		return fmt.Errorf("limit period: %w", err)
+	}
+
+	*r = RateLimitConfig{
+		Limit:     raw.Limit,
+		TTL:       period,
+		Mode:      raw.Mode,
+		Burst:     raw.Burst,
+		Algorithm: raw.Algorithm,
+	}
+
+	return nil
+}
+
+// LoadRateLimitPolicies reads the policy table from RATE_LIMITER_POLICY_FILE.
+// Without one, every client is held to the global limit and order creation
+// to the single route limit.
+func LoadRateLimitPolicies() (RateLimitPolicies, error) {
+	policies := RateLimitPolicies{
+		Policies: []RateLimitPolicy{
+			{
+				Name: "global",
+				Limits: []RateLimitConfig{{
+					Limit:     config.RateLimiter.GlobalLimit,
+					TTL:       config.RateLimiter.GlobalDuration,
+					Mode:      GlobalLimiter,
+					Algorithm: cache.Algorithm(config.RateLimiter.Algorithm),
+				}},
+			},
+			{
+				Name:  "orders-create",
+				Route: "POST /api/v1/orders",
+				Limits: []RateLimitConfig{{
+					Limit:     config.RateLimiter.SingleLimit,
+					TTL:       config.RateLimiter.SingleDuration,
+					Mode:      SingleLimiter,
+					Burst:     config.RateLimiter.Burst,
+					Algorithm: cache.Algorithm(config.RateLimiter.Algorithm),
+				}},
+			},
+		},
+	}
+
+	if config.RateLimiter.PolicyFile != "" {
+		data, err := os.ReadFile(config.RateLimiter.PolicyFile)
+		if err != nil {
+			return policies, err
+		}
+
+		policies = RateLimitPolicies{}
+		if err := json.Unmarshal(data, &policies); err != nil {
+			return policies, fmt.Errorf("rate limit policies: %w", err)
+		}
+	}
+
+	policies.AllowList = append(policies.AllowList, config.RateLimiter.AllowList...)
+	policies.APIKeys = append(policies.APIKeys, config.RateLimiter.APIKeys...)
+
+	return policies, nil
+}
+
+// RateLimitPolicyHandler applies the policies matching the request and
+// reports the most constrained limit through the RateLimit headers. A
+// request refused by one limit is not counted by the others.
+func RateLimitPolicyHandler(cacheClient cache.Cache, policies RateLimitPolicies) gin.HandlerFunc {
+	allow := newAllowList(policies.AllowList)
+	apiKeys := newAPIKeys(policies.APIKeys)
+
+	return func(c *gin.Context) {
+		var err error
+
+		ctx, span := tracer.Start(c.Request.Context())
+		defer func() {
+			span.End(err)
+		}()
+
+		subject := rateLimitSubjectOf(c)
+		if allow.contains(subject) {
+			c.Next()
+			return
+		}
+
+		if !apiKeys[subject.apiKey] {
+			subject.apiKey = ""
+		}
+
+		var tightest *cache.LimitResult
+		var allowed []policyLimit
+		for _, policy := range policies.Policies {
+			if !policy.matches(c, subject.tier) {
+				continue
+			}
+
+			for _, limit := range policy.limits(c, subject) {
+				var res *cache.LimitResult
+				res, err = cacheClient.Allow(ctx, limit.key, limit.limit)
+				if err != nil {
+					refundLimits(ctx, cacheClient, allowed)
+					c.Error(err)
+					c.Abort()
+					return
+				}
+
+				if !res.Allowed {
+					// The request does not go through, so the limits that
+					// counted it before are given it back.
+					refundLimits(ctx, cacheClient, allowed)
+					writeRateLimitHeaders(c, res)
+					c.Header("Retry-After", seconds(res.RetryAfter))
+					c.Error(htterror.NewTooManyRequestError(limit.message))
+					c.Abort()
+					return
+				}
+
+				allowed = append(allowed, limit)
+				if tightest == nil || res.Remaining < tightest.Remaining {
+					tightest = res
+				}
+			}
+		}
+
+		if tightest != nil {
+			writeRateLimitHeaders(c, tightest)
+		}
+
+		c.Next()
+	}
+}
+
+func refundLimits(ctx context.Context, cacheClient cache.Cache, limits []policyLimit) {
+	for _, limit := range limits {
+		if err := cacheClient.Refund(ctx, limit.key, limit.limit); err != nil {
+			logger.Warnf(ctx, "⚠️ Failed to refund the rate limit %s: %v", limit.key, err).Write()
+		}
+	}
+}
+
+func writeRateLimitHeaders(c *gin.Context, res *cache.LimitResult) {
+	c.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Header("RateLimit-Reset", seconds(res.ResetAfter))
+}
+
+// rateLimitSubject is who a request is counted against.
+type rateLimitSubject struct {
+	ip        string
+	principal string
+	apiKey    string
+	tier      string
+}
+
+func rateLimitSubjectOf(c *gin.Context) rateLimitSubject {
+	claims := bearerClaims(c)
+
+	subject := rateLimitSubject{
+		ip:     c.ClientIP(),
+		apiKey: strings.TrimSpace(c.GetHeader(config.RateLimiter.APIKeyHeader)),
+	}
+	subject.principal, _ = claims[config.RateLimiter.PrincipalClaim].(string)
+	subject.tier, _ = claims[config.RateLimiter.TierClaim].(string)
+	if subject.tier == "" {
+		subject.tier = config.RateLimiter.DefaultTier
+	}
+
+	return subject
+}
+
+// matches reports whether the policy applies to the route and tier of the
+// request.
+func (p RateLimitPolicy) matches(c *gin.Context, tier string) bool {
+	if p.Tier != "" && p.Tier != tier {
+		return false
+	}
+
+	if p.Route == "" {
+		return true
+	}
+
+	method, pattern, ok := strings.Cut(p.Route, " ")
+	if !ok {
+		method, pattern = "*", p.Route
+	}
+
+	if method != "*" && !strings.EqualFold(method, c.Request.Method) {
+		return false
+	}
+
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(route, prefix)
+	}
+
+	return route == pattern
+}
+
+type policyLimit struct {
+	key     string
+	limit   cache.Limit
+	message string
+}
+
+// limits returns the limits and quotas of the policy, keyed by the policy
+// and the subject of the request.
+func (p RateLimitPolicy) limits(c *gin.Context, subject rateLimitSubject) []policyLimit {
+	key := fmt.Sprintf("%s:%s", p.Name, p.keyOf(c, subject))
+
+	limits := make([]policyLimit, 0, len(p.Limits)+len(p.Quotas))
+	for _, l := range p.Limits {
+		limit := limitOf(l)
+
+		limitKey := fmt.Sprintf("rate_limit:%s:%s:%s", limit.Algorithm, key, l.TTL)
+		if l.Mode == SingleLimiter {
+			limitKey = fmt.Sprintf("%s:%s %s", limitKey, c.Request.Method, c.FullPath())
+		}
+
+		limits = append(limits, policyLimit{
+			key:     limitKey,
+			limit:   limit,
+			message: "rate limit exceeded, please try again later",
+		})
+	}
+
+	now := time.Now().UTC()
+	for _, q := range p.Quotas {
+		period, end := quotaWindow(now, q.Per)
+
+		limits = append(limits, policyLimit{
+			key: fmt.Sprintf("quota:%s:%s", key, period),
+			limit: cache.Limit{
+				Rate:      q.Limit,
+				Period:    end.Sub(now),
+				Algorithm: cache.FixedWindow,
+			},
+			message: fmt.Sprintf("%s quota exceeded", quotaName(q.Per)),
+		})
+	}
+
+	return limits
+}
+
+// keyOf returns the identity of the subject counted by the policy, falling
+// back to the client IP when the request does not carry it or its API key
+// is not a known one. API keys are hashed so they never reach the cache in
+// clear.
+func (p RateLimitPolicy) keyOf(c *gin.Context, subject rateLimitSubject) string {
+	var id string
+
+	switch p.Key {
+	case KeyByIP:
+	case KeyByPrincipal:
+		id = prefixed("principal", subject.principal)
+	case KeyByAPIKey:
+		id = prefixed("api_key", hashKey(subject.apiKey))
+	case KeyByHeader:
+		id = prefixed("header", hashKey(strings.TrimSpace(c.GetHeader(p.Header))))
+	default:
+		id = prefixed("principal", subject.principal)
+		if id == "" {
+			id = prefixed("api_key", hashKey(subject.apiKey))
+		}
+	}
+
+	if id == "" {
+		return "ip:" + subject.ip
+	}
+
+	return id
+}
+
+func prefixed(prefix string, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	return prefix + ":" + id
+}
+
+func hashKey(key string) string {
+	if key == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// quotaWindow returns the calendar period of a quota and when it ends.
+func quotaWindow(now time.Time, per QuotaPeriod) (string, time.Time) {
+	if per == QuotaMonthly {
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start.Format("200601"), start.AddDate(0, 1, 0)
+	}
+
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return start.Format("20060102"), start.AddDate(0, 0, 1)
+}
+
+func quotaName(per QuotaPeriod) string {
+	if per == QuotaMonthly {
+		return "monthly"
+	}
+
+	return "daily"
+}
+
+// allowList holds the principals, API keys and networks that bypass rate
+// limits.
+type allowList struct {
+	ids      map[string]bool
+	networks []*net.IPNet
+}
+
+func newAllowList(entries []string) allowList {
+	allow := allowList{ids: map[string]bool{}}
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			allow.networks = append(allow.networks, network)
+		} else if ip := net.ParseIP(entry); ip != nil {
+			allow.networks = append(allow.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+		} else {
+			allow.ids[entry] = true
+		}
+	}
+
+	return allow
+}
+
+// newAPIKeys returns the set of the API keys requests are counted by.
+func newAPIKeys(keys []string) map[string]bool {
+	res := map[string]bool{}
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			res[key] = true
+		}
+	}
+
+	return res
+}
+
+func (a allowList) contains(subject rateLimitSubject) bool {
+	if subject.principal != "" && a.ids[subject.principal] {
+		return true
+	}
+	if subject.apiKey != "" && a.ids[subject.apiKey] {
+		return true
+	}
+
+	ip := net.ParseIP(subject.ip)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range a.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,295 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPolicyRouter(t *testing.T, cacheClient cache.Cache, policies RateLimitPolicies) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	config.RateLimiter.APIKeyHeader = "X-API-Key"
+	config.RateLimiter.PrincipalClaim = "sub"
+	config.RateLimiter.TierClaim = "tier"
+	config.RateLimiter.DefaultTier = "free"
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.Use(RateLimitPolicyHandler(cacheClient, policies))
+	router.POST("/api/v1/orders", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+	router.GET("/api/v1/orders/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	return router
+}
+
+func request(router *gin.Engine, method string, path string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func bearer(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	config.JWT.Secret = "secret"
+	t.Cleanup(func() {
+		config.JWT.Secret = ""
+	})
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	return "Bearer " + token
+}
+
+func fixedLimit(limit int) RateLimitConfig {
+	return RateLimitConfig{Limit: limit, TTL: time.Minute, Mode: GlobalLimiter, Algorithm: cache.FixedWindow}
+}
+
+func TestRateLimitPolicyHandler_Limit(t *testing.T) {
+	router := newPolicyRouter(t, memory.NewClient(context.Background()), RateLimitPolicies{
+		Policies: []RateLimitPolicy{{Name: "create", Route: "POST /api/v1/orders", Limits: []RateLimitConfig{fixedLimit(2)}}},
+	})
+
+	first := request(router, http.MethodPost, "/api/v1/orders", nil)
+	request(router, http.MethodPost, "/api/v1/orders", nil)
+	refused := request(router, http.MethodPost, "/api/v1/orders", nil)
+	other := request(router, http.MethodGet, "/api/v1/orders/1", nil)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, http.StatusTooManyRequests, refused.Code)
+	assert.JSONEq(t, `{"message":"rate limit exceeded, please try again later"}`, refused.Body.String())
+	assert.NotEmpty(t, refused.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, other.Code)
+}
+
+func TestRateLimitPolicyHandler_Refund(t *testing.T) {
+	cacheClient := memory.NewClient(context.Background())
+	router := newPolicyRouter(t, cacheClient, RateLimitPolicies{
+		Policies: []RateLimitPolicy{
+			{Name: "global", Limits: []RateLimitConfig{fixedLimit(10)}},
+			{Name: "create", Route: "POST /api/v1/orders", Limits: []RateLimitConfig{fixedLimit(1)}},
+		},
+	})
+
+	request(router, http.MethodPost, "/api/v1/orders", nil)
+	for range 3 {
+		refused := request(router, http.MethodPost, "/api/v1/orders", nil)
+		assert.Equal(t, http.StatusTooManyRequests, refused.Code)
+	}
+
+	// Only the request that went through counts against the global limit.
+	w := request(router, http.MethodGet, "/api/v1/orders/1", nil)
+	assert.Equal(t, "8", w.Header().Get("RateLimit-Remaining"))
+}
+
+func TestRateLimitPolicyHandler_Quota(t *testing.T) {
+	router := newPolicyRouter(t, memory.NewClient(context.Background()), RateLimitPolicies{
+		Policies: []RateLimitPolicy{{Name: "daily", Quotas: []Quota{{Limit: 1, Per: QuotaDaily}}}},
+	})
+
+	request(router, http.MethodGet, "/api/v1/orders/1", nil)
+	refused := request(router, http.MethodGet, "/api/v1/orders/1", nil)
+
+	assert.Equal(t, http.StatusTooManyRequests, refused.Code)
+	assert.JSONEq(t, `{"message":"daily quota exceeded"}`, refused.Body.String())
+}
+
+func TestRateLimitPolicyHandler_Keys(t *testing.T) {
+	router := newPolicyRouter(t, memory.NewClient(context.Background()), RateLimitPolicies{
+		Policies: []RateLimitPolicy{{Name: "global", Key: KeyByAuto, Limits: []RateLimitConfig{fixedLimit(1)}}},
+		APIKeys:  []string{"key-1"},
+	})
+
+	alice := map[string]string{"Authorization": bearer(t, jwt.MapClaims{"sub": "alice"})}
+	bob := map[string]string{"Authorization": bearer(t, jwt.MapClaims{"sub": "bob"})}
+	apiKey := map[string]string{"X-API-Key": "key-1"}
+
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/api/v1/orders/1", alice).Code)
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/api/v1/orders/1", bob).Code)
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/api/v1/orders/1", apiKey).Code)
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/api/v1/orders/1", nil).Code)
+	assert.Equal(t, http.StatusTooManyRequests, request(router, http.MethodGet, "/api/v1/orders/1", alice).Code)
+	assert.Equal(t, http.StatusTooManyRequests, request(router, http.MethodGet, "/api/v1/orders/1", apiKey).Code)
+	assert.Equal(t, http.StatusTooManyRequests, request(router, http.MethodGet, "/api/v1/orders/1", nil).Code)
+}
+
+func TestRateLimitPolicyHandler_UnknownAPIKey(t *testing.T) {
+	router := newPolicyRouter(t, memory.NewClient(context.Background()), RateLimitPolicies{
+		Policies: []RateLimitPolicy{{Name: "global", Key: KeyByAPIKey, Limits: []RateLimitConfig{fixedLimit(1)}}},
+		APIKeys:  []string{"key-1"},
+	})
+
+	// Made up keys count against the client IP instead of a fresh counter.
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/api/v1/orders/1", map[string]string{"X-API-Key": "made-up-1"}).Code)
+	assert.Equal(t, http.StatusTooManyRequests, request(router, http.MethodGet, "/api/v1/orders/1", map[string]string{"X-API-Key": "made-up-2"}).Code)
+	assert.Equal(t, http.StatusTooManyRequests, request(router, http.MethodGet, "/api/v1/orders/1", nil).Code)
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/api/v1/orders/1", map[string]string{"X-API-Key": "key-1"}).Code)
+}
+
+func TestRateLimitPolicyHandler_Tier(t *testing.T) {
+	router := newPolicyRouter(t, memory.NewClient(context.Background()), RateLimitPolicies{
+		Policies: []RateLimitPolicy{{Name: "free", Tier: "free", Limits: []RateLimitConfig{fixedLimit(1)}}},
+	})
+
+	pro := map[string]string{"Authorization": bearer(t, jwt.MapClaims{"sub": "alice", "tier": "pro"})}
+
+	request(router, http.MethodGet, "/api/v1/orders/1", nil)
+	assert.Equal(t, http.StatusTooManyRequests, request(router, http.MethodGet, "/api/v1/orders/1", nil).Code)
+	request(router, http.MethodGet, "/api/v1/orders/1", pro)
+	assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/api/v1/orders/1", pro).Code)
+}
+
+func TestRateLimitPolicyHandler_AllowList(t *testing.T) {
+	router := newPolicyRouter(t, memory.NewClient(context.Background()), RateLimitPolicies{
+		Policies:  []RateLimitPolicy{{Name: "global", Limits: []RateLimitConfig{fixedLimit(1)}}},
+		AllowList: []string{"203.0.113.0/24"},
+	})
+
+	for range 3 {
+		w := request(router, http.MethodGet, "/api/v1/orders/1", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimitPolicy_Matches(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name  string
+		route string
+		tier  string
+		match bool
+	}{
+		{name: "any route", route: "", match: true},
+		{name: "exact route", route: "POST /api/v1/orders", match: true},
+		{name: "other method", route: "GET /api/v1/orders", match: false},
+		{name: "any method", route: "* /api/v1/orders", match: true},
+		{name: "without method", route: "/api/v1/orders", match: true},
+		{name: "lowercase method", route: "post /api/v1/orders", match: true},
+		{name: "prefix", route: "* /api/v1/*", match: true},
+		{name: "other prefix", route: "* /api/v2/*", match: false},
+		{name: "same tier", route: "", tier: "free", match: true},
+		{name: "other tier", route: "", tier: "pro", match: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+
+			policy := RateLimitPolicy{Route: tc.route, Tier: tc.tier}
+
+			assert.Equal(t, tc.match, policy.matches(c, "free"))
+		})
+	}
+}
+
+func TestRateLimitPolicy_KeyOf(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("X-Tenant-ID", "acme")
+
+	subject := rateLimitSubject{ip: "203.0.113.7", principal: "alice", apiKey: "key-1"}
+	anonymous := rateLimitSubject{ip: "203.0.113.7"}
+
+	assert.Equal(t, "principal:alice", RateLimitPolicy{Key: KeyByAuto}.keyOf(c, subject))
+	assert.Equal(t, "api_key:"+hashKey("key-1"), RateLimitPolicy{Key: KeyByAuto}.keyOf(c, rateLimitSubject{ip: "203.0.113.7", apiKey: "key-1"}))
+	assert.Equal(t, "ip:203.0.113.7", RateLimitPolicy{Key: KeyByAuto}.keyOf(c, anonymous))
+	assert.Equal(t, "ip:203.0.113.7", RateLimitPolicy{Key: KeyByIP}.keyOf(c, subject))
+	assert.Equal(t, "api_key:"+hashKey("key-1"), RateLimitPolicy{Key: KeyByAPIKey}.keyOf(c, subject))
+	assert.Equal(t, "header:"+hashKey("acme"), RateLimitPolicy{Key: KeyByHeader, Header: "X-Tenant-ID"}.keyOf(c, subject))
+	assert.Equal(t, "ip:203.0.113.7", RateLimitPolicy{Key: KeyByPrincipal}.keyOf(c, anonymous))
+	assert.NotContains(t, RateLimitPolicy{Key: KeyByAPIKey}.keyOf(c, subject), "key-1")
+}
+
+func TestAllowList_Contains(t *testing.T) {
+	allow := newAllowList([]string{"alice", " key-1 ", "10.0.0.0/8", "192.0.2.1", "2001:db8::/32", ""})
+
+	assert.True(t, allow.contains(rateLimitSubject{principal: "alice"}))
+	assert.True(t, allow.contains(rateLimitSubject{apiKey: "key-1"}))
+	assert.True(t, allow.contains(rateLimitSubject{ip: "10.1.2.3"}))
+	assert.True(t, allow.contains(rateLimitSubject{ip: "192.0.2.1"}))
+	assert.True(t, allow.contains(rateLimitSubject{ip: "2001:db8::1"}))
+	assert.False(t, allow.contains(rateLimitSubject{ip: "192.0.2.2"}))
+	assert.False(t, allow.contains(rateLimitSubject{principal: "bob", ip: "not-an-ip"}))
+}
+
+func TestQuotaWindow(t *testing.T) {
+	now := time.Date(2026, time.February, 14, 15, 4, 5, 0, time.UTC)
+
+	day, dayEnd := quotaWindow(now, QuotaDaily)
+	month, monthEnd := quotaWindow(now, QuotaMonthly)
+
+	assert.Equal(t, "20260214", day)
+	assert.Equal(t, time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC), dayEnd)
+	assert.Equal(t, "202602", month)
+	assert.Equal(t, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), monthEnd)
+}
+
+func TestLoadRateLimitPolicies(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policies.json")
+	err := os.WriteFile(file, []byte(`{
+		"policies": [{
+			"name": "pro",
+			"route": "* /api/v1/*",
+			"tier": "pro",
+			"key": "principal",
+			"limits": [{"limit": 100, "period": "1m", "algorithm": "gcra", "burst": 10}],
+			"quotas": [{"limit": 10000, "per": "month"}]
+		}],
+		"allow_list": ["10.0.0.0/8"]
+	}`), 0o600)
+	assert.NoError(t, err)
+
+	config.RateLimiter.PolicyFile = file
+	config.RateLimiter.AllowList = []string{"alice"}
+	t.Cleanup(func() {
+		config.RateLimiter.PolicyFile = ""
+		config.RateLimiter.AllowList = nil
+	})
+
+	policies, err := LoadRateLimitPolicies()
+
+	assert.NoError(t, err)
+	assert.Equal(t, RateLimitPolicies{
+		Policies: []RateLimitPolicy{{
+			Name:   "pro",
+			Route:  "* /api/v1/*",
+			Tier:   "pro",
+			Key:    KeyByPrincipal,
+			Limits: []RateLimitConfig{{Limit: 100, TTL: time.Minute, Burst: 10, Algorithm: cache.GCRA}},
+			Quotas: []Quota{{Limit: 10000, Per: QuotaMonthly}},
+		}},
+		AllowList: []string{"10.0.0.0/8", "alice"},
+	}, policies)
+}
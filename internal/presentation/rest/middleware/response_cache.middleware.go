@@ -0,0 +1,349 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/tracer"
+)
+
+// refreshLockMinTTL bounds how long the refresh of a stale response stays
+// locked when config.ContextTimeout is shorter or unset, so a request
+// dying before it releases the lock does not keep the response stale.
+const refreshLockMinTTL = 10 * time.Second
+
+// ResponseCachePolicy tells how the GET responses of a route are cached.
+// Zero fields take the defaults of config.ResponseCache.
+type ResponseCachePolicy struct {
+	// TTL is how long a response is fresh, unless it sets its own max-age.
+	TTL time.Duration
+	// StaleWhileRevalidate is how long past TTL a response is still served
+	// while a single request refreshes it.
+	StaleWhileRevalidate time.Duration
+	// Vary lists the request headers the response depends on.
+	Vary []string
+	// Tags returns the tags of the response, e.g. "order:42", which
+	// usecases purge through a cache.TagPurger after changing what it shows.
+	Tags func(c *gin.Context) []string
+}
+
+// cachedResponse is a response stored by ResponseCacheHandler, with the
+// validators and freshness sent along with it.
+type cachedResponse struct {
+	Status               int           `json:"status"`
+	Header               http.Header   `json:"header"`
+	Body                 []byte        `json:"body"`
+	ETag                 string        `json:"etag"`
+	LastModified         time.Time     `json:"last_modified"`
+	StoredAt             time.Time     `json:"stored_at"`
+	MaxAge               time.Duration `json:"max_age"`
+	StaleWhileRevalidate time.Duration `json:"stale_while_revalidate"`
+}
+
+// ResponseCacheHandler caches the successful GET responses of a route in
+// the cache, keyed by route, query, the Vary headers of the policy, tenant
+// and caller, so that every instance serves them. Responses carry an ETag
+// and Last-Modified, answering If-None-Match and If-Modified-Since with
+// 304, and a Cache-Control with their max-age unless the handler set its
+// own; no-store, no-cache and private responses, and those setting a
+// cookie, are not stored. Requests sending Cache-Control: no-store skip
+// the cache, and no-cache or max-age make it refresh the response. Once
+// stale, a response is still served for StaleWhileRevalidate to every
+// request but one, which refreshes it. The X-Cache header tells whether a
+// response was a HIT, STALE or a MISS.
+func ResponseCacheHandler(cacheClient cache.Cache, policy ResponseCachePolicy) gin.HandlerFunc {
+	if policy.TTL == 0 {
+		policy.TTL = config.ResponseCache.TTL
+	}
+	if policy.StaleWhileRevalidate == 0 {
+		policy.StaleWhileRevalidate = config.ResponseCache.StaleWhileRevalidate
+	}
+	if policy.Vary == nil {
+		policy.Vary = config.ResponseCache.Vary
+	}
+
+	return func(c *gin.Context) {
+		var err error
+
+		directives := cacheControl(c.GetHeader("Cache-Control"))
+		if _, noStore := directives["no-store"]; noStore || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		ctx, span := tracer.Start(c.Request.Context())
+		defer func() {
+			span.End(err)
+		}()
+
+		scope := responseCacheScope(c)
+
+		// The cache is an optimization, so the handler answers when it is
+		// unreachable.
+		key, err := responseCacheKey(c, cacheClient, policy, scope)
+		if err != nil {
+			logger.Warnf(ctx, "⚠️ Failed to look up the cached response: %v", err).Write()
+			c.Next()
+			return
+		}
+
+		res, err := cache.GetJSON[cachedResponse](ctx, cacheClient, key)
+		if err != nil {
+			logger.Warnf(ctx, "⚠️ Failed to look up the cached response: %v", err).Write()
+		}
+
+		var age time.Duration
+		if res != nil {
+			age = time.Since(res.StoredAt)
+		}
+
+		if res != nil && acceptsCached(directives, age) {
+			if age < res.MaxAge {
+				writeCachedResponse(c, res, policy, scope, "HIT")
+				return
+			}
+
+			if age < res.MaxAge+res.StaleWhileRevalidate {
+				var refreshing bool
+				refreshing, err = cacheClient.SetNX(ctx, key+":refresh", 1, max(config.ContextTimeout, refreshLockMinTTL))
+				if err == nil && !refreshing {
+					writeCachedResponse(c, res, policy, scope, "STALE")
+					return
+				}
+
+				defer func() {
+					if delErr := cacheClient.Del(ctx, key+":refresh"); delErr != nil && err == nil {
+						err = delErr
+					}
+				}()
+			}
+		}
+
+		before := c.Writer.Header().Clone()
+
+		writer := c.Writer
+		blw := &responseWriter{
+			body:           bytes.NewBufferString(""),
+			ResponseWriter: writer,
+		}
+
+		c.Writer = blw
+		c.Next()
+		c.Writer = writer
+
+		// Errors are rendered by ErrorHandler, with their own status, once
+		// the chain returns.
+		if len(c.Errors) > 0 {
+			return
+		}
+
+		res, store := newCachedResponse(blw, before, policy)
+		if !store {
+			_, err = writer.Write(blw.body.Bytes())
+			if err != nil {
+				c.Error(err)
+			}
+			return
+		}
+
+		err = cache.SetJSON(ctx, cacheClient, key, *res, res.MaxAge+res.StaleWhileRevalidate)
+		if err != nil {
+			logger.Warnf(ctx, "⚠️ Failed to cache the response: %v", err).Write()
+		}
+
+		writeCachedResponse(c, res, policy, scope, "MISS")
+	}
+}
+
+// newCachedResponse returns the response written to blw, reporting whether
+// it may be stored.
+func newCachedResponse(blw *responseWriter, before http.Header, policy ResponseCachePolicy) (*cachedResponse, bool) {
+	header := headerChanges(before, blw.Header())
+	body := blw.body.Bytes()
+
+	res := &cachedResponse{
+		Status:               blw.Status(),
+		Header:               header,
+		Body:                 body,
+		ETag:                 header.Get("ETag"),
+		StoredAt:             time.Now(),
+		MaxAge:               policy.TTL,
+		StaleWhileRevalidate: policy.StaleWhileRevalidate,
+	}
+
+	if res.ETag == "" {
+		sum := sha256.Sum256(body)
+		res.ETag = `"` + hex.EncodeToString(sum[:]) + `"`
+	}
+
+	res.LastModified, _ = http.ParseTime(header.Get("Last-Modified"))
+	if res.LastModified.IsZero() {
+		res.LastModified = res.StoredAt.UTC().Truncate(time.Second)
+	}
+
+	directives := cacheControl(header.Get("Cache-Control"))
+	for _, name := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := directives[name]; ok {
+			return res, false
+		}
+	}
+
+	if maxAge, ok := directives["s-maxage"]; ok {
+		res.MaxAge = directiveSeconds(maxAge)
+	} else if maxAge, ok := directives["max-age"]; ok {
+		res.MaxAge = directiveSeconds(maxAge)
+	}
+	if swr, ok := directives["stale-while-revalidate"]; ok {
+		res.StaleWhileRevalidate = directiveSeconds(swr)
+	}
+
+	store := res.Status == http.StatusOK &&
+		header.Get("Set-Cookie") == "" &&
+		res.MaxAge+res.StaleWhileRevalidate > 0
+
+	return res, store
+}
+
+// writeCachedResponse answers the request with res, or with 304 when the
+// client already holds it.
+func writeCachedResponse(c *gin.Context, res *cachedResponse, policy ResponseCachePolicy, scope string, status string) {
+	header := c.Writer.Header()
+	for name, values := range res.Header {
+		header[name] = values
+	}
+
+	header.Set("ETag", res.ETag)
+	header.Set("Last-Modified", res.LastModified.UTC().Format(http.TimeFormat))
+	header.Set("Age", strconv.Itoa(int(time.Since(res.StoredAt).Seconds())))
+	header.Set("X-Cache", status)
+
+	if res.Header.Get("Cache-Control") == "" {
+		visibility := "public"
+		if _, ok := database.TenantFromContext(c.Request.Context()); ok || scope != "public" {
+			visibility = "private"
+		}
+		header.Set("Cache-Control", fmt.Sprintf("%s, max-age=%d, stale-while-revalidate=%d",
+			visibility, int(res.MaxAge.Seconds()), int(res.StaleWhileRevalidate.Seconds())))
+	}
+	if res.Header.Get("Vary") == "" && len(policy.Vary) > 0 {
+		header.Set("Vary", strings.Join(policy.Vary, ", "))
+	}
+
+	c.Abort()
+
+	if notModified(c.Request, res) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(res.Status, res.Header.Get("Content-Type"), res.Body)
+}
+
+// notModified reports whether the validators of the request match res.
+// If-Modified-Since is only used without If-None-Match, as RFC 9110 asks.
+func notModified(r *http.Request, res *cachedResponse) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, etag := range strings.Split(ifNoneMatch, ",") {
+			etag = strings.TrimSpace(etag)
+			if etag == "*" || strings.TrimPrefix(etag, "W/") == strings.TrimPrefix(res.ETag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !res.LastModified.After(ifModifiedSince)
+}
+
+// acceptsCached reports whether the Cache-Control directives of the
+// request let it be answered with a response of the given age.
+func acceptsCached(directives map[string]string, age time.Duration) bool {
+	if _, noCache := directives["no-cache"]; noCache {
+		return false
+	}
+	if maxAge, ok := directives["max-age"]; ok {
+		return age <= directiveSeconds(maxAge)
+	}
+
+	return true
+}
+
+// responseCacheKey returns the key of the response to the request. The
+// version of its tags moves it to a new key once any of them is purged.
+func responseCacheKey(c *gin.Context, cacheClient cache.Cache, policy ResponseCachePolicy, scope string) (string, error) {
+	var tags []string
+	if policy.Tags != nil {
+		tags = policy.Tags(c)
+	}
+
+	version, err := cache.TagVersion(c.Request.Context(), cacheClient, tags...)
+	if err != nil {
+		return "", err
+	}
+
+	tenant, _ := database.TenantFromContext(c.Request.Context())
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", c.Request.URL.Path, c.Request.URL.Query().Encode())
+	for _, name := range policy.Vary {
+		fmt.Fprintf(hash, "%s: %s\n", http.CanonicalHeaderKey(name), strings.Join(c.Request.Header.Values(name), ", "))
+	}
+	fmt.Fprintf(hash, "%s\n%s\n", tenant, version)
+
+	return fmt.Sprintf("http_cache:%s:%s:%s", scope, c.FullPath(), hex.EncodeToString(hash.Sum(nil)[:16])), nil
+}
+
+// responseCacheScope returns who a response is cached for: anyone when the
+// request carries no credentials, only the caller presenting them
+// otherwise.
+func responseCacheScope(c *gin.Context) string {
+	if principal, _ := bearerClaims(c)[config.RateLimiter.PrincipalClaim].(string); principal != "" {
+		return "principal:" + principal
+	}
+
+	if apiKey := hashKey(c.GetHeader(config.RateLimiter.APIKeyHeader)); apiKey != "" {
+		return "api_key:" + apiKey
+	}
+
+	if authorization := hashKey(c.GetHeader("Authorization")); authorization != "" {
+		return "authorization:" + authorization
+	}
+
+	return "public"
+}
+
+// cacheControl parses a Cache-Control header into its directives, mapped
+// to their arguments.
+func cacheControl(header string) map[string]string {
+	directives := map[string]string{}
+	for _, directive := range strings.Split(header, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if name == "" {
+			continue
+		}
+		directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+	}
+
+	return directives
+}
+
+func directiveSeconds(arg string) time.Duration {
+	seconds, err := strconv.Atoi(arg)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,284 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/memory"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	htterror "github.com/goodone-dev/go-boilerplate/internal/utils/http_response/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResponseCacheRouter(t *testing.T, policy ResponseCachePolicy, handler gin.HandlerFunc) (*gin.Engine, cache.Cache) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cacheClient := memory.NewClient(context.Background())
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/orders/:id", ResponseCacheHandler(cacheClient, policy), handler)
+
+	return router, cacheClient
+}
+
+func serve(router *gin.Engine, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/orders/42?b=2&a=1", nil)
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func countingHandler(calls *atomic.Int32) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		n := calls.Add(1)
+		c.JSON(http.StatusOK, gin.H{"call": n, "lang": c.GetHeader("Accept-Language")})
+	}
+}
+
+func TestResponseCacheHandler_MissThenHit(t *testing.T) {
+	var calls atomic.Int32
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Minute}, countingHandler(&calls))
+
+	miss := serve(router, nil)
+	hit := serve(router, nil)
+
+	assert.Equal(t, http.StatusOK, miss.Code)
+	assert.Equal(t, "MISS", miss.Header().Get("X-Cache"))
+	assert.Equal(t, http.StatusOK, hit.Code)
+	assert.Equal(t, "HIT", hit.Header().Get("X-Cache"))
+	assert.Equal(t, miss.Body.String(), hit.Body.String())
+	assert.Equal(t, miss.Header().Get("ETag"), hit.Header().Get("ETag"))
+	assert.Contains(t, hit.Header().Get("Cache-Control"), "public, max-age=60")
+	assert.NotEmpty(t, hit.Header().Get("Last-Modified"))
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestResponseCacheHandler_Vary(t *testing.T) {
+	var calls atomic.Int32
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Minute, Vary: []string{"Accept-Language"}}, countingHandler(&calls))
+
+	en := serve(router, map[string]string{"Accept-Language": "en"})
+	fr := serve(router, map[string]string{"Accept-Language": "fr"})
+	enAgain := serve(router, map[string]string{"Accept-Language": "en"})
+
+	assert.Equal(t, "MISS", en.Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", fr.Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", enAgain.Header().Get("X-Cache"))
+	assert.Equal(t, "Accept-Language", enAgain.Header().Get("Vary"))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestResponseCacheHandler_NotModified(t *testing.T) {
+	var calls atomic.Int32
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Minute}, countingHandler(&calls))
+
+	first := serve(router, nil)
+
+	cases := []struct {
+		name   string
+		header map[string]string
+		status int
+	}{
+		{name: "matching etag", header: map[string]string{"If-None-Match": first.Header().Get("ETag")}, status: http.StatusNotModified},
+		{name: "weak etag", header: map[string]string{"If-None-Match": `"other", W/` + first.Header().Get("ETag")}, status: http.StatusNotModified},
+		{name: "other etag", header: map[string]string{"If-None-Match": `"other"`}, status: http.StatusOK},
+		{name: "not modified since", header: map[string]string{"If-Modified-Since": first.Header().Get("Last-Modified")}, status: http.StatusNotModified},
+		{name: "modified since", header: map[string]string{"If-Modified-Since": time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)}, status: http.StatusOK},
+		{name: "etag wins over date", header: map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": first.Header().Get("Last-Modified")}, status: http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(router, tc.header)
+
+			assert.Equal(t, tc.status, w.Code)
+			if tc.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestResponseCacheHandler_RequestCacheControl(t *testing.T) {
+	var calls atomic.Int32
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Minute}, countingHandler(&calls))
+
+	serve(router, nil)
+	noStore := serve(router, map[string]string{"Cache-Control": "no-store"})
+	noCache := serve(router, map[string]string{"Cache-Control": "no-cache"})
+	hit := serve(router, nil)
+
+	assert.Empty(t, noStore.Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", noCache.Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", hit.Header().Get("X-Cache"))
+	assert.Equal(t, noCache.Body.String(), hit.Body.String())
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestResponseCacheHandler_ResponseCacheControl(t *testing.T) {
+	var calls atomic.Int32
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Minute}, func(c *gin.Context) {
+		calls.Add(1)
+		c.Header("Cache-Control", "private, max-age=60")
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	serve(router, nil)
+	w := serve(router, nil)
+
+	assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestResponseCacheHandler_Error(t *testing.T) {
+	var calls atomic.Int32
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Minute}, func(c *gin.Context) {
+		calls.Add(1)
+		c.Error(htterror.NewNotFoundError("order with the provided ID was not found"))
+	})
+
+	first := serve(router, nil)
+	second := serve(router, nil)
+
+	assert.Equal(t, http.StatusNotFound, first.Code)
+	assert.JSONEq(t, `{"message":"order with the provided ID was not found"}`, first.Body.String())
+	assert.Equal(t, http.StatusNotFound, second.Code)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestResponseCacheHandler_NonOKStatus(t *testing.T) {
+	var calls atomic.Int32
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Minute}, func(c *gin.Context) {
+		calls.Add(1)
+		c.JSON(http.StatusAccepted, gin.H{"status": "pending"})
+	})
+
+	first := serve(router, nil)
+	serve(router, nil)
+
+	assert.Equal(t, http.StatusAccepted, first.Code)
+	assert.JSONEq(t, `{"status":"pending"}`, first.Body.String())
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestResponseCacheHandler_Stale(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Second, StaleWhileRevalidate: time.Minute}, func(c *gin.Context) {
+		if calls.Add(1) > 1 {
+			<-release
+		}
+		c.JSON(http.StatusOK, gin.H{"call": calls.Load()})
+	})
+
+	first := serve(router, nil)
+	time.Sleep(1100 * time.Millisecond)
+
+	// The first request after expiry refreshes the response, and the
+	// others are served the stale one meanwhile.
+	var wg sync.WaitGroup
+	var refreshed *httptest.ResponseRecorder
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		refreshed = serve(router, nil)
+	}()
+
+	assert.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, 10*time.Millisecond)
+
+	stale := serve(router, nil)
+	close(release)
+	wg.Wait()
+
+	hit := serve(router, nil)
+
+	assert.Equal(t, "STALE", stale.Header().Get("X-Cache"))
+	assert.Equal(t, first.Body.String(), stale.Body.String())
+	assert.Equal(t, "MISS", refreshed.Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", hit.Header().Get("X-Cache"))
+	assert.Equal(t, refreshed.Body.String(), hit.Body.String())
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestResponseCacheHandler_PurgeTags(t *testing.T) {
+	var calls atomic.Int32
+	router, cacheClient := newResponseCacheRouter(t, ResponseCachePolicy{
+		TTL: time.Minute,
+		Tags: func(c *gin.Context) []string {
+			return []string{"order:" + c.Param("id")}
+		},
+	}, countingHandler(&calls))
+
+	serve(router, nil)
+	assert.NoError(t, cache.NewTagPurger(cacheClient).PurgeTags(context.Background(), "order:7"))
+	unrelated := serve(router, nil)
+	assert.NoError(t, cache.NewTagPurger(cacheClient).PurgeTags(context.Background(), "order:42"))
+	purged := serve(router, nil)
+
+	assert.Equal(t, "HIT", unrelated.Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", purged.Header().Get("X-Cache"))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestResponseCacheHandler_Scope(t *testing.T) {
+	var calls atomic.Int32
+	router, _ := newResponseCacheRouter(t, ResponseCachePolicy{TTL: time.Minute}, countingHandler(&calls))
+
+	anonymous := serve(router, nil)
+	alice := serve(router, map[string]string{"Authorization": "Bearer alice"})
+	bob := serve(router, map[string]string{"Authorization": "Bearer bob"})
+
+	assert.Equal(t, "MISS", anonymous.Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", alice.Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", bob.Header().Get("X-Cache"))
+	assert.Contains(t, alice.Header().Get("Cache-Control"), "private")
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestResponseCacheHandler_Tenant(t *testing.T) {
+	var calls atomic.Int32
+	cacheClient := memory.NewClient(context.Background())
+
+	router := gin.New()
+	router.Use(ErrorHandler(), func(c *gin.Context) {
+		c.Request = c.Request.WithContext(database.WithTenant(c.Request.Context(), c.GetHeader("X-Tenant-ID")))
+	})
+	router.GET("/orders/:id", ResponseCacheHandler(cacheClient, ResponseCachePolicy{TTL: time.Minute}), countingHandler(&calls))
+
+	acme := serve(router, map[string]string{"X-Tenant-ID": "acme"})
+	globex := serve(router, map[string]string{"X-Tenant-ID": "globex"})
+
+	assert.Equal(t, "MISS", globex.Header().Get("X-Cache"))
+	assert.True(t, strings.HasPrefix(acme.Header().Get("Cache-Control"), "private"))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestCacheControl(t *testing.T) {
+	directives := cacheControl(`public, Max-Age=60, stale-while-revalidate="30", no-transform`)
+
+	assert.Equal(t, map[string]string{
+		"public":                 "",
+		"max-age":                "60",
+		"stale-while-revalidate": "30",
+		"no-transform":           "",
+	}, directives)
+	assert.Equal(t, time.Minute, directiveSeconds(directives["max-age"]))
+	assert.Zero(t, directiveSeconds("-1"))
+}
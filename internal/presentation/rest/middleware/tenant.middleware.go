@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	htterror "github.com/goodone-dev/go-boilerplate/internal/utils/http_response/error"
+)
+
+type TenantSource string
+
+const (
+	TenantFromHeader    TenantSource = "header"
+	TenantFromSubdomain TenantSource = "subdomain"
+	TenantFromJWT       TenantSource = "jwt"
+)
+
+// TenantHandler resolves the tenant of the request from the configured
+// sources, in order, and carries it in the request context where
+// repositories pick it up. Requests without a tenant are rejected whenever
+// a tenant mode is set.
+func TenantHandler() gin.HandlerFunc {
+	if database.CurrentTenantMode() == "" {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	for _, source := range config.Tenant.Sources {
+		if TenantSource(strings.TrimSpace(source)) == TenantFromJWT && config.JWT.Secret == "" {
+			logger.Warn(context.Background(), "⚠️ JWT_SECRET is empty, tenants are not resolved from bearer tokens").Write()
+		}
+	}
+
+	return func(c *gin.Context) {
+		tenant := resolveTenant(c)
+		if tenant == "" {
+			c.Error(htterror.NewBadRequestError("tenant is required"))
+			c.Abort()
+			return
+		}
+
+		if !database.ValidTenant(tenant) {
+			c.Error(htterror.NewBadRequestError("invalid tenant"))
+			c.Abort()
+			return
+		}
+
+		ctx := database.WithTenant(c.Request.Context(), tenant)
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func resolveTenant(c *gin.Context) string {
+	for _, source := range config.Tenant.Sources {
+		var tenant string
+
+		switch TenantSource(strings.TrimSpace(source)) {
+		case TenantFromHeader:
+			tenant = c.GetHeader(config.Tenant.Header)
+		case TenantFromSubdomain:
+			tenant = tenantFromSubdomain(c.Request.Host)
+		case TenantFromJWT:
+			tenant, _ = bearerClaims(c)[config.Tenant.JWTClaim].(string)
+		}
+
+		if tenant = strings.TrimSpace(tenant); tenant != "" {
+			return tenant
+		}
+	}
+
+	return ""
+}
+
+// tenantFromSubdomain returns the label of host right under the base
+// domain, e.g. "acme" for acme.goodmart.com or api.acme.goodmart.com.
+func tenantFromSubdomain(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	base := strings.TrimPrefix(config.Tenant.BaseDomain, ".")
+	if base == "" {
+		return ""
+	}
+
+	sub, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(base))
+	if !ok || sub == "" {
+		return ""
+	}
+
+	labels := strings.Split(sub, ".")
+	return labels[len(labels)-1]
+}
+
+// bearerClaims returns the claims of the bearer token of the request when
+// it is signed with the configured HMAC secret. Tokens that fail
+// verification carry no claims.
+func bearerClaims(c *gin.Context) jwt.MapClaims {
+	if config.JWT.Secret == "" {
+		return nil
+	}
+
+	raw, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok {
+		return nil
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(*jwt.Token) (any, error) {
+		return []byte(config.JWT.Secret), nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	if err != nil {
+		return nil
+	}
+
+	return claims
+}
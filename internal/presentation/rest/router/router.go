@@ -15,7 +15,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-func NewRouter(healthHandler health.HealthHandler, orderHandler order.OrderHandler, cacheClient cache.Cache) *gin.Engine {
+func NewRouter(healthHandler health.HealthHandler, orderHandler order.OrderHandler, cacheClient cache.Cache, rateLimitPolicies middleware.RateLimitPolicies) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 
 	// ========== Middleware Config ==========
@@ -43,8 +43,9 @@ func NewRouter(healthHandler health.HealthHandler, orderHandler order.OrderHandl
 	// Internal Middleware
 	router.Use(middleware.ContextTimeoutHandler())
 	router.Use(middleware.RequestIdHandler())
-	router.Use(middleware.IdempotencyHandler(cacheClient, config.IdempotencyDuration))
 	router.Use(middleware.ErrorHandler())
+	router.Use(middleware.ConsistencyHandler())
+	router.Use(middleware.AuditHandler())
 
 	router.Use(gin.Recovery())
 
@@ -65,19 +66,22 @@ func NewRouter(healthHandler health.HealthHandler, orderHandler order.OrderHandl
 		debug.GET("/trace", gin.WrapF(pprof.Trace))
 	}
 
-	v1 := router.Group("/api/v1")
+	// Probes and profiling need no tenant, and are neither rate limited nor
+	// idempotent.
+	v1 := router.Group("/api/v1",
+		middleware.TenantHandler(),
+		middleware.RateLimitPolicyHandler(cacheClient, rateLimitPolicies),
+		middleware.IdempotencyHandler(cacheClient),
+	)
 	{
 		orders := v1.Group("/orders")
 		{
-			orders.POST(
-				"",
-				middleware.RateLimiterHandler(cacheClient, middleware.RateLimitConfig{
-					Limit: config.RateLimiter.SingleLimit,
-					TTL:   config.RateLimiter.SingleDuration,
-					Mode:  middleware.SingleLimiter,
-				}),
-				orderHandler.Create,
-			)
+			orders.POST("", orderHandler.Create)
+			orders.GET("/:id", middleware.ResponseCacheHandler(cacheClient, middleware.ResponseCachePolicy{
+				Tags: func(c *gin.Context) []string {
+					return []string{"order:" + c.Param("id")}
+				},
+			}), orderHandler.GetById)
 		}
 	}
 
@@ -0,0 +1,140 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/cache/memory"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/goodone-dev/go-boilerplate/internal/presentation/rest/middleware"
+	htterror "github.com/goodone-dev/go-boilerplate/internal/utils/http_response/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	config.ContextTimeout = 5 * time.Second
+	config.Cors.AllowOrigins = []string{"*"}
+	config.ResponseCache.TTL = time.Minute
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+type healthHandler struct{}
+
+func (healthHandler) LiveCheck(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (healthHandler) ReadyCheck(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+type orderHandler struct{}
+
+func (orderHandler) Create(c *gin.Context) {
+	c.JSON(http.StatusCreated, gin.H{})
+}
+
+func (orderHandler) GetById(c *gin.Context) {
+	if c.Param("id") == "missing" {
+		c.Error(htterror.NewNotFoundError("order with the provided ID was not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+}
+
+func newTestRouter(policies middleware.RateLimitPolicies) (*gin.Engine, cache.Cache) {
+	cacheClient := memory.NewClient(context.Background())
+
+	return NewRouter(healthHandler{}, orderHandler{}, cacheClient, policies), cacheClient
+}
+
+func serve(router *gin.Engine, method string, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+
+	return w
+}
+
+func limitOne() middleware.RateLimitPolicies {
+	return middleware.RateLimitPolicies{
+		Policies: []middleware.RateLimitPolicy{{
+			Name: "global",
+			Limits: []middleware.RateLimitConfig{{
+				Limit:     1,
+				TTL:       time.Minute,
+				Mode:      middleware.GlobalLimiter,
+				Algorithm: cache.FixedWindow,
+			}},
+		}},
+	}
+}
+
+func TestNewRouter_RateLimitRendered(t *testing.T) {
+	router, _ := newTestRouter(limitOne())
+
+	first := serve(router, http.MethodPost, "/api/v1/orders")
+	refused := serve(router, http.MethodPost, "/api/v1/orders")
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, refused.Code)
+	assert.JSONEq(t, `{"message":"rate limit exceeded, please try again later"}`, refused.Body.String())
+}
+
+func TestNewRouter_ProbesNotRateLimited(t *testing.T) {
+	router, _ := newTestRouter(limitOne())
+
+	for range 3 {
+		assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/health").Code)
+		assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/health/ready").Code)
+	}
+}
+
+func TestNewRouter_ErrorOnCachedRoute(t *testing.T) {
+	router, _ := newTestRouter(middleware.RateLimitPolicies{})
+
+	missing := serve(router, http.MethodGet, "/api/v1/orders/missing")
+	found := serve(router, http.MethodGet, "/api/v1/orders/42")
+	cached := serve(router, http.MethodGet, "/api/v1/orders/42")
+
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.JSONEq(t, `{"message":"order with the provided ID was not found"}`, missing.Body.String())
+	assert.Equal(t, http.StatusOK, found.Code)
+	assert.Equal(t, "HIT", cached.Header().Get("X-Cache"))
+}
+
+func TestNewRouter_TenantRequired(t *testing.T) {
+	mode, sources := config.Tenant.Mode, config.Tenant.Sources
+	t.Cleanup(func() {
+		config.Tenant.Mode, config.Tenant.Sources = mode, sources
+	})
+
+	config.Tenant.Mode = "column"
+	config.Tenant.Sources = []string{"header"}
+	config.Tenant.Header = "X-Tenant-ID"
+
+	router, _ := newTestRouter(middleware.RateLimitPolicies{})
+
+	missing := serve(router, http.MethodGet, "/api/v1/orders/42")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/42", nil)
+	req.Header.Set("X-Tenant-ID", "acme")
+	scoped := httptest.NewRecorder()
+	router.ServeHTTP(scoped, req)
+
+	assert.Equal(t, http.StatusBadRequest, missing.Code)
+	assert.JSONEq(t, `{"message":"tenant is required"}`, missing.Body.String())
+	assert.Equal(t, http.StatusOK, scoped.Code)
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/health").Code)
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/lock"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+)
+
+// Purger is implemented by every database.BaseRepository and permanently
+// removes soft-deleted records that were deleted before the given time.
+type Purger interface {
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
+}
+
+type scheduler struct {
+	locker  *lock.Locker
+	purgers []Purger
+}
+
+// NewScheduler returns a scheduler purging with purgers in the given order,
+// so repositories of child records must come before the parents they
+// reference.
+func NewScheduler(locker *lock.Locker, purgers ...Purger) *scheduler {
+	return &scheduler{
+		locker:  locker,
+		purgers: purgers,
+	}
+}
+
+// Schedule starts the tasks, each run by the instance elected for it only.
+func (s *scheduler) Schedule(ctx context.Context) {
+	if config.Database.SoftDeleteRetention > 0 {
+		go func() {
+			err := lock.NewElector(s.locker, "scheduler:purge").Run(ctx, s.purge)
+			if err != nil {
+				logger.Error(ctx, err, "❌ Trashed records will not be purged, no instance can be elected to purge them").Write()
+			}
+		}()
+	}
+}
+
+func (s *scheduler) purge(ctx context.Context) {
+	ticker := time.NewTicker(config.Database.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			total := s.purgeTrashed(ctx, database.Now().Add(-config.Database.SoftDeleteRetention))
+			if total > 0 {
+				logger.Infof(ctx, "🧹 Purged %d soft-deleted records older than %v", total, config.Database.SoftDeleteRetention).Write()
+			}
+		}
+	}
+}
+
+// purgeTrashed runs every purger in order and returns how many records
+// they removed.
+func (s *scheduler) purgeTrashed(ctx context.Context, before time.Time) int64 {
+	// Trashed records of every tenant are purged.
+	purgeCtx := database.WithAllTenants(ctx)
+
+	var total int64
+	for _, purger := range s.purgers {
+		count, err := purger.PurgeTrashed(purgeCtx, before)
+		if err != nil {
+			logger.Error(ctx, err, "❌ Failed to purge soft-deleted records").Write()
+			continue
+		}
+
+		total += count
+	}
+
+	return total
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/config"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/database"
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	config.Tracer.Enabled = true
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+// purgerFunc adapts a function to Purger.
+type purgerFunc func(ctx context.Context, before time.Time) (int64, error)
+
+func (f purgerFunc) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	return f(ctx, before)
+}
+
+func TestScheduler_PurgeTrashed(t *testing.T) {
+	before := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var purged []string
+	purger := func(table string, count int64, err error) Purger {
+		return purgerFunc(func(ctx context.Context, at time.Time) (int64, error) {
+			assert.True(t, database.AllTenants(ctx))
+			assert.Equal(t, before, at)
+
+			purged = append(purged, table)
+			return count, err
+		})
+	}
+
+	s := NewScheduler(nil,
+		purger("order_items", 3, nil),
+		purger("orders", 0, errors.New("lock timeout")),
+		purger("customers", 2, nil),
+	)
+	total := s.purgeTrashed(context.Background(), before)
+
+	assert.Equal(t, []string{"order_items", "orders", "customers"}, purged)
+	assert.Equal(t, int64(5), total)
+}
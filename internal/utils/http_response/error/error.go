@@ -44,6 +44,22 @@ func NewNotFoundError(message string, errors ...string) error {
 	}
 }
 
+func NewConflictError(message string, errors ...string) error {
+	return &CustomError{
+		Status:  http.StatusConflict,
+		Message: message,
+		Errors:  errors,
+	}
+}
+
+func NewUnprocessableEntityError(message string, errors ...string) error {
+	return &CustomError{
+		Status:  http.StatusUnprocessableEntity,
+		Message: message,
+		Errors:  errors,
+	}
+}
+
 func NewRequestTimeoutError(message string, errors ...string) error {
 	return &CustomError{
 		Status:  http.StatusRequestTimeout,
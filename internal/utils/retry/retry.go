@@ -10,12 +10,33 @@ import (
 	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
 )
 
+// Policy bounds how often and how long an operation is retried, doubling
+// the backoff after every attempt.
+type Policy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// BackoffPolicy returns the policy configured through RETRY_*, suited to
+// waiting for dependencies at startup.
+func BackoffPolicy() Policy {
+	return Policy(config.RetryBackoff)
+}
+
 func RetryWithBackoff[D any](ctx context.Context, operation string, fn func() (D, error)) (res D, err error) {
-	backoff := config.RetryBackoff.InitialBackoff
+	return RetryWithPolicyIf(ctx, operation, BackoffPolicy(), func(error) bool { return true }, fn)
+}
+
+// RetryWithPolicyIf behaves like RetryWithBackoff but follows policy and
+// only retries errors accepted by retryable; any other error is returned as
+// is.
+func RetryWithPolicyIf[D any](ctx context.Context, operation string, policy Policy, retryable func(error) bool, fn func() (D, error)) (res D, err error) {
+	backoff := policy.InitialBackoff
 
-	for attempt := 0; attempt <= config.RetryBackoff.MaxRetries; attempt++ {
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
 		if attempt > 0 {
-			logger.Warnf(ctx, "🔁 Retrying %s (attempt %d/%d) after %v", operation, attempt, config.RetryBackoff.MaxRetries, backoff).Write()
+			logger.Warnf(ctx, "🔁 Retrying %s (attempt %d/%d) after %v", operation, attempt, policy.MaxRetries, backoff).Write()
 			select {
 			case <-time.After(backoff):
 			case <-ctx.Done():
@@ -31,10 +52,14 @@ func RetryWithBackoff[D any](ctx context.Context, operation string, fn func() (D
 			return res, nil
 		}
 
-		if attempt < config.RetryBackoff.MaxRetries {
-			backoff = min(time.Duration(float64(config.RetryBackoff.InitialBackoff)*math.Pow(2, float64(attempt))), config.RetryBackoff.MaxBackoff)
+		if !retryable(err) {
+			return res, err
+		}
+
+		if attempt < policy.MaxRetries {
+			backoff = min(time.Duration(float64(policy.InitialBackoff)*math.Pow(2, float64(attempt))), policy.MaxBackoff)
 		}
 	}
 
-	return res, fmt.Errorf("%s failed after %d attempts: %w", operation, config.RetryBackoff.MaxRetries+1, err)
+	return res, fmt.Errorf("%s failed after %d attempts: %w", operation, policy.MaxRetries+1, err)
 }
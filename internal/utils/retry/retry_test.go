@@ -0,0 +1,77 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goodone-dev/go-boilerplate/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	logger.Disabled()
+	code := m.Run()
+
+	os.Exit(code)
+}
+
+var errDeadlock = errors.New("deadlock")
+
+func TestRetryWithPolicyIf(t *testing.T) {
+	policy := Policy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	cases := []struct {
+		name      string
+		fails     int
+		err       error
+		wantCalls int
+		wantErr   string
+	}{
+		{name: "first attempt", wantCalls: 1},
+		{name: "after retries", fails: 2, err: errDeadlock, wantCalls: 3},
+		{name: "retries exhausted", fails: 3, err: errDeadlock, wantCalls: 3, wantErr: "transaction failed after 3 attempts: deadlock"},
+		{name: "not retryable", fails: 3, err: errors.New("syntax error"), wantCalls: 1, wantErr: "syntax error"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			res, err := RetryWithPolicyIf(context.Background(), "transaction", policy, func(err error) bool {
+				return errors.Is(err, errDeadlock)
+			}, func() (int, error) {
+				calls++
+				if calls <= tc.fails {
+					return 0, tc.err
+				}
+				return calls, nil
+			})
+
+			assert.Equal(t, tc.wantCalls, calls)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, calls, res)
+		})
+	}
+}
+
+func TestRetryWithPolicyIf_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := Policy{MaxRetries: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	var calls int
+	_, err := RetryWithPolicyIf(ctx, "transaction", policy, func(error) bool { return true }, func() (any, error) {
+		calls++
+		cancel()
+		return nil, errDeadlock
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,6 @@
+package migrations
+
+import "embed"
+
+//go:embed postgres mysql mongodb
+var FS embed.FS
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS customers (
+    id BINARY(16) PRIMARY KEY DEFAULT (UUID_TO_BIN(UUID())),
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    deleted_at DATETIME(6)
+);
+
+CREATE INDEX idx_customers_name ON customers (name);
+CREATE INDEX idx_customers_email ON customers (email);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS employees (
+    id BINARY(16) PRIMARY KEY DEFAULT (UUID_TO_BIN(UUID())),
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    role VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    deleted_at DATETIME(6)
+);
+
+CREATE INDEX idx_employees_name ON employees (name);
+CREATE INDEX idx_employees_email ON employees (email);
+CREATE INDEX idx_employees_role ON employees (role);
@@ -0,0 +1,15 @@
+DROP INDEX idx_employees_tenant_id ON employees;
+ALTER TABLE employees DROP COLUMN tenant_id;
+
+DROP INDEX idx_order_items_tenant_id ON order_items;
+ALTER TABLE order_items DROP COLUMN tenant_id;
+
+DROP INDEX idx_orders_tenant_id ON orders;
+ALTER TABLE orders DROP COLUMN tenant_id;
+
+DROP INDEX idx_products_tenant_id ON products;
+ALTER TABLE products DROP COLUMN tenant_id;
+
+ALTER TABLE customers DROP INDEX uq_customers_tenant_id_email, ADD UNIQUE INDEX email (email);
+DROP INDEX idx_customers_tenant_id ON customers;
+ALTER TABLE customers DROP COLUMN tenant_id;
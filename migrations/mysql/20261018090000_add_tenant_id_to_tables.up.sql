@@ -0,0 +1,16 @@
+-- MySQL has no row-level security, tenants are scoped by column only (TENANT_MODE=column).
+ALTER TABLE customers ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT '';
+CREATE INDEX idx_customers_tenant_id ON customers (tenant_id);
+ALTER TABLE customers DROP INDEX email, ADD UNIQUE INDEX uq_customers_tenant_id_email (tenant_id, email);
+
+ALTER TABLE products ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT '';
+CREATE INDEX idx_products_tenant_id ON products (tenant_id);
+
+ALTER TABLE orders ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT '';
+CREATE INDEX idx_orders_tenant_id ON orders (tenant_id);
+
+ALTER TABLE order_items ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT '';
+CREATE INDEX idx_order_items_tenant_id ON order_items (tenant_id);
+
+ALTER TABLE employees ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT '';
+CREATE INDEX idx_employees_tenant_id ON employees (tenant_id);
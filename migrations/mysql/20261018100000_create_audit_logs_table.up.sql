@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BINARY(16) PRIMARY KEY DEFAULT (UUID_TO_BIN(UUID())),
+    tenant_id VARCHAR(63) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL,
+    entity VARCHAR(255) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    operation VARCHAR(255) NOT NULL,
+    changes JSON NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity, entity_id, created_at);
+CREATE INDEX idx_audit_logs_actor ON audit_logs (actor);
+CREATE INDEX idx_audit_logs_tenant_id ON audit_logs (tenant_id);
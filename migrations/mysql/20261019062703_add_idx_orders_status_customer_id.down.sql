@@ -0,0 +1 @@
+DROP INDEX idx_orders_status_customer_id ON orders;
@@ -0,0 +1 @@
+CREATE INDEX idx_orders_status_customer_id ON orders (tenant_id, status, customer_id);
@@ -0,0 +1,26 @@
+DROP POLICY IF EXISTS tenant_isolation ON employees;
+ALTER TABLE employees DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_employees_tenant_id;
+ALTER TABLE employees DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON order_items;
+ALTER TABLE order_items DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_order_items_tenant_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON orders;
+ALTER TABLE orders DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_orders_tenant_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON products;
+ALTER TABLE products DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_products_tenant_id;
+ALTER TABLE products DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON customers;
+ALTER TABLE customers DISABLE ROW LEVEL SECURITY;
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_tenant_id_email_key;
+ALTER TABLE customers ADD CONSTRAINT customers_email_key UNIQUE (email);
+DROP INDEX IF EXISTS idx_customers_tenant_id;
+ALTER TABLE customers DROP COLUMN IF EXISTS tenant_id;
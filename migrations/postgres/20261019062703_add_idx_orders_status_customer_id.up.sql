@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_orders_status_customer_id ON orders (tenant_id, status, customer_id);
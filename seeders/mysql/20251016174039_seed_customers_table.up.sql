@@ -0,0 +1,6 @@
+INSERT INTO customers (name, email) VALUES
+    ('Alice Johnson', 'alice.j@example.com'),
+    ('Bob Williams', 'bob.w@example.com'),
+    ('Charlie Brown', 'charlie.b@example.com'),
+    ('Diana Miller', 'diana.m@example.com'),
+    ('Ethan Davis', 'ethan.d@example.com');
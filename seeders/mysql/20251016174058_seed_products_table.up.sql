@@ -0,0 +1,51 @@
+INSERT INTO products (name, description, price, stock) VALUES
+    ('Laptop Pro', 'High-performance laptop for professionals', 1200.00, 50),
+    ('Smartphone X', 'Latest model with advanced features', 800.00, 150),
+    ('Wireless Headphones', 'Noise-cancelling over-ear headphones', 250.00, 300),
+    ('Smartwatch 2', 'Fitness tracking and mobile notifications', 180.00, 200),
+    ('4K Monitor', '27-inch monitor with ultra-high definition', 450.00, 80),
+    ('Mechanical Keyboard', 'RGB backlit keyboard for gaming', 120.00, 120),
+    ('Webcam HD', '1080p webcam for video conferencing', 60.00, 250),
+    ('USB-C Hub', 'Multi-port adapter for laptops', 40.00, 400),
+    ('External SSD 1TB', 'High-speed portable storage', 150.00, 100),
+    ('Gaming Mouse', 'Ergonomic mouse with customizable buttons', 80.00, 180),
+    ('Standing Desk', 'Adjustable height desk for home office', 350.00, 60),
+    ('Ergonomic Chair', 'Office chair with lumbar support', 280.00, 90),
+    ('LED Desk Lamp', 'Modern lamp with adjustable brightness', 35.00, 300),
+    ('Portable Charger', '20000mAh power bank for mobile devices', 50.00, 220),
+    ('Bluetooth Speaker', 'Waterproof speaker for outdoor use', 70.00, 170),
+    ('Graphics Tablet', 'For digital drawing and photo editing', 100.00, 110),
+    ('VR Headset', 'Immersive virtual reality experience', 400.00, 70),
+    ('Drone with Camera', '4K video recording drone', 600.00, 40),
+    ('E-reader', 'Backlit e-reader with long battery life', 130.00, 190),
+    ('Fitness Tracker', 'Monitors heart rate and daily activity', 60.00, 280),
+    ('Electric Toothbrush', 'With multiple cleaning modes', 90.00, 140),
+    ('Coffee Maker', '12-cup programmable coffee machine', 80.00, 160),
+    ('Blender', 'High-speed blender for smoothies', 110.00, 130),
+    ('Air Fryer', 'For healthy, oil-free cooking', 100.00, 200),
+    ('Robot Vacuum', 'Automated cleaning for your home', 300.00, 80),
+    ('Security Camera', 'Indoor/outdoor security camera', 120.00, 150),
+    ('Smart Thermostat', 'Energy-saving home thermostat', 150.00, 100),
+    ('Wi-Fi Router', 'High-speed dual-band router', 100.00, 180),
+    ('Projector', '1080p home theater projector', 500.00, 50),
+    ('Soundbar', 'For enhanced TV audio', 200.00, 90),
+    ('Action Camera', 'Waterproof camera for sports', 250.00, 120),
+    ('Digital Photo Frame', 'Displays a slideshow of your photos', 70.00, 200),
+    ('Electric Kettle', 'Fast-boiling water kettle', 40.00, 250),
+    ('Microwave Oven', 'Compact microwave for small spaces', 90.00, 110),
+    ('Toaster', '2-slice toaster with wide slots', 30.00, 300),
+    ('Hair Dryer', 'Ionic hair dryer for fast drying', 50.00, 170),
+    ('Electric Shaver', 'For a close and comfortable shave', 80.00, 140),
+    ('Yoga Mat', 'Non-slip mat for yoga and exercise', 25.00, 400),
+    ('Dumbbell Set', 'Adjustable dumbbell set for home gym', 150.00, 80),
+    ('Resistance Bands', 'Set of 5 bands for strength training', 20.00, 500),
+    ('Water Bottle', 'Insulated stainless steel water bottle', 20.00, 600),
+    ('Backpack', 'Durable backpack for travel and work', 60.00, 250),
+    ('Sunglasses', 'UV protection sunglasses', 40.00, 350),
+    ('Wristwatch', 'Classic analog wristwatch', 120.00, 180),
+    ('Wallet', 'Leather wallet with RFID blocking', 30.00, 400),
+    ('Desk Organizer', 'Keeps your desk tidy', 20.00, 300),
+    ('Notebook and Pen Set', 'Premium notebook and pen', 25.00, 450),
+    ('Wall Art', 'Abstract canvas print for home decor', 80.00, 120),
+    ('Scented Candle', 'Soy wax candle with lavender scent', 15.00, 500),
+    ('Board Game', 'Strategy board game for game night', 40.00, 200);